@@ -3,12 +3,17 @@ package main
 import (
 	"context"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"Aicon-assignment/internal/infrastructure/server"
 )
 
 func main() {
-	ctx := context.Background()
+	// SIGINT / SIGTERM を受け取ったら ctx をキャンセルして graceful shutdown する
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	server := server.NewServer()
 
@@ -0,0 +1,29 @@
+// openapi はサーバーと同じルート登録から OpenAPI 3 ドキュメントを生成して標準出力に書き出す
+//
+//	go run ./docs/openapi > openapi.json
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/infrastructure/server"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/openapi"
+	"Aicon-assignment/internal/interfaces/controller/system"
+)
+
+func main() {
+	// ハンドラーは呼び出さないので、ルート登録に必要な最小限の依存だけで組み立てる
+	e := echo.New()
+	server.RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), server.RouteOptions{EnableDocs: true})
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(openapi.Build(e.Routes())); err != nil {
+		log.Fatalf("Failed to write OpenAPI document: %v", err)
+	}
+}
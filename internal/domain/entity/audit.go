@@ -0,0 +1,20 @@
+package entity
+
+import "time"
+
+// 監査ログの操作の種類
+const AuditActionCreate = "create"
+
+// actor / request_id の最大文字数（DB の VARCHAR(255) に合わせる）
+const MaxAuditFieldLength = 255
+
+// AuditEntry はアイテムの変更を記録する監査ログ 1 件（アイテムの変更と同じトランザクションで書き込む）
+type AuditEntry struct {
+	ID     int64  `json:"id"`
+	ItemID int64  `json:"item_id"`
+	Action string `json:"action"`
+	// 操作したユーザー（JWT の subject、認証なしの場合は空）とリクエスト ID
+	Actor     string    `json:"actor"`
+	RequestID string    `json:"request_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// カテゴリー名の最大文字数（DB の VARCHAR(50) に合わせる）
+const MaxCategoryLength = 50
+
+// 一覧の絞り込み（?category=時計,バッグ）はカンマ区切りのため、カテゴリー名には使えない
+const categorySeparator = ","
+
+// 初期のカテゴリー（categories テーブルの初期データと同じ）
+var DefaultCategories = []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
+
+// Category はアイテムを登録できるカテゴリー 1 件（アイテムはカテゴリー名で関連付ける）
+type Category struct {
+	ID        int64     `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewCategory(name string) (*Category, error) {
+	category := &Category{Name: strings.TrimSpace(name)}
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+	return category, nil
+}
+
+// Validate はカテゴリーのすべての違反を *domainErrors.ValidationError で返す
+func (c *Category) Validate() error {
+	verr := &domainErrors.ValidationError{}
+
+	if c.Name == "" {
+		verr.Add("name", domainErrors.CodeRequired)
+	} else if utf8.RuneCountInString(c.Name) > MaxCategoryLength {
+		verr.Add("name", domainErrors.CodeTooLong, MaxCategoryLength)
+	} else if strings.Contains(c.Name, categorySeparator) {
+		verr.Add("name", domainErrors.CodeInvalidCharacter, categorySeparator)
+	}
+
+	return verr.Err()
+}
@@ -0,0 +1,46 @@
+package entity
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCategory(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		want        string
+		expectedErr string
+	}{
+		{name: "正常系: 前後の空白を取り除く", input: " 家具 ", want: "家具"},
+		{name: "正常系: 最大文字数", input: strings.Repeat("家", MaxCategoryLength), want: strings.Repeat("家", MaxCategoryLength)},
+		{name: "異常系: 空白のみ", input: "  ", expectedErr: "name is required"},
+		{name: "異常系: 長すぎる", input: strings.Repeat("家", MaxCategoryLength+1), expectedErr: "name must be 50 characters or less"},
+		{name: "異常系: カンマを含む", input: "家具,インテリア", expectedErr: `name must not contain ","`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, err := NewCategory(tt.input)
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Nil(t, category)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, category.Name)
+		})
+	}
+}
+
+func TestDefaultCategories(t *testing.T) {
+	assert.Equal(t, []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}, DefaultCategories)
+	for _, name := range DefaultCategories {
+		_, err := NewCategory(name)
+		assert.NoError(t, err, name)
+	}
+}
@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+// アイテムの変更イベントの種類
+const (
+	ItemEventCreated = "item.created"
+	ItemEventUpdated = "item.updated"
+	ItemEventDeleted = "item.deleted"
+)
+
+// ItemEvent はコミット済みのアイテムの変更を外部（Webhook・SSE など）に通知するイベント
+type ItemEvent struct {
+	Type string `json:"type"`
+	// 変更後のアイテム（削除の場合は削除前の内容）
+	Item      *Item     `json:"item"`
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -1,24 +1,69 @@
 package entity
 
 import (
-	"errors"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
 type Item struct {
-	ID            int64     `json:"id"`
-	Name          string    `json:"name"`
-	Category      string    `json:"category"`
-	Brand         string    `json:"brand"`
-	PurchasePrice int       `json:"purchase_price"`
-	PurchaseDate  string    `json:"purchase_date"` // YYYY-MM-DD 形式
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            int64      `json:"id" xml:"id"`
+	Name          string     `json:"name" xml:"name"`
+	Category      string     `json:"category" xml:"category"`
+	Brand         string     `json:"brand" xml:"brand"`
+	PurchasePrice int        `json:"purchase_price" xml:"purchase_price"`
+	PurchaseDate  string     `json:"purchase_date" xml:"purchase_date"` // YYYY-MM-DD 形式
+	CreatedAt     time.Time  `json:"created_at" xml:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" xml:"updated_at"`
+	DeletedAt     *time.Time `json:"deleted_at,omitempty" xml:"deleted_at,omitempty"` // 論理削除日時（未削除の場合は nil）
+	Version       int        `json:"version" xml:"version"`                           // 楽観ロック用のバージョン（更新のたびに 1 増える）
+	// 保証期限（YYYY-MM-DD 形式、保証がない場合は nil）
+	WarrantyExpiry *string `json:"warranty_expiry,omitempty" xml:"warranty_expiry,omitempty"`
+	// 入手経緯などの自由記述のメモ（未設定の場合は空文字）
+	Notes string `json:"notes" xml:"notes"`
+	// タグ（小文字にそろえた名前順、タグがない場合は空の配列）
+	Tags []string `json:"tags" xml:"tags>tag"`
+	// 写真などの画像の URL（http / https、未設定の場合は空文字で JSON には含めない）
+	ImageURL string `json:"image_url,omitempty" xml:"image_url,omitempty"`
+	// カテゴリーごとの追加情報（時計のムーブメント、バッグの素材など）の任意の JSON オブジェクト
+	// 未設定の場合は nil で JSON には含めない（任意の構造のため XML には含めない）
+	Attributes map[string]interface{} `json:"attributes,omitempty" xml:"-"`
+	// 登録したユーザー（JWT の subject、認証せずに登録した場合は空文字で JSON には含めない）
+	UserID string `json:"user_id,omitempty" xml:"user_id,omitempty"`
 }
 
-// カテゴリー定義
-var ValidCategories = []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
+// name / brand の最大文字数（DB の VARCHAR(100) に合わせ、バイト数ではなく文字数で数える）
+const (
+	MaxNameLength  = 100
+	MaxBrandLength = 100
+)
+
+// notes の最大文字数（TEXT 型のカラムに収まる上限。usecase でより短く設定できる）
+const MaxNotesLength = 10000
+
+// 1 つのアイテムに付けられるタグの数と、タグの最大文字数
+// MySQL の GROUP_CONCAT の既定の上限（1024 バイト）に、すべてのタグが 4 バイト文字でも収まる値にしている
+const (
+	MaxTags      = 10
+	MaxTagLength = 20
+)
+
+// image_url の最大文字数（DB の VARCHAR(2048) に合わせる）
+const MaxImageURLLength = 2048
+
+// attributes を JSON にしたときの最大バイト数と、入れ子の最大の深さ（attributes 自体が 1 階層目）
+const (
+	MaxAttributesSize  = 4096
+	MaxAttributesDepth = 5
+)
+
+// タグはカンマ区切りで読み込むため、タグの名前には使えない
+const tagSeparator = ","
 
 func NewItem(name, category, brand string, purchasePrice int, purchaseDate string) (*Item, error) {
 	item := &Item{
@@ -29,6 +74,8 @@ func NewItem(name, category, brand string, purchasePrice int, purchaseDate strin
 		PurchaseDate:  strings.TrimSpace(purchaseDate),
 		CreatedAt:     time.Now(),
 		UpdatedAt:     time.Now(),
+		Version:       1,
+		Tags:          []string{},
 	}
 
 	if err := item.Validate(); err != nil {
@@ -39,42 +86,80 @@ func NewItem(name, category, brand string, purchasePrice int, purchaseDate strin
 }
 
 // アイテムフィールドのバリデーション
+// すべての違反をフィールド順に *domainErrors.ValidationError として返す
 func (i *Item) Validate() error {
-	var errs []string
+	verr := &domainErrors.ValidationError{}
 
 	if i.Name == "" {
-		errs = append(errs, "name is required")
-	} else if len(i.Name) > 100 {
-		errs = append(errs, "name must be 100 characters or less")
+		verr.Add("name", domainErrors.CodeRequired)
+	} else if utf8.RuneCountInString(i.Name) > MaxNameLength {
+		verr.Add("name", domainErrors.CodeTooLong, MaxNameLength)
 	}
 
+	// 登録されたカテゴリーかどうかは usecase で categories の一覧と照合する
 	if i.Category == "" {
-		errs = append(errs, "category is required")
-	} else if !isValidCategory(i.Category) {
-		errs = append(errs, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+		verr.Add("category", domainErrors.CodeRequired)
+	} else if utf8.RuneCountInString(i.Category) > MaxCategoryLength {
+		verr.Add("category", domainErrors.CodeTooLong, MaxCategoryLength)
 	}
 
 	if i.Brand == "" {
-		errs = append(errs, "brand is required")
-	} else if len(i.Brand) > 100 {
-		errs = append(errs, "brand must be 100 characters or less")
+		verr.Add("brand", domainErrors.CodeRequired)
+	} else if utf8.RuneCountInString(i.Brand) > MaxBrandLength {
+		verr.Add("brand", domainErrors.CodeTooLong, MaxBrandLength)
 	}
 
 	if i.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+		verr.Add("purchase_price", domainErrors.CodeNegative)
 	}
 
 	if i.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
+		verr.Add("purchase_date", domainErrors.CodeRequired)
 	} else if !isValidDateFormat(i.PurchaseDate) {
-		errs = append(errs, "purchase_date must be in YYYY-MM-DD format")
+		verr.Add("purchase_date", domainErrors.CodeInvalidFormat, "YYYY-MM-DD")
+	}
+
+	// 保証期限は購入日より前にできない（購入日が不正な場合は比較しない）
+	// どちらも YYYY-MM-DD のため文字列の大小で比較できる
+	if i.WarrantyExpiry != nil {
+		if !isValidDateFormat(*i.WarrantyExpiry) {
+			verr.Add("warranty_expiry", domainErrors.CodeInvalidFormat, "YYYY-MM-DD")
+		} else if isValidDateFormat(i.PurchaseDate) && *i.WarrantyExpiry < i.PurchaseDate {
+			verr.Add("warranty_expiry", domainErrors.CodeBeforePurchase)
+		}
+	}
+
+	if utf8.RuneCountInString(i.Notes) > MaxNotesLength {
+		verr.Add("notes", domainErrors.CodeTooLong, MaxNotesLength)
+	}
+
+	if len(i.Tags) > MaxTags {
+		verr.Add("tags", domainErrors.CodeTooMany, MaxTags)
+	}
+	for n, tag := range i.Tags {
+		field := fmt.Sprintf("tags[%d]", n)
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			verr.Add(field, domainErrors.CodeTooLong, MaxTagLength)
+		} else if strings.Contains(tag, tagSeparator) {
+			verr.Add(field, domainErrors.CodeInvalidCharacter, tagSeparator)
+		}
+	}
+
+	if utf8.RuneCountInString(i.ImageURL) > MaxImageURLLength {
+		verr.Add("image_url", domainErrors.CodeTooLong, MaxImageURLLength)
 	}
 
-	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, ", "))
+	if len(i.Attributes) > 0 {
+		if jsonDepth(i.Attributes) > MaxAttributesDepth {
+			verr.Add("attributes", domainErrors.CodeTooDeep, MaxAttributesDepth)
+		} else if data, err := json.Marshal(i.Attributes); err != nil {
+			verr.Add("attributes", domainErrors.CodeInvalidType)
+		} else if len(data) > MaxAttributesSize {
+			verr.Add("attributes", domainErrors.CodeTooLarge, MaxAttributesSize)
+		}
 	}
 
-	return nil
+	return verr.Err()
 }
 
 // アイテムフィールドのアップデート
@@ -89,14 +174,69 @@ func (i *Item) Update(name, category, brand string, purchasePrice int, purchaseD
 	return i.Validate()
 }
 
-// カテゴリーのバリデーション
-func isValidCategory(category string) bool {
-	for _, valid := range ValidCategories {
-		if category == valid {
-			return true
+// 保証期限の設定（nil・空文字の場合は保証なし）
+// バリデーションは Validate・Update でほかのフィールドと合わせて行う
+func (i *Item) SetWarrantyExpiry(warrantyExpiry *string) {
+	i.WarrantyExpiry = nil
+	if warrantyExpiry == nil {
+		return
+	}
+	if value := strings.TrimSpace(*warrantyExpiry); value != "" {
+		i.WarrantyExpiry = &value
+	}
+}
+
+// メモの設定（前後の空白は取り除き、空文字の場合はメモなし）
+func (i *Item) SetNotes(notes string) {
+	i.Notes = strings.TrimSpace(notes)
+}
+
+// 画像 URL の設定（前後の空白は取り除き、空文字の場合は画像なし）
+// URL の形式は usecase で検証する
+func (i *Item) SetImageURL(imageURL string) {
+	i.ImageURL = strings.TrimSpace(imageURL)
+}
+
+// 追加情報の設定（空のオブジェクトの場合は追加情報なし）
+func (i *Item) SetAttributes(attributes map[string]interface{}) {
+	i.Attributes = nil
+	if len(attributes) > 0 {
+		i.Attributes = attributes
+	}
+}
+
+// jsonDepth は JSON をデコードした値の入れ子の深さを返す（オブジェクト・配列が 1 階層、それ以外は 0）
+func jsonDepth(value interface{}) int {
+	var children []interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for _, child := range v {
+			children = append(children, child)
+		}
+	case []interface{}:
+		children = v
+	default:
+		return 0
+	}
+
+	depth := 0
+	for _, child := range children {
+		depth = max(depth, jsonDepth(child))
+	}
+	return depth + 1
+}
+
+// タグの設定（前後の空白を取り除いて小文字にそろえ、空のタグと重複を除いて名前順に並べる）
+// 大文字小文字の違いで別のタグにならないよう、保存する値をそろえる
+func (i *Item) SetTags(tags []string) {
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = strings.ToLower(strings.TrimSpace(tag)); tag != "" {
+			normalized = append(normalized, tag)
 		}
 	}
-	return false
+	slices.Sort(normalized)
+	i.Tags = slices.Compact(normalized)
 }
 
 // デート形式のバリデーション
@@ -104,8 +244,3 @@ func isValidDateFormat(dateStr string) bool {
 	_, err := time.Parse("2006-01-02", dateStr)
 	return err == nil
 }
-
-// カテゴリーの取得
-func GetValidCategories() []string {
-	return ValidCategories
-}
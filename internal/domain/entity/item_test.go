@@ -1,6 +1,8 @@
 package entity
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -40,7 +42,7 @@ func TestNewItem(t *testing.T) {
 		},
 		{
 			name:          "異常系: 名前が100文字超過",
-			itemName:      "ロレックス デイトナ 16520 18K イエローゴールド ブラック文字盤 自動巻き クロノグラフ メンズ 腕時計 1988年製 ヴィンテージ 希少 コレクション アイテム",
+			itemName:      strings.Repeat("時", 101),
 			category:      "時計",
 			brand:         "ROLEX",
 			purchasePrice: 1500000,
@@ -59,14 +61,14 @@ func TestNewItem(t *testing.T) {
 			expectedErr:   "category is required",
 		},
 		{
-			name:          "異常系: 無効なカテゴリー",
+			name:          "異常系: カテゴリーが長すぎる",
 			itemName:      "ロレックス デイトナ",
-			category:      "無効なカテゴリー",
+			category:      strings.Repeat("時", MaxCategoryLength+1),
 			brand:         "ROLEX",
 			purchasePrice: 1500000,
 			purchaseDate:  "2023-01-15",
 			wantErr:       true,
-			expectedErr:   "category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
+			expectedErr:   "category must be 50 characters or less",
 		},
 		{
 			name:          "異常系: ブランドが空",
@@ -118,6 +120,15 @@ func TestNewItem(t *testing.T) {
 			wantErr:       true,
 			expectedErr:   "purchase_date must be in YYYY-MM-DD format",
 		},
+		{
+			name:          "正常系: 名前がマルチバイト文字でちょうど100文字",
+			itemName:      strings.Repeat("時", 100),
+			category:      "時計",
+			brand:         "ROLEX",
+			purchasePrice: 1500000,
+			purchaseDate:  "2023-01-15",
+			wantErr:       false,
+		},
 		{
 			name:          "正常系: 購入価格が0",
 			itemName:      "ギフト品",
@@ -185,14 +196,14 @@ func TestItem_Update(t *testing.T) {
 			wantErr:     false,
 		},
 		{
-			name:        "異常系: 無効なカテゴリー",
+			name:        "異常系: カテゴリーが長すぎる",
 			newName:     "更新されたアイテム",
-			newCategory: "無効なカテゴリー",
+			newCategory: strings.Repeat("時", MaxCategoryLength+1),
 			newBrand:    "更新されたブランド",
 			newPrice:    200000,
 			newDate:     "2023-12-31",
 			wantErr:     true,
-			expectedErr: "category must be one of: 時計, バッグ, ジュエリー, 靴, その他",
+			expectedErr: "category must be 50 characters or less",
 		},
 		{
 			name:        "異常系: 負の価格",
@@ -261,6 +272,108 @@ func TestItem_Validate(t *testing.T) {
 			wantErr:     true,
 			expectedErr: "name is required, category is required, brand is required, purchase_price must be 0 or greater, purchase_date is required",
 		},
+		{
+			name: "正常系: 保証期限が購入日と同じ",
+			item: &Item{
+				Name:           "ロレックス デイトナ",
+				Category:       "時計",
+				Brand:          "ROLEX",
+				PurchasePrice:  1500000,
+				PurchaseDate:   "2023-01-15",
+				WarrantyExpiry: strPtr("2023-01-15"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 保証期限が購入日より前",
+			item: &Item{
+				Name:           "ロレックス デイトナ",
+				Category:       "時計",
+				Brand:          "ROLEX",
+				PurchasePrice:  1500000,
+				PurchaseDate:   "2023-01-15",
+				WarrantyExpiry: strPtr("2023-01-14"),
+			},
+			wantErr:     true,
+			expectedErr: "warranty_expiry must not be before purchase_date",
+		},
+		{
+			name: "異常系: 保証期限の形式が不正",
+			item: &Item{
+				Name:           "ロレックス デイトナ",
+				Category:       "時計",
+				Brand:          "ROLEX",
+				PurchasePrice:  1500000,
+				PurchaseDate:   "2023-01-15",
+				WarrantyExpiry: strPtr("2025/01/15"),
+			},
+			wantErr:     true,
+			expectedErr: "warranty_expiry must be in YYYY-MM-DD format",
+		},
+		{
+			name: "異常系: タグの文字数超過とカンマ",
+			item: &Item{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  "2023-01-15",
+				Tags:          []string{"gift", strings.Repeat("タ", 21), "a,b"},
+			},
+			wantErr:     true,
+			expectedErr: `tags[1] must be 20 characters or less, tags[2] must not contain ","`,
+		},
+		{
+			name: "異常系: タグの数が上限を超える",
+			item: &Item{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  "2023-01-15",
+				Tags:          []string{"a", "b", "c", "d", "e", "f", "g", "h", "i", "j", "k"},
+			},
+			wantErr:     true,
+			expectedErr: "tags must have 10 items or fewer",
+		},
+		{
+			name: "正常系: 上限の深さの追加情報",
+			item: &Item{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  "2023-01-15",
+				Attributes:    map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{map[string]interface{}{"c": []interface{}{"d"}}}}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "異常系: 追加情報の入れ子が深すぎる",
+			item: &Item{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  "2023-01-15",
+				Attributes:    map[string]interface{}{"a": map[string]interface{}{"b": []interface{}{map[string]interface{}{"c": []interface{}{[]interface{}{}}}}}},
+			},
+			wantErr:     true,
+			expectedErr: "attributes must be nested 5 levels or fewer",
+		},
+		{
+			name: "異常系: 追加情報のサイズが上限を超える",
+			item: &Item{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  "2023-01-15",
+				Attributes:    map[string]interface{}{"notes": strings.Repeat("a", MaxAttributesSize)},
+			},
+			wantErr:     true,
+			expectedErr: "attributes must be 4096 bytes or less",
+		},
 	}
 
 	for _, tt := range tests {
@@ -278,28 +391,46 @@ func TestItem_Validate(t *testing.T) {
 	}
 }
 
-func TestIsValidCategory(t *testing.T) {
-	tests := []struct {
-		name     string
-		category string
-		want     bool
-	}{
-		{"有効なカテゴリー: 時計", "時計", true},
-		{"有効なカテゴリー: バッグ", "バッグ", true},
-		{"有効なカテゴリー: ジュエリー", "ジュエリー", true},
-		{"有効なカテゴリー: 靴", "靴", true},
-		{"有効なカテゴリー: その他", "その他", true},
-		{"無効なカテゴリー: 衣服", "衣服", false},
-		{"無効なカテゴリー: 空文字", "", false},
-		{"無効なカテゴリー: 英語", "watch", false},
-	}
+func strPtr(s string) *string {
+	return &s
+}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := isValidCategory(tt.category)
-			assert.Equal(t, tt.want, got)
-		})
-	}
+func TestItem_SetTags(t *testing.T) {
+	t.Run("正常系: 小文字にそろえ、空と重複を除いて名前順にする", func(t *testing.T) {
+		item := &Item{}
+		item.SetTags([]string{" Vintage ", "gift", "", "GIFT", "  "})
+		assert.Equal(t, []string{"gift", "vintage"}, item.Tags)
+	})
+
+	t.Run("正常系: nil の場合は空の配列", func(t *testing.T) {
+		item := &Item{Tags: []string{"gift"}}
+		item.SetTags(nil)
+		assert.Equal(t, []string{}, item.Tags)
+	})
+}
+
+func TestItem_SetAttributes(t *testing.T) {
+	t.Run("正常系: 空のオブジェクトの場合は追加情報なし", func(t *testing.T) {
+		item := &Item{Attributes: map[string]interface{}{"movement": "automatic"}}
+		item.SetAttributes(map[string]interface{}{})
+		assert.Nil(t, item.Attributes)
+	})
+}
+
+func TestItem_JSONTimestamps(t *testing.T) {
+	createdAt := time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC)
+	item := Item{ID: 1, Name: "ロレックス デイトナ", CreatedAt: createdAt, UpdatedAt: createdAt.Add(time.Hour)}
+
+	body, err := json.Marshal(item)
+	require.NoError(t, err)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(body, &fields))
+	assert.Equal(t, "2023-01-15T10:00:00Z", fields["created_at"])
+	assert.Equal(t, "2023-01-15T11:00:00Z", fields["updated_at"])
+	// 未削除の場合は deleted_at を、保証がない場合は warranty_expiry を含めない
+	assert.NotContains(t, fields, "deleted_at")
+	assert.NotContains(t, fields, "warranty_expiry")
 }
 
 func TestIsValidDateFormat(t *testing.T) {
@@ -327,11 +458,3 @@ func TestIsValidDateFormat(t *testing.T) {
 		})
 	}
 }
-
-func TestGetValidCategories(t *testing.T) {
-	categories := GetValidCategories()
-	expected := []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
-
-	assert.Equal(t, expected, categories)
-	assert.Len(t, categories, 5)
-}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// ItemVersion は記録したアイテムのバージョン 1 件（UpdatedAt はそのバージョンになった日時）
+// その時点のアイテムは GET /items/{id}/versions/{version} で取得する
+type ItemVersion struct {
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
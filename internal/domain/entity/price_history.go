@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// PriceChange はアイテムの購入価格の変更 1 件（購入価格が変わる更新と同じトランザクションで書き込む）
+type PriceChange struct {
+	ID        int64     `json:"id"`
+	ItemID    int64     `json:"item_id"`
+	OldPrice  int       `json:"old_price"`
+	NewPrice  int       `json:"new_price"`
+	ChangedAt time.Time `json:"changed_at"`
+}
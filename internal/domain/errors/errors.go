@@ -7,10 +7,27 @@ var (
 	ErrInvalidInput   = errors.New("invalid input")
 	ErrDatabaseError  = errors.New("database error")
 	ErrDuplicateEntry = errors.New("duplicate entry")
+	// 楽観ロック: 指定したバージョンが現在のバージョンと一致しない
+	ErrVersionConflict = errors.New("version conflict")
+	// アイテムの指定したバージョンが記録されていない
+	ErrItemVersionNotFound = errors.New("item version not found")
+	// クエリが設定したタイムアウトまでに完了しなかった
+	ErrTimeout = errors.New("database timeout")
+	// 同じカテゴリに同じ名前（大文字・小文字を区別しない）の未削除のアイテムがある
+	ErrDuplicateItem = errors.New("duplicate item")
+	// 指定した ID のカテゴリーがない
+	ErrCategoryNotFound = errors.New("category not found")
+	// 同じ名前（大文字・小文字を区別しない）のカテゴリーがある
+	ErrDuplicateCategory = errors.New("duplicate category")
+	// カテゴリーに属する論理削除されていないアイテムがあるため削除できない
+	ErrCategoryInUse = errors.New("category in use")
+	// ユーザーごとにアイテムを分ける設定で、認証されていない
+	ErrUnauthenticated = errors.New("authentication required")
 )
 
+// IsNotFoundError はアイテム・アイテムのバージョン・カテゴリーが見つからないエラーかを返す
 func IsNotFoundError(err error) bool {
-	return errors.Is(err, ErrItemNotFound)
+	return errors.Is(err, ErrItemNotFound) || errors.Is(err, ErrItemVersionNotFound) || errors.Is(err, ErrCategoryNotFound)
 }
 
 func IsDatabaseError(err error) bool {
@@ -20,3 +37,27 @@ func IsDatabaseError(err error) bool {
 func IsValidationError(err error) bool {
 	return errors.Is(err, ErrInvalidInput)
 }
+
+func IsVersionConflictError(err error) bool {
+	return errors.Is(err, ErrVersionConflict)
+}
+
+func IsTimeoutError(err error) bool {
+	return errors.Is(err, ErrTimeout)
+}
+
+func IsDuplicateItemError(err error) bool {
+	return errors.Is(err, ErrDuplicateItem)
+}
+
+func IsDuplicateCategoryError(err error) bool {
+	return errors.Is(err, ErrDuplicateCategory)
+}
+
+func IsCategoryInUseError(err error) bool {
+	return errors.Is(err, ErrCategoryInUse)
+}
+
+func IsUnauthenticatedError(err error) bool {
+	return errors.Is(err, ErrUnauthenticated)
+}
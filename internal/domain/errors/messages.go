@@ -0,0 +1,94 @@
+package errors
+
+import "fmt"
+
+// Code はバリデーションエラーの種類（クライアントがメッセージの文言に依存せず判定できる）
+type Code string
+
+const (
+	CodeRequired     Code = "required"
+	CodeCannotRemove Code = "cannot_remove"
+	CodeTooLong      Code = "too_long"
+	CodeNegative     Code = "negative"
+	CodeInvalidValue Code = "invalid_value"
+	// 指定値も含めて返す（JSON Patch の op / path など）
+	CodeUnsupportedValue Code = "unsupported_value"
+	CodeInvalidFormat    Code = "invalid_format"
+	CodeInvalidType      Code = "invalid_type"
+	CodeFutureDate       Code = "future_date"
+	CodeUnknownField     Code = "unknown_field"
+	CodeNoFields         Code = "no_fields"
+	// フィールドに紐づかない、リクエスト全体のバリデーションエラー（Problem の detail）
+	CodeValidationFailed Code = "validation_failed"
+	// 保証期限などの日付が購入日より前
+	CodeBeforePurchase Code = "before_purchase_date"
+	// タグなどの配列の要素が多すぎる
+	CodeTooMany Code = "too_many"
+	// 使用できない文字を含む（タグのカンマなど）
+	CodeInvalidCharacter Code = "invalid_character"
+	// アップロードしたファイルや JSON のサイズが上限を超える
+	CodeTooLarge Code = "too_large"
+	// attributes などの JSON の入れ子が深すぎる
+	CodeTooDeep Code = "too_deep"
+)
+
+// 対応している言語（Accept-Language の基本の言語タグ）
+const (
+	LangEnglish  = "en"
+	LangJapanese = "ja"
+)
+
+// messageCatalog は言語ごとのメッセージの書式（引数は fmt の書式で埋める）
+// 英語はすべてのコードを持ち、ほかの言語で見つからない場合にも使う
+var messageCatalog = map[string]map[Code]string{
+	LangEnglish: {
+		CodeRequired:         "is required",
+		CodeCannotRemove:     "is required and cannot be removed",
+		CodeTooLong:          "must be %d characters or less",
+		CodeNegative:         "must be 0 or greater",
+		CodeInvalidValue:     "must be one of: %s",
+		CodeUnsupportedValue: "must be one of: %s (got %q)",
+		CodeInvalidFormat:    "must be in %s format",
+		CodeInvalidType:      "has an invalid type",
+		CodeFutureDate:       "must not be in the future",
+		CodeBeforePurchase:   "must not be before purchase_date",
+		CodeUnknownField:     "is not a known field",
+		CodeNoFields:         "no fields to update",
+		CodeValidationFailed: "validation failed",
+		CodeTooMany:          "must have %d items or fewer",
+		CodeInvalidCharacter: "must not contain %q",
+		CodeTooLarge:         "must be %d bytes or less",
+		CodeTooDeep:          "must be nested %d levels or fewer",
+	},
+	LangJapanese: {
+		CodeRequired:         "必須項目です",
+		CodeCannotRemove:     "必須項目のため削除できません",
+		CodeTooLong:          "%d 文字以内で入力してください",
+		CodeNegative:         "0 以上の値を入力してください",
+		CodeInvalidValue:     "%s のいずれかを指定してください",
+		CodeUnsupportedValue: "%s のいずれかを指定してください（指定値: %q）",
+		CodeInvalidFormat:    "%s 形式で入力してください",
+		CodeInvalidType:      "値の型が正しくありません",
+		CodeFutureDate:       "未来の日付は指定できません",
+		CodeBeforePurchase:   "購入日より前の日付は指定できません",
+		CodeUnknownField:     "存在しない項目です",
+		CodeNoFields:         "更新する項目がありません",
+		CodeValidationFailed: "入力内容に誤りがあります",
+		CodeTooMany:          "%d 件以内で指定してください",
+		CodeInvalidCharacter: "%q は使用できません",
+		CodeTooLarge:         "%d バイト以下にしてください",
+		CodeTooDeep:          "入れ子は %d 階層以内にしてください",
+	},
+}
+
+// Message は code のメッセージを lang の言語で返す（未対応の言語・コードは英語）
+func Message(lang string, code Code, params ...interface{}) string {
+	format, ok := messageCatalog[lang][code]
+	if !ok {
+		format = messageCatalog[LangEnglish][code]
+	}
+	if len(params) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, params...)
+}
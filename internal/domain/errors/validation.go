@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+)
+
+// FieldError は入力フィールド 1 つ分のバリデーションエラー
+// Field が空の場合は特定のフィールドに紐づかないエラー
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    Code   `json:"code"`
+	Message string `json:"message"`
+	// メッセージの書式に埋める値（ほかの言語で Message を組み立て直すために保持する）
+	Params []interface{} `json:"-"`
+}
+
+// Localize は Message を lang の言語にした FieldError を返す（Code がない場合はそのまま）
+func (e FieldError) Localize(lang string) FieldError {
+	if e.Code != "" {
+		e.Message = Message(lang, e.Code, e.Params...)
+	}
+	return e
+}
+
+func (e FieldError) String() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + " " + e.Message
+}
+
+// ValidationError は入力のすべてのバリデーションエラーを検出順に保持する
+// errors.Is(err, ErrInvalidInput) は true になる
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Add はフィールドのエラーを追加する（Message は code の英語のメッセージ）
+func (e *ValidationError) Add(field string, code Code, params ...interface{}) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Code: code, Message: Message(LangEnglish, code, params...), Params: params})
+}
+
+// Err はエラーがあれば e を、なければ nil を返す
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+	return e
+}
+
+// WithPrefix はフィールド名に prefix を付けた ValidationError を返す（一括登録の items[0] など）
+func (e *ValidationError) WithPrefix(prefix string) *ValidationError {
+	prefixed := &ValidationError{Fields: make([]FieldError, 0, len(e.Fields))}
+	for _, f := range e.Fields {
+		field := prefix
+		if f.Field != "" {
+			field += "." + f.Field
+		}
+		f.Field = field
+		prefixed.Fields = append(prefixed.Fields, f)
+	}
+	return prefixed
+}
+
+// Messages は各エラーを "フィールド名 メッセージ" の文字列にして返す
+func (e *ValidationError) Messages() []string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, f := range e.Fields {
+		messages = append(messages, f.String())
+	}
+	return messages
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Messages(), ", ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidInput
+}
+
+// AsValidationError は err に含まれる ValidationError を返す
+func AsValidationError(err error) (*ValidationError, bool) {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return verr, true
+	}
+	return nil, false
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+var errMissingToken = errors.New("missing bearer token")
+
+// Middleware は Authorization: Bearer <JWT>（HS256、secret で署名）を検証し、
+// subject を context に格納する。トークンがない・不正・期限切れの場合は 401 を返す
+func Middleware(secret []byte) echo.MiddlewareFunc {
+	parser := jwt.NewParser(
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	)
+	keyFunc := func(*jwt.Token) (interface{}, error) {
+		return secret, nil
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			subject, err := authenticate(parser, keyFunc, c.Request().Header.Get(echo.HeaderAuthorization))
+			if err != nil {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="items"`)
+				return itemController.WriteProblem(c, itemController.NewProblem(http.StatusUnauthorized, "missing or invalid bearer token"))
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(usecase.ContextWithSubject(req.Context(), subject)))
+			return next(c)
+		}
+	}
+}
+
+// ヘッダーからトークンを取り出して検証し、subject を返す
+func authenticate(parser *jwt.Parser, keyFunc jwt.Keyfunc, header string) (string, error) {
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", errMissingToken
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	if _, err := parser.ParseWithClaims(strings.TrimSpace(token), claims, keyFunc); err != nil {
+		return "", err
+	}
+	if claims.Subject == "" {
+		return "", errors.New("token has no subject")
+	}
+
+	return claims.Subject, nil
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+var testSecret = []byte("test-secret")
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	require.NoError(t, err)
+	return token
+}
+
+func TestMiddleware(t *testing.T) {
+	valid := jwt.RegisteredClaims{
+		Subject:   "user-1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	expired := jwt.RegisteredClaims{
+		Subject:   "user-1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+	}
+	validToken := signToken(t, jwt.SigningMethodHS256, testSecret, valid)
+
+	// ペイロードを書き換えて署名と一致しなくする
+	parts := strings.Split(validToken, ".")
+	tamperedClaims := signToken(t, jwt.SigningMethodHS256, testSecret, jwt.RegisteredClaims{
+		Subject:   "admin",
+		ExpiresAt: valid.ExpiresAt,
+	})
+	tampered := parts[0] + "." + strings.Split(tamperedClaims, ".")[1] + "." + parts[2]
+
+	tests := []struct {
+		name            string
+		authorization   string
+		expectedStatus  int
+		expectedSubject string
+	}{
+		{
+			name:            "valid token",
+			authorization:   "Bearer " + validToken,
+			expectedStatus:  http.StatusOK,
+			expectedSubject: "user-1",
+		},
+		{
+			name:           "expired token",
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, testSecret, expired),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "tampered token",
+			authorization:  "Bearer " + tampered,
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "wrong secret",
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, []byte("other-secret"), valid),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "token without expiry",
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodHS256, testSecret, jwt.RegisteredClaims{Subject: "user-1"}),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "unsigned token",
+			authorization:  "Bearer " + signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, valid),
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "missing token",
+			authorization:  "",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "non-bearer scheme",
+			authorization:  "Basic dXNlcjpwYXNz",
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			var subject string
+			e.POST("/items", func(c echo.Context) error {
+				subject = usecase.SubjectFromContext(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			}, Middleware(testSecret))
+
+			req := httptest.NewRequest(http.MethodPost, "/items", nil)
+			if tt.authorization != "" {
+				req.Header.Set(echo.HeaderAuthorization, tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedSubject, subject)
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, rec.Header().Get(echo.HeaderWWWAuthenticate))
+				assert.JSONEq(t, `{"type":"/problems/unauthorized","title":"Unauthorized","status":401,"detail":"missing or invalid bearer token","instance":"/items"}`, rec.Body.String())
+			}
+		})
+	}
+}
@@ -3,17 +3,127 @@ package config
 import (
 	"fmt"
 	"log"
+	"net"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
 
 var (
+	// データベースのドライバー（mysql / postgres）
+	DBDriver string
+	// 接続文字列（指定した場合は DB_USER などから組み立てずにそのまま使う）
+	DBDSN string
+
 	DBUser     string
 	DBPassword string
 	DBHost     string
 	DBName     string
 	DBPort     string
+
+	// リポジトリのメソッド 1 回あたりの SQL の実行時間の上限（秒、0 以下で無効）
+	DBQueryTimeoutSeconds int
+
+	// コネクションプールの同時接続数・待機接続数の上限と、接続を使い回す秒数
+	DBMaxOpenConns           int
+	DBMaxIdleConns           int
+	DBConnMaxLifetimeSeconds int
+
+	// 起動時にデータベースへの接続を試みる回数と、1 回目の再試行までの秒数（以降は 2 倍ずつ増やす）
+	DBConnectAttempts         int
+	DBConnectBaseDelaySeconds int
+
+	// GET /items で指定できる limit の上限
+	MaxPageLimit int
+
+	// Idempotency-Key を記録しておく秒数
+	IdempotencyTTLSeconds int
+
+	// カテゴリー別集計をキャッシュする秒数（0 以下で無効）
+	SummaryCacheTTLSeconds int
+
+	// アイテムのキャッシュに使う Redis の URL（空の場合はキャッシュしない）と、キャッシュする秒数
+	RedisURL            string
+	ItemCacheTTLSeconds int
+
+	// アイテムの作成・削除を通知する Webhook の URL（空の場合は通知しない）と、送信の再試行回数・待ち時間・タイムアウト
+	WebhookURLs             []string
+	WebhookMaxAttempts      int
+	WebhookBaseDelaySeconds int
+	WebhookTimeoutSeconds   int
+
+	// GET /docs（Swagger UI）を公開するか
+	EnableAPIDocs bool
+
+	// アイテム名の最大文字数（rune 数、DB のカラム長 100 が上限）
+	MaxNameLength int
+	// メモの最大文字数（rune 数、10000 が上限）
+	MaxNotesLength int
+
+	// アップロードした画像の保存先（local / s3）と、アップロードできる画像の最大バイト数
+	ImageStorage      string
+	MaxImageSizeBytes int
+	// local の場合に画像を保存するディレクトリと、配信する URL のベース（GET /images/* で配信する）
+	ImageDir     string
+	ImageBaseURL string
+	// s3 の場合のバケット・リージョン・S3 互換ストレージのエンドポイント（空の場合は AWS）・認証情報
+	S3Bucket           string
+	S3Region           string
+	S3Endpoint         string
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+
+	// CORS で許可するオリジン・メソッド・ヘッダー（オリジンが空の場合はクロスオリジンを許可しない）
+	CORSAllowedOrigins []string
+	CORSAllowedMethods []string
+	CORSAllowedHeaders []string
+
+	// 書き込み系エンドポイントのクライアントごとのレート制限（1 秒あたりのリクエスト数とバースト、0 以下で無効）
+	RateLimitRPS   float64
+	RateLimitBurst int
+	// レート制限で IP アドレスの代わりにクライアントの識別に使える X-API-Key の値（空の場合は常に IP アドレスで識別する）
+	RateLimitAPIKeys []string
+
+	// X-Forwarded-For を信頼するリバースプロキシのアドレス（CIDR、空の場合はヘッダーを信頼せず接続元のアドレスを使う）
+	TrustedProxies []string
+
+	// 書き込み系エンドポイントの JWT（HS256）を検証する秘密鍵（空の場合は認証しない）
+	JWTSecret string
+
+	// 読み取り系のアイテムエンドポイントにも認証を要求するか
+	AuthRequiredForReads bool
+
+	// アイテムを登録したユーザー（JWT の subject）ごとに分けるか（JWTSecret が必要で、読み取り系にも認証を要求する）
+	UserScopedItems bool
+
+	// このバイト数以上のレスポンスを gzip で圧縮する（0 以下で無効）
+	GzipMinLength int
+
+	// 書き込み系のエンドポイントが受け付けるリクエストボディの最大バイト数（0 以下で制限しない。画像のアップロードは MaxImageSizeBytes で制限する）
+	MaxBodyBytes int
+
+	// シャットダウン時に処理中のリクエストの完了を待つ秒数
+	ShutdownTimeoutSeconds int
+
+	// 書き込み系のエンドポイントをすべて 403 で拒否するか（読み取り専用のデモ環境など）
+	ReadOnly bool
+
+	// メンテナンスモードで起動するか（PUT /admin/maintenance で切り替えられる）と、メンテナンス中の Retry-After の秒数
+	MaintenanceMode              bool
+	MaintenanceRetryAfterSeconds int
+
+	// GET / PUT /admin/maintenance に要求する管理者トークン（空の場合はエンドポイントを登録しない）
+	AdminToken string
+
+	// ログレベル（debug / info / warn / error）
+	LogLevel string
+
+	// トレースを OTLP/HTTP で送る送信先（空の場合はトレースを記録しない）と、トレースに付けるサービス名
+	OTLPEndpoint    string
+	OTELServiceName string
 )
 
 func init() {
@@ -22,17 +132,160 @@ func init() {
 		log.Println("⚠️  .envファイルが見つかりませんでした。")
 	}
 
+	DBDriver = os.Getenv("DB_DRIVER")
+	if DBDriver == "" {
+		DBDriver = "mysql"
+	}
+	DBDSN = os.Getenv("DB_DSN")
 	DBUser = os.Getenv("DB_USER")
 	DBPassword = os.Getenv("DB_PASSWORD")
 	DBHost = os.Getenv("DB_HOST")
 	DBPort = os.Getenv("DB_PORT")
 	DBName = os.Getenv("DB_NAME")
+	DBQueryTimeoutSeconds = getEnvInt("DB_QUERY_TIMEOUT_SECONDS", 3)
+	DBMaxOpenConns = getEnvInt("DB_MAX_OPEN_CONNS", 25)
+	DBMaxIdleConns = getEnvInt("DB_MAX_IDLE_CONNS", 10)
+	DBConnMaxLifetimeSeconds = getEnvInt("DB_CONN_MAX_LIFETIME_SECONDS", 300)
+	DBConnectAttempts = getEnvInt("DB_CONNECT_ATTEMPTS", 5)
+	DBConnectBaseDelaySeconds = getEnvInt("DB_CONNECT_BASE_DELAY_SECONDS", 1)
+
+	MaxPageLimit = getEnvInt("MAX_PAGE_LIMIT", 100)
+	IdempotencyTTLSeconds = getEnvInt("IDEMPOTENCY_TTL_SECONDS", 86400)
+	SummaryCacheTTLSeconds = getEnvInt("SUMMARY_CACHE_TTL_SECONDS", 30)
+	RedisURL = os.Getenv("REDIS_URL")
+	ItemCacheTTLSeconds = getEnvInt("ITEM_CACHE_TTL_SECONDS", 60)
+	WebhookURLs = getEnvList("WEBHOOK_URLS", nil)
+	WebhookMaxAttempts = getEnvInt("WEBHOOK_MAX_ATTEMPTS", 5)
+	WebhookBaseDelaySeconds = getEnvInt("WEBHOOK_BASE_DELAY_SECONDS", 1)
+	WebhookTimeoutSeconds = getEnvInt("WEBHOOK_TIMEOUT_SECONDS", 5)
+	MaxNameLength = getEnvInt("MAX_NAME_LENGTH", 100)
+	MaxNotesLength = getEnvInt("MAX_NOTES_LENGTH", 1000)
+	ImageStorage = os.Getenv("IMAGE_STORAGE")
+	if ImageStorage == "" {
+		ImageStorage = "local"
+	}
+	MaxImageSizeBytes = getEnvInt("MAX_IMAGE_SIZE_BYTES", 5<<20)
+	ImageDir = os.Getenv("IMAGE_DIR")
+	if ImageDir == "" {
+		ImageDir = "./uploads"
+	}
+	ImageBaseURL = os.Getenv("IMAGE_BASE_URL")
+	if ImageBaseURL == "" {
+		ImageBaseURL = "http://localhost:8080/images"
+	}
+	S3Bucket = os.Getenv("S3_BUCKET")
+	S3Region = os.Getenv("S3_REGION")
+	S3Endpoint = os.Getenv("S3_ENDPOINT")
+	AWSAccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+	AWSSecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+	EnableAPIDocs = getEnvBool("ENABLE_API_DOCS", true)
+	CORSAllowedOrigins = getEnvList("CORS_ALLOWED_ORIGINS", nil)
+	CORSAllowedMethods = getEnvList("CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE"})
+	CORSAllowedHeaders = getEnvList("CORS_ALLOWED_HEADERS", []string{"Content-Type", "Idempotency-Key", "If-None-Match", "X-Dry-Run", "X-Request-ID"})
+	RateLimitRPS = getEnvFloat("RATE_LIMIT_RPS", 5)
+	RateLimitBurst = getEnvInt("RATE_LIMIT_BURST", 10)
+	RateLimitAPIKeys = getEnvList("RATE_LIMIT_API_KEYS", nil)
+	TrustedProxies = getEnvList("TRUSTED_PROXIES", nil)
+	JWTSecret = os.Getenv("JWT_SECRET")
+	AuthRequiredForReads = getEnvBool("AUTH_REQUIRED_FOR_READS", false)
+	UserScopedItems = getEnvBool("USER_SCOPED_ITEMS", false)
+	GzipMinLength = getEnvInt("GZIP_MIN_LENGTH", 1024)
+	MaxBodyBytes = getEnvInt("MAX_BODY_BYTES", 1<<20)
+	ShutdownTimeoutSeconds = getEnvInt("SHUTDOWN_TIMEOUT_SECONDS", 10)
+	ReadOnly = getEnvBool("READ_ONLY", false)
+	MaintenanceMode = getEnvBool("MAINTENANCE_MODE", false)
+	MaintenanceRetryAfterSeconds = getEnvInt("MAINTENANCE_RETRY_AFTER_SECONDS", 300)
+	AdminToken = os.Getenv("ADMIN_TOKEN")
+	LogLevel = os.Getenv("LOG_LEVEL")
+	OTLPEndpoint = os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	OTELServiceName = os.Getenv("OTEL_SERVICE_NAME")
+	if OTELServiceName == "" {
+		OTELServiceName = "aicon-assignment"
+	}
 }
 
-// DB接続文字列を返す
+// DB接続文字列を返す（DB_DSN が未設定の場合は DB_DRIVER に合わせて組み立てる）
 func GetDSN() string {
+	if DBDSN != "" {
+		return DBDSN
+	}
+	if DBDriver == "postgres" {
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(DBUser, DBPassword),
+			Host:     net.JoinHostPort(DBHost, DBPort),
+			Path:     "/" + DBName,
+			RawQuery: "sslmode=disable",
+		}
+		return dsn.String()
+	}
 	return fmt.Sprintf(
 		"%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&collation=utf8mb4_unicode_ci&parseTime=true&loc=Local&sql_mode=TRADITIONAL",
 		DBUser, DBPassword, DBHost, DBPort, DBName,
 	)
 }
+
+// 整数の環境変数を読み込む（未設定・不正値の場合はデフォルト値）
+func getEnvInt(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("⚠️  %s の値が不正です（%s）。デフォルト値 %d を使用します。", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// 小数の環境変数を読み込む（未設定・不正値の場合はデフォルト値）
+func getEnvFloat(key string, defaultValue float64) float64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		log.Printf("⚠️  %s の値が不正です（%s）。デフォルト値 %g を使用します。", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// 真偽値の環境変数を読み込む（未設定・不正値の場合はデフォルト値）
+func getEnvBool(key string, defaultValue bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("⚠️  %s の値が不正です（%s）。デフォルト値 %t を使用します。", key, value, defaultValue)
+		return defaultValue
+	}
+
+	return parsed
+}
+
+// カンマ区切りの環境変数を読み込む（未設定の場合はデフォルト値、空の要素は無視）
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var values []string
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+
+	return values
+}
@@ -3,111 +3,274 @@ package databaseInfra
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
 	"os"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
 
 	"Aicon-assignment/internal/infrastructure/config"
 	"Aicon-assignment/internal/interfaces/database"
 )
 
-type MySqlHandler struct {
+// ドライバーごとのスキーマ定義ファイル
+var initSQLFiles = map[database.Dialect]string{
+	database.DialectMySQL:    "sql/init.sql",
+	database.DialectPostgres: "sql/init.postgres.sql",
+}
+
+// SqlHandler は database/sql の接続を database.SqlHandler として扱う（MySQL・PostgreSQL 共通）
+type SqlHandler struct {
 	Conn *sql.DB
 }
 
+// PoolConfig は *sql.DB のコネクションプールの設定（0 以下の項目は database/sql のデフォルトのまま）
+type PoolConfig struct {
+	// 同時に開く接続数の上限
+	MaxOpenConns int
+	// 待機させておく接続数の上限（MaxOpenConns を超える場合は MaxOpenConns になる）
+	MaxIdleConns int
+	// 接続を使い回す期間の上限
+	ConnMaxLifetime time.Duration
+}
+
+// PoolConfigFromEnv は DB_MAX_OPEN_CONNS などの環境変数からコネクションプールの設定を返す
+func PoolConfigFromEnv() PoolConfig {
+	return PoolConfig{
+		MaxOpenConns:    config.DBMaxOpenConns,
+		MaxIdleConns:    config.DBMaxIdleConns,
+		ConnMaxLifetime: time.Duration(config.DBConnMaxLifetimeSeconds) * time.Second,
+	}
+}
+
+// Apply は設定を conn に反映する
+func (p PoolConfig) Apply(conn *sql.DB) {
+	if p.MaxOpenConns > 0 {
+		conn.SetMaxOpenConns(p.MaxOpenConns)
+	}
+	if p.MaxIdleConns > 0 {
+		conn.SetMaxIdleConns(p.MaxIdleConns)
+	}
+	if p.ConnMaxLifetime > 0 {
+		conn.SetConnMaxLifetime(p.ConnMaxLifetime)
+	}
+}
+
+// RetryConfig は起動時にデータベースへ接続できるまで再試行する設定
+// データベースのコンテナがアプリより遅れて起動する場合に備える
+type RetryConfig struct {
+	// 接続を試みる回数（1 以下の場合は 1 回だけ）
+	Attempts int
+	// 1 回目の失敗後に待つ時間（以降は失敗するたびに 2 倍にする）
+	BaseDelay time.Duration
+}
+
+// RetryConfigFromEnv は DB_CONNECT_ATTEMPTS などの環境変数から再試行の設定を返す
+func RetryConfigFromEnv() RetryConfig {
+	return RetryConfig{
+		Attempts:  config.DBConnectAttempts,
+		BaseDelay: time.Duration(config.DBConnectBaseDelaySeconds) * time.Second,
+	}
+}
+
+// withRetry は connect が成功するまで、指数的に待ち時間を増やしながら最大 cfg.Attempts 回呼び出す
+func withRetry(cfg RetryConfig, sleep func(time.Duration), connect func() error) error {
+	attempts := max(cfg.Attempts, 1)
+	delay := cfg.BaseDelay
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = connect(); err == nil {
+			return nil
+		}
+		if attempt == attempts {
+			break
+		}
+		slog.Warn("database connection failed, retrying",
+			"attempt", attempt, "max_attempts", attempts, "retry_in", delay.String(), "error", err.Error())
+		sleep(delay)
+		delay *= 2
+	}
+	return fmt.Errorf("gave up after %d attempts: %w", attempts, err)
+}
+
+// NewSqlHandler は DB_DRIVER のデータベースに接続し、スキーマ定義ファイルを実行する
 func NewSqlHandler() database.SqlHandler {
-	dsn := config.GetDSN()
-	conn, err := sql.Open("mysql", dsn)
+	dialect := database.Dialect(config.DBDriver)
+	handler, err := Open(dialect, config.GetDSN(), PoolConfigFromEnv(), RetryConfigFromEnv())
 	if err != nil {
 		panic(fmt.Sprintf("❌ Failed to connect to database: %v", err))
 	}
 
-	// DB接続が確立できているかを確認
-	if err := conn.Ping(); err != nil {
-		panic(fmt.Sprintf("❌ Failed to ping database: %v", err))
-	}
-
 	fmt.Println("✅ Successfully connected to the database!")
 
-	sqlBytes, err := os.ReadFile("sql/init.sql")
+	sqlBytes, err := os.ReadFile(initSQLFiles[dialect])
 	if err != nil {
-		fmt.Printf("❌ Failed to read init.sql: %v\n", err)
+		fmt.Printf("❌ Failed to read %s: %v\n", initSQLFiles[dialect], err)
 	} else {
-		if _, err := conn.Exec(string(sqlBytes)); err != nil {
-			fmt.Printf("❌ Failed to execute init.sql: %v\n", err)
+		if _, err := handler.Conn.Exec(string(sqlBytes)); err != nil {
+			fmt.Printf("❌ Failed to execute %s: %v\n", initSQLFiles[dialect], err)
 		} else {
-			fmt.Println("✅ Successfully initialized database from init.sql")
+			fmt.Printf("✅ Successfully initialized database from %s\n", initSQLFiles[dialect])
 		}
 	}
 
-	return &MySqlHandler{Conn: conn}
+	return handler
 }
 
-func (h *MySqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
-	result, err := h.Conn.ExecContext(ctx, statement, args...)
+// Open は dialect のドライバーで dsn のデータベースに接続し、接続できることを確認する
+// 接続できない場合は retry の設定に従って再試行する
+func Open(dialect database.Dialect, dsn string, pool PoolConfig, retry RetryConfig) (*SqlHandler, error) {
+	if !dialect.IsValid() {
+		return nil, fmt.Errorf("unsupported database driver: %q", dialect)
+	}
+
+	conn, err := sql.Open(string(dialect), dsn)
 	if err != nil {
 		return nil, err
 	}
-	return &mysqlResult{result: result}, nil
+	pool.Apply(conn)
+
+	// DB接続が確立できているかを確認
+	if err := withRetry(retry, time.Sleep, conn.Ping); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return &SqlHandler{Conn: conn}, nil
+}
+
+func (h *SqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	result, err := h.Conn.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return nil, driverError(err)
+	}
+	return &sqlResult{result: result}, nil
 }
 
-func (h *MySqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+func (h *SqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
 	rows, err := h.Conn.QueryContext(ctx, statement, args...)
 	if err != nil {
 		return nil, err
 	}
-	return &mysqlRows{rows: rows}, nil
+	return &sqlRows{rows: rows}, nil
 }
 
-func (h *MySqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+func (h *SqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
 	row := h.Conn.QueryRowContext(ctx, statement, args...)
-	return &mysqlRow{row: row}
+	return &sqlRow{row: row}
+}
+
+func (h *SqlHandler) Begin(ctx context.Context) (database.Tx, error) {
+	tx, err := h.Conn.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlTx{tx: tx}, nil
 }
 
-func (h *MySqlHandler) Close() error {
+func (h *SqlHandler) Ping(ctx context.Context) error {
+	return h.Conn.PingContext(ctx)
+}
+
+func (h *SqlHandler) Close() error {
 	if h.Conn != nil {
 		return h.Conn.Close()
 	}
 	return nil
 }
 
-type mysqlResult struct {
+type sqlTx struct {
+	tx *sql.Tx
+}
+
+func (t *sqlTx) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	result, err := t.tx.ExecContext(ctx, statement, args...)
+	if err != nil {
+		return nil, driverError(err)
+	}
+	return &sqlResult{result: result}, nil
+}
+
+func (t *sqlTx) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	rows, err := t.tx.QueryContext(ctx, statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	return &sqlRows{rows: rows}, nil
+}
+
+func (t *sqlTx) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	row := t.tx.QueryRowContext(ctx, statement, args...)
+	return &sqlRow{row: row}
+}
+
+func (t *sqlTx) Commit() error {
+	return t.tx.Commit()
+}
+
+func (t *sqlTx) Rollback() error {
+	return t.tx.Rollback()
+}
+
+type sqlResult struct {
 	result sql.Result
 }
 
-func (r *mysqlResult) LastInsertId() (int64, error) {
+func (r *sqlResult) LastInsertId() (int64, error) {
 	return r.result.LastInsertId()
 }
 
-func (r *mysqlResult) RowsAffected() (int64, error) {
+func (r *sqlResult) RowsAffected() (int64, error) {
 	return r.result.RowsAffected()
 }
 
-type mysqlRows struct {
+type sqlRows struct {
 	rows *sql.Rows
 }
 
-func (r *mysqlRows) Next() bool {
+func (r *sqlRows) Next() bool {
 	return r.rows.Next()
 }
 
-func (r *mysqlRows) Scan(dest ...interface{}) error {
+func (r *sqlRows) Scan(dest ...interface{}) error {
 	return r.rows.Scan(dest...)
 }
 
-func (r *mysqlRows) Close() error {
+func (r *sqlRows) Close() error {
 	return r.rows.Close()
 }
 
-func (r *mysqlRows) Err() error {
+func (r *sqlRows) Err() error {
 	return r.rows.Err()
 }
 
-type mysqlRow struct {
+type sqlRow struct {
 	row *sql.Row
 }
 
-func (r *mysqlRow) Scan(dest ...interface{}) error {
-	return r.row.Scan(dest...)
+func (r *sqlRow) Scan(dest ...interface{}) error {
+	return driverError(r.row.Scan(dest...))
+}
+
+// 一意制約違反のエラー（MySQL のエラー番号と PostgreSQL の SQLSTATE）
+const (
+	mysqlDuplicateEntry     = 1062
+	postgresUniqueViolation = "23505"
+)
+
+// driverError はドライバーの一意制約違反のエラーを database.ErrUniqueViolation でラップする（それ以外はそのまま返す）
+// PostgreSQL の INSERT ... RETURNING id は QueryRow で実行するため、Scan のエラーも対象にする
+func driverError(err error) error {
+	var mysqlErr *mysql.MySQLError
+	var pqErr *pq.Error
+	switch {
+	case errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry:
+	case errors.As(err, &pqErr) && pqErr.Code == postgresUniqueViolation:
+	default:
+		return err
+	}
+	return fmt.Errorf("%w: %w", database.ErrUniqueViolation, err)
 }
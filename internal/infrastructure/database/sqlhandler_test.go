@@ -0,0 +1,126 @@
+package databaseInfra
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/config"
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// sql.Open は接続しないため、データベースがなくても設定を確認できる
+func openWithoutConnecting(t *testing.T) *sql.DB {
+	t.Helper()
+	conn, err := sql.Open("mysql", "user:password@tcp(127.0.0.1:1)/items_db")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestPoolConfig_Apply(t *testing.T) {
+	t.Run("settings are applied", func(t *testing.T) {
+		conn := openWithoutConnecting(t)
+
+		PoolConfig{MaxOpenConns: 7, MaxIdleConns: 3, ConnMaxLifetime: time.Minute}.Apply(conn)
+
+		assert.Equal(t, 7, conn.Stats().MaxOpenConnections)
+	})
+
+	t.Run("zero values keep the defaults", func(t *testing.T) {
+		conn := openWithoutConnecting(t)
+
+		PoolConfig{}.Apply(conn)
+
+		// database/sql のデフォルトは無制限
+		assert.Equal(t, 0, conn.Stats().MaxOpenConnections)
+	})
+}
+
+func TestPoolConfigFromEnv(t *testing.T) {
+	defer func(open, idle, lifetime int) {
+		config.DBMaxOpenConns, config.DBMaxIdleConns, config.DBConnMaxLifetimeSeconds = open, idle, lifetime
+	}(config.DBMaxOpenConns, config.DBMaxIdleConns, config.DBConnMaxLifetimeSeconds)
+	config.DBMaxOpenConns = 40
+	config.DBMaxIdleConns = 20
+	config.DBConnMaxLifetimeSeconds = 120
+
+	assert.Equal(t, PoolConfig{MaxOpenConns: 40, MaxIdleConns: 20, ConnMaxLifetime: 2 * time.Minute}, PoolConfigFromEnv())
+}
+
+func TestWithRetry(t *testing.T) {
+	t.Run("succeeds after failures", func(t *testing.T) {
+		calls := 0
+		// 2 回失敗してから接続できる
+		connect := func() error {
+			calls++
+			if calls < 3 {
+				return errors.New("connection refused")
+			}
+			return nil
+		}
+		var delays []time.Duration
+
+		err := withRetry(RetryConfig{Attempts: 5, BaseDelay: 100 * time.Millisecond}, func(d time.Duration) { delays = append(delays, d) }, connect)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, delays)
+	})
+
+	t.Run("gives up after the configured attempts", func(t *testing.T) {
+		calls := 0
+		refused := errors.New("connection refused")
+		var delays []time.Duration
+
+		err := withRetry(RetryConfig{Attempts: 3, BaseDelay: time.Second}, func(d time.Duration) { delays = append(delays, d) }, func() error {
+			calls++
+			return refused
+		})
+
+		assert.ErrorIs(t, err, refused)
+		assert.Contains(t, err.Error(), "gave up after 3 attempts")
+		assert.Equal(t, 3, calls)
+		// 最後の失敗の後は待たない
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, delays)
+	})
+
+	t.Run("zero attempts tries once", func(t *testing.T) {
+		calls := 0
+
+		err := withRetry(RetryConfig{}, func(time.Duration) { t.Error("sleep should not be called") }, func() error {
+			calls++
+			return errors.New("connection refused")
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestDriverError(t *testing.T) {
+	t.Run("unique violations are wrapped", func(t *testing.T) {
+		for _, err := range []error{
+			&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '時計-rolex' for key 'items.uq_category_name'"},
+			fmt.Errorf("insert: %w", &pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint \"uq_category_name\""}),
+		} {
+			wrapped := driverError(err)
+			assert.ErrorIs(t, wrapped, database.ErrUniqueViolation)
+			assert.ErrorIs(t, wrapped, err)
+		}
+	})
+
+	t.Run("other errors are returned as is", func(t *testing.T) {
+		mysqlErr := &mysql.MySQLError{Number: 1452, Message: "Cannot add or update a child row"}
+		assert.Same(t, mysqlErr, driverError(mysqlErr))
+		assert.Equal(t, sql.ErrNoRows, driverError(sql.ErrNoRows))
+		assert.NoError(t, driverError(nil))
+	})
+}
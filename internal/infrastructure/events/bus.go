@@ -0,0 +1,80 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 購読者ごとに受け取り待ちにできるイベント数（超えた分はその購読者には届けない）
+const subscriberBuffer = 16
+
+// Bus はアイテムの変更イベントを同じプロセス内の購読者（GET /items/events の接続など）に配る
+// usecase.ItemEventPublisher の実装で、Publish は購読者の受け取りを待たない
+type Bus struct {
+	mu          sync.Mutex
+	closed      bool
+	subscribers map[*subscriber]struct{}
+}
+
+type subscriber struct {
+	events chan entity.ItemEvent
+	once   sync.Once
+}
+
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[*subscriber]struct{})}
+}
+
+// Subscribe は以降に発行されたイベントを受け取るチャネルと、購読をやめる関数を返す
+// チャネルは unsubscribe または Close で閉じられる
+func (b *Bus) Subscribe() (<-chan entity.ItemEvent, func()) {
+	sub := &subscriber{events: make(chan entity.ItemEvent, subscriberBuffer)}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		close(sub.events)
+		return sub.events, func() {}
+	}
+	b.subscribers[sub] = struct{}{}
+
+	return sub.events, func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if _, ok := b.subscribers[sub]; ok {
+			delete(b.subscribers, sub)
+			sub.close()
+		}
+	}
+}
+
+// Publish は event をすべての購読者に送る。受け取り待ちがいっぱいの購読者には送らずログに残す
+func (b *Bus) Publish(ctx context.Context, event entity.ItemEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			slog.WarnContext(ctx, "event subscriber is too slow; event dropped", "type", event.Type)
+		}
+	}
+}
+
+// Close はすべての購読を終了する（シャットダウン時に SSE の接続を閉じるため）
+func (b *Bus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+	for sub := range b.subscribers {
+		sub.close()
+	}
+	clear(b.subscribers)
+}
+
+func (s *subscriber) close() {
+	s.once.Do(func() { close(s.events) })
+}
@@ -0,0 +1,66 @@
+package events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+func TestBus(t *testing.T) {
+	ctx := context.Background()
+	created := entity.ItemEvent{Type: entity.ItemEventCreated, Item: &entity.Item{ID: 1}}
+
+	t.Run("every subscriber receives the event", func(t *testing.T) {
+		bus := NewBus()
+		first, unsubscribeFirst := bus.Subscribe()
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := bus.Subscribe()
+		defer unsubscribeSecond()
+
+		bus.Publish(ctx, created)
+
+		assert.Equal(t, created, <-first)
+		assert.Equal(t, created, <-second)
+	})
+
+	t.Run("unsubscribe closes the channel and stops delivery", func(t *testing.T) {
+		bus := NewBus()
+		events, unsubscribe := bus.Subscribe()
+		unsubscribe()
+		unsubscribe()
+
+		bus.Publish(ctx, created)
+
+		_, ok := <-events
+		assert.False(t, ok)
+	})
+
+	t.Run("a slow subscriber does not block publishing", func(t *testing.T) {
+		bus := NewBus()
+		events, unsubscribe := bus.Subscribe()
+		defer unsubscribe()
+
+		for i := 0; i < subscriberBuffer+5; i++ {
+			bus.Publish(ctx, created)
+		}
+
+		assert.Len(t, events, subscriberBuffer)
+	})
+
+	t.Run("close ends every subscription", func(t *testing.T) {
+		bus := NewBus()
+		events, unsubscribe := bus.Subscribe()
+		bus.Close()
+		unsubscribe()
+
+		_, ok := <-events
+		assert.False(t, ok)
+
+		late, _ := bus.Subscribe()
+		_, ok = <-late
+		assert.False(t, ok)
+	})
+}
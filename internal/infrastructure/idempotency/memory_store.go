@@ -0,0 +1,89 @@
+package idempotency
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryStore は Idempotency-Key と作成済みアイテム ID の対応をメモリ上に TTL 付きで保持する
+// キーは呼び出したユーザー（JWT の subject）ごとに分け、他のユーザーの同じキーとは衝突しない
+// 同じキーの同時リクエストは直列化され、作成処理は 1 回だけ実行される
+type MemoryStore struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[entryKey]*entry
+	now     func() time.Time
+}
+
+// entryKey はユーザーと Idempotency-Key の組
+type entryKey struct {
+	subject string
+	key     string
+}
+
+type entry struct {
+	done      chan struct{}
+	completed bool
+	itemID    int64
+	err       error
+	expiresAt time.Time
+}
+
+func NewMemoryStore(ttl time.Duration) *MemoryStore {
+	return &MemoryStore{
+		ttl:     ttl,
+		entries: make(map[entryKey]*entry),
+		now:     time.Now,
+	}
+}
+
+// Do は subject の key が有効期限内に記録済みならその ID を replayed=true で返し、
+// 未記録なら create を実行して結果を記録する。create が失敗した場合は記録しない
+func (s *MemoryStore) Do(subject, key string, create func() (int64, error)) (int64, bool, error) {
+	k := entryKey{subject: subject, key: key}
+	for {
+		s.mu.Lock()
+		s.evictExpired()
+		if e, ok := s.entries[k]; ok {
+			s.mu.Unlock()
+
+			// 先行リクエストの完了を待つ
+			<-e.done
+			if e.err != nil {
+				// 先行リクエストが失敗した場合は記録が消えているので再試行する
+				continue
+			}
+			return e.itemID, true, nil
+		}
+
+		e := &entry{done: make(chan struct{})}
+		s.entries[k] = e
+		s.mu.Unlock()
+
+		itemID, err := create()
+
+		s.mu.Lock()
+		e.completed = true
+		if err != nil {
+			e.err = err
+			delete(s.entries, k)
+		} else {
+			e.itemID = itemID
+			e.expiresAt = s.now().Add(s.ttl)
+		}
+		s.mu.Unlock()
+		close(e.done)
+
+		return itemID, false, err
+	}
+}
+
+// 期限切れのエントリを削除する（呼び出し側で mu を保持していること）
+func (s *MemoryStore) evictExpired() {
+	now := s.now()
+	for key, e := range s.entries {
+		if e.completed && !now.Before(e.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package idempotency
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore_Do(t *testing.T) {
+	t.Run("fresh key runs create", func(t *testing.T) {
+		store := NewMemoryStore(time.Hour)
+
+		id, replayed, err := store.Do("alice", "key-1", func() (int64, error) { return 10, nil })
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), id)
+		assert.False(t, replayed)
+	})
+
+	t.Run("replayed key returns the recorded id", func(t *testing.T) {
+		store := NewMemoryStore(time.Hour)
+		_, _, err := store.Do("alice", "key-1", func() (int64, error) { return 10, nil })
+		require.NoError(t, err)
+
+		id, replayed, err := store.Do("alice", "key-1", func() (int64, error) {
+			t.Fatal("create must not run for a replayed key")
+			return 0, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), id)
+		assert.True(t, replayed)
+	})
+
+	t.Run("same key from another subject runs create", func(t *testing.T) {
+		store := NewMemoryStore(time.Hour)
+		_, _, err := store.Do("alice", "key-1", func() (int64, error) { return 10, nil })
+		require.NoError(t, err)
+
+		id, replayed, err := store.Do("bob", "key-1", func() (int64, error) { return 11, nil })
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), id)
+		assert.False(t, replayed)
+	})
+
+	t.Run("expired key runs create again", func(t *testing.T) {
+		store := NewMemoryStore(time.Minute)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		store.now = func() time.Time { return now }
+		_, _, err := store.Do("alice", "key-1", func() (int64, error) { return 10, nil })
+		require.NoError(t, err)
+
+		now = now.Add(time.Minute)
+		id, replayed, err := store.Do("alice", "key-1", func() (int64, error) { return 11, nil })
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), id)
+		assert.False(t, replayed)
+	})
+
+	t.Run("failed create is not recorded", func(t *testing.T) {
+		store := NewMemoryStore(time.Hour)
+		_, _, err := store.Do("alice", "key-1", func() (int64, error) { return 0, errors.New("boom") })
+		require.Error(t, err)
+
+		id, replayed, err := store.Do("alice", "key-1", func() (int64, error) { return 12, nil })
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), id)
+		assert.False(t, replayed)
+	})
+
+	t.Run("concurrent requests with the same key create once", func(t *testing.T) {
+		store := NewMemoryStore(time.Hour)
+		var calls int32
+		release := make(chan struct{})
+
+		const workers = 10
+		ids := make([]int64, workers)
+		var wg sync.WaitGroup
+		for i := 0; i < workers; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				id, _, err := store.Do("alice", "key-1", func() (int64, error) {
+					atomic.AddInt32(&calls, 1)
+					<-release
+					return 42, nil
+				})
+				assert.NoError(t, err)
+				ids[i] = id
+			}(i)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls)
+		for _, id := range ids {
+			assert.Equal(t, int64(42), id)
+		}
+	})
+}
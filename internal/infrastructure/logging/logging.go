@@ -0,0 +1,94 @@
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// NewLogger は JSON 形式で出力する slog.Logger を返す
+// level は debug / info / warn / error（不正な値は info）
+func NewLogger(w io.Writer, level string) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: ParseLevel(level)}))
+}
+
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Middleware はリクエストごとに method / path / status / latency / request_id を 1 行の JSON で記録する
+// 更新系のリクエストでは対象アイテムの ID（:id パラメータ）も記録する
+func Middleware(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			err := next(c)
+			if err != nil {
+				// ステータスコードを確定させるため、ここでエラーハンドラーを呼ぶ
+				c.Error(err)
+			}
+
+			req := c.Request()
+			res := c.Response()
+
+			requestID := res.Header().Get(echo.HeaderXRequestID)
+			if requestID == "" {
+				requestID = req.Header.Get(echo.HeaderXRequestID)
+			}
+
+			attrs := []slog.Attr{
+				slog.String("method", req.Method),
+				slog.String("path", req.URL.Path),
+				slog.String("route", c.Path()),
+				slog.Int("status", res.Status),
+				slog.Duration("latency", time.Since(start)),
+				slog.String("request_id", requestID),
+			}
+			if isMutation(req.Method) {
+				if id := c.Param("id"); id != "" {
+					attrs = append(attrs, slog.String("item_id", id))
+				}
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+
+			logger.LogAttrs(req.Context(), levelFor(res.Status), "request", attrs...)
+			return nil
+		}
+	}
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// 5xx は error、4xx は warn、それ以外は info
+func levelFor(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
@@ -0,0 +1,81 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	newServer := func(buf *bytes.Buffer, level string) *echo.Echo {
+		e := echo.New()
+		e.Use(Middleware(NewLogger(buf, level)))
+		e.GET("/items", func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		})
+		e.PATCH("/items/:id", func(c echo.Context) error {
+			return c.NoContent(http.StatusNotFound)
+		})
+		return e
+	}
+
+	decode := func(t *testing.T, buf *bytes.Buffer) map[string]interface{} {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		return entry
+	}
+
+	t.Run("logs request fields as JSON", func(t *testing.T) {
+		var buf bytes.Buffer
+		e := newServer(&buf, "info")
+
+		req := httptest.NewRequest(http.MethodGet, "/items?limit=1", nil)
+		req.Header.Set(echo.HeaderXRequestID, "req-123")
+		e.ServeHTTP(httptest.NewRecorder(), req)
+
+		entry := decode(t, &buf)
+		assert.Equal(t, "INFO", entry["level"])
+		assert.Equal(t, "request", entry["msg"])
+		assert.Equal(t, "GET", entry["method"])
+		assert.Equal(t, "/items", entry["path"])
+		assert.Equal(t, float64(http.StatusOK), entry["status"])
+		assert.Equal(t, "req-123", entry["request_id"])
+		assert.Contains(t, entry, "latency")
+		assert.NotContains(t, entry, "item_id")
+	})
+
+	t.Run("mutation routes log the item id", func(t *testing.T) {
+		var buf bytes.Buffer
+		e := newServer(&buf, "info")
+
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPatch, "/items/42", nil))
+
+		entry := decode(t, &buf)
+		assert.Equal(t, "42", entry["item_id"])
+		assert.Equal(t, "/items/:id", entry["route"])
+		assert.Equal(t, "WARN", entry["level"])
+	})
+
+	t.Run("level filters output", func(t *testing.T) {
+		var buf bytes.Buffer
+		e := newServer(&buf, "error")
+
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/items", nil))
+
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestParseLevel(t *testing.T) {
+	assert.Equal(t, slog.LevelDebug, ParseLevel("debug"))
+	assert.Equal(t, slog.LevelWarn, ParseLevel("WARN"))
+	assert.Equal(t, slog.LevelError, ParseLevel("error"))
+	assert.Equal(t, slog.LevelInfo, ParseLevel("unknown"))
+}
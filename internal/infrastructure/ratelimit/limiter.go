@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// API キーを指定するヘッダー（WithAPIKeys で登録したキーの場合のみ、IP アドレスの代わりにクライアントの識別に使う）
+const HeaderAPIKey = "X-API-Key"
+
+// Limiter はクライアントごとのトークンバケットでリクエスト数を制限する
+// バケットは 1 秒あたり rate 個のトークンが補充され、最大 burst 個まで貯まる
+type Limiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   float64
+	buckets map[string]*bucket
+	// クライアントの識別に使える API キー
+	apiKeys map[string]bool
+	now     func() time.Time
+}
+
+// Limiter の設定を変更するオプション
+type Option func(*Limiter)
+
+// WithAPIKeys は keys のいずれかを X-API-Key に指定したリクエストを、IP アドレスではなく API キーごとに制限する
+// 登録していないキーは無視して IP アドレスで識別するため、任意のキーでバケットを増やして制限を逃れることはできない
+func WithAPIKeys(keys ...string) Option {
+	return func(l *Limiter) {
+		for _, key := range keys {
+			l.apiKeys[key] = true
+		}
+	}
+}
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+func NewLimiter(rate float64, burst int, opts ...Option) *Limiter {
+	l := &Limiter{
+		rate:    rate,
+		burst:   float64(burst),
+		buckets: make(map[string]*bucket),
+		apiKeys: make(map[string]bool),
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Allow は key のバケットからトークンを 1 つ消費する
+// トークンが足りない場合は false と、次のトークンが補充されるまでの時間を返す
+func (l *Limiter) Allow(key string) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: l.burst, lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.lastSeen).Seconds()*l.rate)
+	b.lastSeen = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+	return false, wait
+}
+
+// Sweep は満タンまで補充されたバケットを削除する（新規作成と同じ状態のため、削除しても制限は変わらない）
+func (l *Limiter) Sweep() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.lastSeen).Seconds()*l.rate >= l.burst {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// StartSweeper は interval ごとに Sweep を実行し、リクエストの処理中にはバケットを走査しない
+// 返す関数を呼ぶと停止する
+func (l *Limiter) StartSweeper(interval time.Duration) (stop func()) {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				l.Sweep()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
+}
+
+// Middleware は登録した API キー（なければクライアントの IP アドレス）ごとにリクエスト数を制限し、
+// 超過した場合は Retry-After ヘッダー付きで 429 を返す
+func (l *Limiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ok, wait := l.Allow(l.clientKey(c))
+			if ok {
+				return next(c)
+			}
+
+			c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			return itemController.WriteProblem(c, itemController.NewProblem(http.StatusTooManyRequests, "rate limit exceeded"))
+		}
+	}
+}
+
+// API キーと IP アドレスが衝突しないよう接頭辞を付ける
+// IP アドレスは Echo の IPExtractor で取得する（信頼するプロキシ以外の X-Forwarded-For は使わない）
+func (l *Limiter) clientKey(c echo.Context) string {
+	if key := c.Request().Header.Get(HeaderAPIKey); key != "" && l.apiKeys[key] {
+		return "key:" + key
+	}
+	return "ip:" + c.RealIP()
+}
@@ -0,0 +1,124 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// テスト用に時刻を進められる Limiter を返す
+func newTestLimiter(rate float64, burst int, opts ...Option) (*Limiter, *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter := NewLimiter(rate, burst, opts...)
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	t.Run("burst is allowed then denied", func(t *testing.T) {
+		limiter, _ := newTestLimiter(1, 3)
+
+		for i := 0; i < 3; i++ {
+			ok, _ := limiter.Allow("client")
+			assert.True(t, ok, "request %d should be allowed", i+1)
+		}
+
+		ok, wait := limiter.Allow("client")
+		assert.False(t, ok)
+		assert.Equal(t, time.Second, wait)
+	})
+
+	t.Run("bucket refills over time", func(t *testing.T) {
+		limiter, now := newTestLimiter(2, 1)
+
+		ok, _ := limiter.Allow("client")
+		require.True(t, ok)
+		ok, wait := limiter.Allow("client")
+		require.False(t, ok)
+		assert.Equal(t, 500*time.Millisecond, wait)
+
+		*now = now.Add(500 * time.Millisecond)
+		ok, _ = limiter.Allow("client")
+		assert.True(t, ok)
+	})
+
+	t.Run("clients have separate buckets", func(t *testing.T) {
+		limiter, _ := newTestLimiter(1, 1)
+
+		ok, _ := limiter.Allow("a")
+		require.True(t, ok)
+		ok, _ = limiter.Allow("b")
+		assert.True(t, ok)
+	})
+
+	t.Run("sweep evicts only full buckets", func(t *testing.T) {
+		limiter, now := newTestLimiter(1, 2)
+
+		limiter.Allow("client")
+		*now = now.Add(time.Minute)
+		limiter.Allow("other")
+		assert.Contains(t, limiter.buckets, "client")
+
+		limiter.Sweep()
+
+		assert.NotContains(t, limiter.buckets, "client")
+		assert.Contains(t, limiter.buckets, "other")
+	})
+
+	t.Run("sweeper runs until stopped", func(t *testing.T) {
+		limiter := NewLimiter(1000, 1)
+		limiter.Allow("client")
+
+		stop := limiter.StartSweeper(time.Millisecond)
+		defer stop()
+
+		assert.Eventually(t, func() bool {
+			limiter.mu.Lock()
+			defer limiter.mu.Unlock()
+			return len(limiter.buckets) == 0
+		}, time.Second, time.Millisecond)
+	})
+}
+
+func TestLimiter_Middleware(t *testing.T) {
+	e := echo.New()
+	e.IPExtractor = echo.ExtractIPDirect()
+	limiter, now := newTestLimiter(0.5, 2, WithAPIKeys("key-1"))
+	e.POST("/items", func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	}, limiter.Middleware())
+
+	post := func(header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items", nil)
+		req.RemoteAddr = "192.0.2.1:1234"
+		if header != "" {
+			req.Header.Set(header, value)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, http.StatusCreated, post("", "").Code)
+	assert.Equal(t, http.StatusCreated, post("", "").Code)
+
+	rec := post("", "")
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "2", rec.Header().Get(echo.HeaderRetryAfter))
+	assert.JSONEq(t, `{"type":"/problems/too-many-requests","title":"Too Many Requests","status":429,"detail":"rate limit exceeded","instance":"/items"}`, rec.Body.String())
+
+	// 登録した API キーを指定した場合は IP アドレスとは別のバケットになる
+	assert.Equal(t, http.StatusCreated, post(HeaderAPIKey, "key-1").Code)
+	// 登録していないキーや X-Forwarded-For では IP アドレスのバケットから逃れられない
+	assert.Equal(t, http.StatusTooManyRequests, post(HeaderAPIKey, "unknown").Code)
+	assert.Equal(t, http.StatusTooManyRequests, post(echo.HeaderXForwardedFor, "198.51.100.7").Code)
+
+	// 補充後は再び受け付ける
+	*now = now.Add(2 * time.Second)
+	assert.Equal(t, http.StatusCreated, post("", "").Code)
+}
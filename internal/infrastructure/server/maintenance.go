@@ -0,0 +1,96 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// MaintenanceMode はメンテナンス中に書き込み系のエンドポイントを 503 で拒否する（読み取り系はそのまま応答する）
+// 状態は PUT /admin/maintenance で再起動せずに切り替えられる
+type MaintenanceMode struct {
+	enabled    atomic.Bool
+	retryAfter time.Duration
+}
+
+// MaintenanceStatus は GET / PUT /admin/maintenance のボディ
+type MaintenanceStatus struct {
+	Enabled bool `json:"enabled"`
+}
+
+// NewMaintenanceMode は enabled の状態で始まるメンテナンスモードを返す
+// retryAfter は拒否したレスポンスの Retry-After（秒に切り上げる）
+func NewMaintenanceMode(enabled bool, retryAfter time.Duration) *MaintenanceMode {
+	m := &MaintenanceMode{retryAfter: retryAfter}
+	m.enabled.Store(enabled)
+	return m
+}
+
+func (m *MaintenanceMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+func (m *MaintenanceMode) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// middleware はメンテナンス中のリクエストを Retry-After ヘッダー付きの 503 で拒否する
+func (m *MaintenanceMode) middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !m.Enabled() {
+				return next(c)
+			}
+
+			c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(m.retryAfter.Seconds()))))
+			return itemController.WriteProblem(c, itemController.NewProblem(http.StatusServiceUnavailable, "server is under maintenance"))
+		}
+	}
+}
+
+// adminTokenMiddleware は Authorization: Bearer <token> を要求する
+// トークンがない場合は 401、一致しない場合は 403 を返す
+func adminTokenMiddleware(token string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			scheme, given, ok := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+			given = strings.TrimSpace(given)
+			if !ok || !strings.EqualFold(scheme, "Bearer") || given == "" {
+				c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer realm="admin"`)
+				return itemController.WriteProblem(c, itemController.NewProblem(http.StatusUnauthorized, "missing admin token"))
+			}
+			if subtle.ConstantTimeCompare([]byte(given), []byte(token)) != 1 {
+				return itemController.WriteProblem(c, itemController.NewProblem(http.StatusForbidden, "invalid admin token"))
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// getStatus は現在のメンテナンスモードの状態を返す
+func (m *MaintenanceMode) getStatus(c echo.Context) error {
+	return c.JSON(http.StatusOK, MaintenanceStatus{Enabled: m.Enabled()})
+}
+
+// putStatus はメンテナンスモードを切り替え、切り替えた後の状態を返す
+func (m *MaintenanceMode) putStatus(c echo.Context) error {
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if err := json.NewDecoder(c.Request().Body).Decode(&body); err != nil || body.Enabled == nil {
+		return itemController.WriteProblem(c, itemController.NewProblem(http.StatusBadRequest, "invalid request body", "enabled must be a boolean"))
+	}
+
+	m.SetEnabled(*body.Enabled)
+	return c.JSON(http.StatusOK, MaintenanceStatus{Enabled: m.Enabled()})
+}
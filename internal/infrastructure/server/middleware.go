@@ -0,0 +1,127 @@
+package server
+
+import (
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+// requestIDMiddleware は X-Request-ID（受信したものがあればそれを、なければ新規に生成）を
+// レスポンスヘッダーに設定し、usecase から参照できるよう context にも格納する
+func requestIDMiddleware() echo.MiddlewareFunc {
+	return middleware.RequestIDWithConfig(middleware.RequestIDConfig{
+		RequestIDHandler: func(c echo.Context, requestID string) {
+			req := c.Request()
+			c.SetRequest(req.WithContext(usecase.ContextWithRequestID(req.Context(), requestID)))
+		},
+	})
+}
+
+// recoverMiddleware はハンドラーの panic を回復し、スタックトレースをリクエスト ID とともにログに出力する
+// 回復した panic はエラーとして返し、ErrorHandler で内容を含めない 500 の problem+json にする
+func recoverMiddleware(logger *slog.Logger) echo.MiddlewareFunc {
+	return middleware.RecoverWithConfig(middleware.RecoverConfig{
+		DisableStackAll:     true,
+		DisableErrorHandler: true,
+		LogErrorFunc: func(c echo.Context, err error, stack []byte) error {
+			ctx := c.Request().Context()
+			logger.ErrorContext(ctx, "panic recovered",
+				slog.String("error", err.Error()),
+				slog.String("stack", string(stack)),
+				slog.String("request_id", usecase.RequestIDFromContext(ctx)),
+			)
+			return err
+		},
+	})
+}
+
+// bodyLimitMiddleware はリクエストボディを limit バイトまでに制限し、超える場合は 413 を返す
+// Content-Length で超えると分かる場合は読み込まずに返し、それ以外（chunked など）はデコード中に limit で読み込みを止める
+// 画像のアップロードは MAX_IMAGE_SIZE_BYTES で別に制限し、NDJSON のインポートは読みながら登録してメモリを使わないため対象外にする
+func bodyLimitMiddleware(limit int64) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if c.Path() == "/items/:id/image" || c.Path() == "/items/import-ndjson" {
+				return next(c)
+			}
+
+			req := c.Request()
+			if req.ContentLength > limit {
+				return itemController.WriteProblem(c, itemController.NewProblem(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must be %d bytes or less", limit)))
+			}
+			req.Body = http.MaxBytesReader(c.Response(), req.Body, limit)
+			return next(c)
+		}
+	}
+}
+
+// readOnlyMiddleware は読み取り専用モードで書き込み系のエンドポイントへのリクエストを 403 で拒否する
+// メンテナンスモード（一時的な 503）と異なり、再試行しても受け付けないことを示す
+func readOnlyMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			return itemController.WriteProblem(c, itemController.NewProblem(http.StatusForbidden, "this server is read-only; items and categories cannot be changed"))
+		}
+	}
+}
+
+// CORSOptions は CORS で許可するオリジン・メソッド・ヘッダー
+type CORSOptions struct {
+	AllowOrigins []string
+	AllowMethods []string
+	AllowHeaders []string
+}
+
+// corsMiddleware は許可したオリジンからのリクエストにのみ Access-Control-* ヘッダーを付ける
+// プリフライト（OPTIONS）には 204 を返す
+func corsMiddleware(opts CORSOptions) echo.MiddlewareFunc {
+	return middleware.CORSWithConfig(middleware.CORSConfig{
+		AllowOrigins: opts.AllowOrigins,
+		AllowMethods: opts.AllowMethods,
+		AllowHeaders: opts.AllowHeaders,
+		// SPA からページングや楽観ロックに使うヘッダーを読めるようにする
+		ExposeHeaders: []string{"X-Total-Count", echo.HeaderXRequestID, "ETag", "Idempotent-Replayed", echo.HeaderLocation},
+		MaxAge:        int((10 * time.Minute).Seconds()),
+	})
+}
+
+// gzipMiddleware は Accept-Encoding: gzip のリクエストに対し、minLength バイト以上のレスポンスを gzip で圧縮する
+// /metrics は promhttp が自前で圧縮するため、/items/events（SSE）はイベントごとに送るため対象外にする
+func gzipMiddleware(minLength int) echo.MiddlewareFunc {
+	return middleware.GzipWithConfig(middleware.GzipConfig{
+		MinLength: minLength,
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == "/metrics" || c.Path() == "/items/events"
+		},
+	})
+}
+
+// レート制限の満タンのバケットを削除する間隔
+const rateLimitSweepInterval = time.Minute
+
+// ipExtractor は proxies（CIDR）からの接続の場合のみ X-Forwarded-For を信頼してクライアントの IP アドレスを取得する
+// proxies が空の場合はヘッダーを信頼せず、接続元のアドレスを使う
+func ipExtractor(proxies []string) (echo.IPExtractor, error) {
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	// 既定で信頼するループバック・プライベートアドレスも、指定した範囲に限る
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, proxy := range proxies {
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q: %w", proxy, err)
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
+}
@@ -3,15 +3,28 @@ package server
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"log/slog"
 	"os"
-	"os/signal"
 	"time"
 
 	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 
+	"Aicon-assignment/internal/infrastructure/auth"
+	"Aicon-assignment/internal/infrastructure/cache"
+	"Aicon-assignment/internal/infrastructure/config"
 	databaseInfra "Aicon-assignment/internal/infrastructure/database"
+	"Aicon-assignment/internal/infrastructure/events"
+	"Aicon-assignment/internal/infrastructure/idempotency"
+	"Aicon-assignment/internal/infrastructure/logging"
+	"Aicon-assignment/internal/infrastructure/metrics"
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+	"Aicon-assignment/internal/infrastructure/storage"
+	"Aicon-assignment/internal/infrastructure/tracing"
+	"Aicon-assignment/internal/infrastructure/webhook"
 	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/openapi"
 	"Aicon-assignment/internal/interfaces/controller/system"
 	itemDatabase "Aicon-assignment/internal/interfaces/database"
 	"Aicon-assignment/internal/usecase"
@@ -24,70 +37,345 @@ func NewServer() *Server {
 	return &Server{}
 }
 
-// サーバー起動
+// サーバー起動（ctx がキャンセルされると graceful shutdown する）
 func (s *Server) Run(ctx context.Context) error {
 	e := echo.New()
 
+	logger := logging.NewLogger(os.Stdout, config.LogLevel)
+	slog.SetDefault(logger)
+
+	// OTEL_EXPORTER_OTLP_ENDPOINT を設定した場合のみトレースを送る
+	tracerProvider, shutdownTracing, err := tracing.Setup(ctx, tracing.Config{Endpoint: config.OTLPEndpoint, ServiceName: config.OTELServiceName})
+	if err != nil {
+		return err
+	}
+	// サーバーの停止後、送信待ちのスパンをシャットダウンのタイムアウトまで送る
+	defer func() {
+		closeCtx, cancel := context.WithTimeout(context.Background(), time.Duration(config.ShutdownTimeoutSeconds)*time.Second)
+		defer cancel()
+		if err := shutdownTracing(closeCtx); err != nil {
+			logger.Warn("pending spans were not exported before shutdown", "error", err)
+		}
+	}()
+
 	// 依存性注入
+	dialect := itemDatabase.Dialect(config.DBDriver)
 	dbHandler := databaseInfra.NewSqlHandler()
+	// サーバーの停止（処理中のリクエストの完了）を待ってから接続プールを閉じる
 	defer dbHandler.Close()
 
 	itemRepo := &itemDatabase.ItemRepository{
-		SqlHandler: dbHandler,
+		SqlHandler:   tracing.WrapSqlHandler(dbHandler, tracerProvider, dialect),
+		Dialect:      dialect,
+		QueryTimeout: time.Duration(config.DBQueryTimeoutSeconds) * time.Second,
+	}
+
+	appMetrics := metrics.New()
+	usecaseOpts := []usecase.Option{
+		usecase.WithMetrics(appMetrics),
+		usecase.WithMaxNameLength(config.MaxNameLength),
+		usecase.WithMaxNotesLength(config.MaxNotesLength),
+		// アイテムの作成と監査ログ（item_audits）を 1 つのトランザクションで書き込む
+		usecase.WithAudit(itemRepo, itemRepo),
+		// 購入価格が変わる更新と価格の履歴（item_price_history）を 1 つのトランザクションで書き込む
+		usecase.WithPriceHistory(itemRepo, itemRepo),
+		// 更新と更新前のバージョン（item_versions）を 1 つのトランザクションで書き込む
+		usecase.WithVersionHistory(itemRepo, itemRepo),
+		// アイテムのカテゴリーは categories テーブルの一覧と照合する
+		usecase.WithCategories(itemRepo),
+		usecase.WithSummaryCache(time.Duration(config.SummaryCacheTTLSeconds) * time.Second),
+	}
+	if config.RedisURL != "" {
+		itemCache, err := cache.NewRedisCache(config.RedisURL)
+		if err != nil {
+			return err
+		}
+		defer itemCache.Close()
+		// Redis に接続できなくてもキャッシュを使わずに動作するため、起動は止めない
+		if err := itemCache.Ping(ctx); err != nil {
+			logger.Warn("redis is not reachable; item reads fall back to the database", "error", err)
+		}
+		usecaseOpts = append(usecaseOpts, usecase.WithItemCache(itemCache, time.Duration(config.ItemCacheTTLSeconds)*time.Second))
+	}
+	// GET /items/events の配信元（シャットダウン時にすべての接続を終了させる）
+	eventBus := events.NewBus()
+	e.Server.RegisterOnShutdown(eventBus.Close)
+	usecaseOpts = append(usecaseOpts, usecase.WithEventPublisher(eventBus))
+
+	if len(config.WebhookURLs) > 0 {
+		dispatcher := webhook.NewDispatcher(webhook.Config{
+			URLs:        config.WebhookURLs,
+			MaxAttempts: config.WebhookMaxAttempts,
+			BaseDelay:   time.Duration(config.WebhookBaseDelaySeconds) * time.Second,
+			Timeout:     time.Duration(config.WebhookTimeoutSeconds) * time.Second,
+		}, logger)
+		// サーバーの停止後、送信待ちの Webhook をシャットダウンのタイムアウトまで送る
+		defer func() {
+			closeCtx, cancel := context.WithTimeout(context.Background(), time.Duration(config.ShutdownTimeoutSeconds)*time.Second)
+			defer cancel()
+			if err := dispatcher.Close(closeCtx); err != nil {
+				logger.Warn("pending webhooks were not delivered before shutdown", "error", err)
+			}
+		}()
+		usecaseOpts = append(usecaseOpts, usecase.WithEventPublisher(dispatcher))
+	}
+	// アップロードした画像の保存先（local の場合はこのサーバーが GET /images/* で配信する）
+	var imageDir string
+	switch config.ImageStorage {
+	case "local":
+		localStorage := storage.NewLocalStorage(config.ImageDir, config.ImageBaseURL)
+		imageDir = localStorage.Dir()
+		usecaseOpts = append(usecaseOpts, usecase.WithImageStorage(localStorage))
+	case "s3":
+		s3Storage, err := storage.NewS3Storage(storage.S3Config{
+			Bucket:          config.S3Bucket,
+			Region:          config.S3Region,
+			Endpoint:        config.S3Endpoint,
+			AccessKeyID:     config.AWSAccessKeyID,
+			SecretAccessKey: config.AWSSecretAccessKey,
+		})
+		if err != nil {
+			return err
+		}
+		usecaseOpts = append(usecaseOpts, usecase.WithImageStorage(s3Storage))
+	default:
+		return fmt.Errorf("unsupported IMAGE_STORAGE: %s", config.ImageStorage)
+	}
+	usecaseOpts = append(usecaseOpts, usecase.WithMaxImageSize(int64(config.MaxImageSizeBytes)))
+	// ユーザーごとに分ける場合は、すべての呼び出しで JWT の subject が必要になる
+	if config.UserScopedItems {
+		if config.JWTSecret == "" {
+			return fmt.Errorf("USER_SCOPED_ITEMS requires JWT_SECRET")
+		}
+		usecaseOpts = append(usecaseOpts, usecase.WithUserScope())
+	}
+
+	itemUsecase := usecase.NewItemUsecase(itemRepo, usecaseOpts...)
+
+	systemHandler := system.NewSystemHandler(itemRepo)
+	idempotencyStore := idempotency.NewMemoryStore(time.Duration(config.IdempotencyTTLSeconds) * time.Second)
+	handlerOpts := []itemController.Option{
+		itemController.WithMaxLimit(config.MaxPageLimit),
+		itemController.WithIdempotencyStore(idempotencyStore),
+		itemController.WithEventStream(eventBus, 0),
 	}
+	if config.UserScopedItems {
+		handlerOpts = append(handlerOpts, itemController.WithUserScopedEvents())
+	}
+	itemHandler := itemController.NewItemHandler(itemUsecase, handlerOpts...)
 
-	itemUsecase := usecase.NewItemUsecase(itemRepo)
+	// レート制限などで使うクライアントの IP アドレスは、信頼するプロキシの X-Forwarded-For のみから取得する
+	if e.IPExtractor, err = ipExtractor(config.TrustedProxies); err != nil {
+		return err
+	}
 
-	systemHandler := system.NewSystemHandler()
-	itemHandler := itemController.NewItemHandler(itemUsecase)
+	var writeLimiter *ratelimit.Limiter
+	if config.RateLimitRPS > 0 && config.RateLimitBurst > 0 {
+		writeLimiter = ratelimit.NewLimiter(config.RateLimitRPS, config.RateLimitBurst, ratelimit.WithAPIKeys(config.RateLimitAPIKeys...))
+		// 使われなくなったバケットはリクエストの処理とは別に定期的に削除する
+		defer writeLimiter.StartSweeper(rateLimitSweepInterval)()
+	}
+
+	var authMiddleware echo.MiddlewareFunc
+	if config.JWTSecret != "" {
+		authMiddleware = auth.Middleware([]byte(config.JWTSecret))
+	} else {
+		logger.Warn("JWT_SECRET is not set; item mutations are not authenticated")
+	}
+	if config.AdminToken == "" {
+		logger.Warn("ADMIN_TOKEN is not set; /admin/maintenance is not registered")
+	}
+
+	RegisterRoutes(e, systemHandler, itemHandler, RouteOptions{
+		EnableDocs:   config.EnableAPIDocs,
+		RateLimit:    writeLimiter,
+		Auth:         authMiddleware,
+		AuthForReads: config.AuthRequiredForReads || config.UserScopedItems,
+		CORS: CORSOptions{
+			AllowOrigins: config.CORSAllowedOrigins,
+			AllowMethods: config.CORSAllowedMethods,
+			AllowHeaders: config.CORSAllowedHeaders,
+		},
+		Metrics:       appMetrics,
+		Logger:        logger,
+		GzipMinLength: config.GzipMinLength,
+		ImageDir:      imageDir,
+		MaxBodyBytes:  int64(config.MaxBodyBytes),
+		ReadOnly:      config.ReadOnly,
+		Tracing:       tracerProvider,
+		Maintenance:   NewMaintenanceMode(config.MaintenanceMode, time.Duration(config.MaintenanceRetryAfterSeconds)*time.Second),
+		AdminToken:    config.AdminToken,
+	})
+
+	return serve(ctx, e, ":8080", time.Duration(config.ShutdownTimeoutSeconds)*time.Second)
+}
+
+// RouteOptions は環境によって公開を切り替えるエンドポイントの設定
+type RouteOptions struct {
+	// GET /docs（Swagger UI）を登録する
+	EnableDocs bool
+	// 指定した場合はリクエストメトリクスを記録し、GET /metrics を登録する
+	Metrics *metrics.Metrics
+	// 指定した場合はリクエストごとに JSON のアクセスログを出力する
+	Logger *slog.Logger
+	// 指定した場合はリクエストごとにスパンを作成する（受信したトレースの伝播には otel のグローバルな設定を使う）
+	Tracing trace.TracerProvider
+	// クロスオリジンのリクエストを許可する設定（AllowOrigins が空の場合は許可しない）
+	CORS CORSOptions
+	// 指定した場合は書き込み系のエンドポイントにクライアントごとのレート制限をかける
+	RateLimit *ratelimit.Limiter
+	// 書き込み系のエンドポイントのリクエストボディの上限（バイト、0 以下で制限しない）
+	MaxBodyBytes int64
+	// 指定した場合は書き込み系のアイテムエンドポイントに認証を要求する
+	Auth echo.MiddlewareFunc
+	// Auth を読み取り系のアイテムエンドポイントにも適用する
+	AuthForReads bool
+	// 指定したバイト数以上のレスポンスを gzip で圧縮する（0 以下で圧縮しない）
+	GzipMinLength int
+	// 指定した場合はこのディレクトリに保存した画像を GET /images/* で配信する
+	ImageDir string
+	// 書き込み系のエンドポイントをすべて 403 で拒否する（読み取り専用のデモ環境など）
+	ReadOnly bool
+	// 指定した場合はメンテナンス中に書き込み系のエンドポイントを 503 で拒否する
+	Maintenance *MaintenanceMode
+	// GET / PUT /admin/maintenance に要求する管理者トークン（空の場合は登録しない）
+	AdminToken string
+}
+
+// RegisterRoutes はすべてのエンドポイントを e に登録する
+// OpenAPI ドキュメントの生成（docs/openapi）でも同じ登録内容を使う
+func RegisterRoutes(e *echo.Echo, systemHandler *system.SystemHandler, itemHandler *itemController.ItemHandler, opts RouteOptions) {
+	// 未登録のルートなどのエラーも problem+json で返す
+	e.HTTPErrorHandler = itemController.ErrorHandler
+
+	// リクエスト ID はログ・エラーレスポンスで使うため最初に設定する
+	e.Use(requestIDMiddleware())
+	// スパンにはアクセスログ・メトリクスの処理とエラーハンドラーが決めたステータスも含める
+	if opts.Tracing != nil {
+		e.Use(tracing.Middleware(opts.Tracing, otel.GetTextMapPropagator()))
+	}
+	if opts.Logger != nil {
+		e.Use(logging.Middleware(opts.Logger))
+	}
+	if opts.Metrics != nil {
+		e.Use(opts.Metrics.Middleware())
+		e.GET("/metrics", opts.Metrics.Handler())
+	}
+	// アクセスログ・メトリクスに 500 として記録されるよう、その内側で panic を回復する
+	logger := opts.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	e.Use(recoverMiddleware(logger))
+	if len(opts.CORS.AllowOrigins) > 0 {
+		e.Use(corsMiddleware(opts.CORS))
+	}
+	if opts.GzipMinLength > 0 {
+		e.Use(gzipMiddleware(opts.GzipMinLength))
+	}
 
 	// ヘルスチェック
 	e.GET("/health", func(c echo.Context) error {
 		systemHandler.Health(c)
 		return nil
 	})
+	e.GET("/healthz", systemHandler.Liveness) // liveness
+	e.GET("/readyz", systemHandler.Readiness) // readiness（DB に ping）
 
-	// アイテムに関するエンドポイント
-	itemsGroup := e.Group("/items")
-	{
-		itemsGroup.GET("", itemHandler.GetItems)           // GET /items
-		itemsGroup.POST("", itemHandler.CreateItem)        // POST /items
-		itemsGroup.GET("/:id", itemHandler.GetItem)        // GET /items/{id}
-		itemsGroup.PATCH("/:id", itemHandler.UpdateItem)   // PATCH /items/{id}
-		itemsGroup.DELETE("/:id", itemHandler.DeleteItem)  // DELETE /items/{id}
-		itemsGroup.GET("/summary", itemHandler.GetSummary) // GET /items/summary (bonus)
+	// アップロードした画像（ローカルに保存する場合）
+	if opts.ImageDir != "" {
+		e.Static("/images", opts.ImageDir)
 	}
 
-	return s.startWithGracefulShutdown(ctx, e)
-}
+	// 読み取り系・書き込み系のエンドポイントに付けるミドルウェア
+	var read, write []echo.MiddlewareFunc
+	// 読み取り専用モード・メンテナンス中の書き込みはレート制限・認証より前に拒否する
+	if opts.ReadOnly {
+		write = append(write, readOnlyMiddleware())
+	}
+	if opts.Maintenance != nil {
+		write = append(write, opts.Maintenance.middleware())
+	}
+	if opts.RateLimit != nil {
+		write = append(write, opts.RateLimit.Middleware())
+	}
+	if opts.Auth != nil {
+		write = append(write, opts.Auth)
+		if opts.AuthForReads {
+			read = append(read, opts.Auth)
+		}
+	}
+	// ボディの上限はデコードより前（ハンドラーの外側）で設定する
+	if opts.MaxBodyBytes > 0 {
+		write = append(write, bodyLimitMiddleware(opts.MaxBodyBytes))
+	}
 
-func (s *Server) startWithGracefulShutdown(ctx context.Context, e *echo.Echo) error {
-	go func() {
-		port := ":8080"
-		fmt.Printf("🚀 Server starting on port %s\n", port)
+	// カテゴリーに関するエンドポイント
+	e.GET("/categories", itemHandler.GetCategories, read...)           // GET /categories
+	e.POST("/categories", itemHandler.CreateCategory, write...)        // POST /categories
+	e.DELETE("/categories/:id", itemHandler.DeleteCategory, write...)  // DELETE /categories/{id}
+	e.POST("/categories/rename", itemHandler.RenameCategory, write...) // POST /categories/rename
 
-		if err := e.Start(port); err != nil && err != http.ErrServerClosed {
-			e.Logger.Fatal("Server startup failed:", err)
-		}
-	}()
+	// アイテムに関するエンドポイント
+	itemsGroup := e.Group("/items")
+	{
+		itemsGroup.GET("", itemHandler.GetItems, read...)                     // GET /items
+		itemsGroup.POST("", itemHandler.CreateItem, write...)                 // POST /items
+		itemsGroup.POST("/bulk", itemHandler.CreateItems, write...)           // POST /items/bulk
+		itemsGroup.POST("/validate", itemHandler.ValidateItems, write...)     // POST /items/validate
+		itemsGroup.POST("/import", itemHandler.ImportCSV, write...)           // POST /items/import
+		itemsGroup.POST("/import-ndjson", itemHandler.ImportNDJSON, write...) // POST /items/import-ndjson
+		itemsGroup.DELETE("", itemHandler.DeleteItems, write...)              // DELETE /items
+		itemsGroup.POST("/move", itemHandler.MoveItems, write...)             // POST /items/move
+		itemsGroup.GET("/deleted", itemHandler.GetDeletedItems, read...)      // GET /items/deleted
+		itemsGroup.GET("/recent", itemHandler.GetRecentItems, read...)        // GET /items/recent
+		itemsGroup.GET("/top", itemHandler.GetTopItems, read...)              // GET /items/top
+		itemsGroup.GET("/count", itemHandler.CountItems, read...)             // GET /items/count
+		itemsGroup.GET("/changes", itemHandler.GetItemChanges, read...)       // GET /items/changes
+		itemsGroup.GET("/events", itemHandler.StreamEvents, read...)          // GET /items/events (SSE)
+		itemsGroup.GET("/export.csv", itemHandler.ExportCSV, read...)         // GET /items/export.csv
+		itemsGroup.GET("/export.ndjson", itemHandler.ExportNDJSON, read...)   // GET /items/export.ndjson
+		itemsGroup.GET("/:id", itemHandler.GetItem, read...)                  // GET /items/{id}
+		itemsGroup.HEAD("/:id", itemHandler.HeadItem, read...)                // HEAD /items/{id}
+		itemsGroup.PUT("/:id", itemHandler.ReplaceItem, write...)             // PUT /items/{id}
+		itemsGroup.PATCH("/:id", itemHandler.UpdateItem, write...)            // PATCH /items/{id}
+		itemsGroup.DELETE("/:id", itemHandler.DeleteItem, write...)           // DELETE /items/{id}
+		itemsGroup.POST("/:id/restore", itemHandler.RestoreItem, write...)    // POST /items/{id}/restore
+		itemsGroup.POST("/:id/clone", itemHandler.CloneItem, write...)        // POST /items/{id}/clone
+		itemsGroup.POST("/:id/image", itemHandler.UploadImage, write...)      // POST /items/{id}/image
+		itemsGroup.GET("/summary", itemHandler.GetSummary, read...)           // GET /items/summary (bonus)
 
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, os.Interrupt)
+		// アイテムの変更の履歴
+		itemsGroup.GET("/:id/price-history", itemHandler.GetPriceHistory, read...)    // GET /items/{id}/price-history
+		itemsGroup.GET("/:id/versions", itemHandler.GetItemVersions, read...)         // GET /items/{id}/versions
+		itemsGroup.GET("/:id/versions/:version", itemHandler.GetItemVersion, read...) // GET /items/{id}/versions/{version}
+		itemsGroup.GET("/:id/diff", itemHandler.DiffItemVersions, read...)            // GET /items/{id}/diff?from=&to=
+	}
 
-	select {
-	case <-quit:
-		fmt.Println("\n🛑 Shutting down server...")
-	case <-ctx.Done():
-		fmt.Println("\n🛑 Context cancelled, shutting down server...")
+	// 保証期限に関するエンドポイント
+	warrantyGroup := e.Group("/items/warranty")
+	{
+		warrantyGroup.GET("/expiring", itemHandler.GetExpiringWarranties, read...) // GET /items/warranty/expiring
 	}
 
-	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	// アイテムの集計・分析に関するエンドポイント
+	analyticsGroup := e.Group("/items/analytics")
+	{
+		analyticsGroup.GET("/average-price", itemHandler.GetAveragePrice, read...) // GET /items/analytics/average-price
+		analyticsGroup.GET("/price-range", itemHandler.GetPriceRange, read...)     // GET /items/analytics/price-range
+		analyticsGroup.GET("/timeline", itemHandler.GetTimeline, read...)          // GET /items/analytics/timeline
+	}
 
-	if err := e.Shutdown(shutdownCtx); err != nil {
-		return fmt.Errorf("server forced to shutdown: %w", err)
+	// メンテナンスモードの切り替え（メンテナンス中も解除できるよう、書き込み系のミドルウェアは付けず管理者トークンだけを要求する）
+	if opts.Maintenance != nil && opts.AdminToken != "" {
+		admin := adminTokenMiddleware(opts.AdminToken)
+		e.GET("/admin/maintenance", opts.Maintenance.getStatus, admin) // GET /admin/maintenance
+		e.PUT("/admin/maintenance", opts.Maintenance.putStatus, admin) // PUT /admin/maintenance
 	}
 
-	fmt.Println("✅ Server exited gracefully")
-	return nil
+	// API ドキュメント（登録済みのルートから生成）
+	e.GET("/openapi.json", openapi.NewHandler(e))
+	if opts.EnableDocs {
+		e.GET("/docs", openapi.SwaggerUI)
+	}
 }
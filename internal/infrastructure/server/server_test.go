@@ -0,0 +1,741 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/infrastructure/auth"
+	"Aicon-assignment/internal/infrastructure/events"
+	"Aicon-assignment/internal/infrastructure/metrics"
+	"Aicon-assignment/internal/infrastructure/ratelimit"
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/controller/system"
+	"Aicon-assignment/internal/interfaces/database/memory"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestOpenAPIDocument(t *testing.T) {
+	e := echo.New()
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{EnableDocs: true})
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var doc struct {
+		OpenAPI    string                                `json:"openapi"`
+		Paths      map[string]map[string]json.RawMessage `json:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+
+	// 登録済みのすべてのルートがドキュメントに含まれる
+	for _, route := range e.Routes() {
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		path := regexp.MustCompile(`:(\w+)`).ReplaceAllString(route.Path, "{$1}")
+		if assert.Contains(t, doc.Paths, path) {
+			assert.Contains(t, doc.Paths[path], strings.ToLower(route.Method), "%s %s", route.Method, route.Path)
+		}
+	}
+	for _, path := range []string{"/items", "/items/{id}", "/items/{id}/restore", "/items/summary", "/openapi.json"} {
+		assert.Contains(t, doc.Paths, path)
+	}
+
+	item, ok := doc.Components.Schemas["Item"]
+	require.True(t, ok, "Item schema must be present")
+	for _, field := range []string{"id", "name", "category", "brand", "purchase_price", "purchase_date", "version"} {
+		assert.Contains(t, item.Properties, field)
+	}
+	// 埋め込んだ Item のフィールドは展開される
+	change := doc.Components.Schemas["ItemChange"]
+	for _, field := range []string{"id", "deleted_at", "deleted"} {
+		assert.Contains(t, change.Properties, field)
+	}
+	assert.NotContains(t, change.Properties, "Item")
+	assert.Contains(t, doc.Components.Schemas, "CreateItemInput")
+	assert.Contains(t, doc.Components.Schemas, "UpdateItemInput")
+	assert.Contains(t, doc.Components.Schemas, "Problem")
+}
+
+func TestSwaggerUI(t *testing.T) {
+	tests := []struct {
+		name           string
+		enableDocs     bool
+		expectedStatus int
+	}{
+		{name: "enabled", enableDocs: true, expectedStatus: http.StatusOK},
+		{name: "disabled", enableDocs: false, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{EnableDocs: tt.enableDocs})
+
+			req := httptest.NewRequest(http.MethodGet, "/docs", nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.enableDocs {
+				assert.Contains(t, rec.Header().Get(echo.HeaderContentType), echo.MIMETextHTML)
+				assert.Contains(t, rec.Body.String(), "/openapi.json")
+			}
+		})
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	e := echo.New()
+	appMetrics := metrics.New()
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{Metrics: appMetrics})
+
+	// usecase を呼ばずに応答する不正な ID でアイテムのエンドポイントを叩く
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/items/abc", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+	}
+	appMetrics.ItemsCreated(3)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	body := rec.Body.String()
+	assert.Contains(t, body, `http_requests_total{method="GET",route="/items/:id",status="400"} 2`)
+	assert.Contains(t, body, `http_request_duration_seconds_count{method="GET",route="/items/:id",status="400"} 2`)
+	assert.Contains(t, body, "items_created_total 3")
+	assert.Contains(t, body, "items_deleted_total 0")
+}
+
+func TestRequestID(t *testing.T) {
+	e := echo.New()
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{})
+
+	errorBody := func(t *testing.T, rec *httptest.ResponseRecorder) itemController.Problem {
+		var body itemController.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body
+	}
+
+	t.Run("generated id appears in header and error body", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items/abc", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusBadRequest, rec.Code)
+		requestID := rec.Header().Get(echo.HeaderXRequestID)
+		assert.NotEmpty(t, requestID)
+		assert.Equal(t, requestID, errorBody(t, rec).RequestID)
+	})
+
+	t.Run("incoming id is echoed back", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items/abc", nil)
+		req.Header.Set(echo.HeaderXRequestID, "client-id-1")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, "client-id-1", rec.Header().Get(echo.HeaderXRequestID))
+		assert.Equal(t, "client-id-1", errorBody(t, rec).RequestID)
+	})
+
+	t.Run("each request gets a distinct id", func(t *testing.T) {
+		ids := map[string]bool{}
+		for i := 0; i < 3; i++ {
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+			ids[rec.Header().Get(echo.HeaderXRequestID)] = true
+		}
+		assert.Len(t, ids, 3)
+	})
+}
+
+func TestRecover(t *testing.T) {
+	var logs bytes.Buffer
+	e := echo.New()
+	// usecase を設定しないため、usecase を呼ぶアイテムのハンドラーは nil 参照で panic する
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{
+		Logger: slog.New(slog.NewJSONHandler(&logs, nil)),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set(echo.HeaderXRequestID, "req-panic")
+	rec := httptest.NewRecorder()
+	require.NotPanics(t, func() { e.ServeHTTP(rec, req) })
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, itemController.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+	var problem itemController.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "internal server error", problem.Detail)
+	assert.Equal(t, "req-panic", problem.RequestID)
+	assert.NotContains(t, rec.Body.String(), "nil pointer")
+
+	var entry map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(strings.SplitN(logs.String(), "\n", 2)[0]), &entry))
+	assert.Equal(t, "panic recovered", entry["msg"])
+	assert.Equal(t, "req-panic", entry["request_id"])
+	assert.Contains(t, entry["stack"], "goroutine")
+	assert.Contains(t, logs.String(), `"status":500`)
+
+	// 回復した後も次のリクエストに応答する
+	rec = httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestMethodNotAllowed(t *testing.T) {
+	e := echo.New()
+	// 認証があっても、存在しないメソッドは 401 ではなく 405 で案内する
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{Auth: auth.Middleware([]byte("secret"))})
+
+	t.Run("POST on an item returns 405 with the allowed methods", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/items/1", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, "OPTIONS, DELETE, GET, HEAD, PATCH, PUT", rec.Header().Get(echo.HeaderAllow))
+		assert.Equal(t, itemController.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+		var problem itemController.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		assert.Equal(t, "/problems/method-not-allowed", problem.Type)
+		assert.Equal(t, "method POST is not allowed; allowed methods: OPTIONS, DELETE, GET, HEAD, PATCH, PUT", problem.Detail)
+	})
+
+	t.Run("OPTIONS lists the methods", func(t *testing.T) {
+		for path, allow := range map[string]string{
+			"/items/1":    "OPTIONS, DELETE, GET, HEAD, PATCH, PUT",
+			"/categories": "OPTIONS, GET, POST",
+		} {
+			req := httptest.NewRequest(http.MethodOptions, path, nil)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNoContent, rec.Code, path)
+			assert.Equal(t, allow, rec.Header().Get(echo.HeaderAllow), path)
+		}
+	})
+
+	t.Run("unknown path is still 404", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/unknown", nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderAllow))
+	})
+}
+
+func TestCORS(t *testing.T) {
+	corsOptions := CORSOptions{
+		AllowOrigins: []string{"https://app.example.com"},
+		AllowMethods: []string{http.MethodGet, http.MethodPost, http.MethodPatch},
+		AllowHeaders: []string{echo.HeaderContentType},
+	}
+
+	newEcho := func(opts CORSOptions) *echo.Echo {
+		e := echo.New()
+		RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{CORS: opts})
+		return e
+	}
+
+	t.Run("allowed origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set(echo.HeaderOrigin, "https://app.example.com")
+		rec := httptest.NewRecorder()
+		newEcho(corsOptions).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlExposeHeaders), "X-Total-Count")
+	})
+
+	t.Run("disallowed origin", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.Header.Set(echo.HeaderOrigin, "https://evil.example.com")
+		rec := httptest.NewRecorder()
+		newEcho(corsOptions).ServeHTTP(rec, req)
+
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	})
+
+	t.Run("preflight on item route", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/items/1", nil)
+		req.Header.Set(echo.HeaderOrigin, "https://app.example.com")
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPatch)
+		req.Header.Set(echo.HeaderAccessControlRequestHeaders, echo.HeaderContentType)
+		rec := httptest.NewRecorder()
+		newEcho(corsOptions).ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		assert.Equal(t, "https://app.example.com", rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+		assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods), http.MethodPatch)
+		assert.Equal(t, echo.HeaderContentType, rec.Header().Get(echo.HeaderAccessControlAllowHeaders))
+		assert.NotEmpty(t, rec.Header().Get(echo.HeaderAccessControlMaxAge))
+	})
+
+	t.Run("cross-origin denied by default", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodOptions, "/items", nil)
+		req.Header.Set(echo.HeaderOrigin, "https://app.example.com")
+		req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPost)
+		rec := httptest.NewRecorder()
+		newEcho(CORSOptions{}).ServeHTTP(rec, req)
+
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	})
+}
+
+func TestRateLimitOnWriteRoutes(t *testing.T) {
+	e := echo.New()
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{
+		RateLimit: ratelimit.NewLimiter(0.001, 1),
+	})
+
+	// usecase を呼ばずに応答する不正な ID で叩く
+	request := func(method string) int {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(method, "/items/abc", nil))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusBadRequest, request(http.MethodDelete))
+	assert.Equal(t, http.StatusTooManyRequests, request(http.MethodDelete))
+	assert.Equal(t, http.StatusTooManyRequests, request(http.MethodPatch))
+
+	// 読み取り系は制限しない
+	assert.Equal(t, http.StatusBadRequest, request(http.MethodGet))
+}
+
+func TestIPExtractor(t *testing.T) {
+	request := func(remoteAddr, forwardedFor string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/items", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+		return req
+	}
+
+	t.Run("without trusted proxies the header is ignored", func(t *testing.T) {
+		extract, err := ipExtractor(nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, "10.0.0.5", extract(request("10.0.0.5:1234", "198.51.100.7")))
+	})
+
+	t.Run("forwarded address is used only from a trusted proxy", func(t *testing.T) {
+		extract, err := ipExtractor([]string{"10.0.0.0/8"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "198.51.100.7", extract(request("10.0.0.5:1234", "198.51.100.7")))
+		assert.Equal(t, "192.168.1.5", extract(request("192.168.1.5:1234", "198.51.100.7")))
+	})
+
+	t.Run("invalid cidr is rejected", func(t *testing.T) {
+		_, err := ipExtractor([]string{"10.0.0.0"})
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthOnItemRoutes(t *testing.T) {
+	secret := []byte("test-secret")
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   "user-1",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString(secret)
+	require.NoError(t, err)
+
+	// usecase を呼ばずに応答する不正な ID で叩く
+	request := func(e *echo.Echo, method, authorization string) int {
+		req := httptest.NewRequest(method, "/items/abc", nil)
+		if authorization != "" {
+			req.Header.Set(echo.HeaderAuthorization, authorization)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	t.Run("mutations require a token", func(t *testing.T) {
+		e := echo.New()
+		RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{Auth: auth.Middleware(secret)})
+
+		for _, method := range []string{http.MethodPut, http.MethodPatch, http.MethodDelete} {
+			assert.Equal(t, http.StatusUnauthorized, request(e, method, ""), method)
+			assert.Equal(t, http.StatusBadRequest, request(e, method, "Bearer "+token), method)
+		}
+
+		// 読み取り系は公開のまま
+		assert.Equal(t, http.StatusBadRequest, request(e, http.MethodGet, ""))
+	})
+
+	t.Run("reads require a token when enabled", func(t *testing.T) {
+		e := echo.New()
+		RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{Auth: auth.Middleware(secret), AuthForReads: true})
+
+		assert.Equal(t, http.StatusUnauthorized, request(e, http.MethodGet, ""))
+		assert.Equal(t, http.StatusBadRequest, request(e, http.MethodGet, "Bearer "+token))
+
+		// ヘルスチェックは常に公開
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestBodyLimit(t *testing.T) {
+	e := echo.New()
+	u := usecase.NewItemUsecase(memory.NewItemRepository())
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(u), RouteOptions{MaxBodyBytes: 256})
+
+	post := func(body string, chunked bool) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if chunked {
+			req.ContentLength = -1
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	problemType := func(t *testing.T, rec *httptest.ResponseRecorder) string {
+		var problem itemController.Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+		return problem.Type
+	}
+
+	t.Run("body under the limit is accepted", func(t *testing.T) {
+		rec := post(`{"name":"時計1","category":"時計","brand":"ROLEX","purchase_price":1000000,"purchase_date":"2023-01-01"}`, false)
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("body over the limit is rejected before decoding", func(t *testing.T) {
+		rec := post(`{"name":"時計2","category":"時計","brand":"ROLEX","purchase_price":1000000,"purchase_date":"2023-01-01","notes":"`+strings.Repeat("a", 300)+`"}`, false)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Equal(t, "/problems/payload-too-large", problemType(t, rec))
+	})
+
+	t.Run("chunked body over the limit is stopped while decoding", func(t *testing.T) {
+		rec := post(`{"name":"時計3","category":"時計","brand":"ROLEX","purchase_price":1000000,"purchase_date":"2023-01-01","notes":"`+strings.Repeat("a", 300)+`"}`, true)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Equal(t, "/problems/payload-too-large", problemType(t, rec))
+	})
+
+	t.Run("reads are not limited", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func TestMaintenanceMode(t *testing.T) {
+	e := echo.New()
+	u := usecase.NewItemUsecase(memory.NewItemRepository())
+	maintenance := NewMaintenanceMode(false, 90*time.Second)
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(u), RouteOptions{Maintenance: maintenance, AdminToken: "admin-secret"})
+
+	doAs := func(token, method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if token != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		if strings.HasPrefix(path, "/admin/") {
+			return doAs("admin-secret", method, path, body)
+		}
+		return doAs("", method, path, body)
+	}
+	item := `{"name":"時計1","category":"時計","brand":"ROLEX","purchase_price":1000000,"purchase_date":"2023-01-01"}`
+
+	require.Equal(t, http.StatusCreated, do(http.MethodPost, "/items", item).Code)
+
+	t.Run("enabled at runtime", func(t *testing.T) {
+		rec := do(http.MethodPut, "/admin/maintenance", `{"enabled":true}`)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"enabled":true}`, rec.Body.String())
+		assert.True(t, maintenance.Enabled())
+	})
+
+	t.Run("reads pass while enabled", func(t *testing.T) {
+		for _, path := range []string{"/items", "/items/1", "/items/summary", "/admin/maintenance"} {
+			assert.Equal(t, http.StatusOK, do(http.MethodGet, path, "").Code, path)
+		}
+	})
+
+	t.Run("writes get 503 while enabled", func(t *testing.T) {
+		for _, req := range []struct{ method, path, body string }{
+			{http.MethodPost, "/items", item},
+			{http.MethodPatch, "/items/1", `{"name":"時計2"}`},
+			{http.MethodDelete, "/items/1", ""},
+			{http.MethodPost, "/categories", `{"name":"靴"}`},
+		} {
+			rec := do(req.method, req.path, req.body)
+
+			assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "%s %s", req.method, req.path)
+			assert.Equal(t, "90", rec.Header().Get(echo.HeaderRetryAfter))
+		}
+		// 拒否した書き込みは反映されない
+		assert.JSONEq(t, `{"count":1}`, do(http.MethodGet, "/items/count", "").Body.String())
+	})
+
+	t.Run("disabled again", func(t *testing.T) {
+		rec := do(http.MethodPut, "/admin/maintenance", `{"enabled":false}`)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		assert.Equal(t, http.StatusOK, do(http.MethodPatch, "/items/1", `{"name":"時計2"}`).Code)
+	})
+
+	t.Run("invalid toggle", func(t *testing.T) {
+		for _, body := range []string{``, `{}`, `{"enabled":"yes"}`} {
+			assert.Equal(t, http.StatusBadRequest, do(http.MethodPut, "/admin/maintenance", body).Code, body)
+		}
+		assert.False(t, maintenance.Enabled())
+	})
+
+	t.Run("toggle without admin token is rejected", func(t *testing.T) {
+		for _, tc := range []struct {
+			token  string
+			status int
+			typ    string
+		}{
+			{"", http.StatusUnauthorized, "/problems/unauthorized"},
+			{"wrong", http.StatusForbidden, "/problems/forbidden"},
+		} {
+			for _, method := range []string{http.MethodGet, http.MethodPut} {
+				rec := doAs(tc.token, method, "/admin/maintenance", `{"enabled":true}`)
+
+				require.Equal(t, tc.status, rec.Code, "%s %q", method, tc.token)
+				var problem itemController.Problem
+				require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+				assert.Equal(t, tc.typ, problem.Type)
+			}
+		}
+		assert.False(t, maintenance.Enabled())
+	})
+}
+
+func TestMaintenanceModeWithoutAdminToken(t *testing.T) {
+	e := echo.New()
+	u := usecase.NewItemUsecase(memory.NewItemRepository())
+	maintenance := NewMaintenanceMode(true, 90*time.Second)
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(u), RouteOptions{Maintenance: maintenance})
+
+	for _, method := range []string{http.MethodGet, http.MethodPut} {
+		req := httptest.NewRequest(method, "/admin/maintenance", strings.NewReader(`{"enabled":false}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code, method)
+	}
+	// 切り替えのエンドポイントがなくても、起動時の状態で書き込みは拒否する
+	assert.True(t, maintenance.Enabled())
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/items/1", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestReadOnlyMode(t *testing.T) {
+	e := echo.New()
+	u := usecase.NewItemUsecase(memory.NewItemRepository())
+	_, err := u.CreateItem(context.Background(), usecase.CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"})
+	require.NoError(t, err)
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(u), RouteOptions{ReadOnly: true})
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("each write method is blocked", func(t *testing.T) {
+		for _, req := range []struct{ method, path, body string }{
+			{http.MethodPost, "/items", `{"name":"時計2","category":"時計","brand":"ROLEX","purchase_price":1000000,"purchase_date":"2023-01-01"}`},
+			{http.MethodPut, "/items/1", `{"name":"時計2","category":"時計","brand":"ROLEX","purchase_price":1000000,"purchase_date":"2023-01-01"}`},
+			{http.MethodPatch, "/items/1", `{"name":"時計2"}`},
+			{http.MethodDelete, "/items/1", ""},
+			{http.MethodPost, "/categories", `{"name":"靴"}`},
+		} {
+			rec := do(req.method, req.path, req.body)
+
+			require.Equal(t, http.StatusForbidden, rec.Code, "%s %s", req.method, req.path)
+			var problem itemController.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, "/problems/forbidden", problem.Type)
+			assert.Contains(t, problem.Detail, "read-only")
+		}
+	})
+
+	t.Run("reads still work", func(t *testing.T) {
+		rec := do(http.MethodGet, "/items/1", "")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var item struct {
+			Name string `json:"name"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &item))
+		assert.Equal(t, "時計1", item.Name)
+
+		for _, path := range []string{"/items", "/items/summary", "/categories"} {
+			assert.Equal(t, http.StatusOK, do(http.MethodGet, path, "").Code, path)
+		}
+	})
+}
+
+func TestGzip(t *testing.T) {
+	u := usecase.NewItemUsecase(memory.NewItemRepository())
+	for i := 0; i < 50; i++ {
+		_, err := u.CreateItem(context.Background(), usecase.CreateItemInput{
+			Name: fmt.Sprintf("ロレックス デイトナ %d", i), Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+		})
+		require.NoError(t, err)
+	}
+
+	e := echo.New()
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(u), RouteOptions{GzipMinLength: 1024})
+
+	request := func(path, acceptEncoding string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		if acceptEncoding != "" {
+			req.Header.Set(echo.HeaderAcceptEncoding, acceptEncoding)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("large list is gzipped", func(t *testing.T) {
+		rec := request("/items?limit=50", "gzip, deflate")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "gzip", rec.Header().Get(echo.HeaderContentEncoding))
+		assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAcceptEncoding)
+
+		reader, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		var items []map[string]interface{}
+		require.NoError(t, json.Unmarshal(body, &items))
+		assert.Len(t, items, 50)
+	})
+
+	t.Run("tiny response is not gzipped", func(t *testing.T) {
+		rec := request("/healthz", "gzip")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+		assert.Contains(t, rec.Body.String(), "ok")
+	})
+
+	t.Run("metrics are compressed only once", func(t *testing.T) {
+		e := echo.New()
+		RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(nil), RouteOptions{Metrics: metrics.New(), GzipMinLength: 1})
+		req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+		req.Header.Set(echo.HeaderAcceptEncoding, "gzip")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		reader, err := gzip.NewReader(rec.Body)
+		require.NoError(t, err)
+		body, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		assert.Contains(t, string(body), "# HELP")
+	})
+
+	t.Run("without Accept-Encoding", func(t *testing.T) {
+		rec := request("/items?limit=50", "")
+
+		assert.Empty(t, rec.Header().Get(echo.HeaderContentEncoding))
+		assert.True(t, json.Valid(rec.Body.Bytes()))
+	})
+}
+
+func TestItemEventStream(t *testing.T) {
+	bus := events.NewBus()
+	u := usecase.NewItemUsecase(memory.NewItemRepository(), usecase.WithEventPublisher(bus))
+	e := echo.New()
+	RegisterRoutes(e, system.NewSystemHandler(nil), itemController.NewItemHandler(u, itemController.WithEventStream(bus, 0)), RouteOptions{GzipMinLength: 1})
+	server := httptest.NewServer(e)
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/items/events", nil)
+	require.NoError(t, err)
+	req.Header.Set(echo.HeaderAcceptEncoding, "gzip")
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	// ヘッダーを受け取った時点で購読済み
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get(echo.HeaderContentType))
+	assert.Empty(t, res.Header.Get(echo.HeaderContentEncoding))
+
+	created, err := http.Post(server.URL+"/items", echo.MIMEApplicationJSON, strings.NewReader(
+		`{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`))
+	require.NoError(t, err)
+	created.Body.Close()
+	require.Equal(t, http.StatusCreated, created.StatusCode)
+
+	reader := bufio.NewReader(res.Body)
+	eventLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	dataLine, err := reader.ReadString('\n')
+	require.NoError(t, err)
+
+	assert.Equal(t, "event: item.created\n", eventLine)
+	data, ok := strings.CutPrefix(strings.TrimSuffix(dataLine, "\n"), "data: ")
+	require.True(t, ok, dataLine)
+	var event struct {
+		Type string `json:"type"`
+		Item struct {
+			ID   int64  `json:"id"`
+			Name string `json:"name"`
+		} `json:"item"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(data), &event))
+	assert.Equal(t, "item.created", event.Type)
+	assert.Equal(t, "ロレックス デイトナ", event.Item.Name)
+	assert.NotZero(t, event.Item.ID)
+}
@@ -0,0 +1,70 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+)
+
+// shutdownGate はシャットダウン開始後に届いたリクエストを 503 で拒否する
+type shutdownGate struct {
+	closed atomic.Bool
+}
+
+func (g *shutdownGate) close() {
+	g.closed.Store(true)
+}
+
+func (g *shutdownGate) middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if g.closed.Load() {
+				// keep-alive の接続も閉じて、クライアントに別のインスタンスへ再接続させる
+				c.Response().Header().Set(echo.HeaderConnection, "close")
+				return itemController.WriteProblem(c, itemController.NewProblem(http.StatusServiceUnavailable, "server is shutting down"))
+			}
+			return next(c)
+		}
+	}
+}
+
+// serve は e を address で起動し、ctx がキャンセルされたら graceful shutdown する
+// 処理中のリクエストは timeout まで完了を待ち、新しいリクエストは 503 で拒否する
+func serve(ctx context.Context, e *echo.Echo, address string, timeout time.Duration) error {
+	gate := &shutdownGate{}
+	e.Pre(gate.middleware())
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- e.Start(address)
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return fmt.Errorf("server startup failed: %w", err)
+	case <-ctx.Done():
+		fmt.Println("\n🛑 Shutting down server...")
+	}
+
+	gate.close()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("server forced to shutdown: %w", err)
+	}
+
+	fmt.Println("✅ Server exited gracefully")
+	return nil
+}
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// S3Config は S3（互換のストレージを含む）の接続設定
+type S3Config struct {
+	Bucket string
+	Region string
+	// S3 互換のストレージ（MinIO など）の URL。指定した場合はパス形式（{Endpoint}/{Bucket}/{key}）で送る
+	// 空の場合は AWS の仮想ホスト形式（https://{Bucket}.s3.{Region}.amazonaws.com/{key}）
+	Endpoint        string
+	AccessKeyID     string
+	SecretAccessKey string
+	// 1 回のアップロードのタイムアウト（0 以下の場合は 30 秒）
+	Timeout time.Duration
+}
+
+// S3Storage は usecase.ImageStorage の S3 実装
+// PutObject を署名バージョン 4 で署名して送り、オブジェクトの URL を返す（バケットは公開読み取りを想定）
+type S3Storage struct {
+	config S3Config
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewS3Storage(config S3Config) (*S3Storage, error) {
+	if config.Bucket == "" || config.Region == "" {
+		return nil, errors.New("s3 bucket and region are required")
+	}
+	if config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		return nil, errors.New("s3 access key id and secret access key are required")
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	return &S3Storage{config: config, client: &http.Client{Timeout: config.Timeout}, now: time.Now}, nil
+}
+
+func (s *S3Storage) Save(ctx context.Context, key, contentType string, body io.Reader) (string, error) {
+	// 署名にはボディの SHA-256 が必要なため、先に読み込む（サイズは usecase で制限している）
+	payload, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+
+	objectURL := s.objectURL(key)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, payload)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("s3 put object failed: %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	}
+
+	return objectURL, nil
+}
+
+func (s *S3Storage) objectURL(key string) string {
+	u := url.URL{Path: "/" + key}
+	if s.config.Endpoint != "" {
+		return s.config.Endpoint + "/" + s.config.Bucket + u.EscapedPath()
+	}
+	return "https://" + s.config.Bucket + ".s3." + s.config.Region + ".amazonaws.com" + u.EscapedPath()
+}
+
+// sign は req に署名バージョン 4 の Authorization ヘッダーを付ける
+// 署名するヘッダーは content-type・host・x-amz-content-sha256・x-amz-date（名前順）
+func (s *S3Storage) sign(req *http.Request, payload []byte) {
+	now := s.now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "content-type:" + req.Header.Get("Content-Type") + "\n" +
+		"host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.config.Region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	for _, part := range []string{s.config.Region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorage_Save(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("writes under the dir and returns the url", func(t *testing.T) {
+		dir := t.TempDir()
+		storage := NewLocalStorage(dir, "http://localhost:8080/images/")
+
+		url, err := storage.Save(ctx, "items/1/100.png", "image/png", strings.NewReader("png"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "http://localhost:8080/images/items/1/100.png", url)
+		data, err := os.ReadFile(filepath.Join(dir, "items", "1", "100.png"))
+		require.NoError(t, err)
+		assert.Equal(t, "png", string(data))
+	})
+
+	t.Run("overwrites an existing key", func(t *testing.T) {
+		dir := t.TempDir()
+		storage := NewLocalStorage(dir, "http://localhost:8080/images")
+
+		_, err := storage.Save(ctx, "items/1/100.png", "image/png", strings.NewReader("old"))
+		require.NoError(t, err)
+		_, err = storage.Save(ctx, "items/1/100.png", "image/png", strings.NewReader("new"))
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(dir, "items", "1", "100.png"))
+		require.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+		// 一時ファイルは残らない
+		entries, err := os.ReadDir(filepath.Join(dir, "items", "1"))
+		require.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("rejects keys outside the dir", func(t *testing.T) {
+		storage := NewLocalStorage(t.TempDir(), "http://localhost:8080/images")
+
+		for _, key := range []string{"../x.png", "/etc/x.png", ""} {
+			_, err := storage.Save(ctx, key, "image/png", strings.NewReader("png"))
+			assert.Error(t, err, key)
+		}
+	})
+}
+
+func TestS3Storage_Save(t *testing.T) {
+	ctx := context.Background()
+	config := func(endpoint string) S3Config {
+		return S3Config{Bucket: "items", Region: "ap-northeast-1", Endpoint: endpoint, AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"}
+	}
+
+	t.Run("puts a signed object and returns its url", func(t *testing.T) {
+		var method, path, body string
+		var header http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			method, path, body, header = r.Method, r.URL.Path, string(data), r.Header.Clone()
+		}))
+		defer server.Close()
+		storage, err := NewS3Storage(config(server.URL + "/"))
+		require.NoError(t, err)
+		storage.now = func() time.Time { return time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC) }
+
+		url, err := storage.Save(ctx, "items/1/100.png", "image/png", strings.NewReader("png"))
+
+		require.NoError(t, err)
+		assert.Equal(t, server.URL+"/items/items/1/100.png", url)
+		assert.Equal(t, http.MethodPut, method)
+		assert.Equal(t, "/items/items/1/100.png", path)
+		assert.Equal(t, "png", body)
+		assert.Equal(t, "image/png", header.Get("Content-Type"))
+		assert.Equal(t, "20240101T090000Z", header.Get("X-Amz-Date"))
+		assert.Equal(t, sha256Hex([]byte("png")), header.Get("X-Amz-Content-Sha256"))
+		assert.True(t, strings.HasPrefix(header.Get("Authorization"),
+			"AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20240101/ap-northeast-1/s3/aws4_request, SignedHeaders=content-type;host;x-amz-content-sha256;x-amz-date, Signature="))
+	})
+
+	t.Run("error status fails", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "AccessDenied", http.StatusForbidden)
+		}))
+		defer server.Close()
+		storage, err := NewS3Storage(config(server.URL))
+		require.NoError(t, err)
+
+		_, err = storage.Save(ctx, "items/1/100.png", "image/png", strings.NewReader("png"))
+
+		assert.ErrorContains(t, err, "403 Forbidden: AccessDenied")
+	})
+
+	t.Run("aws url without endpoint", func(t *testing.T) {
+		storage, err := NewS3Storage(config(""))
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://items.s3.ap-northeast-1.amazonaws.com/items/1/100.png", storage.objectURL("items/1/100.png"))
+	})
+
+	t.Run("requires bucket and credentials", func(t *testing.T) {
+		_, err := NewS3Storage(S3Config{Region: "ap-northeast-1", AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"})
+		assert.Error(t, err)
+		_, err = NewS3Storage(S3Config{Bucket: "items", Region: "ap-northeast-1"})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,182 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"Aicon-assignment/internal/interfaces/database"
+)
+
+// 取得した行数（SELECT）または変更した行数（INSERT / UPDATE / DELETE）
+var rowCountKey = attribute.Key("db.response.returned_rows")
+
+// WrapSqlHandler は SQL の実行（トランザクション内を含む）ごとに CLIENT のスパンを作成する SqlHandler を返す
+// スパン名は SQL の操作名（SELECT、INSERT など）で、SQL 文と行数を属性に記録する（引数の値は記録しない）
+func WrapSqlHandler(handler database.SqlHandler, provider trace.TracerProvider, dialect database.Dialect) database.SqlHandler {
+	system := semconv.DBSystemMySQL
+	if dialect == database.DialectPostgres {
+		system = semconv.DBSystemPostgreSQL
+	}
+	return &tracedSqlHandler{
+		SqlHandler: handler,
+		executor:   tracedExecutor{executor: handler, tracer: provider.Tracer(instrumentationName), system: system},
+	}
+}
+
+type tracedSqlHandler struct {
+	database.SqlHandler
+	executor tracedExecutor
+}
+
+func (h *tracedSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	return h.executor.Execute(ctx, statement, args...)
+}
+
+func (h *tracedSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	return h.executor.Query(ctx, statement, args...)
+}
+
+func (h *tracedSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	return h.executor.QueryRow(ctx, statement, args...)
+}
+
+func (h *tracedSqlHandler) Begin(ctx context.Context) (database.Tx, error) {
+	tx, err := h.SqlHandler.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	executor := h.executor
+	executor.executor = tx
+	return &tracedTx{Tx: tx, executor: executor}, nil
+}
+
+type tracedTx struct {
+	database.Tx
+	executor tracedExecutor
+}
+
+func (t *tracedTx) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	return t.executor.Execute(ctx, statement, args...)
+}
+
+func (t *tracedTx) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	return t.executor.Query(ctx, statement, args...)
+}
+
+func (t *tracedTx) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	return t.executor.QueryRow(ctx, statement, args...)
+}
+
+// tracedExecutor は executor の SQL の実行をスパンで囲む
+type tracedExecutor struct {
+	executor database.Executor
+	tracer   trace.Tracer
+	system   attribute.KeyValue
+}
+
+func (e tracedExecutor) start(ctx context.Context, statement string) (context.Context, trace.Span) {
+	operation := operationName(statement)
+	return e.tracer.Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(e.system, semconv.DBOperationName(operation), semconv.DBQueryText(statement)),
+	)
+}
+
+func (e tracedExecutor) Execute(ctx context.Context, statement string, args ...interface{}) (database.Result, error) {
+	ctx, span := e.start(ctx, statement)
+	defer span.End()
+
+	result, err := e.executor.Execute(ctx, statement, args...)
+	if err != nil {
+		recordError(span, err)
+		return nil, err
+	}
+	if affected, err := result.RowsAffected(); err == nil {
+		span.SetAttributes(rowCountKey.Int64(affected))
+	}
+	return result, nil
+}
+
+func (e tracedExecutor) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	ctx, span := e.start(ctx, statement)
+
+	rows, err := e.executor.Query(ctx, statement, args...)
+	if err != nil {
+		recordError(span, err)
+		span.End()
+		return nil, err
+	}
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func (e tracedExecutor) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	ctx, span := e.start(ctx, statement)
+	return &tracedRow{Row: e.executor.QueryRow(ctx, statement, args...), span: span}
+}
+
+// tracedRows は読み込んだ行数を数え、Close でスパンを終える
+type tracedRows struct {
+	database.Rows
+	span  trace.Span
+	count int64
+}
+
+func (r *tracedRows) Next() bool {
+	if !r.Rows.Next() {
+		return false
+	}
+	r.count++
+	return true
+}
+
+func (r *tracedRows) Close() error {
+	err := r.Rows.Close()
+	if rowsErr := r.Rows.Err(); rowsErr != nil {
+		recordError(r.span, rowsErr)
+	}
+	r.span.SetAttributes(rowCountKey.Int64(r.count))
+	r.span.End()
+	return err
+}
+
+// tracedRow は Scan でスパンを終える（行がない場合はエラーとせず 0 行とする）
+type tracedRow struct {
+	database.Row
+	span trace.Span
+}
+
+func (r *tracedRow) Scan(dest ...interface{}) error {
+	defer r.span.End()
+
+	err := r.Row.Scan(dest...)
+	switch {
+	case err == nil:
+		r.span.SetAttributes(rowCountKey.Int64(1))
+	case errors.Is(err, sql.ErrNoRows):
+		r.span.SetAttributes(rowCountKey.Int64(0))
+	default:
+		recordError(r.span, err)
+	}
+	return err
+}
+
+func recordError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// operationName は SQL 文の最初のキーワード（SELECT、INSERT など）を返す
+func operationName(statement string) string {
+	fields := strings.Fields(statement)
+	if len(fields) == 0 {
+		return "SQL"
+	}
+	return strings.ToUpper(fields[0])
+}
@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// トレースの計装ライブラリの名前（Tracer の名前）
+const instrumentationName = "Aicon-assignment"
+
+// Config は OTLP でトレースを送る設定
+type Config struct {
+	// OTLP/HTTP の送信先（例: http://localhost:4318、空の場合はトレースを送らない）
+	Endpoint string
+	// トレースに付けるサービス名
+	ServiceName string
+}
+
+// Setup は Endpoint に OTLP/HTTP でトレースを送る TracerProvider を作成し、W3C Trace Context の伝播とともにグローバルに設定する
+// Endpoint が空の場合は何も記録しない TracerProvider を返す
+// 返す関数はシャットダウン時に送信待ちのスパンを送り切る
+func Setup(ctx context.Context, cfg Config) (trace.TracerProvider, func(context.Context) error, error) {
+	if cfg.Endpoint == "" {
+		return noop.NewTracerProvider(), func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(cfg.ServiceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return provider, provider.Shutdown, nil
+}
+
+// Middleware はリクエストごとに SERVER のスパンを作成し、ハンドラーに渡す context に設定する
+// 受信した traceparent ヘッダーのトレースがあれば、その子のスパンにする
+// スパン名は「メソッド ルート」（例: GET /items/:id）で、5xx のレスポンスはエラーとして記録する
+func Middleware(provider trace.TracerProvider, propagator propagation.TextMapPropagator) echo.MiddlewareFunc {
+	tracer := provider.Tracer(instrumentationName)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := propagator.Extract(req.Context(), propagation.HeaderCarrier(req.Header))
+
+			name := req.Method
+			if route := c.Path(); route != "" {
+				name += " " + route
+			}
+			ctx, span := tracer.Start(ctx, name,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					semconv.HTTPRequestMethodKey.String(req.Method),
+					semconv.HTTPRoute(c.Path()),
+					semconv.URLPath(req.URL.Path),
+				),
+			)
+			defer span.End()
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+			if err != nil {
+				// ステータスコードを確定させるため、ここでエラーハンドラーを呼ぶ
+				c.Error(err)
+			}
+
+			status := c.Response().Status
+			span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+			if status >= 500 {
+				span.SetStatus(codes.Error, strconv.Itoa(status))
+				if err != nil {
+					span.RecordError(err)
+				}
+			}
+			return nil
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	itemController "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/interfaces/database"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeSqlHandler は QueryRow に rows の先頭の行を、Query に rows のすべての行を返すテスト用の SqlHandler
+type fakeSqlHandler struct {
+	database.SqlHandler
+	rows [][]interface{}
+}
+
+func (h *fakeSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (database.Rows, error) {
+	return &fakeRows{rows: h.rows, index: -1}, nil
+}
+
+func (h *fakeSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) database.Row {
+	if len(h.rows) == 0 {
+		return fakeRow{}
+	}
+	return fakeRow{values: h.rows[0]}
+}
+
+type fakeRows struct {
+	rows  [][]interface{}
+	index int
+}
+
+func (r *fakeRows) Next() bool {
+	r.index++
+	return r.index < len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	return assignValues(r.rows[r.index], dest)
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Err() error { return nil }
+
+type fakeRow struct {
+	values []interface{}
+}
+
+func (r fakeRow) Scan(dest ...interface{}) error {
+	if r.values == nil {
+		return sql.ErrNoRows
+	}
+	return assignValues(r.values, dest)
+}
+
+func assignValues(values []interface{}, dest []interface{}) error {
+	if len(values) != len(dest) {
+		return fmt.Errorf("expected %d columns, got %d", len(dest), len(values))
+	}
+	for i, value := range values {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+// items テーブルの 1 行分のカラム値
+func itemRow(id int64, name string) []interface{} {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []interface{}{id, name, "時計", "ROLEX", 1000000, "2023-01-01", now, now, sql.NullTime{}, 1, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, "", sql.NullString{}}
+}
+
+// newTracedServer は SQL のリポジトリで GET /items と GET /items/:id に応答し、スパンを exporter に記録するサーバーを返す
+func newTracedServer(handler database.SqlHandler) (*echo.Echo, *tracetest.InMemoryExporter) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	repo := &database.ItemRepository{SqlHandler: WrapSqlHandler(handler, provider, database.DialectMySQL)}
+	itemHandler := itemController.NewItemHandler(usecase.NewItemUsecase(repo))
+
+	e := echo.New()
+	e.HTTPErrorHandler = itemController.ErrorHandler
+	e.Use(Middleware(provider, propagation.TraceContext{}))
+	e.GET("/items", itemHandler.GetItems)
+	e.GET("/items/:id", itemHandler.GetItem)
+	return e, exporter
+}
+
+func attributeValue(span tracetest.SpanStub, key attribute.Key) attribute.Value {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value
+		}
+	}
+	return attribute.Value{}
+}
+
+func TestTracing_GetItem(t *testing.T) {
+	t.Run("request span with a child span per query", func(t *testing.T) {
+		e, exporter := newTracedServer(&fakeSqlHandler{rows: [][]interface{}{itemRow(1, "時計1")}})
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/1", nil))
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		// 子のスパンが先に終わる
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		query, request := spans[0], spans[1]
+
+		assert.Equal(t, "GET /items/:id", request.Name)
+		assert.Equal(t, trace.SpanKindServer, request.SpanKind)
+		assert.False(t, request.Parent.IsValid())
+		assert.Equal(t, "/items/:id", attributeValue(request, "http.route").AsString())
+		assert.Equal(t, int64(http.StatusOK), attributeValue(request, "http.response.status_code").AsInt64())
+
+		assert.Equal(t, "SELECT", query.Name)
+		assert.Equal(t, trace.SpanKindClient, query.SpanKind)
+		assert.Equal(t, request.SpanContext.TraceID(), query.SpanContext.TraceID())
+		assert.Equal(t, request.SpanContext.SpanID(), query.Parent.SpanID())
+		assert.Equal(t, "SELECT", attributeValue(query, "db.operation.name").AsString())
+		assert.Equal(t, "mysql", attributeValue(query, "db.system").AsString())
+		assert.Contains(t, attributeValue(query, "db.query.text").AsString(), "FROM items")
+		assert.Equal(t, int64(1), attributeValue(query, rowCountKey).AsInt64())
+	})
+
+	t.Run("missing item has no rows", func(t *testing.T) {
+		e, exporter := newTracedServer(&fakeSqlHandler{})
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/9", nil))
+		require.Equal(t, http.StatusNotFound, rec.Code)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		assert.Equal(t, int64(0), attributeValue(spans[0], rowCountKey).AsInt64())
+		assert.Equal(t, int64(http.StatusNotFound), attributeValue(spans[1], "http.response.status_code").AsInt64())
+	})
+
+	t.Run("incoming trace context is propagated", func(t *testing.T) {
+		e, exporter := newTracedServer(&fakeSqlHandler{rows: [][]interface{}{itemRow(1, "時計1")}})
+
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+		e.ServeHTTP(httptest.NewRecorder(), req)
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 2)
+		request := spans[1]
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", request.SpanContext.TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", request.Parent.SpanID().String())
+		assert.True(t, request.Parent.IsRemote())
+		assert.Equal(t, request.SpanContext.TraceID(), spans[0].SpanContext.TraceID())
+	})
+}
+
+func TestWrapSqlHandler_QueryCountsRows(t *testing.T) {
+	e, exporter := newTracedServer(&fakeSqlHandler{rows: [][]interface{}{itemRow(1, "時計1"), itemRow(2, "時計2"), itemRow(3, "時計3")}})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items?ids=1,2,3", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "SELECT", spans[0].Name)
+	assert.Equal(t, int64(3), attributeValue(spans[0], rowCountKey).AsInt64())
+	assert.Equal(t, spans[1].SpanContext.SpanID(), spans[0].Parent.SpanID())
+}
@@ -0,0 +1,175 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// 配送を並行して行う数と、配送待ちにできるイベント数（超えた分は破棄してログに残す）
+const (
+	workers   = 4
+	queueSize = 100
+)
+
+// Config は Webhook の通知先と再試行の設定
+type Config struct {
+	// イベントを POST する URL（すべての URL に同じイベントを送る）
+	URLs []string
+	// 1 つの URL に送信を試みる回数（1 以下の場合は 1 回だけ）
+	MaxAttempts int
+	// 1 回目の失敗後に待つ時間（以降は失敗するたびに 2 倍にする）
+	BaseDelay time.Duration
+	// 1 回の送信のタイムアウト
+	Timeout time.Duration
+}
+
+// 通知するイベントの種類（更新は件数が多くなるため送らない）
+var deliveredEvents = map[string]bool{
+	entity.ItemEventCreated: true,
+	entity.ItemEventDeleted: true,
+}
+
+type delivery struct {
+	url       string
+	eventType string
+	body      []byte
+}
+
+// Dispatcher は usecase.ItemEventPublisher の実装で、イベントを JSON にして設定した URL に非同期で POST する
+// 2xx 以外の応答や通信エラーは待ち時間を増やしながら再試行し、上限に達した場合はログに残して諦める
+type Dispatcher struct {
+	urls        []string
+	client      *http.Client
+	maxAttempts int
+	baseDelay   time.Duration
+	sleep       func(time.Duration)
+	logger      *slog.Logger
+
+	mu     sync.Mutex
+	closed bool
+	queue  chan delivery
+	wg     sync.WaitGroup
+}
+
+func NewDispatcher(cfg Config, logger *slog.Logger) *Dispatcher {
+	return newDispatcher(cfg, logger, time.Sleep)
+}
+
+func newDispatcher(cfg Config, logger *slog.Logger, sleep func(time.Duration)) *Dispatcher {
+	d := &Dispatcher{
+		urls:        cfg.URLs,
+		client:      &http.Client{Timeout: cfg.Timeout},
+		maxAttempts: max(cfg.MaxAttempts, 1),
+		baseDelay:   cfg.BaseDelay,
+		sleep:       sleep,
+		logger:      logger,
+		queue:       make(chan delivery, queueSize),
+	}
+	for i := 0; i < workers; i++ {
+		d.wg.Add(1)
+		go d.work()
+	}
+	return d
+}
+
+// Publish は作成・削除のイベントを配送待ちに追加してすぐに戻る（Close の後は何もしない）
+func (d *Dispatcher) Publish(ctx context.Context, event entity.ItemEvent) {
+	if !deliveredEvents[event.Type] {
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.logger.ErrorContext(ctx, "failed to encode webhook event", "type", event.Type, "error", err)
+		return
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.closed {
+		return
+	}
+	for _, url := range d.urls {
+		select {
+		case d.queue <- delivery{url: url, eventType: event.Type, body: body}:
+		default:
+			d.logger.WarnContext(ctx, "webhook queue is full; event dropped", "type", event.Type, "url", url)
+		}
+	}
+}
+
+// Close は新しいイベントの受け付けをやめ、配送待ちのイベントを送り終えるか ctx が終了するまで待つ
+func (d *Dispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *Dispatcher) work() {
+	defer d.wg.Done()
+	for dl := range d.queue {
+		d.deliver(dl)
+	}
+}
+
+// deliver は成功するまで最大 maxAttempts 回 POST する
+func (d *Dispatcher) deliver(dl delivery) {
+	delay := d.baseDelay
+	for attempt := 1; ; attempt++ {
+		err := d.post(dl)
+		if err == nil {
+			return
+		}
+		if attempt == d.maxAttempts {
+			d.logger.Error("webhook delivery failed",
+				"type", dl.eventType, "url", dl.url, "attempts", attempt, "error", err.Error())
+			return
+		}
+		d.logger.Warn("webhook delivery failed, retrying",
+			"type", dl.eventType, "url", dl.url, "attempt", attempt, "max_attempts", d.maxAttempts, "retry_in", delay.String(), "error", err.Error())
+		d.sleep(delay)
+		delay *= 2
+	}
+}
+
+func (d *Dispatcher) post(dl delivery) error {
+	req, err := http.NewRequest(http.MethodPost, dl.url, bytes.NewReader(dl.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Webhook-Event", dl.eventType)
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	res.Body.Close()
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %d", res.StatusCode)
+	}
+	return nil
+}
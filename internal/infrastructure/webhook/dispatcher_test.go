@@ -0,0 +1,146 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// receiver は受け取ったリクエストを記録し、statuses の順に応答する（使い切った後は 200）
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.bodies = append(r.bodies, body)
+	r.headers = append(r.headers, req.Header.Clone())
+	status := http.StatusOK
+	if len(r.statuses) > 0 {
+		status, r.statuses = r.statuses[0], r.statuses[1:]
+	}
+	w.WriteHeader(status)
+}
+
+func (r *receiver) requests() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.bodies)
+}
+
+var testEvent = entity.ItemEvent{
+	Type:      entity.ItemEventCreated,
+	Item:      &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15", Version: 1},
+	Timestamp: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+}
+
+func closeDispatcher(t *testing.T, d *Dispatcher) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, d.Close(ctx))
+}
+
+func TestDispatcher_Publish(t *testing.T) {
+	t.Run("event is posted as json to every url", func(t *testing.T) {
+		first, second := &receiver{}, &receiver{}
+		firstServer, secondServer := httptest.NewServer(first), httptest.NewServer(second)
+		defer firstServer.Close()
+		defer secondServer.Close()
+
+		d := NewDispatcher(Config{URLs: []string{firstServer.URL, secondServer.URL}, MaxAttempts: 1, Timeout: time.Second}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		d.Publish(context.Background(), testEvent)
+		closeDispatcher(t, d)
+
+		require.Equal(t, 1, first.requests())
+		require.Equal(t, 1, second.requests())
+		assert.Equal(t, "application/json", first.headers[0].Get("Content-Type"))
+		assert.Equal(t, "item.created", first.headers[0].Get("X-Webhook-Event"))
+
+		var payload map[string]interface{}
+		require.NoError(t, json.Unmarshal(first.bodies[0], &payload))
+		assert.Equal(t, "item.created", payload["type"])
+		assert.Equal(t, "2024-01-01T09:00:00Z", payload["timestamp"])
+		item := payload["item"].(map[string]interface{})
+		assert.Equal(t, float64(1), item["id"])
+		assert.Equal(t, "ロレックス デイトナ", item["name"])
+		assert.Equal(t, first.bodies[0], second.bodies[0])
+	})
+
+	t.Run("failures are retried with backoff", func(t *testing.T) {
+		recv := &receiver{statuses: []int{http.StatusServiceUnavailable, http.StatusInternalServerError}}
+		server := httptest.NewServer(recv)
+		defer server.Close()
+
+		var mu sync.Mutex
+		var delays []time.Duration
+		sleep := func(d time.Duration) {
+			mu.Lock()
+			defer mu.Unlock()
+			delays = append(delays, d)
+		}
+		d := newDispatcher(Config{URLs: []string{server.URL}, MaxAttempts: 5, BaseDelay: time.Second, Timeout: time.Second}, slog.New(slog.NewTextHandler(io.Discard, nil)), sleep)
+		d.Publish(context.Background(), testEvent)
+		closeDispatcher(t, d)
+
+		assert.Equal(t, 3, recv.requests())
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, delays)
+	})
+
+	t.Run("gives up after max attempts and logs the failure", func(t *testing.T) {
+		recv := &receiver{statuses: []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError, http.StatusInternalServerError}}
+		server := httptest.NewServer(recv)
+		defer server.Close()
+
+		var logs bytes.Buffer
+		d := newDispatcher(Config{URLs: []string{server.URL}, MaxAttempts: 3, Timeout: time.Second}, slog.New(slog.NewTextHandler(&logs, nil)), func(time.Duration) {})
+		d.Publish(context.Background(), testEvent)
+		closeDispatcher(t, d)
+
+		assert.Equal(t, 3, recv.requests())
+		assert.Contains(t, logs.String(), `level=ERROR msg="webhook delivery failed"`)
+		assert.Contains(t, logs.String(), "unexpected status 500")
+	})
+
+	t.Run("update events are not delivered", func(t *testing.T) {
+		recv := &receiver{}
+		server := httptest.NewServer(recv)
+		defer server.Close()
+
+		d := NewDispatcher(Config{URLs: []string{server.URL}}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		d.Publish(context.Background(), entity.ItemEvent{Type: entity.ItemEventUpdated, Item: testEvent.Item})
+		closeDispatcher(t, d)
+
+		assert.Equal(t, 0, recv.requests())
+	})
+
+	t.Run("publish after close is ignored", func(t *testing.T) {
+		recv := &receiver{}
+		server := httptest.NewServer(recv)
+		defer server.Close()
+
+		d := NewDispatcher(Config{URLs: []string{server.URL}}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+		closeDispatcher(t, d)
+		d.Publish(context.Background(), testEvent)
+
+		assert.Equal(t, 0, recv.requests())
+	})
+}
@@ -0,0 +1,65 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetCategories はアイテムを登録できるカテゴリーを ID 順に返す
+func (h *ItemHandler) GetCategories(c echo.Context) error {
+	categories, err := h.itemUsecase.GetCategories(c.Request().Context())
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, categories)
+}
+
+// CreateCategory はカテゴリーを追加し、201 で返す（同じ名前のカテゴリーがある場合は 409）
+func (h *ItemHandler) CreateCategory(c echo.Context) error {
+	var input usecase.CreateCategoryInput
+	if err := bindJSONStrict(c, &input); err != nil {
+		return writeBindError(c, err)
+	}
+
+	category, err := h.itemUsecase.CreateCategory(c.Request().Context(), input)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, category)
+}
+
+// RenameCategory はカテゴリー名と、そのカテゴリーのすべてのアイテムのカテゴリーを変更する
+// 別の既存のカテゴリーの名前には統合せず 409 を返す
+func (h *ItemHandler) RenameCategory(c echo.Context) error {
+	var input usecase.RenameCategoryInput
+	if err := bindJSONStrict(c, &input); err != nil {
+		return writeBindError(c, err)
+	}
+
+	result, err := h.itemUsecase.RenameCategory(c.Request().Context(), input)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// DeleteCategory はカテゴリーを削除する（アイテムが登録されている場合は 409）
+func (h *ItemHandler) DeleteCategory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid category ID"))
+	}
+
+	if err := h.itemUsecase.DeleteCategory(c.Request().Context(), id); err != nil {
+		return writeError(c, err)
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,176 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_GetCategories(t *testing.T) {
+	e := echo.New()
+	mockUsecase := &mockItemUsecase{}
+	mockUsecase.getCategoriesFunc = func(ctx context.Context) ([]*entity.Category, error) {
+		return []*entity.Category{{ID: 1, Name: "時計"}, {ID: 6, Name: "家具"}}, nil
+	}
+	req := httptest.NewRequest(http.MethodGet, "/categories", nil)
+	rec := httptest.NewRecorder()
+
+	assert.NoError(t, NewItemHandler(mockUsecase).GetCategories(e.NewContext(req, rec)))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var categories []entity.Category
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &categories))
+	assert.Equal(t, []string{"時計", "家具"}, []string{categories[0].Name, categories[1].Name})
+	assert.Equal(t, int64(6), categories[1].ID)
+}
+
+func TestItemHandler_CreateCategory(t *testing.T) {
+	e := echo.New()
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		return req
+	}
+
+	t.Run("creates the category", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.createCategoryFunc = func(ctx context.Context, input usecase.CreateCategoryInput) (*entity.Category, error) {
+			assert.Equal(t, "家具", input.Name)
+			return &entity.Category{ID: 6, Name: input.Name}, nil
+		}
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(mockUsecase).CreateCategory(e.NewContext(newRequest(`{"name":"家具"}`), rec)))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"id":6`)
+	})
+
+	t.Run("duplicate is a conflict", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.createCategoryFunc = func(ctx context.Context, input usecase.CreateCategoryInput) (*entity.Category, error) {
+			return nil, domainErrors.ErrDuplicateCategory
+		}
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(mockUsecase).CreateCategory(e.NewContext(newRequest(`{"name":"時計"}`), rec)))
+
+		assertProblem(t, rec, http.StatusConflict, "/problems/duplicate-category")
+	})
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(&mockItemUsecase{}).CreateCategory(e.NewContext(newRequest(`{"title":"家具"}`), rec)))
+
+		assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+	})
+}
+
+func TestItemHandler_DeleteCategory(t *testing.T) {
+	e := echo.New()
+	newContext := func(id string, rec *httptest.ResponseRecorder) echo.Context {
+		c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/categories/"+id, nil), rec)
+		c.SetPath("/categories/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+		return c
+	}
+
+	t.Run("deletes the category", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.deleteCategoryFunc = func(ctx context.Context, id int64) error {
+			assert.Equal(t, int64(6), id)
+			return nil
+		}
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(mockUsecase).DeleteCategory(newContext("6", rec)))
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+	})
+
+	t.Run("category with items is a conflict", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.deleteCategoryFunc = func(ctx context.Context, id int64) error {
+			return domainErrors.ErrCategoryInUse
+		}
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(mockUsecase).DeleteCategory(newContext("1", rec)))
+
+		assertProblem(t, rec, http.StatusConflict, "/problems/category-in-use")
+	})
+
+	t.Run("missing category and invalid id", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.deleteCategoryFunc = func(ctx context.Context, id int64) error {
+			return domainErrors.ErrCategoryNotFound
+		}
+
+		rec := httptest.NewRecorder()
+		assert.NoError(t, NewItemHandler(mockUsecase).DeleteCategory(newContext("99", rec)))
+		assertProblem(t, rec, http.StatusNotFound, "/problems/not-found")
+
+		rec = httptest.NewRecorder()
+		assert.NoError(t, NewItemHandler(mockUsecase).DeleteCategory(newContext("abc", rec)))
+		assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+	})
+}
+
+func TestItemHandler_RenameCategory(t *testing.T) {
+	e := echo.New()
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/categories/rename", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		return req
+	}
+
+	t.Run("renames the category", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.renameCategoryFunc = func(ctx context.Context, input usecase.RenameCategoryInput) (*usecase.RenameCategoryResult, error) {
+			assert.Equal(t, usecase.RenameCategoryInput{From: "時計", To: "腕時計"}, input)
+			return &usecase.RenameCategoryResult{Category: &entity.Category{ID: 1, Name: input.To}, UpdatedItems: 3}, nil
+		}
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(mockUsecase).RenameCategory(e.NewContext(newRequest(`{"from":"時計","to":"腕時計"}`), rec)))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"category":{"id":1,"name":"腕時計","created_at":"0001-01-01T00:00:00Z"},"updated_items":3}`, rec.Body.String())
+	})
+
+	t.Run("existing target is a conflict", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.renameCategoryFunc = func(ctx context.Context, input usecase.RenameCategoryInput) (*usecase.RenameCategoryResult, error) {
+			return nil, domainErrors.ErrDuplicateCategory
+		}
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(mockUsecase).RenameCategory(e.NewContext(newRequest(`{"from":"時計","to":"バッグ"}`), rec)))
+
+		assertProblem(t, rec, http.StatusConflict, "/problems/duplicate-category")
+	})
+
+	t.Run("missing category is not found", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.renameCategoryFunc = func(ctx context.Context, input usecase.RenameCategoryInput) (*usecase.RenameCategoryResult, error) {
+			return nil, domainErrors.ErrCategoryNotFound
+		}
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(mockUsecase).RenameCategory(e.NewContext(newRequest(`{"from":"家具","to":"インテリア"}`), rec)))
+
+		assertProblem(t, rec, http.StatusNotFound, "/problems/not-found")
+	})
+}
@@ -0,0 +1,37 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GetAveragePrice はカテゴリー別の平均購入価格を返す（アイテムのないカテゴリーは average_price が null）
+func (h *ItemHandler) GetAveragePrice(c echo.Context) error {
+	analytics, err := h.itemUsecase.GetAveragePriceAnalytics(c.Request().Context())
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, analytics)
+}
+
+// GetPriceRange はカテゴリー別の最低・最高購入価格を返す（アイテムのないカテゴリーは min_price / max_price が null）
+func (h *ItemHandler) GetPriceRange(c echo.Context) error {
+	analytics, err := h.itemUsecase.GetPriceRangeAnalytics(c.Request().Context())
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, analytics)
+}
+
+// GetTimeline は購入月ごとの件数と購入価格の合計を古い月から順に返す
+func (h *ItemHandler) GetTimeline(c echo.Context) error {
+	timeline, err := h.itemUsecase.GetTimelineAnalytics(c.Request().Context())
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, timeline)
+}
@@ -0,0 +1,151 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_GetAveragePrice(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, mockUsecase *mockItemUsecase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/analytics/average-price", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetAveragePrice(c))
+		return rec
+	}
+
+	t.Run("empty categories have a null average", func(t *testing.T) {
+		average := 1150000.0
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getAveragePriceFunc = func(ctx context.Context) (*usecase.AveragePriceAnalytics, error) {
+			return &usecase.AveragePriceAnalytics{Categories: map[string]usecase.CategoryAveragePrice{
+				"時計": {Count: 2, AveragePrice: &average},
+				"靴":  {},
+			}}, nil
+		}
+
+		rec := fetch(t, mockUsecase)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"categories": {
+			"時計": {"count": 2, "average_price": 1150000},
+			"靴": {"count": 0, "average_price": null}
+		}}`, rec.Body.String())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getAveragePriceFunc = func(ctx context.Context) (*usecase.AveragePriceAnalytics, error) {
+			return nil, domainErrors.ErrDatabaseError
+		}
+
+		rec := fetch(t, mockUsecase)
+
+		assertProblem(t, rec, http.StatusInternalServerError, "about:blank")
+	})
+}
+
+func TestItemHandler_GetPriceRange(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, mockUsecase *mockItemUsecase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/analytics/price-range", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetPriceRange(c))
+		return rec
+	}
+
+	t.Run("pairs each category with its min and max", func(t *testing.T) {
+		price := func(v int) *int { return &v }
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getPriceRangeFunc = func(ctx context.Context) (*usecase.PriceRangeAnalytics, error) {
+			return &usecase.PriceRangeAnalytics{Categories: map[string]usecase.CategoryPriceRange{
+				"時計":  {Count: 2, MinPrice: price(800000), MaxPrice: price(1500000)},
+				"バッグ": {Count: 1, MinPrice: price(2000000), MaxPrice: price(2000000)},
+				"靴":   {},
+			}}, nil
+		}
+
+		rec := fetch(t, mockUsecase)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"categories": {
+			"時計": {"count": 2, "min_price": 800000, "max_price": 1500000},
+			"バッグ": {"count": 1, "min_price": 2000000, "max_price": 2000000},
+			"靴": {"count": 0, "min_price": null, "max_price": null}
+		}}`, rec.Body.String())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getPriceRangeFunc = func(ctx context.Context) (*usecase.PriceRangeAnalytics, error) {
+			return nil, domainErrors.ErrDatabaseError
+		}
+
+		rec := fetch(t, mockUsecase)
+
+		assertProblem(t, rec, http.StatusInternalServerError, "about:blank")
+	})
+}
+
+func TestItemHandler_GetTimeline(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, mockUsecase *mockItemUsecase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/analytics/timeline", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetTimeline(c))
+		return rec
+	}
+
+	t.Run("returns the monthly buckets", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getTimelineFunc = func(ctx context.Context) ([]usecase.MonthlyStats, error) {
+			return []usecase.MonthlyStats{
+				{Month: "2023-01", Count: 2, TotalValue: 2300000},
+				{Month: "2023-02", Count: 1, TotalValue: 2000000},
+			}, nil
+		}
+
+		rec := fetch(t, mockUsecase)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[
+			{"month": "2023-01", "count": 2, "total_value": 2300000},
+			{"month": "2023-02", "count": 1, "total_value": 2000000}
+		]`, rec.Body.String())
+	})
+
+	t.Run("no items is an empty array", func(t *testing.T) {
+		rec := fetch(t, &mockItemUsecase{})
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[]`, rec.Body.String())
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getTimelineFunc = func(ctx context.Context) ([]usecase.MonthlyStats, error) {
+			return nil, domainErrors.ErrDatabaseError
+		}
+
+		rec := fetch(t, mockUsecase)
+
+		assertProblem(t, rec, http.StatusInternalServerError, "about:blank")
+	})
+}
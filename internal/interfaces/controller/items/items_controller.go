@@ -1,128 +1,581 @@
 package controller
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"reflect"
+	"regexp"
 	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
 	"Aicon-assignment/internal/usecase"
 
 	"github.com/labstack/echo/v4"
 )
 
+const (
+	headerIdempotencyKey = "Idempotency-Key"
+	// 再送に対して記録済みの結果を返したことを示す
+	headerIdempotentReplayed = "Idempotent-Replayed"
+)
+
+const (
+	// limit 未指定時の取得件数
+	defaultLimit = 20
+	// limit の上限（WithMaxLimit で変更可能）
+	defaultMaxLimit = 100
+)
+
 type ItemHandler struct {
-	itemUsecase usecase.ItemUsecase
+	itemUsecase      usecase.ItemUsecase
+	maxLimit         int
+	idempotencyStore IdempotencyStore
+	// GET /items/events で配信するイベントの購読先（nil の場合は 503）
+	eventSubscriber   EventSubscriber
+	heartbeatInterval time.Duration
+	// GET /items/events で購読したユーザーのアイテムのイベントのみを配信する
+	userScopedEvents bool
+	// POST /items/import-ndjson で 1 回の一括登録にまとめる行数
+	ndjsonBatchSize int
+}
+
+// IdempotencyStore は Idempotency-Key と作成済みアイテム ID の対応をユーザー（JWT の subject）ごとに保持する
+// Do は subject の記録済みのキーなら create を実行せずに記録済みの ID を replayed=true で返す
+// 同じキーの同時呼び出しでは create が 1 回だけ実行されること
+type IdempotencyStore interface {
+	Do(subject, key string, create func() (int64, error)) (itemID int64, replayed bool, err error)
+}
+
+// ItemHandler の設定を変更するオプション
+type Option func(*ItemHandler)
+
+// limit の上限を設定する
+func WithMaxLimit(maxLimit int) Option {
+	return func(h *ItemHandler) {
+		if maxLimit > 0 {
+			h.maxLimit = maxLimit
+		}
+	}
+}
+
+// WithIdempotencyStore は POST /items の Idempotency-Key ヘッダーを有効にする
+func WithIdempotencyStore(store IdempotencyStore) Option {
+	return func(h *ItemHandler) {
+		h.idempotencyStore = store
+	}
 }
 
-func NewItemHandler(itemUsecase usecase.ItemUsecase) *ItemHandler {
-	return &ItemHandler{
-		itemUsecase: itemUsecase,
+func NewItemHandler(itemUsecase usecase.ItemUsecase, opts ...Option) *ItemHandler {
+	h := &ItemHandler{
+		itemUsecase:       itemUsecase,
+		maxLimit:          defaultMaxLimit,
+		heartbeatInterval: defaultHeartbeatInterval,
+		ndjsonBatchSize:   defaultNDJSONBatchSize,
+	}
+	for _, opt := range opts {
+		opt(h)
 	}
+	return h
 }
 
-// エラーレスポンスの形式
-type ErrorResponse struct {
-	Error   string   `json:"error"`
-	Details []string `json:"details,omitempty"`
+// GET /items/count のレスポンス形式
+type ItemCountResponse struct {
+	Count int `json:"count"`
+}
+
+// カーソルページングのレスポンス形式
+type ItemPageResponse struct {
+	Items      []*entity.Item `json:"items"`
+	NextCursor string         `json:"next_cursor"`
 }
 
 func (h *ItemHandler) GetItems(c echo.Context) error {
-	items, err := h.itemUsecase.GetAllItems(c.Request().Context())
+	if c.QueryParams().Has("ids") {
+		return h.getItemsByIDs(c)
+	}
+	if c.QueryParams().Has("cursor") {
+		return h.getItemsByCursor(c)
+	}
+
+	limit, offset, err := h.parsePagination(c)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid pagination parameters", err.Error()))
+	}
+
+	sort, err := parseSortOption(c.QueryParam("sort"))
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid sort parameter", err.Error()))
+	}
+
+	filter, err := parseItemFilter(c)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid filter parameters", err.Error()))
+	}
+
+	items, total, err := h.itemUsecase.GetItems(c.Request().Context(), filter, sort, limit, offset)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(total))
+	return writeItems(c, items)
+}
+
+// CountItems は一覧と同じ絞り込み条件に一致するアイテムの件数を返す
+func (h *ItemHandler) CountItems(c echo.Context) error {
+	filter, err := parseItemFilter(c)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid filter parameters", err.Error()))
+	}
+
+	count, err := h.itemUsecase.CountItems(c.Request().Context(), filter)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, ItemCountResponse{Count: count})
+}
+
+func (h *ItemHandler) GetDeletedItems(c echo.Context) error {
+	limit, offset, err := h.parsePagination(c)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve items",
-		})
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid pagination parameters", err.Error()))
 	}
 
-	return c.JSON(http.StatusOK, items)
+	items, total, err := h.itemUsecase.GetDeletedItems(c.Request().Context(), limit, offset)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(total))
+	return writeItems(c, items)
+}
+
+// 差分同期のレスポンスの要素（論理削除されたアイテムは deleted を true にする）
+type ItemChange struct {
+	*entity.Item
+	Deleted bool `json:"deleted"`
+}
+
+// GetItemChanges は since（RFC3339）以降に変更されたアイテムを、論理削除されたものも含めて返す
+func (h *ItemHandler) GetItemChanges(c echo.Context) error {
+	value := c.QueryParam("since")
+	if value == "" {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid since parameter", "since is required"))
+	}
+	since, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid since parameter", "since must be an RFC3339 timestamp"))
+	}
+
+	items, err := h.itemUsecase.GetItemChanges(c.Request().Context(), since)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	changes := make([]ItemChange, 0, len(items))
+	for _, item := range items {
+		changes = append(changes, ItemChange{Item: item, Deleted: item.DeletedAt != nil})
+	}
+
+	return c.JSON(http.StatusOK, changes)
+}
+
+// HeadItem はアイテムが存在するかをボディなしのステータスで返す（存在する場合は 200、しない場合は 404）
+func (h *ItemHandler) HeadItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.NoContent(http.StatusBadRequest)
+	}
+
+	exists, err := h.itemUsecase.ItemExists(c.Request().Context(), id)
+	if err != nil {
+		return c.NoContent(ProblemFromError(err).Status)
+	}
+	if !exists {
+		return c.NoContent(http.StatusNotFound)
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// GetRecentItems は最近登録したアイテムを登録日時の新しい順に返す
+// limit の既定値は usecase.DefaultRecentItems で、usecase.MaxRecentItems を超える値は上限に切り詰める
+func (h *ItemHandler) GetRecentItems(c echo.Context) error {
+	limit := usecase.DefaultRecentItems
+	if v := c.QueryParam("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid limit parameter", "limit must be a positive integer"))
+		}
+		limit = min(parsed, usecase.MaxRecentItems)
+	}
+
+	items, err := h.itemUsecase.GetRecentItems(c.Request().Context(), limit)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return writeItems(c, items)
+}
+
+// GetTopItems は購入価格の高い順に n 件（category で絞り込み可）を返す
+// n の既定値は usecase.DefaultTopItems で、usecase.MaxTopItems を超える値は上限に切り詰める
+func (h *ItemHandler) GetTopItems(c echo.Context) error {
+	n := usecase.DefaultTopItems
+	if v := c.QueryParam("n"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 1 {
+			return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid n parameter", "n must be a positive integer"))
+		}
+		n = min(parsed, usecase.MaxTopItems)
+	}
+
+	var category *string
+	if c.QueryParams().Has("category") {
+		value := c.QueryParam("category")
+		category = &value
+	}
+
+	items, err := h.itemUsecase.GetTopItems(c.Request().Context(), category, n)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return writeItems(c, items)
+}
+
+// GetExpiringWarranties は保証期限が今日から days 日後までのアイテムを保証期限の近い順に返す
+// days の既定値は usecase.DefaultWarrantyDays で、0 から usecase.MaxWarrantyDays まで指定できる
+func (h *ItemHandler) GetExpiringWarranties(c echo.Context) error {
+	days := usecase.DefaultWarrantyDays
+	if v := c.QueryParam("days"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil || parsed < 0 || parsed > usecase.MaxWarrantyDays {
+			return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid days parameter",
+				fmt.Sprintf("days must be an integer between 0 and %d", usecase.MaxWarrantyDays)))
+		}
+		days = parsed
+	}
+
+	items, err := h.itemUsecase.GetExpiringWarranties(c.Request().Context(), days)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return writeItems(c, items)
 }
 
 func (h *ItemHandler) GetItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
 	}
 
 	item, err := h.itemUsecase.GetItemByID(c.Request().Context(), id)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve item",
-		})
+		return writeError(c, err)
 	}
 
-	return c.JSON(http.StatusOK, item)
+	format, ok := negotiateFormat(c)
+	if !ok {
+		return writeNotAcceptable(c)
+	}
+	body, contentType, err := marshalItem(c, format, item)
+	if err != nil {
+		return err
+	}
+
+	// レスポンスボディ（fields 適用後、形式ごと）のハッシュを ETag とする
+	etag := computeETag(body)
+	c.Response().Header().Set(headerETag, etag)
+	if etagMatches(c.Request().Header.Get(headerIfNoneMatch), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+
+	return c.Blob(http.StatusOK, contentType, body)
 }
 
 func (h *ItemHandler) CreateItem(c echo.Context) error {
 	var input usecase.CreateItemInput
-	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid request format",
-		})
+	if err := bindJSONStrict(c, &input); err != nil {
+		return writeBindError(c, err)
 	}
 
 	// バリデーション
-	if validationErrors := validateCreateItemInput(input); len(validationErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "validation failed",
-			Details: validationErrors,
-		})
+	if err := validateCreateItemInput(input); err != nil {
+		return writeError(c, err)
+	}
+
+	// dry run では保存しないため、Idempotency-Key も記録しない
+	dryRun, err := isDryRun(c)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, err.Error()))
+	}
+	if dryRun {
+		return h.dryRunCreateItem(c, input)
+	}
+
+	key := c.Request().Header.Get(headerIdempotencyKey)
+	if key != "" && h.idempotencyStore != nil {
+		return h.createItemIdempotent(c, key, input)
 	}
 
 	item, err := h.itemUsecase.CreateItem(c.Request().Context(), input)
 	if err != nil {
-		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{
-				Error:   "validation failed",
-				Details: []string{err.Error()},
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to create item",
-		})
+		return writeError(c, err)
 	}
 
+	return writeCreated(c, item)
+}
+
+// 作成したアイテムを 201 と Location ヘッダー付きで返す
+func writeCreated(c echo.Context, item *entity.Item) error {
+	c.Response().Header().Set(echo.HeaderLocation, fmt.Sprintf("/items/%d", item.ID))
 	return c.JSON(http.StatusCreated, item)
 }
 
+// 同じユーザーの同じ Idempotency-Key の再送では新規作成せず、最初に作成したアイテムを返す
+func (h *ItemHandler) createItemIdempotent(c echo.Context, key string, input usecase.CreateItemInput) error {
+	ctx := c.Request().Context()
+
+	var created *entity.Item
+	itemID, replayed, err := h.idempotencyStore.Do(usecase.SubjectFromContext(ctx), key, func() (int64, error) {
+		item, err := h.itemUsecase.CreateItem(ctx, input)
+		if err != nil {
+			return 0, err
+		}
+		created = item
+		return item.ID, nil
+	})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	if !replayed {
+		return writeCreated(c, created)
+	}
+
+	item, err := h.itemUsecase.GetItemByID(ctx, itemID)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	c.Response().Header().Set(headerIdempotentReplayed, "true")
+	return writeCreated(c, item)
+}
+
+func (h *ItemHandler) CreateItems(c echo.Context) error {
+	var inputs []usecase.CreateItemInput
+	if err := bindJSONStrict(c, &inputs); err != nil {
+		return writeBindError(c, err)
+	}
+
+	// 各要素のバリデーション（すべての要素の違反をまとめて返す）
+	verr := &domainErrors.ValidationError{}
+	for i, input := range inputs {
+		if err := validateCreateItemInput(input); err != nil {
+			itemErr, _ := domainErrors.AsValidationError(err)
+			verr.Fields = append(verr.Fields, itemErr.WithPrefix(fmt.Sprintf("items[%d]", i)).Fields...)
+		}
+	}
+	if err := verr.Err(); err != nil {
+		return writeError(c, err)
+	}
+
+	items, err := h.itemUsecase.CreateItems(c.Request().Context(), inputs)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, items)
+}
+
 func (h *ItemHandler) DeleteItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error: "invalid item ID",
-		})
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
 	}
 
 	err = h.itemUsecase.DeleteItem(c.Request().Context(), id)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{
-				Error: "item not found",
-			})
-		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to delete item",
-		})
+		return writeError(c, err)
 	}
 
 	return c.NoContent(http.StatusNoContent)
 }
 
+func (h *ItemHandler) RestoreItem(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
+	}
+
+	item, err := h.itemUsecase.RestoreItem(c.Request().Context(), id)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
+
+// GetPriceHistory はアイテムの購入価格の変更を古い順に返す
+func (h *ItemHandler) GetPriceHistory(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
+	}
+
+	changes, err := h.itemUsecase.GetPriceHistory(c.Request().Context(), id)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, changes)
+}
+
+// GetItemVersions はアイテムの記録したバージョンと現在のバージョンを古い順に返す
+func (h *ItemHandler) GetItemVersions(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
+	}
+
+	versions, err := h.itemUsecase.GetItemVersions(c.Request().Context(), id)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, versions)
+}
+
+// GetItemVersion は指定したバージョンの時点のアイテムを返す
+func (h *ItemHandler) GetItemVersion(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
+	}
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil || version <= 0 {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid version", "version must be a positive integer"))
+	}
+
+	item, err := h.itemUsecase.GetItemVersion(c.Request().Context(), id, version)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return writeNegotiated(c, xmlItem{Item: item}, func() error {
+		return c.JSON(http.StatusOK, item)
+	})
+}
+
+// DiffItemVersions は from と to のクエリパラメータのバージョンの間で値が変わったフィールドを返す
+func (h *ItemHandler) DiffItemVersions(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
+	}
+
+	var details []string
+	versions := make([]int, 2)
+	for i, name := range []string{"from", "to"} {
+		v, err := strconv.Atoi(c.QueryParam(name))
+		if err != nil || v <= 0 {
+			details = append(details, fmt.Sprintf("%s must be a positive integer", name))
+			continue
+		}
+		versions[i] = v
+	}
+	if len(details) > 0 {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid diff parameters", details...))
+	}
+
+	diff, err := h.itemUsecase.DiffItemVersions(c.Request().Context(), id, versions[0], versions[1])
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, diff)
+}
+
+// CloneItem は既存のアイテムを複製して 201 で返す
+func (h *ItemHandler) CloneItem(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
+	}
+
+	item, err := h.itemUsecase.CloneItem(c.Request().Context(), id)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return writeCreated(c, item)
+}
+
+// 一括削除のリクエスト形式
+type DeleteItemsRequest struct {
+	IDs []int64 `json:"ids"`
+}
+
+func (h *ItemHandler) DeleteItems(c echo.Context) error {
+	var req DeleteItemsRequest
+	if err := c.Bind(&req); err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid request format"))
+	}
+
+	result, err := h.itemUsecase.DeleteItems(c.Request().Context(), req.IDs)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// MoveItems は指定したアイテムのカテゴリーを一括で変更する（存在しない ID は not_found で返す）
+func (h *ItemHandler) MoveItems(c echo.Context) error {
+	var input usecase.MoveItemsInput
+	if err := bindJSONStrict(c, &input); err != nil {
+		return writeBindError(c, err)
+	}
+
+	result, err := h.itemUsecase.MoveItems(c.Request().Context(), input)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
 func (h *ItemHandler) GetSummary(c echo.Context) error {
-	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context())
+	// from / to で購入日の範囲を指定できる（片方のみも可）
+	from, err := parseDateParam(c.QueryParam("from"), "from")
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid summary parameters", err.Error()))
+	}
+	to, err := parseDateParam(c.QueryParam("to"), "to")
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid summary parameters", err.Error()))
+	}
+	if from != nil && to != nil && from.After(*to) {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid summary parameters", "from must be earlier than or equal to to"))
+	}
+
+	summary, err := h.itemUsecase.GetCategorySummary(c.Request().Context(), from, to)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "failed to retrieve summary",
-		})
+		return writeError(c, err)
 	}
 
 	return c.JSON(http.StatusOK, summary)
@@ -132,84 +585,530 @@ func (h *ItemHandler) UpdateItem(c echo.Context) error {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid item ID"})
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
 	}
 
 	var input usecase.UpdateItemInput
-	if err := c.Bind(&input); err != nil {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid request format"})
+	if err := bindUpdateItemInput(c, &input); err != nil {
+		return writeBindError(c, err)
 	}
 
-	if validationErrors := validateUpdateItemInput(input); len(validationErrors) > 0 {
-		return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "validation failed", Details: validationErrors})
+	if err := validateUpdateItemInput(input); err != nil {
+		return writeError(c, err)
 	}
 
 	updated, err := h.itemUsecase.UpdateItem(c.Request().Context(), id, input)
 	if err != nil {
-		if domainErrors.IsNotFoundError(err) {
-			return c.JSON(http.StatusNotFound, ErrorResponse{Error: "item not found"})
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
+
+// cursor クエリパラメータによるキーセットページング
+func (h *ItemHandler) getItemsByCursor(c echo.Context) error {
+	limit, _, err := h.parsePagination(c)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid pagination parameters", err.Error()))
+	}
+
+	afterID, err := decodeCursor(c.QueryParam("cursor"))
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid cursor", err.Error()))
+	}
+
+	// カーソルは ID 順の位置を表すため、ほかの並び順とは組み合わせられない
+	if c.QueryParams().Has("sort") {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid sort parameter", "sort cannot be combined with cursor"))
+	}
+
+	filter, err := parseItemFilter(c)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid filter parameters", err.Error()))
+	}
+
+	items, hasMore, err := h.itemUsecase.GetItemsAfter(c.Request().Context(), filter, afterID, limit)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	response := ItemPageResponse{Items: items}
+	if hasMore && len(items) > 0 {
+		response.NextCursor = encodeCursor(items[len(items)-1].ID)
+	}
+
+	return writeNegotiated(c, xmlItemPage{Items: items, NextCursor: response.NextCursor}, func() error {
+		// fields 指定時は items の各要素のみを絞り込む
+		if fields := parseFields(c); len(fields) > 0 {
+			selected, err := selectFields(items, fields)
+			if err != nil {
+				return err
+			}
+			return c.JSON(http.StatusOK, map[string]interface{}{
+				"items":       selected,
+				"next_cursor": response.NextCursor,
+			})
 		}
-		if domainErrors.IsValidationError(err) {
-			return c.JSON(http.StatusBadRequest, ErrorResponse{Error: "validation failed", Details: []string{err.Error()}})
+
+		return c.JSON(http.StatusOK, response)
+	})
+}
+
+// ids クエリパラメータ（カンマ区切り）で指定したアイテムを指定順で返す
+// 見つからない ID は結果に含めない。fields 以外のクエリパラメータは無視する
+func (h *ItemHandler) getItemsByIDs(c echo.Context) error {
+	ids, err := parseIDList(c.QueryParam("ids"))
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid ids parameter", err.Error()))
+	}
+
+	items, err := h.itemUsecase.GetItemsByIDs(c.Request().Context(), ids)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	c.Response().Header().Set("X-Total-Count", strconv.Itoa(len(items)))
+	return writeItems(c, items)
+}
+
+// カンマ区切りの ID 一覧を解析する（空の要素は無視する）
+func parseIDList(value string) ([]int64, error) {
+	ids := []int64{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 64)
+		if err != nil || id <= 0 {
+			return nil, fmt.Errorf("ids must be a comma-separated list of positive integers, got %q", part)
 		}
-		return c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "failed to update item"})
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// 最後に取得したアイテムの ID をカーソル文字列にエンコードする
+func encodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// カーソル文字列をアイテム ID にデコードする（空文字は先頭から）
+func decodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
 	}
 
-	return c.JSON(http.StatusOK, updated)
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, fmt.Errorf("%w: cursor is malformed", domainErrors.ErrInvalidInput)
+	}
+
+	id, err := strconv.ParseInt(string(decoded), 10, 64)
+	if err != nil || id < 0 {
+		return 0, fmt.Errorf("%w: cursor is malformed", domainErrors.ErrInvalidInput)
+	}
+
+	return id, nil
 }
 
-func validateCreateItemInput(input usecase.CreateItemInput) []string {
-	var errs []string
+// category / category_not / brand / tag / tag_mode / q / min_price / max_price / purchased_after / purchased_before
+// クエリパラメータから絞り込み条件を組み立てる
+// パラメータが指定されていない項目は絞り込みに使用しない
+func parseItemFilter(c echo.Context) (usecase.ItemFilter, error) {
+	var filter usecase.ItemFilter
+	params := c.QueryParams()
+
+	// category は繰り返しとカンマ区切りのどちらでも複数指定でき、いずれかに一致するアイテムを返す
+	filter.Categories = splitListParam(params["category"])
+	// category_not も同じ形式で指定でき、いずれかに一致するアイテムを除く
+	filter.ExcludedCategories = splitListParam(params["category_not"])
+	if params.Has("brand") {
+		brand := params.Get("brand")
+		filter.Brand = &brand
+	}
+	// tag も繰り返しとカンマ区切りで複数指定でき、既定ではすべてのタグを持つアイテム、tag_mode=any ではいずれかを持つアイテムを返す
+	filter.Tags = splitListParam(params["tag"])
+	switch params.Get("tag_mode") {
+	case "", "all":
+	case "any":
+		filter.TagsMatchAny = true
+	default:
+		return filter, fmt.Errorf("%w: tag_mode must be all or any", domainErrors.ErrInvalidInput)
+	}
+	// attr=key:value は繰り返しで複数指定でき、すべてに一致するアイテムを返す（値にカンマを含められるよう分割しない）
+	for _, value := range params["attr"] {
+		attribute, err := parseAttrParam(value)
+		if err != nil {
+			return filter, err
+		}
+		filter.Attributes = append(filter.Attributes, attribute)
+	}
+	filter.Search = params.Get("q")
+
+	minPrice, err := parsePriceParam(params.Get("min_price"), "min_price")
+	if err != nil {
+		return filter, err
+	}
+	maxPrice, err := parsePriceParam(params.Get("max_price"), "max_price")
+	if err != nil {
+		return filter, err
+	}
+	if minPrice != nil && maxPrice != nil && *minPrice > *maxPrice {
+		return filter, fmt.Errorf("%w: min_price must be less than or equal to max_price", domainErrors.ErrInvalidInput)
+	}
+	filter.MinPrice = minPrice
+	filter.MaxPrice = maxPrice
+
+	after, err := parseDateParam(params.Get("purchased_after"), "purchased_after")
+	if err != nil {
+		return filter, err
+	}
+	before, err := parseDateParam(params.Get("purchased_before"), "purchased_before")
+	if err != nil {
+		return filter, err
+	}
+	if after != nil && before != nil && after.After(*before) {
+		return filter, fmt.Errorf("%w: purchased_after must be earlier than or equal to purchased_before", domainErrors.ErrInvalidInput)
+	}
+	filter.PurchasedAfter = after
+	filter.PurchasedBefore = before
+
+	return filter, nil
+}
+
+// 日付のクエリパラメータを解析する（RFC3339 の日時または YYYY-MM-DD、未指定の場合は nil）
+func parseDateParam(value, name string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s must be an RFC3339 date", domainErrors.ErrInvalidInput, name)
+}
+
+// attr のキーの 1 階層分の名前（入れ子のメンバーは "." で区切る）
+var attrKeySegment = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+
+// attr のクエリパラメータ（key:value）を解析する。キーとの区切りは最初の ":" で、値には ":" を含められる
+func parseAttrParam(value string) (usecase.AttributeFilter, error) {
+	key, attrValue, ok := strings.Cut(value, ":")
+	if !ok || attrValue == "" {
+		return usecase.AttributeFilter{}, fmt.Errorf("%w: attr must be in key:value format", domainErrors.ErrInvalidInput)
+	}
+	path := strings.Split(key, ".")
+	for _, segment := range path {
+		if !attrKeySegment.MatchString(segment) {
+			return usecase.AttributeFilter{}, fmt.Errorf("%w: attr key must be dot-separated names of letters, digits, _ and -", domainErrors.ErrInvalidInput)
+		}
+	}
+	return usecase.AttributeFilter{Path: path, Value: attrValue}, nil
+}
+
+// 繰り返し指定されたクエリパラメータの値をカンマで分割し、空の要素を除いて返す
+func splitListParam(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				result = append(result, part)
+			}
+		}
+	}
+	return result
+}
+
+// 価格のクエリパラメータを解析する（未指定の場合は nil）
+func parsePriceParam(value, name string) (*int, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	price, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be an integer", domainErrors.ErrInvalidInput, name)
+	}
+	if price < 0 {
+		return nil, fmt.Errorf("%w: %s must be 0 or greater", domainErrors.ErrInvalidInput, name)
+	}
+
+	return &price, nil
+}
+
+// sort クエリパラメータ（カンマ区切りで複数指定可、前から順に優先）を並び替え条件に変換する
+// 先頭の "-" は降順を表す。同じフィールドを 2 回指定した場合はエラーとする
+// フィールド名の妥当性はリポジトリの許可リストで検証される
+func parseSortOption(value string) (usecase.SortOption, error) {
+	if value == "" {
+		return usecase.SortOption{}, nil
+	}
+
+	var sort usecase.SortOption
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		descending := strings.HasPrefix(part, "-")
+		field := strings.TrimPrefix(part, "-")
+		if field == "" {
+			return usecase.SortOption{}, fmt.Errorf("%w: sort field is required", domainErrors.ErrInvalidInput)
+		}
+		if seen[field] {
+			return usecase.SortOption{}, fmt.Errorf("%w: duplicate sort field: %s", domainErrors.ErrInvalidInput, field)
+		}
+		seen[field] = true
+		sort.Keys = append(sort.Keys, usecase.SortKey{Field: usecase.SortField(field), Descending: descending})
+	}
+
+	return sort, nil
+}
+
+// limit / offset クエリパラメータの解析とバリデーション
+func (h *ItemHandler) parsePagination(c echo.Context) (int, int, error) {
+	limit := defaultLimit
+	offset := 0
+
+	if v := c.QueryParam("limit"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: limit must be an integer", domainErrors.ErrInvalidInput)
+		}
+		if parsed < 1 || parsed > h.maxLimit {
+			return 0, 0, fmt.Errorf("%w: limit must be between 1 and %d", domainErrors.ErrInvalidInput, h.maxLimit)
+		}
+		limit = parsed
+	}
+
+	if v := c.QueryParam("offset"); v != "" {
+		parsed, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, 0, fmt.Errorf("%w: offset must be an integer", domainErrors.ErrInvalidInput)
+		}
+		if parsed < 0 {
+			return 0, 0, fmt.Errorf("%w: offset must be 0 or greater", domainErrors.ErrInvalidInput)
+		}
+		offset = parsed
+	}
+
+	return limit, offset, nil
+}
+
+func (h *ItemHandler) ReplaceItem(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
+	}
+
+	var input usecase.ReplaceItemInput
+	if err := bindJSONStrict(c, &input); err != nil {
+		return writeBindError(c, err)
+	}
+
+	if err := validateReplaceItemInput(input); err != nil {
+		return writeError(c, err)
+	}
+
+	replaced, err := h.itemUsecase.ReplaceItem(c.Request().Context(), id, input)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, replaced)
+}
+
+// bindJSONStrict は JSON のリクエストボディを、構造体にないフィールドを許可せずに v へデコードする
+// フィールド名の打ち間違いを黙って無視しないため（PATCH で省略したフィールドは従来どおり nil のまま）
+// JSON 以外の Content-Type は c.Bind に任せる
+func bindJSONStrict(c echo.Context, v any) error {
+	req := c.Request()
+	if !strings.HasPrefix(req.Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		return c.Bind(v)
+	}
+	if req.ContentLength == 0 {
+		return malformedJSON("request body is empty")
+	}
+	return decodeJSONStrict(req.Body, v)
+}
+
+// decodeJSONStrict は未知のフィールドをバリデーションエラー、構文・型の誤りと空のボディを malformed JSON の Problem にする
+// ボディは 1 つの JSON 値に限り、後ろに続く値や文字も malformed JSON とする（前後の空白は許す）
+func decodeJSONStrict(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if err == io.EOF {
+			return malformedJSON("request body is empty")
+		}
+		if field, ok := unknownJSONField(err); ok {
+			verr := &domainErrors.ValidationError{}
+			verr.Add(field, domainErrors.CodeUnknownField)
+			return verr
+		}
+		return malformedJSONError(err)
+	}
+
+	var syntaxErr *json.SyntaxError
+	switch err := dec.Decode(&json.RawMessage{}); {
+	case err == io.EOF:
+		return nil
+	case err == nil, errors.As(err, &syntaxErr), errors.Is(err, io.ErrUnexpectedEOF):
+		return malformedJSON("request body must contain a single JSON value")
+	default:
+		return err
+	}
+}
+
+// malformedJSON は JSON として読めないボディの 400（バリデーションエラーとは type で区別する）
+func malformedJSON(reason string) *Problem {
+	problem := NewProblem(http.StatusBadRequest, "malformed JSON body", reason)
+	problem.Type, problem.Title = malformedJSONProblemType.uri, malformedJSONProblemType.title
+	return problem
+}
+
+// malformedJSONError はデコードのエラーを、型の誤りはフィールド名、構文の誤りは位置を含めた malformed JSON にする
+// それ以外（ボディの上限超過など）はそのまま返す
+func malformedJSONError(err error) error {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return malformedJSON(fmt.Sprintf("%s must be %s, got %s", typeErr.Field, jsonTypeName(typeErr.Type), typeErr.Value))
+	case errors.As(err, &typeErr):
+		return malformedJSON(fmt.Sprintf("body must be %s, got %s", jsonTypeName(typeErr.Type), typeErr.Value))
+	case errors.As(err, &syntaxErr):
+		return malformedJSON(fmt.Sprintf("%s (at byte %d)", syntaxErr.Error(), syntaxErr.Offset))
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return malformedJSON("unexpected end of JSON input")
+	}
+	return err
+}
+
+// jsonTypeName はデコード先の Go の型を JSON の型名にする
+func jsonTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	}
+	return "object"
+}
+
+// encoding/json は未知のフィールドを `json: unknown field "name"` 形式のエラーでしか返さない
+func unknownJSONField(err error) (string, bool) {
+	quoted, ok := strings.CutPrefix(err.Error(), "json: unknown field ")
+	if !ok {
+		return "", false
+	}
+	field, err := strconv.Unquote(quoted)
+	if err != nil {
+		return "", false
+	}
+	return field, true
+}
+
+// 未知のフィールドはフィールド名付きのバリデーションエラー、JSON の構文・型の誤りは malformed JSON、
+// ボディの上限超過は 413、それ以外は形式エラーとして返す
+func writeBindError(c echo.Context, err error) error {
+	var problem *Problem
+	var maxBytesErr *http.MaxBytesError
+	if _, ok := domainErrors.AsValidationError(err); ok || errors.As(err, &problem) || errors.As(err, &maxBytesErr) {
+		return writeError(c, err)
+	}
+	return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid request format"))
+}
+
+func validateCreateItemInput(input usecase.CreateItemInput) error {
+	verr := &domainErrors.ValidationError{}
 
 	// Basic required field validation
 	if input.Name == "" {
-		errs = append(errs, "name is required")
+		verr.Add("name", domainErrors.CodeRequired)
 	}
 	if input.Category == "" {
-		errs = append(errs, "category is required")
+		verr.Add("category", domainErrors.CodeRequired)
 	}
 	if input.Brand == "" {
-		errs = append(errs, "brand is required")
-	}
-	if input.PurchaseDate == "" {
-		errs = append(errs, "purchase_date is required")
+		verr.Add("brand", domainErrors.CodeRequired)
 	}
 	if input.PurchasePrice < 0 {
-		errs = append(errs, "purchase_price must be 0 or greater")
+		verr.Add("purchase_price", domainErrors.CodeNegative)
+	}
+	if input.PurchaseDate == "" {
+		verr.Add("purchase_date", domainErrors.CodeRequired)
 	}
 
-	return errs
+	return verr.Err()
 }
 
-func validateUpdateItemInput(input usecase.UpdateItemInput) []string {
-	var errs []string
+func validateUpdateItemInput(input usecase.UpdateItemInput) error {
+	verr := &domainErrors.ValidationError{}
 
-	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil {
-		errs = append(errs, "no fields to update")
-		return errs
+	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil && input.WarrantyExpiry == nil && input.Notes == nil && input.Tags == nil && input.ImageURL == nil && input.Attributes == nil {
+		verr.Add("", domainErrors.CodeNoFields)
+		return verr
 	}
 
 	if input.Name != nil {
 		if *input.Name == "" {
-			errs = append(errs, "name is required")
-		} else if len(*input.Name) > 100 {
-			errs = append(errs, "name must be 100 characters or less")
+			verr.Add("name", domainErrors.CodeRequired)
+		} else if utf8.RuneCountInString(*input.Name) > entity.MaxNameLength {
+			verr.Add("name", domainErrors.CodeTooLong, entity.MaxNameLength)
 		}
 	}
 
 	if input.Brand != nil {
 		if *input.Brand == "" {
-			errs = append(errs, "brand is required")
-		} else if len(*input.Brand) > 100 {
-			errs = append(errs, "brand must be 100 characters or less")
+			verr.Add("brand", domainErrors.CodeRequired)
+		} else if utf8.RuneCountInString(*input.Brand) > entity.MaxBrandLength {
+			verr.Add("brand", domainErrors.CodeTooLong, entity.MaxBrandLength)
 		}
 	}
 
 	if input.PurchasePrice != nil {
 		if *input.PurchasePrice < 0 {
-			errs = append(errs, "purchase_price must be 0 or greater")
+			verr.Add("purchase_price", domainErrors.CodeNegative)
 		}
 	}
 
-	return errs
+	return verr.Err()
+}
+
+// PUT では未指定のフィールドを既存値で補わないため、すべてのフィールドを必須とする
+func validateReplaceItemInput(input usecase.ReplaceItemInput) error {
+	verr := &domainErrors.ValidationError{}
+
+	if input.Name == nil || *input.Name == "" {
+		verr.Add("name", domainErrors.CodeRequired)
+	}
+	if input.Category == nil || *input.Category == "" {
+		verr.Add("category", domainErrors.CodeRequired)
+	}
+	if input.Brand == nil || *input.Brand == "" {
+		verr.Add("brand", domainErrors.CodeRequired)
+	}
+	if input.PurchasePrice == nil {
+		verr.Add("purchase_price", domainErrors.CodeRequired)
+	} else if *input.PurchasePrice < 0 {
+		verr.Add("purchase_price", domainErrors.CodeNegative)
+	}
+	if input.PurchaseDate == nil || *input.PurchaseDate == "" {
+		verr.Add("purchase_date", domainErrors.CodeRequired)
+	}
+
+	return verr.Err()
 }
@@ -4,31 +4,249 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/infrastructure/idempotency"
 	"Aicon-assignment/internal/usecase"
 )
 
 type mockItemUsecase struct {
-	updateItemFunc func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error)
+	getAllItemsFunc     func(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error)
+	getItemsFunc        func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error)
+	getDeletedItemsFunc func(ctx context.Context, limit, offset int) ([]*entity.Item, int, error)
+	getItemsAfterFunc   func(ctx context.Context, filter usecase.ItemFilter, afterID int64, limit int) ([]*entity.Item, bool, error)
+	getItemChangesFunc  func(ctx context.Context, since time.Time) ([]*entity.Item, error)
+	getItemByIDFunc     func(ctx context.Context, id int64) (*entity.Item, error)
+	getPriceHistoryFunc func(ctx context.Context, id int64) ([]*entity.PriceChange, error)
+	getItemVersionsFunc func(ctx context.Context, id int64) ([]*entity.ItemVersion, error)
+	getItemVersionFunc  func(ctx context.Context, id int64, version int) (*entity.Item, error)
+	diffVersionsFunc    func(ctx context.Context, id int64, from, to int) (*usecase.ItemDiff, error)
+	getItemsByIDsFunc   func(ctx context.Context, ids []int64) ([]*entity.Item, error)
+	getRecentItemsFunc  func(ctx context.Context, limit int) ([]*entity.Item, error)
+	getTopItemsFunc     func(ctx context.Context, category *string, n int) ([]*entity.Item, error)
+	getExpiringFunc     func(ctx context.Context, days int) ([]*entity.Item, error)
+	countItemsFunc      func(ctx context.Context, filter usecase.ItemFilter) (int, error)
+	itemExistsFunc      func(ctx context.Context, id int64) (bool, error)
+	createItemFunc      func(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error)
+	dryRunCreateFunc    func(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error)
+	validateItemsFunc   func(ctx context.Context, inputs []usecase.CreateItemInput) (*usecase.BatchValidationResult, error)
+	createItemsFunc     func(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error)
+	cloneItemFunc       func(ctx context.Context, id int64) (*entity.Item, error)
+	uploadImageFunc     func(ctx context.Context, id int64, image usecase.ImageUpload) (*entity.Item, error)
+	replaceItemFunc     func(ctx context.Context, id int64, input usecase.ReplaceItemInput) (*entity.Item, error)
+	restoreItemFunc     func(ctx context.Context, id int64) (*entity.Item, error)
+	deleteItemsFunc     func(ctx context.Context, ids []int64) (*usecase.BulkDeleteResult, error)
+	moveItemsFunc       func(ctx context.Context, input usecase.MoveItemsInput) (*usecase.BulkMoveResult, error)
+	updateItemFunc      func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error)
+	getSummaryFunc      func(ctx context.Context, from, to *time.Time) (*usecase.CategorySummary, error)
+	getAveragePriceFunc func(ctx context.Context) (*usecase.AveragePriceAnalytics, error)
+	getPriceRangeFunc   func(ctx context.Context) (*usecase.PriceRangeAnalytics, error)
+	getTimelineFunc     func(ctx context.Context) ([]usecase.MonthlyStats, error)
+	getCategoriesFunc   func(ctx context.Context) ([]*entity.Category, error)
+	createCategoryFunc  func(ctx context.Context, input usecase.CreateCategoryInput) (*entity.Category, error)
+	deleteCategoryFunc  func(ctx context.Context, id int64) error
+	renameCategoryFunc  func(ctx context.Context, input usecase.RenameCategoryInput) (*usecase.RenameCategoryResult, error)
 }
 
-func (m *mockItemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
+func (m *mockItemUsecase) GetCategories(ctx context.Context) ([]*entity.Category, error) {
+	if m.getCategoriesFunc != nil {
+		return m.getCategoriesFunc(ctx)
+	}
+	categories := make([]*entity.Category, len(entity.DefaultCategories))
+	for i, name := range entity.DefaultCategories {
+		categories[i] = &entity.Category{ID: int64(i + 1), Name: name}
+	}
+	return categories, nil
+}
+
+func (m *mockItemUsecase) CreateCategory(ctx context.Context, input usecase.CreateCategoryInput) (*entity.Category, error) {
+	if m.createCategoryFunc != nil {
+		return m.createCategoryFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) DeleteCategory(ctx context.Context, id int64) error {
+	if m.deleteCategoryFunc != nil {
+		return m.deleteCategoryFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *mockItemUsecase) RenameCategory(ctx context.Context, input usecase.RenameCategoryInput) (*usecase.RenameCategoryResult, error) {
+	if m.renameCategoryFunc != nil {
+		return m.renameCategoryFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) GetAllItems(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	if m.getAllItemsFunc != nil {
+		return m.getAllItemsFunc(ctx, filter)
+	}
+	return []*entity.Item{}, nil
+}
+
+func (m *mockItemUsecase) GetItems(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+	if m.getItemsFunc != nil {
+		return m.getItemsFunc(ctx, filter, sort, limit, offset)
+	}
+	return []*entity.Item{}, 0, nil
+}
+
+func (m *mockItemUsecase) GetDeletedItems(ctx context.Context, limit, offset int) ([]*entity.Item, int, error) {
+	if m.getDeletedItemsFunc != nil {
+		return m.getDeletedItemsFunc(ctx, limit, offset)
+	}
+	return []*entity.Item{}, 0, nil
+}
+
+func (m *mockItemUsecase) GetRecentItems(ctx context.Context, limit int) ([]*entity.Item, error) {
+	if m.getRecentItemsFunc != nil {
+		return m.getRecentItemsFunc(ctx, limit)
+	}
+	return []*entity.Item{}, nil
+}
+
+func (m *mockItemUsecase) GetTopItems(ctx context.Context, category *string, n int) ([]*entity.Item, error) {
+	if m.getTopItemsFunc != nil {
+		return m.getTopItemsFunc(ctx, category, n)
+	}
+	return []*entity.Item{}, nil
+}
+
+func (m *mockItemUsecase) GetExpiringWarranties(ctx context.Context, days int) ([]*entity.Item, error) {
+	if m.getExpiringFunc != nil {
+		return m.getExpiringFunc(ctx, days)
+	}
+	return []*entity.Item{}, nil
+}
+
+func (m *mockItemUsecase) CountItems(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+	if m.countItemsFunc != nil {
+		return m.countItemsFunc(ctx, filter)
+	}
+	return 0, nil
+}
+
+func (m *mockItemUsecase) ItemExists(ctx context.Context, id int64) (bool, error) {
+	if m.itemExistsFunc != nil {
+		return m.itemExistsFunc(ctx, id)
+	}
+	return false, nil
+}
+
+func (m *mockItemUsecase) CloneItem(ctx context.Context, id int64) (*entity.Item, error) {
+	if m.cloneItemFunc != nil {
+		return m.cloneItemFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) UploadItemImage(ctx context.Context, id int64, image usecase.ImageUpload) (*entity.Item, error) {
+	if m.uploadImageFunc != nil {
+		return m.uploadImageFunc(ctx, id, image)
+	}
 	return nil, nil
 }
 
+func (m *mockItemUsecase) GetItemsAfter(ctx context.Context, filter usecase.ItemFilter, afterID int64, limit int) ([]*entity.Item, bool, error) {
+	if m.getItemsAfterFunc != nil {
+		return m.getItemsAfterFunc(ctx, filter, afterID, limit)
+	}
+	return []*entity.Item{}, false, nil
+}
+
+func (m *mockItemUsecase) GetItemChanges(ctx context.Context, since time.Time) ([]*entity.Item, error) {
+	if m.getItemChangesFunc != nil {
+		return m.getItemChangesFunc(ctx, since)
+	}
+	return []*entity.Item{}, nil
+}
+
 func (m *mockItemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
+	if m.getItemByIDFunc != nil {
+		return m.getItemByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) GetPriceHistory(ctx context.Context, id int64) ([]*entity.PriceChange, error) {
+	if m.getPriceHistoryFunc != nil {
+		return m.getPriceHistoryFunc(ctx, id)
+	}
+	return []*entity.PriceChange{}, nil
+}
+
+func (m *mockItemUsecase) GetItemVersions(ctx context.Context, id int64) ([]*entity.ItemVersion, error) {
+	if m.getItemVersionsFunc != nil {
+		return m.getItemVersionsFunc(ctx, id)
+	}
+	return []*entity.ItemVersion{}, nil
+}
+
+func (m *mockItemUsecase) GetItemVersion(ctx context.Context, id int64, version int) (*entity.Item, error) {
+	if m.getItemVersionFunc != nil {
+		return m.getItemVersionFunc(ctx, id, version)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) DiffItemVersions(ctx context.Context, id int64, from, to int) (*usecase.ItemDiff, error) {
+	if m.diffVersionsFunc != nil {
+		return m.diffVersionsFunc(ctx, id, from, to)
+	}
 	return nil, nil
 }
 
+func (m *mockItemUsecase) GetItemsByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	if m.getItemsByIDsFunc != nil {
+		return m.getItemsByIDsFunc(ctx, ids)
+	}
+	return []*entity.Item{}, nil
+}
+
 func (m *mockItemUsecase) CreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+	if m.createItemFunc != nil {
+		return m.createItemFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) DryRunCreateItem(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+	if m.dryRunCreateFunc != nil {
+		return m.dryRunCreateFunc(ctx, input)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) ValidateItems(ctx context.Context, inputs []usecase.CreateItemInput) (*usecase.BatchValidationResult, error) {
+	if m.validateItemsFunc != nil {
+		return m.validateItemsFunc(ctx, inputs)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) CreateItems(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+	if m.createItemsFunc != nil {
+		return m.createItemsFunc(ctx, inputs)
+	}
 	return nil, nil
 }
 
@@ -39,14 +257,80 @@ func (m *mockItemUsecase) UpdateItem(ctx context.Context, id int64, input usecas
 	return nil, nil
 }
 
+func (m *mockItemUsecase) ReplaceItem(ctx context.Context, id int64, input usecase.ReplaceItemInput) (*entity.Item, error) {
+	if m.replaceItemFunc != nil {
+		return m.replaceItemFunc(ctx, id, input)
+	}
+	return nil, nil
+}
+
 func (m *mockItemUsecase) DeleteItem(ctx context.Context, id int64) error {
 	return nil
 }
 
-func (m *mockItemUsecase) GetCategorySummary(ctx context.Context) (*usecase.CategorySummary, error) {
+func (m *mockItemUsecase) RestoreItem(ctx context.Context, id int64) (*entity.Item, error) {
+	if m.restoreItemFunc != nil {
+		return m.restoreItemFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) DeleteItems(ctx context.Context, ids []int64) (*usecase.BulkDeleteResult, error) {
+	if m.deleteItemsFunc != nil {
+		return m.deleteItemsFunc(ctx, ids)
+	}
+	return &usecase.BulkDeleteResult{}, nil
+}
+
+func (m *mockItemUsecase) MoveItems(ctx context.Context, input usecase.MoveItemsInput) (*usecase.BulkMoveResult, error) {
+	if m.moveItemsFunc != nil {
+		return m.moveItemsFunc(ctx, input)
+	}
+	return &usecase.BulkMoveResult{}, nil
+}
+
+func (m *mockItemUsecase) GetCategorySummary(ctx context.Context, from, to *time.Time) (*usecase.CategorySummary, error) {
+	if m.getSummaryFunc != nil {
+		return m.getSummaryFunc(ctx, from, to)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) GetAveragePriceAnalytics(ctx context.Context) (*usecase.AveragePriceAnalytics, error) {
+	if m.getAveragePriceFunc != nil {
+		return m.getAveragePriceFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockItemUsecase) GetPriceRangeAnalytics(ctx context.Context) (*usecase.PriceRangeAnalytics, error) {
+	if m.getPriceRangeFunc != nil {
+		return m.getPriceRangeFunc(ctx)
+	}
 	return nil, nil
 }
 
+func (m *mockItemUsecase) GetTimelineAnalytics(ctx context.Context) ([]usecase.MonthlyStats, error) {
+	if m.getTimelineFunc != nil {
+		return m.getTimelineFunc(ctx)
+	}
+	return []usecase.MonthlyStats{}, nil
+}
+
+// assertProblem はレスポンスが指定したステータス・type の problem+json であることを検証する
+func assertProblem(t *testing.T, rec *httptest.ResponseRecorder, status int, problemType string) Problem {
+	t.Helper()
+	assert.Equal(t, status, rec.Code)
+	assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, status, problem.Status)
+	assert.Equal(t, problemType, problem.Type)
+	assert.NotEmpty(t, problem.Title)
+	return problem
+}
+
 func TestItemHandler_UpdateItem(t *testing.T) {
 	e := echo.New()
 
@@ -93,7 +377,9 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 
 		err := handler.UpdateItem(c)
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, "invalid item ID", problem.Detail)
+		assert.Equal(t, "/items/abc", problem.Instance)
 	})
 
 	t.Run("validation error", func(t *testing.T) {
@@ -108,7 +394,240 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 
 		err := handler.UpdateItem(c)
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, "validation failed", problem.Detail)
+		assert.Equal(t, []domainErrors.FieldError{{Field: "", Code: domainErrors.CodeNoFields, Message: "no fields to update"}}, problem.InvalidParams)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.updateItemFunc = func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			t.Fatal("UpdateItem should not be called")
+			return nil, nil
+		}
+
+		handler := NewItemHandler(mockUsecase)
+		req := httptest.NewRequest(http.MethodPatch, "/items/1", bytes.NewReader([]byte(`{"name":"Updated","nmae":"typo"}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		err := handler.UpdateItem(c)
+		assert.NoError(t, err)
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, []domainErrors.FieldError{{Field: "nmae", Code: domainErrors.CodeUnknownField, Message: "is not a known field"}}, problem.InvalidParams)
+	})
+
+	t.Run("partial body with known optional fields", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.updateItemFunc = func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			assert.Nil(t, input.Name)
+			assert.Nil(t, input.Brand)
+			if assert.NotNil(t, input.PurchasePrice) {
+				assert.Equal(t, 5000, *input.PurchasePrice)
+			}
+			return &entity.Item{ID: 1, PurchasePrice: 5000}, nil
+		}
+
+		handler := NewItemHandler(mockUsecase)
+		req := httptest.NewRequest(http.MethodPatch, "/items/1", bytes.NewReader([]byte(`{"purchase_price":5000}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		err := handler.UpdateItem(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	mergePatch := func(t *testing.T, body string, update func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error)) *httptest.ResponseRecorder {
+		t.Helper()
+		mockUsecase := &mockItemUsecase{updateItemFunc: update}
+		req := httptest.NewRequest(http.MethodPatch, "/items/1", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, MIMEApplicationMergePatchJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		assert.NoError(t, NewItemHandler(mockUsecase).UpdateItem(c))
+		return rec
+	}
+
+	t.Run("merge patch leaves absent brand untouched", func(t *testing.T) {
+		rec := mergePatch(t, `{"name":"Updated"}`, func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			assert.Nil(t, input.Brand)
+			if assert.NotNil(t, input.Name) {
+				assert.Equal(t, "Updated", *input.Name)
+			}
+			return &entity.Item{ID: 1, Name: "Updated", Brand: "ROLEX"}, nil
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("merge patch rejects removing a required brand with null", func(t *testing.T) {
+		rec := mergePatch(t, `{"name":"Updated","brand":null}`, func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			t.Fatal("UpdateItem should not be called")
+			return nil, nil
+		})
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, []domainErrors.FieldError{{Field: "brand", Code: domainErrors.CodeCannotRemove, Message: "is required and cannot be removed"}}, problem.InvalidParams)
+	})
+
+	t.Run("merge patch removes the optional warranty_expiry and notes with null", func(t *testing.T) {
+		rec := mergePatch(t, `{"warranty_expiry":null,"notes":null}`, func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			if assert.NotNil(t, input.WarrantyExpiry) {
+				assert.Equal(t, "", *input.WarrantyExpiry)
+			}
+			if assert.NotNil(t, input.Notes) {
+				assert.Equal(t, "", *input.Notes)
+			}
+			return &entity.Item{ID: 1}, nil
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "warranty_expiry")
+		// メモは未設定でも空文字として返す
+		assert.Contains(t, rec.Body.String(), `"notes":""`)
+	})
+
+	t.Run("merge patch removes all tags and the image_url with null", func(t *testing.T) {
+		rec := mergePatch(t, `{"tags":null,"image_url":null}`, func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			if assert.NotNil(t, input.Tags) {
+				assert.Empty(t, *input.Tags)
+			}
+			if assert.NotNil(t, input.ImageURL) {
+				assert.Equal(t, "", *input.ImageURL)
+			}
+			return &entity.Item{ID: 1, Tags: []string{}}, nil
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"tags":[]`)
+		assert.NotContains(t, rec.Body.String(), "image_url")
+	})
+
+	t.Run("merge patch removes the attributes with null", func(t *testing.T) {
+		rec := mergePatch(t, `{"attributes":null}`, func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			if assert.NotNil(t, input.Attributes) {
+				assert.Empty(t, *input.Attributes)
+			}
+			return &entity.Item{ID: 1}, nil
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotContains(t, rec.Body.String(), "attributes")
+	})
+
+	t.Run("merge patch treats null version as unspecified", func(t *testing.T) {
+		rec := mergePatch(t, `{"purchase_price":5000,"version":null}`, func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			assert.Nil(t, input.Version)
+			return &entity.Item{ID: 1, PurchasePrice: 5000}, nil
+		})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("merge patch rejects unknown fields and non-object bodies", func(t *testing.T) {
+		for _, body := range []string{`{"nmae":null}`, `[]`, `"name"`} {
+			rec := mergePatch(t, body, func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+				t.Fatal("UpdateItem should not be called")
+				return nil, nil
+			})
+			assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+		}
+	})
+
+	jsonPatch := func(t *testing.T, body string, update func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error)) *httptest.ResponseRecorder {
+		t.Helper()
+		mockUsecase := &mockItemUsecase{updateItemFunc: update}
+		req := httptest.NewRequest(http.MethodPatch, "/items/1", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, MIMEApplicationJSONPatchJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		assert.NoError(t, NewItemHandler(mockUsecase).UpdateItem(c))
+		return rec
+	}
+	notCalled := func(t *testing.T) func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+		return func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			t.Error("UpdateItem should not be called")
+			return nil, nil
+		}
+	}
+
+	t.Run("json patch replace", func(t *testing.T) {
+		rec := jsonPatch(t, `[{"op":"replace","path":"/name","value":"Updated"},{"op":"replace","path":"/purchase_price","value":5000}]`,
+			func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+				if assert.NotNil(t, input.Name) {
+					assert.Equal(t, "Updated", *input.Name)
+				}
+				if assert.NotNil(t, input.PurchasePrice) {
+					assert.Equal(t, 5000, *input.PurchasePrice)
+				}
+				assert.Nil(t, input.Brand)
+				return &entity.Item{ID: 1, Name: "Updated", PurchasePrice: 5000}, nil
+			})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("json patch remove and replace optional fields", func(t *testing.T) {
+		rec := jsonPatch(t, `[{"op":"remove","path":"/notes"},{"op":"remove","path":"/tags"},{"op":"replace","path":"/warranty_expiry","value":"2026-01-15"},{"op":"replace","path":"/attributes","value":{"serial":"A1"}}]`,
+			func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+				if assert.NotNil(t, input.Notes) {
+					assert.Equal(t, "", *input.Notes)
+				}
+				if assert.NotNil(t, input.Tags) {
+					assert.Empty(t, *input.Tags)
+				}
+				if assert.NotNil(t, input.WarrantyExpiry) {
+					assert.Equal(t, "2026-01-15", *input.WarrantyExpiry)
+				}
+				if assert.NotNil(t, input.Attributes) {
+					assert.Equal(t, map[string]interface{}{"serial": "A1"}, *input.Attributes)
+				}
+				assert.Nil(t, input.Name)
+				assert.Nil(t, input.ImageURL)
+				return &entity.Item{ID: 1}, nil
+			})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("json patch malformed body", func(t *testing.T) {
+		rec := jsonPatch(t, `[{"op":"replace"`, notCalled(t))
+		assertProblem(t, rec, http.StatusBadRequest, "/problems/malformed-json")
+	})
+
+	t.Run("json patch invalid path", func(t *testing.T) {
+		rec := jsonPatch(t, `[{"op":"replace","path":"/category","value":"時計"}]`, notCalled(t))
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		if assert.Len(t, problem.InvalidParams, 1) {
+			assert.Equal(t, "operations[0].path", problem.InvalidParams[0].Field)
+		}
+	})
+
+	t.Run("json patch unsupported op", func(t *testing.T) {
+		rec := jsonPatch(t, `[{"op":"add","path":"/name","value":"Updated"}]`, notCalled(t))
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		if assert.Len(t, problem.InvalidParams, 1) {
+			assert.Equal(t, "operations[0].op", problem.InvalidParams[0].Field)
+		}
+	})
+
+	t.Run("json patch values are validated", func(t *testing.T) {
+		rec := jsonPatch(t, `[{"op":"replace","path":"/name","value":""},{"op":"replace","path":"/purchase_price","value":"free"}]`, notCalled(t))
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, []domainErrors.FieldError{{Field: "purchase_price", Code: domainErrors.CodeInvalidType, Message: "has an invalid type"}}, problem.InvalidParams)
+
+		rec = jsonPatch(t, `[{"op":"replace","path":"/name","value":"`+strings.Repeat("時", 101)+`"}]`, notCalled(t))
+		problem = assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, []domainErrors.FieldError{{Field: "name", Code: domainErrors.CodeTooLong, Message: "must be 100 characters or less"}}, problem.InvalidParams)
 	})
 
 	t.Run("not found", func(t *testing.T) {
@@ -128,7 +647,8 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 
 		err := handler.UpdateItem(c)
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusNotFound, rec.Code)
+		problem := assertProblem(t, rec, http.StatusNotFound, "/problems/not-found")
+		assert.Equal(t, "item not found", problem.Detail)
 	})
 
 	t.Run("domain validation error", func(t *testing.T) {
@@ -148,6 +668,2452 @@ func TestItemHandler_UpdateItem(t *testing.T) {
 
 		err := handler.UpdateItem(c)
 		assert.NoError(t, err)
-		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+	})
+
+	t.Run("version is passed through", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.updateItemFunc = func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			if assert.NotNil(t, input.Version) {
+				assert.Equal(t, 3, *input.Version)
+			}
+			return &entity.Item{ID: 1, Name: "Updated", Version: 4}, nil
+		}
+
+		handler := NewItemHandler(mockUsecase)
+		req := httptest.NewRequest(http.MethodPatch, "/items/1", bytes.NewReader([]byte(`{"name":"Updated","version":3}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		err := handler.UpdateItem(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"version":4`)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.updateItemFunc = func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			return nil, domainErrors.ErrVersionConflict
+		}
+
+		handler := NewItemHandler(mockUsecase)
+		req := httptest.NewRequest(http.MethodPatch, "/items/1", bytes.NewReader([]byte(`{"name":"Updated","version":2}`)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		err := handler.UpdateItem(c)
+		assert.NoError(t, err)
+		assertProblem(t, rec, http.StatusConflict, "/problems/version-conflict")
+	})
+}
+
+func TestItemHandler_GetItems(t *testing.T) {
+	e := echo.New()
+
+	newContext := func(target string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		return e.NewContext(req, rec), rec
+	}
+
+	t.Run("default pagination", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			assert.Equal(t, defaultLimit, limit)
+			assert.Equal(t, 0, offset)
+			return []*entity.Item{{ID: 1, Name: "Item"}}, 42, nil
+		}
+
+		handler := NewItemHandler(mockUsecase)
+		c, rec := newContext("/items")
+
+		err := handler.GetItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "42", rec.Header().Get("X-Total-Count"))
+
+		var actual []entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.Len(t, actual, 1)
+	})
+
+	t.Run("explicit limit and offset", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			assert.Equal(t, 5, limit)
+			assert.Equal(t, 10, offset)
+			return []*entity.Item{}, 0, nil
+		}
+
+		handler := NewItemHandler(mockUsecase)
+		c, rec := newContext("/items?limit=5&offset=10")
+
+		err := handler.GetItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("boundary limits", func(t *testing.T) {
+		for _, limit := range []string{"1", "50"} {
+			handler := NewItemHandler(&mockItemUsecase{}, WithMaxLimit(50))
+			c, rec := newContext("/items?limit=" + limit)
+
+			err := handler.GetItems(c)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code, "limit=%s", limit)
+		}
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		targets := []string{
+			"/items?limit=0",
+			"/items?limit=51",
+			"/items?limit=abc",
+			"/items?offset=-1",
+			"/items?offset=abc",
+		}
+		for _, target := range targets {
+			mockUsecase := &mockItemUsecase{}
+			mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+				t.Errorf("usecase should not be called for %s", target)
+				return nil, 0, nil
+			}
+
+			handler := NewItemHandler(mockUsecase, WithMaxLimit(50))
+			c, rec := newContext(target)
+
+			err := handler.GetItems(c)
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+		}
+	})
+
+	t.Run("usecase error", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			return nil, 0, domainErrors.ErrDatabaseError
+		}
+
+		handler := NewItemHandler(mockUsecase)
+		c, rec := newContext("/items")
+
+		err := handler.GetItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestItemHandler_GetItemsByCursor(t *testing.T) {
+	e := echo.New()
+
+	// ID 1〜5 のアイテムを ID 順に返すユースケース
+	dataset := []*entity.Item{{ID: 1}, {ID: 2}, {ID: 3}, {ID: 4}, {ID: 5}}
+	mockUsecase := &mockItemUsecase{}
+	mockUsecase.getItemsAfterFunc = func(ctx context.Context, filter usecase.ItemFilter, afterID int64, limit int) ([]*entity.Item, bool, error) {
+		var page []*entity.Item
+		for _, item := range dataset {
+			if item.ID > afterID {
+				page = append(page, item)
+			}
+		}
+		hasMore := len(page) > limit
+		if hasMore {
+			page = page[:limit]
+		}
+		return page, hasMore, nil
+	}
+
+	fetch := func(t *testing.T, cursor string) (int, ItemPageResponse) {
+		req := httptest.NewRequest(http.MethodGet, "/items?limit=2&cursor="+cursor, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).GetItems(c)
+		assert.NoError(t, err)
+
+		var page ItemPageResponse
+		if rec.Code == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+		}
+		return rec.Code, page
+	}
+
+	t.Run("forward traversal across pages", func(t *testing.T) {
+		var ids []int64
+		cursor := ""
+		pages := 0
+		for {
+			code, page := fetch(t, cursor)
+			assert.Equal(t, http.StatusOK, code)
+			for _, item := range page.Items {
+				ids = append(ids, item.ID)
+			}
+			pages++
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+
+		assert.Equal(t, []int64{1, 2, 3, 4, 5}, ids)
+		assert.Equal(t, 3, pages)
+	})
+
+	t.Run("empty cursor starts from the beginning", func(t *testing.T) {
+		code, page := fetch(t, "")
+		assert.Equal(t, http.StatusOK, code)
+		if assert.Len(t, page.Items, 2) {
+			assert.Equal(t, int64(1), page.Items[0].ID)
+		}
+		assert.Equal(t, encodeCursor(2), page.NextCursor)
+	})
+
+	t.Run("malformed cursor", func(t *testing.T) {
+		for _, cursor := range []string{"!!!", encodeCursor(0) + "x", "YWJj"} {
+			code, _ := fetch(t, cursor)
+			assert.Equal(t, http.StatusBadRequest, code, cursor)
+		}
+	})
+
+	t.Run("filters are forwarded with the cursor", func(t *testing.T) {
+		var got usecase.ItemFilter
+		filtered := &mockItemUsecase{}
+		filtered.getItemsAfterFunc = func(ctx context.Context, filter usecase.ItemFilter, afterID int64, limit int) ([]*entity.Item, bool, error) {
+			got = filter
+			return []*entity.Item{}, false, nil
+		}
+		req := httptest.NewRequest(http.MethodGet, "/items?cursor="+encodeCursor(2)+"&category=時計&brand=ROLEX&q=デイトナ&min_price=100", nil)
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(filtered).GetItems(e.NewContext(req, rec)))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []string{"時計"}, got.Categories)
+		if assert.NotNil(t, got.Brand) {
+			assert.Equal(t, "ROLEX", *got.Brand)
+		}
+		assert.Equal(t, "デイトナ", got.Search)
+		if assert.NotNil(t, got.MinPrice) {
+			assert.Equal(t, 100, *got.MinPrice)
+		}
+	})
+
+	t.Run("invalid filter with the cursor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items?cursor=&min_price=abc", nil)
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetItems(e.NewContext(req, rec)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("sort cannot be combined with the cursor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items?cursor=&sort=-purchase_price", nil)
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetItems(e.NewContext(req, rec)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "sort cannot be combined with cursor")
+	})
+}
+
+func TestItemHandler_GetItemsByIDs(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, query string, mockUsecase *mockItemUsecase) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, "/items?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		assert.NoError(t, NewItemHandler(mockUsecase).GetItems(c))
+		return rec
+	}
+
+	t.Run("ids are passed in the requested order", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsByIDsFunc = func(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+			assert.Equal(t, []int64{3, 1, 2}, ids)
+			return []*entity.Item{{ID: 3}, {ID: 1}}, nil
+		}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			t.Error("GetItems should not be called")
+			return nil, 0, nil
+		}
+
+		rec := fetch(t, "ids=3,1,2", mockUsecase)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("X-Total-Count"))
+		var actual []entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		if assert.Len(t, actual, 2) {
+			assert.Equal(t, int64(3), actual[0].ID)
+			assert.Equal(t, int64(1), actual[1].ID)
+		}
+	})
+
+	t.Run("empty list returns an empty array", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsByIDsFunc = func(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+			assert.Empty(t, ids)
+			return []*entity.Item{}, nil
+		}
+
+		rec := fetch(t, "ids=", mockUsecase)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[]`, rec.Body.String())
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		for _, query := range []string{"ids=1,abc", "ids=0", "ids=-1"} {
+			rec := fetch(t, query, &mockItemUsecase{})
+			problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+			assert.Equal(t, "invalid ids parameter", problem.Detail, query)
+		}
+	})
+}
+
+func TestItemHandler_GetItemsWithFilter(t *testing.T) {
+	e := echo.New()
+
+	dataset := []*entity.Item{
+		{ID: 1, Category: "時計", Brand: "ROLEX"},
+		{ID: 2, Category: "バッグ", Brand: "HERMÈS"},
+		{ID: 3, Category: "時計", Brand: "OMEGA"},
+		{ID: 4, Category: "ジュエリー", Brand: "ROLEX"},
+	}
+	mockUsecase := &mockItemUsecase{}
+	mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+		var matched []*entity.Item
+		for _, item := range dataset {
+			if len(filter.Categories) > 0 && !slices.Contains(filter.Categories, item.Category) {
+				continue
+			}
+			if slices.Contains(filter.ExcludedCategories, item.Category) {
+				continue
+			}
+			if filter.Brand != nil && item.Brand != *filter.Brand {
+				continue
+			}
+			matched = append(matched, item)
+		}
+		return matched, len(matched), nil
+	}
+
+	fetch := func(t *testing.T, query url.Values) []entity.Item {
+		req := httptest.NewRequest(http.MethodGet, "/items?"+query.Encode(), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).GetItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var items []entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &items))
+		return items
+	}
+
+	ids := func(items []entity.Item) []int64 {
+		var result []int64
+		for _, item := range items {
+			result = append(result, item.ID)
+		}
+		return result
+	}
+
+	t.Run("category only", func(t *testing.T) {
+		items := fetch(t, url.Values{"category": {"時計"}})
+		assert.Equal(t, []int64{1, 3}, ids(items))
+	})
+
+	t.Run("brand only", func(t *testing.T) {
+		items := fetch(t, url.Values{"brand": {"ROLEX"}})
+		assert.Equal(t, []int64{1, 4}, ids(items))
+	})
+
+	t.Run("category and brand are combined with AND", func(t *testing.T) {
+		items := fetch(t, url.Values{"category": {"時計"}, "brand": {"ROLEX"}})
+		assert.Equal(t, []int64{1}, ids(items))
+	})
+
+	t.Run("no filter returns everything", func(t *testing.T) {
+		items := fetch(t, url.Values{})
+		assert.Equal(t, []int64{1, 2, 3, 4}, ids(items))
+	})
+
+	t.Run("unknown category returns an empty list", func(t *testing.T) {
+		items := fetch(t, url.Values{"category": {"unknown"}})
+		assert.Empty(t, items)
+	})
+
+	t.Run("multiple categories are combined with OR", func(t *testing.T) {
+		for _, query := range []url.Values{
+			{"category": {"時計", "バッグ"}},
+			{"category": {"時計,バッグ"}},
+			{"category": {"時計, バッグ", ""}},
+		} {
+			items := fetch(t, query)
+			assert.Equal(t, []int64{1, 2, 3}, ids(items), query.Encode())
+		}
+	})
+
+	t.Run("multiple categories compose with brand", func(t *testing.T) {
+		items := fetch(t, url.Values{"category": {"時計,ジュエリー"}, "brand": {"ROLEX"}})
+		assert.Equal(t, []int64{1, 4}, ids(items))
+	})
+
+	t.Run("category_not excludes the listed categories", func(t *testing.T) {
+		for _, query := range []url.Values{
+			{"category_not": {"時計", "バッグ"}},
+			{"category_not": {"時計,バッグ"}},
+		} {
+			items := fetch(t, query)
+			assert.Equal(t, []int64{4}, ids(items), query.Encode())
+		}
+	})
+
+	t.Run("category_not composes with brand", func(t *testing.T) {
+		items := fetch(t, url.Values{"category_not": {"ジュエリー"}, "brand": {"ROLEX"}})
+		assert.Equal(t, []int64{1}, ids(items))
+	})
+
+	t.Run("an empty category is no filter", func(t *testing.T) {
+		items := fetch(t, url.Values{"category": {""}})
+		assert.Equal(t, []int64{1, 2, 3, 4}, ids(items))
+	})
+}
+
+func TestItemHandler_GetItemsWithTags(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, query url.Values) (*httptest.ResponseRecorder, usecase.ItemFilter) {
+		var received usecase.ItemFilter
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			received = filter
+			return []*entity.Item{}, 0, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items?"+query.Encode(), nil)
+		rec := httptest.NewRecorder()
+		assert.NoError(t, NewItemHandler(mockUsecase).GetItems(e.NewContext(req, rec)))
+		return rec, received
+	}
+
+	t.Run("tag accepts repeated and comma separated values", func(t *testing.T) {
+		for _, query := range []url.Values{
+			{"tag": {"gift", "vintage"}},
+			{"tag": {"gift,vintage"}},
+		} {
+			rec, filter := fetch(t, query)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, []string{"gift", "vintage"}, filter.Tags, query.Encode())
+			assert.False(t, filter.TagsMatchAny)
+		}
+	})
+
+	t.Run("tag_mode=any matches any tag", func(t *testing.T) {
+		rec, filter := fetch(t, url.Values{"tag": {"gift,vintage"}, "tag_mode": {"any"}})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.True(t, filter.TagsMatchAny)
+	})
+
+	t.Run("unknown tag_mode is rejected", func(t *testing.T) {
+		rec, _ := fetch(t, url.Values{"tag": {"gift"}, "tag_mode": {"some"}})
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, "invalid filter parameters", problem.Detail)
+	})
+}
+
+func TestItemHandler_GetItemsWithAttributes(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, query url.Values) (*httptest.ResponseRecorder, usecase.ItemFilter) {
+		var received usecase.ItemFilter
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			received = filter
+			return []*entity.Item{}, 0, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items?"+query.Encode(), nil)
+		rec := httptest.NewRecorder()
+		assert.NoError(t, NewItemHandler(mockUsecase).GetItems(e.NewContext(req, rec)))
+		return rec, received
+	}
+
+	t.Run("attr is parsed into a path and a value", func(t *testing.T) {
+		rec, filter := fetch(t, url.Values{"attr": {"material:leather", "case.material:steel", "note:a:b,c"}})
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, []usecase.AttributeFilter{
+			{Path: []string{"material"}, Value: "leather"},
+			{Path: []string{"case", "material"}, Value: "steel"},
+			{Path: []string{"note"}, Value: "a:b,c"},
+		}, filter.Attributes)
+	})
+
+	t.Run("malformed attr is rejected", func(t *testing.T) {
+		for _, attr := range []string{"material", "material:", ":leather", "case..material:steel", `ma"terial:leather`} {
+			rec, _ := fetch(t, url.Values{"attr": {attr}})
+			problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+			assert.Equal(t, "invalid filter parameters", problem.Detail, attr)
+		}
+	})
+}
+
+func TestItemHandler_GetItemsWithSort(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, mockUsecase *mockItemUsecase, sort string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items?sort="+url.QueryEscape(sort), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).GetItems(c)
+		assert.NoError(t, err)
+		return rec
+	}
+
+	t.Run("ascending and descending", func(t *testing.T) {
+		cases := map[string]usecase.SortKey{
+			"purchase_date":   {Field: usecase.SortByPurchaseDate},
+			"-purchase_date":  {Field: usecase.SortByPurchaseDate, Descending: true},
+			"purchase_price":  {Field: usecase.SortByPurchasePrice},
+			"-purchase_price": {Field: usecase.SortByPurchasePrice, Descending: true},
+			"created_at":      {Field: usecase.SortByCreatedAt},
+			"-created_at":     {Field: usecase.SortByCreatedAt, Descending: true},
+			"category":        {Field: usecase.SortByCategory},
+		}
+		for raw, expected := range cases {
+			mockUsecase := &mockItemUsecase{}
+			mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+				assert.Equal(t, usecase.SortOption{Keys: []usecase.SortKey{expected}}, sort, raw)
+				return []*entity.Item{}, 0, nil
+			}
+
+			rec := fetch(t, mockUsecase, raw)
+			assert.Equal(t, http.StatusOK, rec.Code, raw)
+		}
+	})
+
+	t.Run("unrecognized sort field", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			return nil, 0, fmt.Errorf("failed to retrieve items: %w", domainErrors.ErrInvalidInput)
+		}
+
+		rec := fetch(t, mockUsecase, "name; DROP TABLE items")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("multiple keys keep their order", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			assert.Equal(t, usecase.SortOption{Keys: []usecase.SortKey{
+				{Field: usecase.SortByCategory},
+				{Field: usecase.SortByPurchasePrice, Descending: true},
+			}}, sort)
+			return []*entity.Item{}, 0, nil
+		}
+
+		rec := fetch(t, mockUsecase, "category,-purchase_price")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("unknown key in the list", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			return nil, 0, fmt.Errorf("failed to retrieve items: %w", domainErrors.ErrInvalidInput)
+		}
+
+		rec := fetch(t, mockUsecase, "category,name")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("duplicate or empty keys", func(t *testing.T) {
+		for _, sort := range []string{"category,-category", "purchase_price,purchase_price", "category,", ",category"} {
+			rec := fetch(t, &mockItemUsecase{}, sort)
+			assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		}
+	})
+
+	t.Run("missing sort field", func(t *testing.T) {
+		rec := fetch(t, &mockItemUsecase{}, "-")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestItemHandler_GetItemsWithSearch(t *testing.T) {
+	e := echo.New()
+
+	for target, expected := range map[string]string{
+		"/items?q=" + url.QueryEscape("デイトナ"): "デイトナ",
+		"/items?q=": "",
+		"/items":    "",
+	} {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			assert.Equal(t, expected, filter.Search, target)
+			return []*entity.Item{}, 0, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).GetItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code, target)
+	}
+}
+
+func TestItemHandler_GetItemsWithPriceRange(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, query string) (*httptest.ResponseRecorder, *usecase.ItemFilter) {
+		var received *usecase.ItemFilter
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			received = &filter
+			return []*entity.Item{}, 0, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).GetItems(c)
+		assert.NoError(t, err)
+		return rec, received
+	}
+
+	t.Run("both bounds", func(t *testing.T) {
+		rec, filter := fetch(t, "min_price=1000&max_price=5000")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		if assert.NotNil(t, filter) {
+			assert.Equal(t, 1000, *filter.MinPrice)
+			assert.Equal(t, 5000, *filter.MaxPrice)
+		}
+	})
+
+	t.Run("single bound", func(t *testing.T) {
+		rec, filter := fetch(t, "min_price=1000")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		if assert.NotNil(t, filter) {
+			assert.Equal(t, 1000, *filter.MinPrice)
+			assert.Nil(t, filter.MaxPrice)
+		}
+	})
+
+	t.Run("equal bounds", func(t *testing.T) {
+		rec, _ := fetch(t, "min_price=1000&max_price=1000")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("inverted bounds", func(t *testing.T) {
+		rec, filter := fetch(t, "min_price=5000&max_price=1000")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Nil(t, filter)
+		assert.Contains(t, rec.Body.String(), "min_price must be less than or equal to max_price")
+	})
+
+	t.Run("malformed values", func(t *testing.T) {
+		for _, query := range []string{"min_price=abc", "max_price=1.5", "min_price=-1"} {
+			rec, filter := fetch(t, query)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+			assert.Nil(t, filter, query)
+		}
+	})
+}
+
+func TestItemHandler_GetItemsWithDateRange(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, query string) (*httptest.ResponseRecorder, *usecase.ItemFilter) {
+		var received *usecase.ItemFilter
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+			received = &filter
+			return []*entity.Item{}, 0, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).GetItems(c)
+		assert.NoError(t, err)
+		return rec, received
+	}
+
+	t.Run("valid range", func(t *testing.T) {
+		rec, filter := fetch(t, "purchased_after=2023-01-01T00:00:00Z&purchased_before=2023-12-31T23:59:59Z&category="+url.QueryEscape("時計"))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		if assert.NotNil(t, filter) {
+			assert.Equal(t, "2023-01-01", filter.PurchasedAfter.Format("2006-01-02"))
+			assert.Equal(t, "2023-12-31", filter.PurchasedBefore.Format("2006-01-02"))
+			assert.Equal(t, []string{"時計"}, filter.Categories)
+		}
+	})
+
+	t.Run("open-ended range", func(t *testing.T) {
+		rec, filter := fetch(t, "purchased_before=2023-06-30")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		if assert.NotNil(t, filter) {
+			assert.Nil(t, filter.PurchasedAfter)
+			assert.NotNil(t, filter.PurchasedBefore)
+		}
+	})
+
+	t.Run("inverted range", func(t *testing.T) {
+		rec, filter := fetch(t, "purchased_after=2023-12-31&purchased_before=2023-01-01")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Nil(t, filter)
+	})
+
+	t.Run("malformed dates", func(t *testing.T) {
+		for _, query := range []string{"purchased_after=yesterday", "purchased_before=2023/01/01", "purchased_after=2023-13-01"} {
+			rec, filter := fetch(t, query)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+			assert.Nil(t, filter, query)
+		}
+	})
+}
+
+func TestItemHandler_CreateItems(t *testing.T) {
+	e := echo.New()
+
+	post := func(t *testing.T, mockUsecase *mockItemUsecase, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items/bulk", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).CreateItems(c)
+		assert.NoError(t, err)
+		return rec
+	}
+
+	t.Run("successful batch", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.createItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+			assert.Len(t, inputs, 2)
+			return []*entity.Item{{ID: 10, Name: inputs[0].Name}, {ID: 11, Name: inputs[1].Name}}, nil
+		}
+
+		rec := post(t, mockUsecase, `[
+			{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"},
+			{"name":"エルメス バーキン","category":"バッグ","brand":"HERMÈS","purchase_price":2000000,"purchase_date":"2023-02-20"}
+		]`)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+
+		var actual []entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		if assert.Len(t, actual, 2) {
+			assert.Equal(t, int64(10), actual[0].ID)
+			assert.Equal(t, int64(11), actual[1].ID)
+		}
+	})
+
+	t.Run("partially invalid batch", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.createItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+			t.Error("usecase should not be called for an invalid batch")
+			return nil, nil
+		}
+
+		rec := post(t, mockUsecase, `[
+			{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"},
+			{"name":"","category":"バッグ","brand":"HERMÈS","purchase_price":2000000,"purchase_date":"2023-02-20"}
+		]`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var actual Problem
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.Equal(t, []domainErrors.FieldError{{Field: "items[1].name", Code: domainErrors.CodeRequired, Message: "is required"}}, actual.InvalidParams)
+	})
+
+	t.Run("every invalid element is reported", func(t *testing.T) {
+		rec := post(t, &mockItemUsecase{}, `[
+			{"name":"","category":"時計","brand":"ROLEX","purchase_price":-1,"purchase_date":"2023-01-15"},
+			{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"},
+			{"name":"エルメス バーキン","category":"","brand":"","purchase_price":0,"purchase_date":""}
+		]`)
+
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, []domainErrors.FieldError{
+			{Field: "items[0].name", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "items[0].purchase_price", Code: domainErrors.CodeNegative, Message: "must be 0 or greater"},
+			{Field: "items[2].category", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "items[2].brand", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "items[2].purchase_date", Code: domainErrors.CodeRequired, Message: "is required"},
+		}, problem.InvalidParams)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.createItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+			return nil, fmt.Errorf("%w: at least one item is required", domainErrors.ErrInvalidInput)
+		}
+
+		rec := post(t, mockUsecase, `[]`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("not an array", func(t *testing.T) {
+		rec := post(t, &mockItemUsecase{}, `{"name":"single"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestItemHandler_DeleteItems(t *testing.T) {
+	e := echo.New()
+
+	send := func(t *testing.T, mockUsecase *mockItemUsecase, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodDelete, "/items", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).DeleteItems(c)
+		assert.NoError(t, err)
+		return rec
+	}
+
+	t.Run("all deleted", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.deleteItemsFunc = func(ctx context.Context, ids []int64) (*usecase.BulkDeleteResult, error) {
+			assert.Equal(t, []int64{1, 2}, ids)
+			return &usecase.BulkDeleteResult{Deleted: ids, NotFound: []int64{}}, nil
+		}
+
+		rec := send(t, mockUsecase, `{"ids":[1,2]}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"deleted":[1,2],"not_found":[]}`, rec.Body.String())
+	})
+
+	t.Run("some missing", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.deleteItemsFunc = func(ctx context.Context, ids []int64) (*usecase.BulkDeleteResult, error) {
+			return &usecase.BulkDeleteResult{Deleted: []int64{1}, NotFound: []int64{999}}, nil
+		}
+
+		rec := send(t, mockUsecase, `{"ids":[1,999]}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"deleted":[1],"not_found":[999]}`, rec.Body.String())
+	})
+
+	t.Run("none exist", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.deleteItemsFunc = func(ctx context.Context, ids []int64) (*usecase.BulkDeleteResult, error) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+
+		rec := send(t, mockUsecase, `{"ids":[998,999]}`)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("empty list", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.deleteItemsFunc = func(ctx context.Context, ids []int64) (*usecase.BulkDeleteResult, error) {
+			return nil, fmt.Errorf("%w: ids must not be empty", domainErrors.ErrInvalidInput)
+		}
+
+		rec := send(t, mockUsecase, `{"ids":[]}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		rec := send(t, &mockItemUsecase{}, `{"ids":"1,2"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestItemHandler_MoveItems(t *testing.T) {
+	e := echo.New()
+
+	send := func(t *testing.T, mockUsecase *mockItemUsecase, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items/move", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).MoveItems(c)
+		assert.NoError(t, err)
+		return rec
+	}
+
+	t.Run("some missing", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.moveItemsFunc = func(ctx context.Context, input usecase.MoveItemsInput) (*usecase.BulkMoveResult, error) {
+			assert.Equal(t, usecase.MoveItemsInput{IDs: []int64{1, 999}, TargetCategory: "バッグ"}, input)
+			return &usecase.BulkMoveResult{Moved: []int64{1}, NotFound: []int64{999}}, nil
+		}
+
+		rec := send(t, mockUsecase, `{"ids":[1,999],"target_category":"バッグ"}`)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"moved":[1],"not_found":[999]}`, rec.Body.String())
+	})
+
+	t.Run("invalid target category", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.moveItemsFunc = func(ctx context.Context, input usecase.MoveItemsInput) (*usecase.BulkMoveResult, error) {
+			verr := &domainErrors.ValidationError{}
+			verr.Add("target_category", domainErrors.CodeInvalidValue, "時計, バッグ")
+			return nil, verr.Err()
+		}
+
+		rec := send(t, mockUsecase, `{"ids":[1],"target_category":"家具"}`)
+		assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Contains(t, rec.Body.String(), `"field":"target_category"`)
+	})
+
+	t.Run("none exist", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.moveItemsFunc = func(ctx context.Context, input usecase.MoveItemsInput) (*usecase.BulkMoveResult, error) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+
+		rec := send(t, mockUsecase, `{"ids":[998,999],"target_category":"バッグ"}`)
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("unknown fields are rejected", func(t *testing.T) {
+		rec := send(t, &mockItemUsecase{}, `{"ids":[1],"category":"バッグ"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestItemHandler_ReplaceItem(t *testing.T) {
+	e := echo.New()
+
+	newContext := func(method, body string) (echo.Context, *httptest.ResponseRecorder) {
+		req := httptest.NewRequest(method, "/items/1", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		return c, rec
+	}
+
+	fullBody := `{"name":"エルメス バーキン","category":"バッグ","brand":"HERMÈS","purchase_price":2000000,"purchase_date":"2023-02-20"}`
+
+	t.Run("success", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.replaceItemFunc = func(ctx context.Context, id int64, input usecase.ReplaceItemInput) (*entity.Item, error) {
+			assert.Equal(t, int64(1), id)
+			assert.Equal(t, "バッグ", *input.Category)
+			assert.Equal(t, 2000000, *input.PurchasePrice)
+			return &entity.Item{ID: 1, Name: *input.Name, Category: *input.Category}, nil
+		}
+
+		c, rec := newContext(http.MethodPut, fullBody)
+		err := NewItemHandler(mockUsecase).ReplaceItem(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("partial body: PUT rejects while PATCH accepts", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.replaceItemFunc = func(ctx context.Context, id int64, input usecase.ReplaceItemInput) (*entity.Item, error) {
+			t.Error("ReplaceItem should not be called for a partial body")
+			return nil, nil
+		}
+		mockUsecase.updateItemFunc = func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			return &entity.Item{ID: 1, Name: *input.Name}, nil
+		}
+		handler := NewItemHandler(mockUsecase)
+		partialBody := `{"name":"Updated"}`
+
+		c, rec := newContext(http.MethodPut, partialBody)
+		assert.NoError(t, handler.ReplaceItem(c))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var actual Problem
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.Equal(t, []domainErrors.FieldError{
+			{Field: "category", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "brand", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "purchase_price", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "purchase_date", Code: domainErrors.CodeRequired, Message: "is required"},
+		}, actual.InvalidParams)
+
+		c, rec = newContext(http.MethodPatch, partialBody)
+		assert.NoError(t, handler.UpdateItem(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("zero price is accepted when provided", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.replaceItemFunc = func(ctx context.Context, id int64, input usecase.ReplaceItemInput) (*entity.Item, error) {
+			return &entity.Item{ID: 1}, nil
+		}
+
+		c, rec := newContext(http.MethodPut, `{"name":"a","category":"靴","brand":"b","purchase_price":0,"purchase_date":"2023-01-01"}`)
+		assert.NoError(t, NewItemHandler(mockUsecase).ReplaceItem(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.replaceItemFunc = func(ctx context.Context, id int64, input usecase.ReplaceItemInput) (*entity.Item, error) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+
+		c, rec := newContext(http.MethodPut, fullBody)
+		assert.NoError(t, NewItemHandler(mockUsecase).ReplaceItem(c))
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
+
+func TestItemHandler_RestoreItem(t *testing.T) {
+	e := echo.New()
+
+	restore := func(t *testing.T, mockUsecase *mockItemUsecase, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items/"+id+"/restore", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id/restore")
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+
+		err := NewItemHandler(mockUsecase).RestoreItem(c)
+		assert.NoError(t, err)
+		return rec
+	}
+
+	t.Run("restore a soft-deleted item", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.restoreItemFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+			assert.Equal(t, int64(1), id)
+			return &entity.Item{ID: 1}, nil
+		}
+
+		rec := restore(t, mockUsecase, "1")
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var actual entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.Equal(t, int64(1), actual.ID)
+		assert.Nil(t, actual.DeletedAt)
+	})
+
+	t.Run("restore an already-active item", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.restoreItemFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+			return &entity.Item{ID: 2}, nil
+		}
+
+		rec := restore(t, mockUsecase, "2")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("restore a missing id", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.restoreItemFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+
+		rec := restore(t, mockUsecase, "999")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		rec := restore(t, &mockItemUsecase{}, "abc")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestItemHandler_GetPriceHistory(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, mockUsecase *mockItemUsecase, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/"+id+"/price-history", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id/price-history")
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetPriceHistory(c))
+		return rec
+	}
+
+	t.Run("changes in chronological order", func(t *testing.T) {
+		changedAt := time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC)
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getPriceHistoryFunc = func(ctx context.Context, id int64) ([]*entity.PriceChange, error) {
+			assert.Equal(t, int64(1), id)
+			return []*entity.PriceChange{
+				{ID: 1, ItemID: 1, OldPrice: 1000000, NewPrice: 1200000, ChangedAt: changedAt},
+				{ID: 2, ItemID: 1, OldPrice: 1200000, NewPrice: 900000, ChangedAt: changedAt.Add(time.Hour)},
+			}, nil
+		}
+
+		rec := fetch(t, mockUsecase, "1")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[
+			{"id":1,"item_id":1,"old_price":1000000,"new_price":1200000,"changed_at":"2023-02-01T10:00:00Z"},
+			{"id":2,"item_id":1,"old_price":1200000,"new_price":900000,"changed_at":"2023-02-01T11:00:00Z"}
+		]`, rec.Body.String())
+	})
+
+	t.Run("no changes is an empty array", func(t *testing.T) {
+		rec := fetch(t, &mockItemUsecase{}, "1")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[]`, rec.Body.String())
+	})
+
+	t.Run("missing item", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getPriceHistoryFunc = func(ctx context.Context, id int64) ([]*entity.PriceChange, error) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+
+		rec := fetch(t, mockUsecase, "999")
+		assertProblem(t, rec, http.StatusNotFound, "/problems/not-found")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		rec := fetch(t, &mockItemUsecase{}, "abc")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestItemHandler_GetItemVersions(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, mockUsecase *mockItemUsecase, path string, params ...string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		names := []string{"id", "version"}[:len(params)]
+		c.SetParamNames(names...)
+		c.SetParamValues(params...)
+
+		handler := NewItemHandler(mockUsecase)
+		if len(params) == 1 {
+			assert.NoError(t, handler.GetItemVersions(c))
+		} else {
+			assert.NoError(t, handler.GetItemVersion(c))
+		}
+		return rec
+	}
+
+	t.Run("list versions", func(t *testing.T) {
+		updatedAt := time.Date(2023, 2, 1, 10, 0, 0, 0, time.UTC)
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemVersionsFunc = func(ctx context.Context, id int64) ([]*entity.ItemVersion, error) {
+			assert.Equal(t, int64(1), id)
+			return []*entity.ItemVersion{{Version: 1, UpdatedAt: updatedAt}, {Version: 2, UpdatedAt: updatedAt.Add(time.Hour)}}, nil
+		}
+
+		rec := fetch(t, mockUsecase, "/items/1/versions", "1")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[
+			{"version":1,"updated_at":"2023-02-01T10:00:00Z"},
+			{"version":2,"updated_at":"2023-02-01T11:00:00Z"}
+		]`, rec.Body.String())
+	})
+
+	t.Run("get an old version", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemVersionFunc = func(ctx context.Context, id int64, version int) (*entity.Item, error) {
+			assert.Equal(t, int64(1), id)
+			assert.Equal(t, 2, version)
+			return &entity.Item{ID: 1, Name: "時計1", Version: 2}, nil
+		}
+
+		rec := fetch(t, mockUsecase, "/items/1/versions/2", "1", "2")
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var actual entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.Equal(t, 2, actual.Version)
+		assert.Equal(t, "時計1", actual.Name)
+	})
+
+	t.Run("version not recorded", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemVersionFunc = func(ctx context.Context, id int64, version int) (*entity.Item, error) {
+			return nil, domainErrors.ErrItemVersionNotFound
+		}
+
+		rec := fetch(t, mockUsecase, "/items/1/versions/9", "1", "9")
+		problem := assertProblem(t, rec, http.StatusNotFound, "/problems/not-found")
+		assert.Equal(t, "item version not found", problem.Detail)
+	})
+
+	t.Run("missing item", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemVersionsFunc = func(ctx context.Context, id int64) ([]*entity.ItemVersion, error) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+
+		rec := fetch(t, mockUsecase, "/items/999/versions", "999")
+		assertProblem(t, rec, http.StatusNotFound, "/problems/not-found")
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		for _, version := range []string{"abc", "0", "-1"} {
+			rec := fetch(t, &mockItemUsecase{}, "/items/1/versions/"+version, "1", version)
+			assert.Equal(t, http.StatusBadRequest, rec.Code, version)
+		}
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		rec := fetch(t, &mockItemUsecase{}, "/items/abc/versions", "abc")
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestItemHandler_DiffItemVersions(t *testing.T) {
+	e := echo.New()
+
+	diff := func(t *testing.T, mockUsecase *mockItemUsecase, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/1/diff?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		assert.NoError(t, NewItemHandler(mockUsecase).DiffItemVersions(c))
+		return rec
+	}
+
+	t.Run("changed fields", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.diffVersionsFunc = func(ctx context.Context, id int64, from, to int) (*usecase.ItemDiff, error) {
+			assert.Equal(t, int64(1), id)
+			assert.Equal(t, 2, from)
+			assert.Equal(t, 5, to)
+			return &usecase.ItemDiff{From: from, To: to, Changes: []usecase.FieldChange{
+				{Field: "name", Before: "時計1", After: "時計2"},
+				{Field: "purchase_price", Before: 1000000, After: 1200000},
+			}}, nil
+		}
+
+		rec := diff(t, mockUsecase, "from=2&to=5")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{
+			"from": 2,
+			"to": 5,
+			"changes": [
+				{"field": "name", "before": "時計1", "after": "時計2"},
+				{"field": "purchase_price", "before": 1000000, "after": 1200000}
+			]
+		}`, rec.Body.String())
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.diffVersionsFunc = func(ctx context.Context, id int64, from, to int) (*usecase.ItemDiff, error) {
+			return &usecase.ItemDiff{From: from, To: to, Changes: []usecase.FieldChange{}}, nil
+		}
+
+		rec := diff(t, mockUsecase, "from=3&to=3")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"from": 3, "to": 3, "changes": []}`, rec.Body.String())
+	})
+
+	t.Run("version not recorded", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.diffVersionsFunc = func(ctx context.Context, id int64, from, to int) (*usecase.ItemDiff, error) {
+			return nil, domainErrors.ErrItemVersionNotFound
+		}
+
+		rec := diff(t, mockUsecase, "from=1&to=9")
+		problem := assertProblem(t, rec, http.StatusNotFound, "/problems/not-found")
+		assert.Equal(t, "item version not found", problem.Detail)
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		for _, query := range []string{"", "from=1", "from=0&to=2", "from=a&to=2"} {
+			rec := diff(t, &mockItemUsecase{}, query)
+			assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		}
+	})
+}
+
+func TestItemHandler_GetRecentItems(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, query string, expectedLimit int) *httptest.ResponseRecorder {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getRecentItemsFunc = func(ctx context.Context, limit int) ([]*entity.Item, error) {
+			assert.Equal(t, expectedLimit, limit)
+			return []*entity.Item{{ID: 3}, {ID: 2}}, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/recent"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetRecentItems(c))
+		return rec
+	}
+
+	t.Run("default limit", func(t *testing.T) {
+		rec := fetch(t, "", usecase.DefaultRecentItems)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var actual []entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.Len(t, actual, 2)
+	})
+
+	t.Run("custom limit", func(t *testing.T) {
+		rec := fetch(t, "?limit=2", 2)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("limit is capped", func(t *testing.T) {
+		rec := fetch(t, "?limit=1000", usecase.MaxRecentItems)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		for _, limit := range []string{"0", "-1", "abc"} {
+			req := httptest.NewRequest(http.MethodGet, "/items/recent?limit="+limit, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, NewItemHandler(&mockItemUsecase{}).GetRecentItems(c))
+			assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		}
+	})
+}
+
+func TestItemHandler_GetTopItems(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, query string, expectedCategory *string, expectedN int) *httptest.ResponseRecorder {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getTopItemsFunc = func(ctx context.Context, category *string, n int) ([]*entity.Item, error) {
+			assert.Equal(t, expectedCategory, category)
+			assert.Equal(t, expectedN, n)
+			return []*entity.Item{{ID: 2, PurchasePrice: 2000000}, {ID: 1, PurchasePrice: 1500000}}, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/top"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetTopItems(c))
+		return rec
+	}
+
+	t.Run("default n keeps the usecase order", func(t *testing.T) {
+		rec := fetch(t, "", nil, usecase.DefaultTopItems)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var actual []entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		if assert.Len(t, actual, 2) {
+			assert.Equal(t, []int64{2, 1}, []int64{actual[0].ID, actual[1].ID})
+		}
+	})
+
+	t.Run("n is capped", func(t *testing.T) {
+		rec := fetch(t, "?n=1000", nil, usecase.MaxTopItems)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("category filter", func(t *testing.T) {
+		category := "時計"
+		rec := fetch(t, "?n=3&category="+url.QueryEscape(category), &category, 3)
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("invalid n", func(t *testing.T) {
+		for _, n := range []string{"0", "-1", "abc"} {
+			req := httptest.NewRequest(http.MethodGet, "/items/top?n="+n, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, NewItemHandler(&mockItemUsecase{}).GetTopItems(c))
+			assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		}
+	})
+}
+
+func TestItemHandler_GetExpiringWarranties(t *testing.T) {
+	e := echo.New()
+
+	fetch := func(t *testing.T, query string, mockUsecase *mockItemUsecase) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/warranty/expiring"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetExpiringWarranties(c))
+		return rec
+	}
+
+	t.Run("days is passed to the usecase", func(t *testing.T) {
+		for query, expected := range map[string]int{"": usecase.DefaultWarrantyDays, "?days=7": 7, "?days=0": 0} {
+			expiry := "2024-01-10"
+			mockUsecase := &mockItemUsecase{}
+			mockUsecase.getExpiringFunc = func(ctx context.Context, days int) ([]*entity.Item, error) {
+				assert.Equal(t, expected, days, query)
+				return []*entity.Item{{ID: 1, WarrantyExpiry: &expiry}}, nil
+			}
+
+			rec := fetch(t, query, mockUsecase)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Contains(t, rec.Body.String(), `"warranty_expiry":"2024-01-10"`)
+		}
+	})
+
+	t.Run("invalid days", func(t *testing.T) {
+		for _, days := range []string{"-1", "abc", "366"} {
+			rec := fetch(t, "?days="+days, &mockItemUsecase{})
+			assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		}
+	})
+}
+
+func TestItemHandler_CountItems(t *testing.T) {
+	e := echo.New()
+
+	count := func(t *testing.T, mockUsecase *mockItemUsecase, query string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/count"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).CountItems(c))
+		return rec
+	}
+
+	t.Run("unfiltered", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.countItemsFunc = func(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+			assert.Equal(t, usecase.ItemFilter{}, filter)
+			return 5, nil
+		}
+
+		rec := count(t, mockUsecase, "")
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"count":5}`, rec.Body.String())
+	})
+
+	t.Run("filtered by category, brand and search", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.countItemsFunc = func(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+			assert.Equal(t, []string{"時計"}, filter.Categories)
+			if assert.NotNil(t, filter.Brand) {
+				assert.Equal(t, "ROLEX", *filter.Brand)
+			}
+			assert.Equal(t, "デイトナ", filter.Search)
+			return 1, nil
+		}
+
+		rec := count(t, mockUsecase, "?category="+url.QueryEscape("時計")+"&brand=ROLEX&q="+url.QueryEscape("デイトナ"))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `{"count":1}`, rec.Body.String())
+	})
+
+	t.Run("invalid filter", func(t *testing.T) {
+		rec := count(t, &mockItemUsecase{}, "?min_price=abc")
+		assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+	})
+}
+
+func TestItemHandler_CloneItem(t *testing.T) {
+	e := echo.New()
+
+	clone := func(t *testing.T, mockUsecase *mockItemUsecase, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items/"+id+"/clone", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id/clone")
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).CloneItem(c))
+		return rec
+	}
+
+	t.Run("201 with the clone", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.cloneItemFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+			assert.Equal(t, int64(1), id)
+			return &entity.Item{ID: 7, Name: "ロレックス デイトナ (copy)", Category: "時計", Brand: "ROLEX", Version: 1}, nil
+		}
+
+		rec := clone(t, mockUsecase, "1")
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "/items/7", rec.Header().Get(echo.HeaderLocation))
+
+		var actual entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.Equal(t, int64(7), actual.ID)
+		assert.Equal(t, "ロレックス デイトナ (copy)", actual.Name)
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.cloneItemFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+			return nil, fmt.Errorf("failed to find item: %w", domainErrors.ErrItemNotFound)
+		}
+
+		assertProblem(t, clone(t, mockUsecase, "99"), http.StatusNotFound, "/problems/not-found")
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		assertProblem(t, clone(t, &mockItemUsecase{}, "abc"), http.StatusBadRequest, "/problems/invalid-input")
+	})
+}
+
+func TestItemHandler_UploadImage(t *testing.T) {
+	e := echo.New()
+
+	upload := func(t *testing.T, mockUsecase *mockItemUsecase, field string, content []byte) *httptest.ResponseRecorder {
+		var body bytes.Buffer
+		writer := multipart.NewWriter(&body)
+		part, err := writer.CreateFormFile(field, "photo.png")
+		assert.NoError(t, err)
+		_, err = part.Write(content)
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/items/1/image", &body)
+		req.Header.Set(echo.HeaderContentType, writer.FormDataContentType())
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id/image")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		assert.NoError(t, NewItemHandler(mockUsecase).UploadImage(c))
+		return rec
+	}
+
+	t.Run("200 with the updated image_url", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.uploadImageFunc = func(ctx context.Context, id int64, image usecase.ImageUpload) (*entity.Item, error) {
+			assert.Equal(t, int64(1), id)
+			assert.Equal(t, int64(4), image.Size)
+			content, err := io.ReadAll(image.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("\x89PNG"), content)
+			return &entity.Item{ID: 1, ImageURL: "http://localhost:8080/images/items/1/1.png", Version: 2}, nil
+		}
+
+		rec := upload(t, mockUsecase, "image", []byte("\x89PNG"))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var actual entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.Equal(t, "http://localhost:8080/images/items/1/1.png", actual.ImageURL)
+	})
+
+	t.Run("missing image part", func(t *testing.T) {
+		problem := assertProblem(t, upload(t, &mockItemUsecase{}, "file", []byte("\x89PNG")), http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, "invalid request format", problem.Detail)
+	})
+
+	t.Run("oversized image is a validation error", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.uploadImageFunc = func(ctx context.Context, id int64, image usecase.ImageUpload) (*entity.Item, error) {
+			verr := &domainErrors.ValidationError{}
+			verr.Add("image", domainErrors.CodeTooLarge, 10)
+			return nil, verr
+		}
+
+		problem := assertProblem(t, upload(t, mockUsecase, "image", []byte("\x89PNG")), http.StatusBadRequest, "/problems/invalid-input")
+		if assert.Len(t, problem.InvalidParams, 1) {
+			assert.Equal(t, "image", problem.InvalidParams[0].Field)
+			assert.Equal(t, domainErrors.CodeTooLarge, problem.InvalidParams[0].Code)
+		}
+	})
+}
+
+func TestItemHandler_GetDeletedItems(t *testing.T) {
+	e := echo.New()
+	deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("returns soft-deleted items with deleted_at", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getDeletedItemsFunc = func(ctx context.Context, limit, offset int) ([]*entity.Item, int, error) {
+			assert.Equal(t, 5, limit)
+			assert.Equal(t, 10, offset)
+			return []*entity.Item{{ID: 3, DeletedAt: &deletedAt}}, 11, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/deleted?limit=5&offset=10", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).GetDeletedItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "11", rec.Header().Get("X-Total-Count"))
+
+		var actual []entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		if assert.Len(t, actual, 1) && assert.NotNil(t, actual[0].DeletedAt) {
+			assert.True(t, deletedAt.Equal(*actual[0].DeletedAt))
+		}
+	})
+
+	t.Run("invalid pagination", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items/deleted?offset=-1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(&mockItemUsecase{}).GetDeletedItems(c)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestItemHandler_GetItemChanges(t *testing.T) {
+	e := echo.New()
+
+	t.Run("returns created, updated and deleted items with the deleted flag", func(t *testing.T) {
+		since := time.Date(2024, 1, 1, 9, 0, 0, 0, time.FixedZone("", 9*60*60))
+		deletedAt := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemChangesFunc = func(ctx context.Context, actual time.Time) ([]*entity.Item, error) {
+			assert.True(t, since.Equal(actual))
+			return []*entity.Item{{ID: 1, Version: 1}, {ID: 2, Version: 2}, {ID: 3, DeletedAt: &deletedAt}}, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/changes?since=2024-01-01T09:00:00%2B09:00", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetItemChanges(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var actual []map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		if assert.Len(t, actual, 3) {
+			assert.Equal(t, float64(1), actual[0]["id"])
+			assert.Equal(t, false, actual[0]["deleted"])
+			assert.Equal(t, float64(2), actual[1]["version"])
+			assert.Equal(t, false, actual[1]["deleted"])
+			assert.Equal(t, true, actual[2]["deleted"])
+			assert.Equal(t, "2024-01-02T00:00:00Z", actual[2]["deleted_at"])
+		}
+	})
+
+	t.Run("no changes is an empty array", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items/changes?since=2024-01-01T00:00:00Z", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(&mockItemUsecase{}).GetItemChanges(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.JSONEq(t, `[]`, rec.Body.String())
+	})
+
+	t.Run("missing or invalid since", func(t *testing.T) {
+		for _, query := range []string{"", "?since=", "?since=2024-01-01", "?since=yesterday"} {
+			req := httptest.NewRequest(http.MethodGet, "/items/changes"+query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, NewItemHandler(&mockItemUsecase{}).GetItemChanges(c))
+			assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		}
+	})
+}
+
+func TestItemHandler_SparseFields(t *testing.T) {
+	e := echo.New()
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}
+
+	mockUsecase := &mockItemUsecase{}
+	mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+		return []*entity.Item{item}, 1, nil
+	}
+	mockUsecase.getItemByIDFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+		return item, nil
+	}
+	mockUsecase.getItemsAfterFunc = func(ctx context.Context, filter usecase.ItemFilter, afterID int64, limit int) ([]*entity.Item, bool, error) {
+		return []*entity.Item{item}, true, nil
+	}
+	handler := NewItemHandler(mockUsecase)
+
+	getItem := func(t *testing.T, query string) map[string]interface{} {
+		req := httptest.NewRequest(http.MethodGet, "/items/1?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		assert.NoError(t, handler.GetItem(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var body map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body
+	}
+
+	t.Run("single item returns only requested fields", func(t *testing.T) {
+		body := getItem(t, "fields=id,name,category")
+		assert.Equal(t, map[string]interface{}{
+			"id":       float64(1),
+			"name":     "ロレックス デイトナ",
+			"category": "時計",
+		}, body)
+	})
+
+	t.Run("unknown field names are skipped", func(t *testing.T) {
+		body := getItem(t, "fields=id,+color+,name,")
+		assert.Equal(t, map[string]interface{}{
+			"id":   float64(1),
+			"name": "ロレックス デイトナ",
+		}, body)
+	})
+
+	t.Run("empty fields returns everything", func(t *testing.T) {
+		body := getItem(t, "fields=")
+		assert.Contains(t, body, "brand")
+		assert.Contains(t, body, "purchase_price")
+		assert.Contains(t, body, "created_at")
+	})
+
+	t.Run("list applies fields to each item", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items?fields=id,brand", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, handler.GetItems(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var body []map[string]interface{}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, []map[string]interface{}{{"id": float64(1), "brand": "ROLEX"}}, body)
+	})
+
+	t.Run("cursor page keeps next_cursor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items?cursor=&fields=name", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, handler.GetItems(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var body struct {
+			Items      []map[string]interface{} `json:"items"`
+			NextCursor string                   `json:"next_cursor"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, []map[string]interface{}{{"name": "ロレックス デイトナ"}}, body.Items)
+		assert.Equal(t, encodeCursor(1), body.NextCursor)
+	})
+}
+
+func TestItemHandler_GetItemETag(t *testing.T) {
+	e := echo.New()
+	item := &entity.Item{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15", UpdatedAt: time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)}
+
+	mockUsecase := &mockItemUsecase{}
+	mockUsecase.getItemByIDFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+		copied := *item
+		return &copied, nil
+	}
+	handler := NewItemHandler(mockUsecase)
+
+	getItem := func(t *testing.T, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		assert.NoError(t, handler.GetItem(c))
+		return rec
+	}
+
+	first := getItem(t, "")
+	etag := first.Header().Get("ETag")
+
+	t.Run("200 with ETag", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.NotEmpty(t, etag)
+
+		var actual entity.Item
+		assert.NoError(t, json.Unmarshal(first.Body.Bytes(), &actual))
+		assert.Equal(t, item.Name, actual.Name)
+
+		// 同じ内容なら ETag は安定している
+		assert.Equal(t, etag, getItem(t, "").Header().Get("ETag"))
+	})
+
+	t.Run("304 when If-None-Match matches", func(t *testing.T) {
+		rec := getItem(t, etag)
+		assert.Equal(t, http.StatusNotModified, rec.Code)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, etag, rec.Header().Get("ETag"))
+
+		assert.Equal(t, http.StatusNotModified, getItem(t, `"other", W/`+etag).Code)
+	})
+
+	t.Run("stale ETag returns a fresh 200", func(t *testing.T) {
+		item.UpdatedAt = item.UpdatedAt.Add(time.Minute)
+		defer func() { item.UpdatedAt = item.UpdatedAt.Add(-time.Minute) }()
+
+		rec := getItem(t, etag)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+		assert.NotEmpty(t, rec.Body.String())
+	})
+}
+
+func TestItemHandler_HeadItem(t *testing.T) {
+	e := echo.New()
+
+	head := func(t *testing.T, mockUsecase *mockItemUsecase, id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodHead, "/items/"+id, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues(id)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).HeadItem(c))
+		assert.Empty(t, rec.Body.String())
+		return rec
+	}
+	existing := &mockItemUsecase{}
+	existing.itemExistsFunc = func(ctx context.Context, id int64) (bool, error) {
+		return id == 1, nil
+	}
+	// 本体を取得しない
+	existing.getItemByIDFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+		t.Error("GetItemByID should not be called")
+		return nil, nil
+	}
+
+	t.Run("exists", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, head(t, existing, "1").Code)
+	})
+
+	t.Run("does not exist", func(t *testing.T) {
+		assert.Equal(t, http.StatusNotFound, head(t, existing, "2").Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		assert.Equal(t, http.StatusBadRequest, head(t, &mockItemUsecase{}, "abc").Code)
+	})
+
+	t.Run("usecase error", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.itemExistsFunc = func(ctx context.Context, id int64) (bool, error) {
+			return false, domainErrors.ErrInvalidInput
+		}
+		assert.Equal(t, http.StatusBadRequest, head(t, mockUsecase, "0").Code)
+	})
+}
+
+func TestItemHandler_QueryTimeout(t *testing.T) {
+	e := echo.New()
+
+	// リポジトリのクエリがタイムアウトまでに終わらなかった場合と同じエラーを返す
+	mockUsecase := &mockItemUsecase{}
+	mockUsecase.getItemByIDFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+		defer cancel()
+		<-ctx.Done()
+		return nil, fmt.Errorf("%w: %s", domainErrors.ErrTimeout, ctx.Err())
+	}
+	handler := NewItemHandler(mockUsecase)
+
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/items/:id")
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	assert.NoError(t, handler.GetItem(c))
+	problem := assertProblem(t, rec, http.StatusGatewayTimeout, "/problems/timeout")
+	assert.NotContains(t, problem.Detail, "deadline")
+}
+
+func TestItemHandler_CreateItemStrictJSON(t *testing.T) {
+	e := echo.New()
+
+	post := func(t *testing.T, body string) (*httptest.ResponseRecorder, bool) {
+		t.Helper()
+		called := false
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.createItemFunc = func(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+			called = true
+			return &entity.Item{ID: 1, Name: input.Name}, nil
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/items", bytes.NewReader([]byte(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		assert.NoError(t, NewItemHandler(mockUsecase).CreateItem(c))
+		return rec, called
+	}
+
+	t.Run("known fields", func(t *testing.T) {
+		rec, called := post(t, `{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, "/items/1", rec.Header().Get(echo.HeaderLocation))
+		assert.True(t, called)
+	})
+
+	t.Run("attributes accept arbitrary members", func(t *testing.T) {
+		rec, called := post(t, `{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15","attributes":{"movement":"automatic","case":{"nmae":"steel"}}}`)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.True(t, called)
+	})
+
+	t.Run("attributes must be an object", func(t *testing.T) {
+		rec, called := post(t, `{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15","attributes":["automatic"]}`)
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/malformed-json")
+		assert.Equal(t, []string{"attributes must be object, got array"}, problem.Errors)
+		assert.False(t, called)
+	})
+
+	t.Run("misspelled field is rejected with its name", func(t *testing.T) {
+		rec, called := post(t, `{"nmae":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`)
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, []domainErrors.FieldError{{Field: "nmae", Code: domainErrors.CodeUnknownField, Message: "is not a known field"}}, problem.InvalidParams)
+		assert.False(t, called)
+	})
+
+	t.Run("truncated json", func(t *testing.T) {
+		rec, called := post(t, `{"name":`)
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/malformed-json")
+		assert.Equal(t, "malformed JSON body", problem.Detail)
+		assert.Equal(t, []string{"unexpected end of JSON input"}, problem.Errors)
+		assert.Empty(t, problem.InvalidParams)
+		assert.False(t, called)
+	})
+
+	t.Run("trailing data after the object is rejected", func(t *testing.T) {
+		item := `{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`
+		for _, body := range []string{item + `{"nmae":"x"}`, item + ` garbage`, item + `}`, item + `{`} {
+			rec, called := post(t, body)
+			problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/malformed-json")
+			assert.Equal(t, []string{"request body must contain a single JSON value"}, problem.Errors, body)
+			assert.False(t, called, body)
+		}
+
+		// 後ろの空白・改行は許す
+		rec, called := post(t, item+"\n  ")
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.True(t, called)
+	})
+
+	t.Run("invalid syntax reports the position", func(t *testing.T) {
+		rec, called := post(t, `{"name":"ロレックス" "category":"時計"}`)
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/malformed-json")
+		assert.Equal(t, []string{"invalid character '\"' after object key:value pair (at byte 27)"}, problem.Errors)
+		assert.False(t, called)
+	})
+
+	t.Run("type mismatch reports the field", func(t *testing.T) {
+		rec, called := post(t, `{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":"1500000","purchase_date":"2023-01-15"}`)
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/malformed-json")
+		assert.Equal(t, "malformed JSON body", problem.Detail)
+		assert.Equal(t, []string{"purchase_price must be number, got string"}, problem.Errors)
+		assert.False(t, called)
+	})
+
+	t.Run("empty body", func(t *testing.T) {
+		rec, called := post(t, ``)
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/malformed-json")
+		assert.Equal(t, []string{"request body is empty"}, problem.Errors)
+		assert.False(t, called)
+	})
+}
+
+func TestItemHandler_CreateItemDryRun(t *testing.T) {
+	e := echo.New()
+	body := `{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`
+
+	post := func(t *testing.T, mockUsecase *mockItemUsecase, target, body string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		assert.NoError(t, NewItemHandler(mockUsecase).CreateItem(e.NewContext(req, rec)))
+		return rec
+	}
+	dryRunUsecase := func() *mockItemUsecase {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.dryRunCreateFunc = func(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+			return &entity.Item{Name: input.Name, Category: input.Category, Tags: []string{}}, nil
+		}
+		mockUsecase.createItemFunc = func(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+			t.Fatal("CreateItem should not be called on dry run")
+			return nil, nil
+		}
+		return mockUsecase
+	}
+
+	t.Run("query parameter returns 200 without saving", func(t *testing.T) {
+		rec := post(t, dryRunUsecase(), "/items?dry_run=true", body, nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "true", rec.Header().Get(headerDryRun))
+		assert.Empty(t, rec.Header().Get(echo.HeaderLocation))
+
+		var actual DryRunResult
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.True(t, actual.DryRun)
+		assert.False(t, actual.Saved)
+		assert.Equal(t, int64(0), actual.Item.ID)
+		assert.Equal(t, "ロレックス デイトナ", actual.Item.Name)
+		assert.Contains(t, rec.Body.String(), `"saved":false`)
+	})
+
+	t.Run("header also enables dry run", func(t *testing.T) {
+		rec := post(t, dryRunUsecase(), "/items", body, http.Header{headerDryRun: {"1"}})
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+
+	t.Run("validation errors still surface", func(t *testing.T) {
+		mockUsecase := dryRunUsecase()
+		mockUsecase.dryRunCreateFunc = func(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+			verr := &domainErrors.ValidationError{}
+			verr.Add("purchase_date", domainErrors.CodeFutureDate)
+			return nil, verr
+		}
+
+		problem := assertProblem(t, post(t, mockUsecase, "/items?dry_run=true", body, nil), http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, "purchase_date", problem.InvalidParams[0].Field)
+
+		// 必須フィールドの不足はコントローラーで検出する
+		problem = assertProblem(t, post(t, dryRunUsecase(), "/items?dry_run=true", `{"category":"時計"}`, nil), http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, "name", problem.InvalidParams[0].Field)
+	})
+
+	t.Run("dry_run=false creates the item", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.createItemFunc = func(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+			return &entity.Item{ID: 1}, nil
+		}
+
+		rec := post(t, mockUsecase, "/items?dry_run=false", body, nil)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+	})
+
+	t.Run("invalid dry_run value", func(t *testing.T) {
+		problem := assertProblem(t, post(t, dryRunUsecase(), "/items?dry_run=maybe", body, nil), http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, "dry_run must be true or false", problem.Detail)
+	})
+}
+
+func TestItemHandler_ValidateItems(t *testing.T) {
+	e := echo.New()
+
+	post := func(t *testing.T, mockUsecase *mockItemUsecase, body string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items/validate", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		assert.NoError(t, NewItemHandler(mockUsecase).ValidateItems(e.NewContext(req, rec)))
+		return rec
+	}
+	mixedUsecase := func() *mockItemUsecase {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.validateItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) (*usecase.BatchValidationResult, error) {
+			verr := &domainErrors.ValidationError{}
+			verr.Add("purchase_date", domainErrors.CodeFutureDate)
+			return &usecase.BatchValidationResult{Valid: false, Items: []usecase.ItemValidation{
+				{Index: 0, Valid: true, Errors: []domainErrors.FieldError{}},
+				{Index: 1, Valid: false, Errors: verr.Fields},
+			}}, nil
+		}
+		return mockUsecase
+	}
+	body := `[{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"},{"name":"時計1","category":"時計","brand":"ROLEX","purchase_date":"2099-01-01"}]`
+
+	t.Run("returns per-index verdicts", func(t *testing.T) {
+		rec := post(t, mixedUsecase(), body, nil)
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var actual usecase.BatchValidationResult
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.False(t, actual.Valid)
+		assert.Len(t, actual.Items, 2)
+		assert.True(t, actual.Items[0].Valid)
+		assert.Empty(t, actual.Items[0].Errors)
+		assert.False(t, actual.Items[1].Valid)
+		assert.Equal(t, "purchase_date", actual.Items[1].Errors[0].Field)
+		assert.Equal(t, domainErrors.CodeFutureDate, actual.Items[1].Errors[0].Code)
+		assert.Equal(t, "must not be in the future", actual.Items[1].Errors[0].Message)
+		assert.Contains(t, rec.Body.String(), `"errors":[]`)
+	})
+
+	t.Run("messages follow Accept-Language", func(t *testing.T) {
+		rec := post(t, mixedUsecase(), body, http.Header{"Accept-Language": {"ja"}})
+
+		var actual usecase.BatchValidationResult
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.Equal(t, domainErrors.Message(domainErrors.LangJapanese, domainErrors.CodeFutureDate), actual.Items[1].Errors[0].Message)
+	})
+
+	t.Run("malformed body", func(t *testing.T) {
+		called := false
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.validateItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) (*usecase.BatchValidationResult, error) {
+			called = true
+			return nil, nil
+		}
+
+		problem := assertProblem(t, post(t, mockUsecase, `{"name":"時計1"}`, nil), http.StatusBadRequest, "/problems/malformed-json")
+		assert.Equal(t, []string{"body must be array, got object"}, problem.Errors)
+		assert.False(t, called)
+	})
+
+	t.Run("empty array", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.validateItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) (*usecase.BatchValidationResult, error) {
+			return nil, fmt.Errorf("%w: at least one item is required", domainErrors.ErrInvalidInput)
+		}
+
+		assertProblem(t, post(t, mockUsecase, `[]`, nil), http.StatusBadRequest, "/problems/invalid-input")
+	})
+}
+
+func TestItemHandler_CreateItemIdempotency(t *testing.T) {
+	e := echo.New()
+	body := `{"name":"ロレックス デイトナ","category":"時計","brand":"ROLEX","purchase_price":1500000,"purchase_date":"2023-01-15"}`
+
+	newUsecase := func(calls *int32) *mockItemUsecase {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.createItemFunc = func(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+			id := atomic.AddInt32(calls, 1)
+			return &entity.Item{ID: int64(id), Name: input.Name}, nil
+		}
+		mockUsecase.getItemByIDFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+			return &entity.Item{ID: id, Name: "ロレックス デイトナ"}, nil
+		}
+		return mockUsecase
+	}
+
+	postAs := func(handler *ItemHandler, subject, key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/items", bytes.NewReader([]byte(body)))
+		if subject != "" {
+			req = req.WithContext(usecase.ContextWithSubject(req.Context(), subject))
+		}
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set("Idempotency-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		assert.NoError(t, handler.CreateItem(c))
+		return rec
+	}
+	post := func(handler *ItemHandler, key string) *httptest.ResponseRecorder {
+		return postAs(handler, "", key)
+	}
+
+	decodeID := func(rec *httptest.ResponseRecorder) int64 {
+		var actual entity.Item
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		return actual.ID
+	}
+
+	t.Run("fresh key creates the item", func(t *testing.T) {
+		var calls int32
+		handler := NewItemHandler(newUsecase(&calls), WithIdempotencyStore(idempotency.NewMemoryStore(time.Hour)))
+
+		rec := post(handler, "key-1")
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.Equal(t, int64(1), decodeID(rec))
+		assert.Equal(t, "/items/1", rec.Header().Get(echo.HeaderLocation))
+		assert.Empty(t, rec.Header().Get("Idempotent-Replayed"))
+
+		rec = post(handler, "key-2")
+		assert.Equal(t, int64(2), decodeID(rec))
+		assert.Equal(t, int32(2), calls)
+	})
+
+	t.Run("replayed key returns the original item", func(t *testing.T) {
+		var calls int32
+		handler := NewItemHandler(newUsecase(&calls), WithIdempotencyStore(idempotency.NewMemoryStore(time.Hour)))
+
+		first := post(handler, "key-1")
+		second := post(handler, "key-1")
+
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Equal(t, decodeID(first), decodeID(second))
+		assert.Equal(t, "true", second.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, first.Header().Get(echo.HeaderLocation), second.Header().Get(echo.HeaderLocation))
+		assert.Equal(t, int32(1), calls)
+	})
+
+	t.Run("same key from another user creates a new item", func(t *testing.T) {
+		var calls int32
+		handler := NewItemHandler(newUsecase(&calls), WithIdempotencyStore(idempotency.NewMemoryStore(time.Hour)))
+
+		first := postAs(handler, "alice", "key-1")
+		second := postAs(handler, "bob", "key-1")
+
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.NotEqual(t, decodeID(first), decodeID(second))
+		assert.Empty(t, second.Header().Get("Idempotent-Replayed"))
+		assert.Equal(t, int32(2), calls)
+	})
+
+	t.Run("without a key every request creates", func(t *testing.T) {
+		var calls int32
+		handler := NewItemHandler(newUsecase(&calls), WithIdempotencyStore(idempotency.NewMemoryStore(time.Hour)))
+
+		post(handler, "")
+		post(handler, "")
+		assert.Equal(t, int32(2), calls)
+	})
+
+	t.Run("concurrent requests with the same key insert once", func(t *testing.T) {
+		var calls int32
+		handler := NewItemHandler(newUsecase(&calls), WithIdempotencyStore(idempotency.NewMemoryStore(time.Hour)))
+
+		recs := make([]*httptest.ResponseRecorder, 2)
+		var wg sync.WaitGroup
+		for i := range recs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				recs[i] = post(handler, "key-1")
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calls)
+		for _, rec := range recs {
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Equal(t, int64(1), decodeID(rec))
+		}
+	})
+}
+
+func TestItemHandler_GetSummary(t *testing.T) {
+	e := echo.New()
+
+	t.Run("count and total price per category", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getSummaryFunc = func(ctx context.Context, from, to *time.Time) (*usecase.CategorySummary, error) {
+			return &usecase.CategorySummary{
+				Categories: map[string]usecase.CategoryStats{
+					"時計":  {Count: 2, TotalPrice: 2500000},
+					"バッグ": {Count: 1, TotalPrice: 2000000},
+					"靴":   {},
+				},
+				Total: 3,
+			}, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/summary", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetSummary(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var actual struct {
+			Categories map[string]struct {
+				Count      int `json:"count"`
+				TotalPrice int `json:"total_price"`
+			} `json:"categories"`
+			Total int `json:"total"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual))
+		assert.Equal(t, 2, actual.Categories["時計"].Count)
+		assert.Equal(t, 2500000, actual.Categories["時計"].TotalPrice)
+		assert.Equal(t, 2000000, actual.Categories["バッグ"].TotalPrice)
+		assert.Equal(t, 0, actual.Categories["靴"].TotalPrice)
+		assert.Equal(t, 3, actual.Total)
+	})
+
+	t.Run("usecase error", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getSummaryFunc = func(ctx context.Context, from, to *time.Time) (*usecase.CategorySummary, error) {
+			return nil, domainErrors.ErrDatabaseError
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/summary", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetSummary(c))
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
+
+func TestItemHandler_GetSummaryWithDateRange(t *testing.T) {
+	e := echo.New()
+	timePtr := func(v time.Time) *time.Time { return &v }
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedFrom   *time.Time
+		expectedTo     *time.Time
+	}{
+		{
+			name:           "bounded summary",
+			query:          "from=2023-01-01T00:00:00Z&to=2023-12-31T00:00:00Z",
+			expectedStatus: http.StatusOK,
+			expectedFrom:   timePtr(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)),
+			expectedTo:     timePtr(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:           "open-ended bound",
+			query:          "from=2023-06-01",
+			expectedStatus: http.StatusOK,
+			expectedFrom:   timePtr(time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)),
+		},
+		{
+			name:           "no filter",
+			query:          "",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "inverted bounds",
+			query:          "from=2023-12-31&to=2023-01-01",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "malformed date",
+			query:          "to=yesterday",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			mockUsecase := &mockItemUsecase{}
+			mockUsecase.getSummaryFunc = func(ctx context.Context, from, to *time.Time) (*usecase.CategorySummary, error) {
+				called = true
+				assert.Equal(t, tt.expectedFrom, from)
+				assert.Equal(t, tt.expectedTo, to)
+				return &usecase.CategorySummary{}, nil
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/items/summary?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			assert.NoError(t, NewItemHandler(mockUsecase).GetSummary(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Equal(t, tt.expectedStatus == http.StatusOK, called)
+		})
+	}
+}
+
+type fakeEventSubscriber struct {
+	events       chan entity.ItemEvent
+	unsubscribed chan struct{}
+}
+
+func (f *fakeEventSubscriber) Subscribe() (<-chan entity.ItemEvent, func()) {
+	return f.events, func() { close(f.unsubscribed) }
+}
+
+func TestItemHandler_StreamEvents(t *testing.T) {
+	e := echo.New()
+
+	t.Run("heartbeats until the client disconnects", func(t *testing.T) {
+		subscriber := &fakeEventSubscriber{events: make(chan entity.ItemEvent), unsubscribed: make(chan struct{})}
+		ctx, cancel := context.WithCancel(context.Background())
+		req := httptest.NewRequest(http.MethodGet, "/items/events", nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		done := make(chan error)
+		go func() {
+			done <- NewItemHandler(&mockItemUsecase{}, WithEventStream(subscriber, 10*time.Millisecond)).StreamEvents(c)
+		}()
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("handler did not return after the client disconnected")
+		}
+		<-subscriber.unsubscribed
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, MIMETextEventStream, rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Body.String(), ": heartbeat\n\n")
+	})
+
+	t.Run("events are written until the subscription ends", func(t *testing.T) {
+		subscriber := &fakeEventSubscriber{events: make(chan entity.ItemEvent, 1), unsubscribed: make(chan struct{})}
+		subscriber.events <- entity.ItemEvent{Type: entity.ItemEventDeleted, Item: &entity.Item{ID: 3}, Timestamp: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		close(subscriber.events)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/events", nil), rec)
+
+		assert.NoError(t, NewItemHandler(&mockItemUsecase{}, WithEventStream(subscriber, time.Hour)).StreamEvents(c))
+
+		assert.True(t, strings.HasPrefix(rec.Body.String(), "event: item.deleted\ndata: {\"type\":\"item.deleted\",\"item\":{\"id\":3,"), rec.Body.String())
+		assert.True(t, strings.HasSuffix(rec.Body.String(), "\"timestamp\":\"2024-01-01T00:00:00Z\"}\n\n"), rec.Body.String())
+	})
+
+	t.Run("user scoped stream only writes the subscriber's events", func(t *testing.T) {
+		subscriber := &fakeEventSubscriber{events: make(chan entity.ItemEvent, 2), unsubscribed: make(chan struct{})}
+		subscriber.events <- entity.ItemEvent{Type: entity.ItemEventCreated, Item: &entity.Item{ID: 1, UserID: "bob"}}
+		subscriber.events <- entity.ItemEvent{Type: entity.ItemEventCreated, Item: &entity.Item{ID: 2, UserID: "alice"}}
+		close(subscriber.events)
+		req := httptest.NewRequest(http.MethodGet, "/items/events", nil)
+		req = req.WithContext(usecase.ContextWithSubject(req.Context(), "alice"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(&mockItemUsecase{}, WithEventStream(subscriber, time.Hour), WithUserScopedEvents()).StreamEvents(c))
+
+		assert.Equal(t, 1, strings.Count(rec.Body.String(), "event: item.created\n"), rec.Body.String())
+		assert.Contains(t, rec.Body.String(), `"id":2,`)
+		assert.NotContains(t, rec.Body.String(), `"id":1,`)
+	})
+
+	t.Run("user scoped stream requires a subject", func(t *testing.T) {
+		subscriber := &fakeEventSubscriber{events: make(chan entity.ItemEvent), unsubscribed: make(chan struct{})}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/events", nil), rec)
+
+		assert.NoError(t, NewItemHandler(&mockItemUsecase{}, WithEventStream(subscriber, time.Hour), WithUserScopedEvents()).StreamEvents(c))
+
+		assertProblem(t, rec, http.StatusUnauthorized, "/problems/unauthorized")
+	})
+
+	t.Run("503 without a subscriber", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/items/events", nil), rec)
+
+		assert.NoError(t, NewItemHandler(&mockItemUsecase{}).StreamEvents(c))
+
+		assertProblem(t, rec, http.StatusServiceUnavailable, "about:blank")
 	})
 }
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// CSV のヘッダー行（エクスポート・インポート共通）
+var csvHeader = []string{"id", "name", "category", "brand", "purchase_price", "purchase_date"}
+
+// CSVRowError は CSV インポートで失敗した行のエラー（line はヘッダーを 1 行目とした行番号）
+type CSVRowError struct {
+	Line     int      `json:"line"`
+	Messages []string `json:"errors"`
+}
+
+func (h *ItemHandler) ExportCSV(c echo.Context) error {
+	filter, err := parseItemFilter(c)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid filter parameters", err.Error()))
+	}
+
+	items, err := h.itemUsecase.GetAllItems(c.Request().Context(), filter)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="items.csv"`)
+	res.WriteHeader(http.StatusOK)
+
+	// カンマや引用符を含むフィールドのエスケープは encoding/csv に任せる
+	w := csv.NewWriter(res)
+	if err := w.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := w.Write(itemToCSVRecord(item)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+
+	return w.Error()
+}
+
+func itemToCSVRecord(item *entity.Item) []string {
+	return []string{
+		strconv.FormatInt(item.ID, 10),
+		item.Name,
+		item.Category,
+		item.Brand,
+		strconv.Itoa(item.PurchasePrice),
+		item.PurchaseDate,
+	}
+}
+
+// ImportCSV は multipart の file フィールド（なければリクエストボディ）の CSV を一括登録する
+// 1 行でも不正な行があれば何も登録しない
+func (h *ItemHandler) ImportCSV(c echo.Context) error {
+	body, err := openCSVUpload(c)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid request format", err.Error()))
+	}
+	defer body.Close()
+
+	r := csv.NewReader(body)
+	// ヘッダーは列数も含めて validateCSVHeader で検証する
+	r.FieldsPerRecord = -1
+
+	header, err := r.Read()
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid csv header", csvReadErrorMessage(err)))
+	}
+	if details := validateCSVHeader(header); len(details) > 0 {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid csv header", details...))
+	}
+
+	r.FieldsPerRecord = len(csvHeader)
+
+	var inputs []usecase.CreateItemInput
+	var lines []int
+	var rowErrors []CSVRowError
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid request format"))
+			}
+			rowErrors = append(rowErrors, CSVRowError{Line: parseErr.StartLine, Messages: []string{csvReadErrorMessage(err)}})
+			// 列数不一致以外の構文エラーは以降の行を信用できないので打ち切る
+			if !errors.Is(err, csv.ErrFieldCount) {
+				break
+			}
+			continue
+		}
+
+		line, _ := r.FieldPos(0)
+		input, err := csvRecordToInput(record)
+		if err != nil {
+			rowErrors = append(rowErrors, CSVRowError{Line: line, Messages: []string{err.Error()}})
+			continue
+		}
+		inputs = append(inputs, input)
+		lines = append(lines, line)
+	}
+
+	// 単一登録と同じルール（未来の購入日・名前の長さ・カテゴリーなど）で検証し、結果の Index を CSV の行番号に戻す
+	if len(inputs) > 0 {
+		validation, err := h.itemUsecase.ValidateItems(c.Request().Context(), inputs)
+		if err != nil {
+			return writeError(c, err)
+		}
+		for _, item := range validation.Items {
+			if !item.Valid {
+				rowErrors = append(rowErrors, CSVRowError{Line: lines[item.Index], Messages: (&domainErrors.ValidationError{Fields: item.Errors}).Messages()})
+			}
+		}
+		slices.SortStableFunc(rowErrors, func(a, b CSVRowError) int { return a.Line - b.Line })
+	}
+
+	if len(rowErrors) > 0 {
+		problem := NewProblem(http.StatusBadRequest, domainErrors.Message(domainErrors.LangEnglish, domainErrors.CodeValidationFailed))
+		problem.Rows = rowErrors
+		return WriteProblem(c, problem)
+	}
+	if len(inputs) == 0 {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "csv has no item rows"))
+	}
+
+	items, err := h.itemUsecase.CreateItems(c.Request().Context(), inputs)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusCreated, items)
+}
+
+func openCSVUpload(c echo.Context) (io.ReadCloser, error) {
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return nil, fmt.Errorf("file is required")
+		}
+		return fileHeader.Open()
+	}
+	return c.Request().Body, nil
+}
+
+func validateCSVHeader(header []string) []string {
+	if len(header) != len(csvHeader) {
+		return []string{fmt.Sprintf("header must be %s", strings.Join(csvHeader, ","))}
+	}
+
+	var errs []string
+	for i, name := range header {
+		// Excel などが付与する BOM は無視する
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		if strings.TrimSpace(name) != csvHeader[i] {
+			errs = append(errs, fmt.Sprintf("column %d must be %q, got %q", i+1, csvHeader[i], name))
+		}
+	}
+	return errs
+}
+
+// csvRecordToInput は 1 行分を CreateItemInput に変換する（値の検証は ValidateItems でまとめて行う）
+// id 列は新規登録では使わないため無視する
+func csvRecordToInput(record []string) (usecase.CreateItemInput, error) {
+	input := usecase.CreateItemInput{
+		Name:         strings.TrimSpace(record[1]),
+		Category:     strings.TrimSpace(record[2]),
+		Brand:        strings.TrimSpace(record[3]),
+		PurchaseDate: strings.TrimSpace(record[5]),
+	}
+
+	price, err := strconv.Atoi(strings.TrimSpace(record[4]))
+	if err != nil {
+		return input, errors.New("purchase_price must be an integer")
+	}
+	input.PurchasePrice = price
+
+	return input, nil
+}
+
+// 行のエラーとして表示するメッセージ（ValidationError は違反ごとに分ける）
+func validationMessages(err error) []string {
+	if verr, ok := domainErrors.AsValidationError(err); ok {
+		return verr.Messages()
+	}
+	return []string{err.Error()}
+}
+
+func csvReadErrorMessage(err error) string {
+	if err == io.EOF {
+		return "csv is empty"
+	}
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return parseErr.Err.Error()
+	}
+	return err.Error()
+}
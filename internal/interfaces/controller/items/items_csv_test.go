@@ -0,0 +1,276 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// csvUsecase は名前が空・既定にないカテゴリー・2100 年以降の購入日の行を不正とするモック
+func csvUsecase() *mockItemUsecase {
+	mockUsecase := &mockItemUsecase{}
+	mockUsecase.validateItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) (*usecase.BatchValidationResult, error) {
+		result := &usecase.BatchValidationResult{Valid: true}
+		for i, input := range inputs {
+			verr := &domainErrors.ValidationError{}
+			if input.Name == "" {
+				verr.Add("name", domainErrors.CodeRequired)
+			}
+			if !slices.Contains(entity.DefaultCategories, input.Category) {
+				verr.Add("category", domainErrors.CodeInvalidValue, strings.Join(entity.DefaultCategories, ", "))
+			}
+			if input.PurchaseDate >= "2100-01-01" {
+				verr.Add("purchase_date", domainErrors.CodeFutureDate)
+			}
+			item := usecase.ItemValidation{Index: i, Valid: len(verr.Fields) == 0, Errors: []domainErrors.FieldError{}}
+			if !item.Valid {
+				item.Errors, result.Valid = verr.Fields, false
+			}
+			result.Items = append(result.Items, item)
+		}
+		return result, nil
+	}
+	return mockUsecase
+}
+
+func TestItemHandler_ExportCSV(t *testing.T) {
+	e := echo.New()
+
+	t.Run("rows and escaping", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getAllItemsFunc = func(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+			return []*entity.Item{
+				{ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"},
+				{ID: 3, Name: `ネックレス "Return to Tiffany"`, Category: "ジュエリー", Brand: "Tiffany, & Co.", PurchasePrice: 300000, PurchaseDate: "2023-03-10"},
+			}, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/export.csv", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).ExportCSV(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", rec.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="items.csv"`, rec.Header().Get(echo.HeaderContentDisposition))
+
+		records, err := csv.NewReader(strings.NewReader(rec.Body.String())).ReadAll()
+		require.NoError(t, err)
+		assert.Equal(t, [][]string{
+			{"id", "name", "category", "brand", "purchase_price", "purchase_date"},
+			{"1", "ロレックス デイトナ", "時計", "ROLEX", "1500000", "2023-01-15"},
+			{"3", `ネックレス "Return to Tiffany"`, "ジュエリー", "Tiffany, & Co.", "300000", "2023-03-10"},
+		}, records)
+		assert.Contains(t, rec.Body.String(), `"Tiffany, & Co."`)
+		assert.Contains(t, rec.Body.String(), `"ネックレス ""Return to Tiffany"""`)
+	})
+
+	t.Run("honors list filters", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getAllItemsFunc = func(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+			assert.Equal(t, []string{"時計"}, filter.Categories)
+			if assert.NotNil(t, filter.Brand) {
+				assert.Equal(t, "ROLEX", *filter.Brand)
+			}
+			return []*entity.Item{}, nil
+		}
+
+		query := url.Values{"category": {"時計"}, "brand": {"ROLEX"}}
+		req := httptest.NewRequest(http.MethodGet, "/items/export.csv?"+query.Encode(), nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err := NewItemHandler(mockUsecase).ExportCSV(c)
+		require.NoError(t, err)
+
+		records, err := csv.NewReader(strings.NewReader(rec.Body.String())).ReadAll()
+		require.NoError(t, err)
+		assert.Len(t, records, 1, "only the header row is expected")
+	})
+}
+
+func TestItemHandler_ImportCSV(t *testing.T) {
+	e := echo.New()
+	header := "id,name,category,brand,purchase_price,purchase_date\n"
+
+	newRequest := func(body string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/items/import", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, "text/csv")
+		return req
+	}
+
+	t.Run("clean import", func(t *testing.T) {
+		var received []usecase.CreateItemInput
+		mockUsecase := csvUsecase()
+		mockUsecase.createItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+			received = inputs
+			items := make([]*entity.Item, len(inputs))
+			for i, in := range inputs {
+				items[i] = &entity.Item{ID: int64(i + 1), Name: in.Name, Category: in.Category, Brand: in.Brand, PurchasePrice: in.PurchasePrice, PurchaseDate: in.PurchaseDate}
+			}
+			return items, nil
+		}
+
+		body := header +
+			",ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+			`99,"バッグ ""バーキン""",バッグ,"HERMÈS, Paris",2500000,2023-02-20` + "\n"
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(body), rec)
+
+		err := NewItemHandler(mockUsecase).ImportCSV(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		require.Len(t, received, 2)
+		assert.Equal(t, usecase.CreateItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}, received[0])
+		assert.Equal(t, `バッグ "バーキン"`, received[1].Name)
+		assert.Equal(t, "HERMÈS, Paris", received[1].Brand)
+	})
+
+	t.Run("multipart upload", func(t *testing.T) {
+		called := false
+		mockUsecase := csvUsecase()
+		mockUsecase.createItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+			called = true
+			return []*entity.Item{}, nil
+		}
+
+		var buf bytes.Buffer
+		mw := multipart.NewWriter(&buf)
+		fw, err := mw.CreateFormFile("file", "items.csv")
+		require.NoError(t, err)
+		_, _ = fw.Write([]byte(header + "1,ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n"))
+		require.NoError(t, mw.Close())
+
+		req := httptest.NewRequest(http.MethodPost, "/items/import", &buf)
+		req.Header.Set(echo.HeaderContentType, mw.FormDataContentType())
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		err = NewItemHandler(mockUsecase).ImportCSV(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		assert.True(t, called)
+	})
+
+	t.Run("bad rows are reported by line and nothing is created", func(t *testing.T) {
+		mockUsecase := csvUsecase()
+		mockUsecase.createItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+			t.Fatal("CreateItems must not be called when a row is invalid")
+			return nil, nil
+		}
+
+		body := header +
+			"1,ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+			"2,,時計,ROLEX,abc,2023-01-15\n" +
+			"3,財布,財布,LOUIS VUITTON,80000,2023-01-15\n" +
+			"4,too,few\n"
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(body), rec)
+
+		err := NewItemHandler(mockUsecase).ImportCSV(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var response Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "validation failed", response.Detail)
+		require.Len(t, response.Rows, 3)
+		assert.Equal(t, 3, response.Rows[0].Line)
+		assert.Equal(t, []string{"purchase_price must be an integer"}, response.Rows[0].Messages)
+		assert.Equal(t, 4, response.Rows[1].Line)
+		assert.Equal(t, 5, response.Rows[2].Line)
+	})
+
+	t.Run("usecase rules are reported by csv line", func(t *testing.T) {
+		var validated []usecase.CreateItemInput
+		mockUsecase := csvUsecase()
+		validate := mockUsecase.validateItemsFunc
+		mockUsecase.validateItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) (*usecase.BatchValidationResult, error) {
+			validated = inputs
+			return validate(ctx, inputs)
+		}
+		mockUsecase.createItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+			t.Fatal("CreateItems must not be called when a row is invalid")
+			return nil, nil
+		}
+
+		body := header +
+			"1,ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n" +
+			"2,時計2,時計,ROLEX,abc,2023-01-15\n" +
+			"3,時計3,時計,ROLEX,1500000,2999-01-01\n"
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(body), rec)
+
+		err := NewItemHandler(mockUsecase).ImportCSV(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		// 読み取れた行だけを検証する
+		assert.Len(t, validated, 2)
+
+		var response Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		require.Len(t, response.Rows, 2)
+		assert.Equal(t, 3, response.Rows[0].Line)
+		assert.Equal(t, 4, response.Rows[1].Line)
+		require.Len(t, response.Rows[1].Messages, 1)
+		assert.Contains(t, response.Rows[1].Messages[0], "purchase_date")
+	})
+
+	t.Run("validation error from the usecase", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.validateItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) (*usecase.BatchValidationResult, error) {
+			return nil, domainErrors.ErrDatabaseError
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(header+"1,ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n"), rec)
+
+		err := NewItemHandler(mockUsecase).ImportCSV(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+
+	t.Run("wrong header", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		body := "id,title,category,brand,purchase_price,purchase_date\n" +
+			"1,ロレックス デイトナ,時計,ROLEX,1500000,2023-01-15\n"
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest(body), rec)
+
+		err := NewItemHandler(mockUsecase).ImportCSV(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+		var response Problem
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "invalid csv header", response.Detail)
+		assert.Equal(t, []string{`column 2 must be "name", got "title"`}, response.Errors)
+	})
+
+	t.Run("missing header column", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(newRequest("id,name,category,brand,purchase_price\n"), rec)
+
+		err := NewItemHandler(mockUsecase).ImportCSV(c)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "invalid csv header")
+	})
+}
@@ -0,0 +1,52 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// dry run を指定するヘッダー（クエリパラメータの dry_run と同じ値を受け付ける）
+const headerDryRun = "X-Dry-Run"
+
+// DryRunResult は dry run の作成のレスポンス
+// item は登録される内容で、保存していないため ID は 0 になる
+type DryRunResult struct {
+	DryRun bool         `json:"dry_run"`
+	Saved  bool         `json:"saved"`
+	Item   *entity.Item `json:"item"`
+}
+
+// isDryRun は ?dry_run= または X-Dry-Run ヘッダーで dry run が指定されたかを返す（クエリパラメータを優先）
+// 値は strconv.ParseBool の形式（true / false / 1 / 0 など）で、不正な値はエラーにする
+func isDryRun(c echo.Context) (bool, error) {
+	name, value := "dry_run", c.QueryParam("dry_run")
+	if value == "" {
+		name, value = headerDryRun, c.Request().Header.Get(headerDryRun)
+	}
+	if value == "" {
+		return false, nil
+	}
+
+	dryRun, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false", name)
+	}
+	return dryRun, nil
+}
+
+// dryRunCreateItem は登録と同じバリデーションだけを行い、保存せずに 200 で登録される内容を返す
+func (h *ItemHandler) dryRunCreateItem(c echo.Context, input usecase.CreateItemInput) error {
+	item, err := h.itemUsecase.DryRunCreateItem(c.Request().Context(), input)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	c.Response().Header().Set(headerDryRun, "true")
+	return c.JSON(http.StatusOK, DryRunResult{DryRun: true, Saved: false, Item: item})
+}
@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+const (
+	// SSE（Server-Sent Events）の Content-Type
+	MIMETextEventStream = "text/event-stream"
+
+	// 接続を維持するためにコメント行を送る間隔（WithEventStream で変更可能）
+	defaultHeartbeatInterval = 15 * time.Second
+)
+
+// EventSubscriber はアイテムの変更イベントを購読する（GET /items/events）
+// unsubscribe を呼ぶと events は閉じられ、購読側の終了（シャットダウンなど）でも閉じられる
+type EventSubscriber interface {
+	Subscribe() (events <-chan entity.ItemEvent, unsubscribe func())
+}
+
+// WithEventStream は GET /items/events で subscriber のイベントを配信する
+// heartbeat が 0 以下の場合は defaultHeartbeatInterval とする
+func WithEventStream(subscriber EventSubscriber, heartbeat time.Duration) Option {
+	return func(h *ItemHandler) {
+		h.eventSubscriber = subscriber
+		if heartbeat > 0 {
+			h.heartbeatInterval = heartbeat
+		}
+	}
+}
+
+// WithUserScopedEvents は GET /items/events で購読したユーザー（JWT の subject）のアイテムのイベントのみを配信する
+// usecase.WithUserScope と合わせて設定する
+func WithUserScopedEvents() Option {
+	return func(h *ItemHandler) {
+		h.userScopedEvents = true
+	}
+}
+
+// StreamEvents はアイテムの作成・更新・削除を SSE で配信する
+// イベント名は type（item.created など）、data はイベントの JSON
+// クライアントが切断する（リクエストの context が終了する）か、購読が終了するまで接続を維持する
+func (h *ItemHandler) StreamEvents(c echo.Context) error {
+	if h.eventSubscriber == nil {
+		return WriteProblem(c, NewProblem(http.StatusServiceUnavailable, "event stream is not enabled"))
+	}
+
+	ctx := c.Request().Context()
+	subject := usecase.SubjectFromContext(ctx)
+	if h.userScopedEvents && subject == "" {
+		return writeError(c, domainErrors.ErrUnauthenticated)
+	}
+
+	events, unsubscribe := h.eventSubscriber.Subscribe()
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, MIMETextEventStream)
+	res.Header().Set("Cache-Control", "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	// リバースプロキシ（nginx）にバッファリングさせない
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			// 他のユーザーのアイテムのイベントは配信しない
+			if h.userScopedEvents && (event.Item == nil || event.Item.UserID != subject) {
+				continue
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(res, "event: %s\ndata: %s\n\n", event.Type, data); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// parseFields は fields クエリパラメータ（カンマ区切り）を返す。未指定・空なら nil（全フィールド）
+func parseFields(c echo.Context) []string {
+	var fields []string
+	for _, name := range strings.Split(c.QueryParam("fields"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// selectFields は v を JSON に変換し、指定されたキーだけを残す
+// 存在しないフィールド名は無視する。v はオブジェクトかオブジェクトの配列を想定
+func selectFields(v interface{}, fields []string) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return nil, err
+	}
+
+	switch val := decoded.(type) {
+	case map[string]interface{}:
+		return pickKeys(val, fields), nil
+	case []interface{}:
+		for i, elem := range val {
+			if obj, ok := elem.(map[string]interface{}); ok {
+				val[i] = pickKeys(obj, fields)
+			}
+		}
+		return val, nil
+	default:
+		return decoded, nil
+	}
+}
+
+func pickKeys(obj map[string]interface{}, fields []string) map[string]interface{} {
+	picked := make(map[string]interface{}, len(fields))
+	for _, name := range fields {
+		if value, ok := obj[name]; ok {
+			picked[name] = value
+		}
+	}
+	return picked
+}
+
+// applyFields は fields 指定があれば該当キーのみに絞った値を、なければ v をそのまま返す
+func applyFields(c echo.Context, v interface{}) (interface{}, error) {
+	fields := parseFields(c)
+	if len(fields) == 0 {
+		return v, nil
+	}
+	return selectFields(v, fields)
+}
+
+// jsonWithFields は fields 指定があれば該当キーのみに絞ってレスポンスを返す
+func jsonWithFields(c echo.Context, code int, v interface{}) error {
+	selected, err := applyFields(c, v)
+	if err != nil {
+		return err
+	}
+	return c.JSON(code, selected)
+}
@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// multipart/form-data で画像を送るパートの名前
+const imageFormField = "image"
+
+// UploadImage は multipart/form-data の image パートの画像を保存し、image_url を保存先にしたアイテムを返す
+// 画像の形式・サイズは usecase で検証する
+func (h *ItemHandler) UploadImage(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid item ID"))
+	}
+
+	fileHeader, err := c.FormFile(imageFormField)
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid request format", imageFormField+" is required"))
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		return WriteProblem(c, NewProblem(http.StatusBadRequest, "invalid request format"))
+	}
+	defer file.Close()
+
+	item, err := h.itemUsecase.UploadItemImage(c.Request().Context(), id, usecase.ImageUpload{Size: fileHeader.Size, Body: file})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, item)
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 改行区切りの JSON（1 行に 1 つの JSON オブジェクト）
+const MIMEApplicationNDJSON = "application/x-ndjson"
+
+const (
+	// NDJSON インポートで 1 回の一括登録にまとめる行数（WithNDJSONBatchSize で変更可能）
+	defaultNDJSONBatchSize = 100
+	// NDJSON の 1 行の最大バイト数
+	maxNDJSONLineBytes = 64 << 10
+	// レスポンスに含める失敗した行の上限（超えた分は failed の件数にのみ数える）
+	maxNDJSONLineErrors = 100
+	// NDJSON エクスポートで 1 回に取得する件数（このページごとに書き出して flush する）
+	ndjsonExportPageSize = 500
+)
+
+// NDJSONLineError は NDJSON インポートで失敗した行のエラー（line は 1 始まりの行番号）
+type NDJSONLineError struct {
+	Line     int      `json:"line"`
+	Messages []string `json:"errors"`
+}
+
+// NDJSONImportResult は NDJSON インポートの結果
+// Errors は先頭の maxNDJSONLineErrors 行まで（Failed は失敗したすべての行数）
+type NDJSONImportResult struct {
+	Created int               `json:"created"`
+	Failed  int               `json:"failed"`
+	Errors  []NDJSONLineError `json:"errors"`
+}
+
+// WithNDJSONBatchSize は POST /items/import-ndjson で 1 回の一括登録にまとめる行数を設定する
+func WithNDJSONBatchSize(size int) Option {
+	return func(h *ItemHandler) {
+		if size > 0 {
+			h.ndjsonBatchSize = size
+		}
+	}
+}
+
+// ImportNDJSON は 1 行に 1 つの JSON オブジェクト（POST /items と同じ形式）を読みながら、batch 行ずつ一括登録する
+// ボディ全体を読み込まないため、メモリの使用量はファイルの大きさによらず batch 行分に収まる
+// 不正な行は行番号とともに結果に含めて読み飛ばし、ほかの行は登録する（登録済みのバッチは取り消さない）
+// 同じバッチの行は 1 つのトランザクションで登録するため、名前の重複などで失敗した場合はそのバッチの行がすべて失敗になる
+func (h *ItemHandler) ImportNDJSON(c echo.Context) error {
+	ctx := c.Request().Context()
+	result := &NDJSONImportResult{Errors: []NDJSONLineError{}}
+	fail := func(line int, messages ...string) {
+		result.Failed++
+		if len(result.Errors) < maxNDJSONLineErrors {
+			result.Errors = append(result.Errors, NDJSONLineError{Line: line, Messages: messages})
+		}
+	}
+
+	var inputs []usecase.CreateItemInput
+	var lines []int
+	flush := func() error {
+		if len(inputs) == 0 {
+			return nil
+		}
+		defer func() { inputs, lines = inputs[:0], lines[:0] }()
+
+		// 登録できない行を除き、残りの行をまとめて登録する
+		validation, err := h.itemUsecase.ValidateItems(ctx, inputs)
+		if err != nil {
+			return err
+		}
+		valid := make([]usecase.CreateItemInput, 0, len(inputs))
+		validLines := make([]int, 0, len(inputs))
+		for i, item := range validation.Items {
+			if !item.Valid {
+				fail(lines[i], (&domainErrors.ValidationError{Fields: item.Errors}).Messages()...)
+				continue
+			}
+			valid = append(valid, inputs[i])
+			validLines = append(validLines, lines[i])
+		}
+		if len(valid) == 0 {
+			return nil
+		}
+
+		created, err := h.itemUsecase.CreateItems(ctx, valid)
+		if err != nil {
+			if !domainErrors.IsDuplicateItemError(err) && !domainErrors.IsValidationError(err) {
+				return err
+			}
+			for _, line := range validLines {
+				fail(line, err.Error())
+			}
+			return nil
+		}
+		result.Created += len(created)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(c.Request().Body)
+	scanner.Buffer(make([]byte, 0, 4096), maxNDJSONLineBytes)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var input usecase.CreateItemInput
+		if err := decodeJSONStrict(bytes.NewReader(text), &input); err != nil {
+			fail(line, lineErrorMessages(err)...)
+			continue
+		}
+		inputs = append(inputs, input)
+		lines = append(lines, line)
+		if len(inputs) >= h.ndjsonBatchSize {
+			if err := flush(); err != nil {
+				return writeError(c, err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if !errors.Is(err, bufio.ErrTooLong) {
+			return writeError(c, err)
+		}
+		// 長すぎる行の後は行の区切りが分からないため、以降の行は読まない
+		fail(line+1, fmt.Sprintf("line must be %d bytes or less", maxNDJSONLineBytes))
+	}
+	if err := flush(); err != nil {
+		return writeError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// ExportNDJSON はアイテムを ID 順に 1 行に 1 つの JSON オブジェクトで書き出す
+// ID のカーソルで ndjsonExportPageSize 件ずつ取得しては書き出すため、メモリの使用量は件数によらず 1 ページ分に収まる
+// 書き出しを始めた後のエラーはステータスを変更できないため、途中で接続を終える
+func (h *ItemHandler) ExportNDJSON(c echo.Context) error {
+	ctx := c.Request().Context()
+	items, hasMore, err := h.itemUsecase.GetItemsAfter(ctx, usecase.ItemFilter{}, 0, ndjsonExportPageSize)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, MIMEApplicationNDJSON)
+	res.Header().Set(echo.HeaderContentDisposition, `attachment; filename="items.ndjson"`)
+	res.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(res)
+	for {
+		for _, item := range items {
+			if err := enc.Encode(item); err != nil {
+				return err
+			}
+		}
+		res.Flush()
+		if !hasMore || len(items) == 0 {
+			return nil
+		}
+
+		items, hasMore, err = h.itemUsecase.GetItemsAfter(ctx, usecase.ItemFilter{}, items[len(items)-1].ID, ndjsonExportPageSize)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// lineErrorMessages は 1 行のデコードのエラーを行のエラーとして表示するメッセージにする
+func lineErrorMessages(err error) []string {
+	var problem *Problem
+	if errors.As(err, &problem) && len(problem.Errors) > 0 {
+		return problem.Errors
+	}
+	return validationMessages(err)
+}
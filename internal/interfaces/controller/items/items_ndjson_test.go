@@ -0,0 +1,234 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ndjsonUsecase は名前が空の行を不正とし、正しい行はすべて登録するモック
+func ndjsonUsecase(batches *[][]string) *mockItemUsecase {
+	mockUsecase := &mockItemUsecase{}
+	mockUsecase.validateItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) (*usecase.BatchValidationResult, error) {
+		result := &usecase.BatchValidationResult{Valid: true}
+		for i, input := range inputs {
+			item := usecase.ItemValidation{Index: i, Valid: true, Errors: []domainErrors.FieldError{}}
+			if input.Name == "" {
+				item.Valid, result.Valid = false, false
+				item.Errors = append(item.Errors, domainErrors.FieldError{Field: "name", Code: domainErrors.CodeRequired, Message: "is required"})
+			}
+			result.Items = append(result.Items, item)
+		}
+		return result, nil
+	}
+	mockUsecase.createItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+		names := make([]string, len(inputs))
+		items := make([]*entity.Item, len(inputs))
+		for i, input := range inputs {
+			names[i] = input.Name
+			items[i] = &entity.Item{ID: int64(i + 1), Name: input.Name}
+		}
+		*batches = append(*batches, names)
+		return items, nil
+	}
+	return mockUsecase
+}
+
+func ndjsonLine(name string) string {
+	return fmt.Sprintf(`{"name":%q,"category":"時計","brand":"ROLEX","purchase_price":1000,"purchase_date":"2023-01-15"}`, name)
+}
+
+func postNDJSON(t *testing.T, h *ItemHandler, body string) (*httptest.ResponseRecorder, NDJSONImportResult) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/items/import-ndjson", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, MIMEApplicationNDJSON)
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, h.ImportNDJSON(echo.New().NewContext(req, rec)))
+
+	var result NDJSONImportResult
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &result))
+	}
+	return rec, result
+}
+
+func TestItemHandler_ImportNDJSON(t *testing.T) {
+	t.Run("valid stream", func(t *testing.T) {
+		var batches [][]string
+		body := ndjsonLine("A") + "\n\n" + ndjsonLine("B") + "\r\n" + ndjsonLine("C")
+
+		rec, result := postNDJSON(t, NewItemHandler(ndjsonUsecase(&batches)), body)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, NDJSONImportResult{Created: 3, Failed: 0, Errors: []NDJSONLineError{}}, result)
+		assert.Equal(t, [][]string{{"A", "B", "C"}}, batches)
+	})
+
+	t.Run("bad lines are reported with their line numbers", func(t *testing.T) {
+		var batches [][]string
+		body := strings.Join([]string{
+			ndjsonLine("A"),
+			`{"name":"B","category":`,
+			`{"name":"C","colour":"red"}`,
+			ndjsonLine(""),
+			ndjsonLine("E"),
+		}, "\n")
+
+		rec, result := postNDJSON(t, NewItemHandler(ndjsonUsecase(&batches)), body)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 2, result.Created)
+		assert.Equal(t, 3, result.Failed)
+		if assert.Len(t, result.Errors, 3) {
+			assert.Equal(t, NDJSONLineError{Line: 2, Messages: []string{"unexpected end of JSON input"}}, result.Errors[0])
+			assert.Equal(t, 3, result.Errors[1].Line)
+			assert.Contains(t, result.Errors[1].Messages[0], "colour")
+			assert.Equal(t, NDJSONLineError{Line: 4, Messages: []string{"name is required"}}, result.Errors[2])
+		}
+		assert.Equal(t, [][]string{{"A", "E"}}, batches)
+	})
+
+	t.Run("inserts in batches while reading", func(t *testing.T) {
+		var batches [][]string
+		lines := make([]string, 5)
+		for i := range lines {
+			lines[i] = ndjsonLine(string(rune('A' + i)))
+		}
+
+		rec, result := postNDJSON(t, NewItemHandler(ndjsonUsecase(&batches), WithNDJSONBatchSize(2)), strings.Join(lines, "\n")+"\n")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 5, result.Created)
+		assert.Equal(t, [][]string{{"A", "B"}, {"C", "D"}, {"E"}}, batches)
+	})
+
+	t.Run("duplicate in a batch fails only that batch", func(t *testing.T) {
+		var batches [][]string
+		mockUsecase := ndjsonUsecase(&batches)
+		create := mockUsecase.createItemsFunc
+		mockUsecase.createItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) ([]*entity.Item, error) {
+			if inputs[0].Name == "C" {
+				return nil, domainErrors.ErrDuplicateItem
+			}
+			return create(ctx, inputs)
+		}
+		body := strings.Join([]string{ndjsonLine("A"), ndjsonLine("B"), ndjsonLine("C"), ndjsonLine("D")}, "\n")
+
+		rec, result := postNDJSON(t, NewItemHandler(mockUsecase, WithNDJSONBatchSize(2)), body)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 2, result.Created)
+		assert.Equal(t, 2, result.Failed)
+		if assert.Len(t, result.Errors, 2) {
+			assert.Equal(t, 3, result.Errors[0].Line)
+			assert.Equal(t, 4, result.Errors[1].Line)
+		}
+	})
+
+	t.Run("line too long stops reading", func(t *testing.T) {
+		var batches [][]string
+		long := ndjsonLine(strings.Repeat("a", maxNDJSONLineBytes))
+		body := ndjsonLine("A") + "\n" + long + "\n" + ndjsonLine("C")
+
+		rec, result := postNDJSON(t, NewItemHandler(ndjsonUsecase(&batches)), body)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, 1, result.Created)
+		assert.Equal(t, []NDJSONLineError{{Line: 2, Messages: []string{fmt.Sprintf("line must be %d bytes or less", maxNDJSONLineBytes)}}}, result.Errors)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.validateItemsFunc = func(ctx context.Context, inputs []usecase.CreateItemInput) (*usecase.BatchValidationResult, error) {
+			return nil, domainErrors.ErrDatabaseError
+		}
+
+		rec, _ := postNDJSON(t, NewItemHandler(mockUsecase), ndjsonLine("A"))
+
+		assertProblem(t, rec, http.StatusInternalServerError, "about:blank")
+	})
+}
+
+func TestItemHandler_ExportNDJSON(t *testing.T) {
+	e := echo.New()
+
+	t.Run("stream reconstructs the item set", func(t *testing.T) {
+		// 1 ページに収まらない件数にして、カーソルで続きを取得することも確認する
+		all := make([]*entity.Item, ndjsonExportPageSize*2+3)
+		for i := range all {
+			all[i] = &entity.Item{ID: int64(i*2 + 1), Name: fmt.Sprintf("item %d", i), Category: "時計", Brand: "ROLEX", PurchasePrice: i * 100, PurchaseDate: "2023-01-15"}
+		}
+		var afterIDs []int64
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsAfterFunc = func(ctx context.Context, filter usecase.ItemFilter, afterID int64, limit int) ([]*entity.Item, bool, error) {
+			afterIDs = append(afterIDs, afterID)
+			page := []*entity.Item{}
+			for _, item := range all {
+				if item.ID > afterID && len(page) < limit {
+					page = append(page, item)
+				}
+			}
+			return page, len(page) > 0 && page[len(page)-1].ID < all[len(all)-1].ID, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/export.ndjson", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		require.NoError(t, NewItemHandler(mockUsecase).ExportNDJSON(c))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, MIMEApplicationNDJSON, rec.Header().Get(echo.HeaderContentType))
+		assert.True(t, rec.Flushed)
+		assert.Equal(t, len(all), strings.Count(rec.Body.String(), "\n"))
+
+		var got []*entity.Item
+		dec := json.NewDecoder(rec.Body)
+		for dec.More() {
+			var item entity.Item
+			require.NoError(t, dec.Decode(&item))
+			got = append(got, &item)
+		}
+		assert.Equal(t, all, got)
+		assert.Equal(t, []int64{0, all[ndjsonExportPageSize-1].ID, all[ndjsonExportPageSize*2-1].ID}, afterIDs)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsAfterFunc = func(ctx context.Context, filter usecase.ItemFilter, afterID int64, limit int) ([]*entity.Item, bool, error) {
+			return []*entity.Item{}, false, nil
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/export.ndjson", nil)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, NewItemHandler(mockUsecase).ExportNDJSON(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	})
+
+	t.Run("error before streaming", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemsAfterFunc = func(ctx context.Context, filter usecase.ItemFilter, afterID int64, limit int) ([]*entity.Item, bool, error) {
+			return nil, false, domainErrors.ErrUnauthenticated
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/export.ndjson", nil)
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, NewItemHandler(mockUsecase).ExportNDJSON(e.NewContext(req, rec)))
+		assertProblem(t, rec, http.StatusUnauthorized, "/problems/unauthorized")
+	})
+}
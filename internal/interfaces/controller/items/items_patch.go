@@ -0,0 +1,181 @@
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// PATCH /items/:id で受け付ける Content-Type（application/json 以外）
+const (
+	// RFC 7396 の JSON Merge Patch
+	MIMEApplicationMergePatchJSON = "application/merge-patch+json"
+	// RFC 6902 の JSON Patch
+	MIMEApplicationJSONPatchJSON = "application/json-patch+json"
+)
+
+// bindUpdateItemInput は PATCH のボディを Content-Type に応じて input にデコードする
+func bindUpdateItemInput(c echo.Context, input *usecase.UpdateItemInput) error {
+	contentType := c.Request().Header.Get(echo.HeaderContentType)
+	switch {
+	case strings.HasPrefix(contentType, MIMEApplicationMergePatchJSON):
+		return bindMergePatch(c, input)
+	case strings.HasPrefix(contentType, MIMEApplicationJSONPatchJSON):
+		return bindJSONPatch(c, input)
+	default:
+		return bindJSONStrict(c, input)
+	}
+}
+
+// bindMergePatch は JSON Merge Patch（RFC 7396）のボディを input にデコードする
+// 省略したフィールドは変更しない。null はフィールドの削除（既定値に戻す）を表すため、
+// 任意の warranty_expiry・notes・tags・image_url・attributes は値の削除、ほかの必須のフィールドはバリデーションエラーにする
+// version は更新対象ではなく前提条件のため、null は未指定として扱う
+func bindMergePatch(c echo.Context, input *usecase.UpdateItemInput) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return err
+	}
+	if err := decodeJSONStrict(bytes.NewReader(body), input); err != nil {
+		return err
+	}
+
+	// 省略と null を区別するため、メンバーの有無を改めて調べる
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(members))
+	for name, raw := range members {
+		if string(bytes.TrimSpace(raw)) != "null" {
+			continue
+		}
+		if name != "version" && !clearOptionalField(input, name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	verr := &domainErrors.ValidationError{}
+	for _, name := range names {
+		verr.Add(name, domainErrors.CodeCannotRemove)
+	}
+	return verr.Err()
+}
+
+// clearOptionalField は任意のフィールドを削除する値（既定値）にし、必須のフィールドの場合は false を返す
+func clearOptionalField(input *usecase.UpdateItemInput, name string) bool {
+	switch name {
+	case "warranty_expiry":
+		// UpdateItemInput では空文字が保証期限・メモ・画像の URL の削除を表す
+		input.WarrantyExpiry = new(string)
+	case "notes":
+		input.Notes = new(string)
+	case "image_url":
+		input.ImageURL = new(string)
+	case "tags":
+		// 空の配列はすべてのタグを外す
+		input.Tags = &[]string{}
+	case "attributes":
+		// 空のオブジェクトは追加情報の削除（値を指定した場合もメンバーごとにはマージせず置き換える）
+		input.Attributes = &map[string]interface{}{}
+	default:
+		return false
+	}
+	return true
+}
+
+// JSONPatchOperation は JSON Patch の 1 操作（from は未対応の操作のメンバーのため読み捨てる）
+type JSONPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+// JSON Patch で更新できるフィールドのパス（バリデーションエラーで指定できる値として返す）
+const patchablePaths = "/name, /brand, /purchase_price, /warranty_expiry, /notes, /image_url, /tags, /attributes"
+
+// bindJSONPatch は JSON Patch（RFC 6902）の操作を順に input に適用する
+// 対応する操作は replace と remove のみで、対象は更新できるフィールド（patchablePaths）に限る
+// remove と null への replace は JSON Merge Patch の null と同じく、任意のフィールドは値の削除、必須のフィールドはバリデーションエラーにする
+// 適用後の値は通常の PATCH と同じくドメインのバリデーションを通す
+func bindJSONPatch(c echo.Context, input *usecase.UpdateItemInput) error {
+	var ops []JSONPatchOperation
+	if err := decodeJSONStrict(c.Request().Body, &ops); err != nil {
+		return err
+	}
+
+	verr := &domainErrors.ValidationError{}
+	for i, op := range ops {
+		field := fmt.Sprintf("operations[%d]", i)
+		if op.Op != "replace" && op.Op != "remove" {
+			verr.Add(field+".op", domainErrors.CodeUnsupportedValue, "replace, remove", op.Op)
+			continue
+		}
+
+		name, ok := strings.CutPrefix(op.Path, "/")
+		if !ok || !isPatchableField(name) {
+			verr.Add(field+".path", domainErrors.CodeUnsupportedValue, patchablePaths, op.Path)
+			continue
+		}
+		if op.Op == "remove" {
+			if !clearOptionalField(input, name) {
+				verr.Add(name, domainErrors.CodeCannotRemove)
+			}
+			continue
+		}
+
+		if len(op.Value) == 0 {
+			verr.Add(field+".value", domainErrors.CodeRequired)
+			continue
+		}
+		if string(bytes.TrimSpace(op.Value)) == "null" {
+			if !clearOptionalField(input, name) {
+				verr.Add(field+".value", domainErrors.CodeRequired)
+			}
+			continue
+		}
+		if err := applyPatchValue(input, name, op.Value); err != nil {
+			verr.Add(name, domainErrors.CodeInvalidType)
+		}
+	}
+	return verr.Err()
+}
+
+func isPatchableField(name string) bool {
+	switch name {
+	case "name", "brand", "purchase_price", "warranty_expiry", "notes", "image_url", "tags", "attributes":
+		return true
+	}
+	return false
+}
+
+func applyPatchValue(input *usecase.UpdateItemInput, name string, value json.RawMessage) error {
+	switch name {
+	case "name":
+		return json.Unmarshal(value, &input.Name)
+	case "brand":
+		return json.Unmarshal(value, &input.Brand)
+	case "purchase_price":
+		return json.Unmarshal(value, &input.PurchasePrice)
+	case "warranty_expiry":
+		return json.Unmarshal(value, &input.WarrantyExpiry)
+	case "notes":
+		return json.Unmarshal(value, &input.Notes)
+	case "image_url":
+		return json.Unmarshal(value, &input.ImageURL)
+	case "tags":
+		return json.Unmarshal(value, &input.Tags)
+	default:
+		return json.Unmarshal(value, &input.Attributes)
+	}
+}
@@ -0,0 +1,31 @@
+package controller
+
+import (
+	"net/http"
+
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ValidateItems は POST /items と同じバリデーションで複数の入力を検証し、入力ごとの結果を 200 で返す（何も保存しない）
+// エラーのメッセージは Accept-Language の言語にする
+func (h *ItemHandler) ValidateItems(c echo.Context) error {
+	var inputs []usecase.CreateItemInput
+	if err := bindJSONStrict(c, &inputs); err != nil {
+		return writeBindError(c, err)
+	}
+
+	result, err := h.itemUsecase.ValidateItems(c.Request().Context(), inputs)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	lang := requestLanguage(c)
+	for i := range result.Items {
+		for j, f := range result.Items[i].Errors {
+			result.Items[i].Errors[j] = f.Localize(lang)
+		}
+	}
+	return c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// レスポンスの形式（Accept から選ぶ）
+type responseFormat int
+
+const (
+	formatJSON responseFormat = iota
+	formatXML
+)
+
+// Accept のメディアタイプごとの形式（ワイルドカードは JSON とする）
+var responseFormats = map[string]responseFormat{
+	echo.MIMEApplicationJSON: formatJSON,
+	echo.MIMEApplicationXML:  formatXML,
+	echo.MIMETextXML:         formatXML,
+	"application/*":          formatJSON,
+	"*/*":                    formatJSON,
+}
+
+// XML のアイテム（要素名を item にする）
+type xmlItem struct {
+	XMLName xml.Name `xml:"item"`
+	*entity.Item
+}
+
+// XML のアイテムの配列
+type xmlItemList struct {
+	XMLName xml.Name       `xml:"items"`
+	Items   []*entity.Item `xml:"item"`
+}
+
+// XML のカーソルページングのレスポンス
+type xmlItemPage struct {
+	XMLName    xml.Name       `xml:"page"`
+	Items      []*entity.Item `xml:"items>item"`
+	NextCursor string         `xml:"next_cursor"`
+}
+
+// negotiateFormat は Accept から、対応している形式のうち最も優先度（q）の高いものを返し、対応する形式がなければ false を返す
+// Accept がない場合は JSON とする。同じ優先度ではワイルドカードより具体的なメディアタイプを、具体的なものどうしは先に書かれたものを選ぶ
+// 形式によってレスポンスが変わるため、Vary: Accept を付ける
+func negotiateFormat(c echo.Context) (responseFormat, bool) {
+	c.Response().Header().Add(echo.HeaderVary, echo.HeaderAccept)
+
+	accept := strings.TrimSpace(c.Request().Header.Get(echo.HeaderAccept))
+	if accept == "" {
+		return formatJSON, true
+	}
+
+	format, best, found, wildcard := formatJSON, 0.0, false, false
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			if v, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				parsed, err := strconv.ParseFloat(v, 64)
+				if err != nil {
+					parsed = 0
+				}
+				q = parsed
+			}
+		}
+
+		mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+		f, ok := responseFormats[mediaType]
+		if !ok || q <= 0 {
+			continue
+		}
+		isWildcard := strings.HasSuffix(mediaType, "/*")
+		if !found || q > best || (q == best && wildcard && !isWildcard) {
+			format, best, found, wildcard = f, q, true, isWildcard
+		}
+	}
+	return format, found
+}
+
+// writeNotAcceptable は Accept に対応する形式がない場合の 406 を返す
+func writeNotAcceptable(c echo.Context) error {
+	return WriteProblem(c, NewProblem(http.StatusNotAcceptable, "supported media types are application/json and application/xml"))
+}
+
+// writeNegotiated は Accept が XML の場合は xmlBody を XML で、それ以外は writeJSON で 200 を返す
+func writeNegotiated(c echo.Context, xmlBody interface{}, writeJSON func() error) error {
+	format, ok := negotiateFormat(c)
+	if !ok {
+		return writeNotAcceptable(c)
+	}
+	if format == formatXML {
+		return c.XML(http.StatusOK, xmlBody)
+	}
+	return writeJSON()
+}
+
+// writeItems は Accept に合わせてアイテムの配列を JSON（fields を適用する）か XML で返す
+func writeItems(c echo.Context, items []*entity.Item) error {
+	return writeNegotiated(c, xmlItemList{Items: items}, func() error {
+		return jsonWithFields(c, http.StatusOK, items)
+	})
+}
+
+// marshalItem は format の形式でアイテムをシリアライズし、Content-Type とともに返す（JSON の場合は fields を適用する）
+func marshalItem(c echo.Context, format responseFormat, item *entity.Item) ([]byte, string, error) {
+	if format == formatXML {
+		body, err := xml.Marshal(xmlItem{Item: item})
+		if err != nil {
+			return nil, "", err
+		}
+		return append([]byte(xml.Header), body...), echo.MIMEApplicationXMLCharsetUTF8, nil
+	}
+
+	payload, err := applyFields(c, item)
+	if err != nil {
+		return nil, "", err
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, "", err
+	}
+	return body, echo.MIMEApplicationJSON, nil
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestItemHandler_ContentNegotiation(t *testing.T) {
+	e := echo.New()
+	createdAt := time.Date(2023, 1, 15, 10, 0, 0, 0, time.UTC)
+	newItem := func(id int64) *entity.Item {
+		return &entity.Item{
+			ID: id, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+			CreatedAt: createdAt, UpdatedAt: createdAt, Version: 1, Tags: []string{"限定", "箱付き"},
+			Attributes: map[string]interface{}{"movement": "自動巻き"},
+		}
+	}
+	mockUsecase := &mockItemUsecase{}
+	mockUsecase.getItemByIDFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+		return newItem(id), nil
+	}
+	mockUsecase.getItemsFunc = func(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, int, error) {
+		return []*entity.Item{newItem(1), newItem(2)}, 2, nil
+	}
+	handler := NewItemHandler(mockUsecase)
+
+	getItem := func(t *testing.T, accept string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		if accept != "" {
+			req.Header.Set(echo.HeaderAccept, accept)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+		require.NoError(t, handler.GetItem(c))
+		return rec
+	}
+
+	t.Run("item as XML", func(t *testing.T) {
+		rec := getItem(t, "application/xml")
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, echo.MIMEApplicationXMLCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, rec.Header().Values(echo.HeaderVary), echo.HeaderAccept)
+		assert.NotEmpty(t, rec.Header().Get(headerETag))
+		assert.True(t, strings.HasPrefix(rec.Body.String(), xml.Header))
+		assert.Contains(t, rec.Body.String(), "<item><id>1</id><name>時計1</name>")
+		assert.Contains(t, rec.Body.String(), "<tags><tag>限定</tag><tag>箱付き</tag></tags>")
+		assert.NotContains(t, rec.Body.String(), "movement")
+
+		var actual entity.Item
+		require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &actual))
+		expected := newItem(1)
+		expected.Attributes = nil
+		assert.Equal(t, expected, &actual)
+	})
+
+	t.Run("list as XML", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/items", nil)
+		req.Header.Set(echo.HeaderAccept, "text/xml")
+		rec := httptest.NewRecorder()
+
+		require.NoError(t, handler.GetItems(e.NewContext(req, rec)))
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "2", rec.Header().Get("X-Total-Count"))
+
+		var actual struct {
+			Items []entity.Item `xml:"item"`
+		}
+		require.NoError(t, xml.Unmarshal(rec.Body.Bytes(), &actual))
+		if assert.Len(t, actual.Items, 2) {
+			assert.Equal(t, int64(1), actual.Items[0].ID)
+			assert.Equal(t, int64(2), actual.Items[1].ID)
+		}
+	})
+
+	t.Run("JSON by default", func(t *testing.T) {
+		for _, accept := range []string{"", "*/*", echo.MIMEApplicationJSON, "application/xml;q=0.5, application/json", "text/html, */*;q=0.8"} {
+			rec := getItem(t, accept)
+
+			assert.Equal(t, http.StatusOK, rec.Code, accept)
+			assert.Equal(t, echo.MIMEApplicationJSON, rec.Header().Get(echo.HeaderContentType), accept)
+			var actual entity.Item
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &actual), accept)
+			assert.Equal(t, "自動巻き", actual.Attributes["movement"])
+		}
+	})
+
+	t.Run("XML preferred over a wildcard", func(t *testing.T) {
+		rec := getItem(t, "*/*, application/xml")
+
+		assert.Equal(t, echo.MIMEApplicationXMLCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+	})
+
+	t.Run("unsupported Accept", func(t *testing.T) {
+		for _, accept := range []string{"text/html", "application/json;q=0, application/xml;q=0"} {
+			rec := getItem(t, accept)
+
+			assertProblem(t, rec, http.StatusNotAcceptable, "/problems/not-acceptable")
+		}
+	})
+}
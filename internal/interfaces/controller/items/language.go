@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// バリデーションエラーのメッセージに対応している言語
+var supportedLanguages = map[string]bool{
+	domainErrors.LangEnglish:  true,
+	domainErrors.LangJapanese: true,
+}
+
+// requestLanguage は Accept-Language から、対応している言語のうち最も優先度（q）の高いものを返す
+// ja-JP などは基本の言語タグ（ja）で判定し、対応する言語がなければ英語とする
+func requestLanguage(c echo.Context) string {
+	lang, best := domainErrors.LangEnglish, 0.0
+	for _, part := range strings.Split(c.Request().Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+
+		base, _, _ := strings.Cut(strings.ToLower(tag), "-")
+		if supportedLanguages[base] && q > best {
+			lang, best = base, q
+		}
+	}
+	return lang
+}
+
+// localizeProblem はバリデーションエラーのメッセージを lang の言語にした Problem を返す
+func localizeProblem(problem Problem, lang string) Problem {
+	if lang == domainErrors.LangEnglish {
+		return problem
+	}
+	if problem.Detail == domainErrors.Message(domainErrors.LangEnglish, domainErrors.CodeValidationFailed) {
+		problem.Detail = domainErrors.Message(lang, domainErrors.CodeValidationFailed)
+	}
+	if len(problem.InvalidParams) > 0 {
+		params := make([]domainErrors.FieldError, len(problem.InvalidParams))
+		for i, f := range problem.InvalidParams {
+			params[i] = f.Localize(lang)
+		}
+		problem.InvalidParams = params
+	}
+	return problem
+}
@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RFC 7807 のエラーレスポンスの Content-Type
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// Problem は RFC 7807（problem+json）形式のエラーレスポンス
+type Problem struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+	// 以下は拡張メンバー
+	// 不正なパラメータなどの詳細
+	Errors []string `json:"errors,omitempty"`
+	// 入力のバリデーションエラー（フィールドと内容、検出順）
+	InvalidParams []domainErrors.FieldError `json:"invalid_params,omitempty"`
+	// CSV インポートで失敗した行
+	Rows []CSVRowError `json:"rows,omitempty"`
+	// ログと突き合わせるためのリクエスト ID（X-Request-ID と同じ値）
+	RequestID string `json:"request_id,omitempty"`
+}
+
+func (p *Problem) Error() string {
+	if p.Detail == "" {
+		return p.Title
+	}
+	return p.Title + ": " + p.Detail
+}
+
+type problemType struct {
+	uri   string
+	title string
+}
+
+// ステータスごとの type と title（一覧にないステータスは about:blank と標準のステータス文言）
+var problemTypes = map[int]problemType{
+	http.StatusBadRequest:            {uri: "/problems/invalid-input", title: "Invalid Input"},
+	http.StatusUnauthorized:          {uri: "/problems/unauthorized", title: "Unauthorized"},
+	http.StatusForbidden:             {uri: "/problems/forbidden", title: "Forbidden"},
+	http.StatusNotFound:              {uri: "/problems/not-found", title: "Not Found"},
+	http.StatusMethodNotAllowed:      {uri: "/problems/method-not-allowed", title: "Method Not Allowed"},
+	http.StatusNotAcceptable:         {uri: "/problems/not-acceptable", title: "Not Acceptable"},
+	http.StatusConflict:              {uri: "/problems/version-conflict", title: "Version Conflict"},
+	http.StatusRequestEntityTooLarge: {uri: "/problems/payload-too-large", title: "Payload Too Large"},
+	http.StatusTooManyRequests:       {uri: "/problems/too-many-requests", title: "Too Many Requests"},
+	http.StatusGatewayTimeout:        {uri: "/problems/timeout", title: "Timeout"},
+}
+
+// バージョンの不一致以外の 409（type で区別する）
+var (
+	// 同じカテゴリに同じ名前のアイテムがある
+	duplicateItemProblemType = problemType{uri: "/problems/duplicate-item", title: "Duplicate Item"}
+	// 同じ名前のカテゴリーがある
+	duplicateCategoryProblemType = problemType{uri: "/problems/duplicate-category", title: "Duplicate Category"}
+	// アイテムが登録されているカテゴリーは削除できない
+	categoryInUseProblemType = problemType{uri: "/problems/category-in-use", title: "Category In Use"}
+)
+
+// JSON として読めないボディの 400（バリデーションエラーの /problems/invalid-input と区別する）
+var malformedJSONProblemType = problemType{uri: "/problems/malformed-json", title: "Malformed JSON"}
+
+// NewProblem は status に対応する type・title の Problem を返す
+func NewProblem(status int, detail string, errs ...string) *Problem {
+	pt, ok := problemTypes[status]
+	if !ok {
+		pt = problemType{uri: "about:blank", title: http.StatusText(status)}
+	}
+	return &Problem{
+		Type:   pt.uri,
+		Title:  pt.title,
+		Status: status,
+		Detail: detail,
+		Errors: errs,
+	}
+}
+
+// ProblemFromError はエラーを Problem に変換する
+// ドメインエラーは種類に応じたステータスに対応付け、それ以外は内容を隠して 500 とする
+func ProblemFromError(err error) *Problem {
+	var problem *Problem
+	if errors.As(err, &problem) {
+		return problem
+	}
+
+	// ボディの読み込みが上限（bodyLimitMiddleware）で止められた
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return NewProblem(http.StatusRequestEntityTooLarge, fmt.Sprintf("request body must be %d bytes or less", maxBytesErr.Limit))
+	}
+
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		detail, _ := httpErr.Message.(string)
+		return NewProblem(httpErr.Code, detail)
+	}
+
+	if verr, ok := domainErrors.AsValidationError(err); ok {
+		problem := NewProblem(http.StatusBadRequest, domainErrors.Message(domainErrors.LangEnglish, domainErrors.CodeValidationFailed))
+		problem.InvalidParams = verr.Fields
+		return problem
+	}
+
+	switch {
+	case domainErrors.IsNotFoundError(err):
+		return NewProblem(http.StatusNotFound, err.Error())
+	case domainErrors.IsUnauthenticatedError(err):
+		return NewProblem(http.StatusUnauthorized, err.Error())
+	case domainErrors.IsValidationError(err):
+		return NewProblem(http.StatusBadRequest, err.Error())
+	case domainErrors.IsVersionConflictError(err):
+		return NewProblem(http.StatusConflict, err.Error())
+	case domainErrors.IsDuplicateItemError(err):
+		return conflictProblem(duplicateItemProblemType, err)
+	case domainErrors.IsDuplicateCategoryError(err):
+		return conflictProblem(duplicateCategoryProblemType, err)
+	case domainErrors.IsCategoryInUseError(err):
+		return conflictProblem(categoryInUseProblemType, err)
+	case domainErrors.IsTimeoutError(err):
+		// データベースのエラー内容は返さない
+		return NewProblem(http.StatusGatewayTimeout, "the database did not respond in time")
+	}
+
+	return NewProblem(http.StatusInternalServerError, "internal server error")
+}
+
+// conflictProblem は pt の type・title の 409 を返す
+func conflictProblem(pt problemType, err error) *Problem {
+	problem := NewProblem(http.StatusConflict, err.Error())
+	problem.Type, problem.Title = pt.uri, pt.title
+	return problem
+}
+
+// WriteProblem は problem+json でエラーレスポンスを返す
+// instance にはリクエストのパス、request_id には context のリクエスト ID を設定する
+// バリデーションエラーのメッセージは Accept-Language の言語で返す
+func WriteProblem(c echo.Context, problem *Problem) error {
+	res := localizeProblem(*problem, requestLanguage(c))
+	res.Instance = c.Request().URL.Path
+	res.RequestID = usecase.RequestIDFromContext(c.Request().Context())
+
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	return c.JSON(res.Status, res)
+}
+
+// writeError は err をドメインエラーの種類に応じた Problem で返す
+func writeError(c echo.Context, err error) error {
+	return WriteProblem(c, ProblemFromError(err))
+}
+
+// ErrorHandler は Echo の HTTPErrorHandler として、ハンドラーから返されたエラー
+// （未登録のルートや許可されていないメソッドを含む）を problem+json で返す
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	problem := ProblemFromError(err)
+	if problem.Status >= http.StatusInternalServerError {
+		c.Logger().Error(err)
+	}
+	// Allow ヘッダーはルーターがそのパスに登録されたメソッドから設定している
+	if allow := c.Response().Header().Get(echo.HeaderAllow); problem.Status == http.StatusMethodNotAllowed && allow != "" {
+		withAllow := *problem
+		withAllow.Detail = fmt.Sprintf("method %s is not allowed; allowed methods: %s", c.Request().Method, allow)
+		problem = &withAllow
+	}
+
+	if c.Request().Method == http.MethodHead {
+		c.NoContent(problem.Status)
+		return
+	}
+	if werr := WriteProblem(c, problem); werr != nil {
+		c.Logger().Error(werr)
+	}
+}
@@ -0,0 +1,338 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestProblemFromError(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+		expectedType   string
+		expectedTitle  string
+		expectedDetail string
+	}{
+		{
+			name:           "not found",
+			err:            fmt.Errorf("%w: id 10", domainErrors.ErrItemNotFound),
+			expectedStatus: http.StatusNotFound,
+			expectedType:   "/problems/not-found",
+			expectedTitle:  "Not Found",
+			expectedDetail: "item not found: id 10",
+		},
+		{
+			name:           "unauthenticated",
+			err:            domainErrors.ErrUnauthenticated,
+			expectedStatus: http.StatusUnauthorized,
+			expectedType:   "/problems/unauthorized",
+			expectedTitle:  "Unauthorized",
+			expectedDetail: "authentication required",
+		},
+		{
+			name:           "invalid input",
+			err:            fmt.Errorf("%w: name is required", domainErrors.ErrInvalidInput),
+			expectedStatus: http.StatusBadRequest,
+			expectedType:   "/problems/invalid-input",
+			expectedTitle:  "Invalid Input",
+			expectedDetail: "invalid input: name is required",
+		},
+		{
+			name:           "version conflict",
+			err:            domainErrors.ErrVersionConflict,
+			expectedStatus: http.StatusConflict,
+			expectedType:   "/problems/version-conflict",
+			expectedTitle:  "Version Conflict",
+			expectedDetail: "version conflict",
+		},
+		{
+			name:           "duplicate item",
+			err:            fmt.Errorf("failed to create item: %w: an item with the same name already exists in the category", domainErrors.ErrDuplicateItem),
+			expectedStatus: http.StatusConflict,
+			expectedType:   "/problems/duplicate-item",
+			expectedTitle:  "Duplicate Item",
+			expectedDetail: "failed to create item: duplicate item: an item with the same name already exists in the category",
+		},
+		{
+			name:           "duplicate category",
+			err:            domainErrors.ErrDuplicateCategory,
+			expectedStatus: http.StatusConflict,
+			expectedType:   "/problems/duplicate-category",
+			expectedTitle:  "Duplicate Category",
+			expectedDetail: "duplicate category",
+		},
+		{
+			name:           "category in use",
+			err:            domainErrors.ErrCategoryInUse,
+			expectedStatus: http.StatusConflict,
+			expectedType:   "/problems/category-in-use",
+			expectedTitle:  "Category In Use",
+			expectedDetail: "category in use",
+		},
+		{
+			name:           "body over the limit",
+			err:            fmt.Errorf("decode: %w", &http.MaxBytesError{Limit: 1024}),
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedType:   "/problems/payload-too-large",
+			expectedTitle:  "Payload Too Large",
+			expectedDetail: "request body must be 1024 bytes or less",
+		},
+		{
+			name:           "database error is hidden",
+			err:            fmt.Errorf("%w: connection refused", domainErrors.ErrDatabaseError),
+			expectedStatus: http.StatusInternalServerError,
+			expectedType:   "about:blank",
+			expectedTitle:  "Internal Server Error",
+			expectedDetail: "internal server error",
+		},
+		{
+			name:           "timeout",
+			err:            fmt.Errorf("%w: context deadline exceeded", domainErrors.ErrTimeout),
+			expectedStatus: http.StatusGatewayTimeout,
+			expectedType:   "/problems/timeout",
+			expectedTitle:  "Timeout",
+			expectedDetail: "the database did not respond in time",
+		},
+		{
+			name:           "echo http error",
+			err:            echo.ErrMethodNotAllowed,
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedType:   "/problems/method-not-allowed",
+			expectedTitle:  "Method Not Allowed",
+			expectedDetail: "Method Not Allowed",
+		},
+		{
+			name:           "status without a problem type",
+			err:            echo.ErrUnsupportedMediaType,
+			expectedStatus: http.StatusUnsupportedMediaType,
+			expectedType:   "about:blank",
+			expectedTitle:  "Unsupported Media Type",
+			expectedDetail: "Unsupported Media Type",
+		},
+		{
+			name:           "wrapped problem is kept",
+			err:            fmt.Errorf("wrapped: %w", NewProblem(http.StatusTooManyRequests, "slow down")),
+			expectedStatus: http.StatusTooManyRequests,
+			expectedType:   "/problems/too-many-requests",
+			expectedTitle:  "Too Many Requests",
+			expectedDetail: "slow down",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := ProblemFromError(tt.err)
+
+			assert.Equal(t, tt.expectedStatus, problem.Status)
+			assert.Equal(t, tt.expectedType, problem.Type)
+			assert.Equal(t, tt.expectedTitle, problem.Title)
+			assert.Equal(t, tt.expectedDetail, problem.Detail)
+		})
+	}
+}
+
+func TestItemHandler_ProblemResponses(t *testing.T) {
+	e := echo.New()
+
+	t.Run("item not found", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemByIDFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/999", nil)
+		req = req.WithContext(usecase.ContextWithRequestID(req.Context(), "req-1"))
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("999")
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetItem(c))
+
+		problem := assertProblem(t, rec, http.StatusNotFound, "/problems/not-found")
+		assert.Equal(t, "Not Found", problem.Title)
+		assert.Equal(t, "item not found", problem.Detail)
+		assert.Equal(t, "/items/999", problem.Instance)
+		assert.Equal(t, "req-1", problem.RequestID)
+	})
+
+	t.Run("duplicate item", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.createItemFunc = func(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+			return nil, fmt.Errorf("%w: an item with the same name already exists in the category", domainErrors.ErrDuplicateItem)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"name":"rolex","category":"時計","brand":"ROLEX","purchase_price":1000,"purchase_date":"2023-01-15"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+
+		assert.NoError(t, NewItemHandler(mockUsecase).CreateItem(e.NewContext(req, rec)))
+
+		problem := assertProblem(t, rec, http.StatusConflict, "/problems/duplicate-item")
+		assert.Equal(t, "Duplicate Item", problem.Title)
+		assert.Empty(t, rec.Header().Get(echo.HeaderLocation))
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.createItemFunc = func(ctx context.Context, input usecase.CreateItemInput) (*entity.Item, error) {
+			t.Error("usecase should not be called for an invalid item")
+			return nil, nil
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(`{"name":"","category":"時計","brand":"ROLEX","purchase_price":-1,"purchase_date":"2023-01-15"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+
+		assert.NoError(t, NewItemHandler(mockUsecase).CreateItem(c))
+
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, "Invalid Input", problem.Title)
+		assert.Equal(t, "validation failed", problem.Detail)
+		assert.Equal(t, []domainErrors.FieldError{
+			{Field: "name", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "purchase_price", Code: domainErrors.CodeNegative, Message: "must be 0 or greater"},
+		}, problem.InvalidParams)
+	})
+
+	t.Run("usecase validation error is rendered as invalid_params", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.updateItemFunc = func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+			verr := &domainErrors.ValidationError{}
+			verr.Add("name", domainErrors.CodeTooLong, 100)
+			verr.Add("brand", domainErrors.CodeTooLong, 100)
+			return nil, verr
+		}
+
+		req := httptest.NewRequest(http.MethodPatch, "/items/1", strings.NewReader(`{"name":"a","brand":"b"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		assert.NoError(t, NewItemHandler(mockUsecase).UpdateItem(c))
+
+		assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Contains(t, rec.Body.String(), `"invalid_params":[{"field":"name","code":"too_long","message":"must be 100 characters or less"},{"field":"brand","code":"too_long","message":"must be 100 characters or less"}]`)
+	})
+
+	t.Run("internal error hides the cause", func(t *testing.T) {
+		mockUsecase := &mockItemUsecase{}
+		mockUsecase.getItemByIDFunc = func(ctx context.Context, id int64) (*entity.Item, error) {
+			return nil, errors.New("dial tcp: connection refused")
+		}
+
+		req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		assert.NoError(t, NewItemHandler(mockUsecase).GetItem(c))
+
+		problem := assertProblem(t, rec, http.StatusInternalServerError, "about:blank")
+		assert.NotContains(t, rec.Body.String(), "connection refused")
+		assert.Equal(t, "internal server error", problem.Detail)
+	})
+}
+
+func TestItemHandler_LocalizedValidationErrors(t *testing.T) {
+	e := echo.New()
+	mockUsecase := &mockItemUsecase{}
+	mockUsecase.updateItemFunc = func(ctx context.Context, id int64, input usecase.UpdateItemInput) (*entity.Item, error) {
+		verr := &domainErrors.ValidationError{}
+		verr.Add("name", domainErrors.CodeTooLong, 50)
+		verr.Add("purchase_price", domainErrors.CodeNegative)
+		return nil, verr
+	}
+
+	update := func(t *testing.T, acceptLanguage string) Problem {
+		req := httptest.NewRequest(http.MethodPatch, "/items/1", strings.NewReader(`{"name":"a","purchase_price":1}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if acceptLanguage != "" {
+			req.Header.Set("Accept-Language", acceptLanguage)
+		}
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.SetPath("/items/:id")
+		c.SetParamNames("id")
+		c.SetParamValues("1")
+
+		assert.NoError(t, NewItemHandler(mockUsecase).UpdateItem(c))
+		return assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+	}
+
+	english := []domainErrors.FieldError{
+		{Field: "name", Code: domainErrors.CodeTooLong, Message: "must be 50 characters or less"},
+		{Field: "purchase_price", Code: domainErrors.CodeNegative, Message: "must be 0 or greater"},
+	}
+	japanese := []domainErrors.FieldError{
+		{Field: "name", Code: domainErrors.CodeTooLong, Message: "50 文字以内で入力してください"},
+		{Field: "purchase_price", Code: domainErrors.CodeNegative, Message: "0 以上の値を入力してください"},
+	}
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		detail         string
+		want           []domainErrors.FieldError
+	}{
+		{name: "no header is english", detail: "validation failed", want: english},
+		{name: "japanese", acceptLanguage: "ja", detail: "入力内容に誤りがあります", want: japanese},
+		{name: "region subtag", acceptLanguage: "ja-JP,ja;q=0.9,en-US;q=0.8", detail: "入力内容に誤りがあります", want: japanese},
+		{name: "highest quality wins", acceptLanguage: "ja;q=0.5, en;q=0.9", detail: "validation failed", want: english},
+		{name: "unknown locale falls back to english", acceptLanguage: "fr-FR, de;q=0.8", detail: "validation failed", want: english},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := update(t, tt.acceptLanguage)
+
+			assert.Equal(t, "Invalid Input", problem.Title)
+			assert.Equal(t, tt.detail, problem.Detail)
+			assert.Equal(t, tt.want, problem.InvalidParams)
+		})
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	e.GET("/items/:id", func(c echo.Context) error {
+		return fmt.Errorf("%w: id must be positive", domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("returned domain error", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/items/0", nil))
+
+		problem := assertProblem(t, rec, http.StatusBadRequest, "/problems/invalid-input")
+		assert.Equal(t, "invalid input: id must be positive", problem.Detail)
+	})
+
+	t.Run("unknown route", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/unknown", nil))
+
+		problem := assertProblem(t, rec, http.StatusNotFound, "/problems/not-found")
+		assert.Equal(t, "/unknown", problem.Instance)
+	})
+}
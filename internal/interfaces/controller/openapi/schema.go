@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+)
+
+// schemaRegistry は Go の型から JSON Schema を生成し、構造体は components に登録して $ref で参照する
+type schemaRegistry struct {
+	schemas map[string]*Schema
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{schemas: make(map[string]*Schema)}
+}
+
+// ref は v の型を登録し、その参照を返す
+func (r *schemaRegistry) ref(v interface{}) *Schema {
+	return r.schemaOf(reflect.TypeOf(v))
+}
+
+// arrayOf は v の型の配列スキーマを返す
+func (r *schemaRegistry) arrayOf(v interface{}) *Schema {
+	return &Schema{Type: "array", Items: r.ref(v)}
+}
+
+func (r *schemaRegistry) schemaOf(t reflect.Type) *Schema {
+	if t.Kind() == reflect.Ptr {
+		schema := r.schemaOf(t.Elem())
+		if schema.Ref == "" {
+			schema.Nullable = true
+		}
+		return schema
+	}
+
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		// 任意の JSON 値
+		return &Schema{}
+	case t.Kind() == reflect.Struct:
+		name := t.Name()
+		if _, ok := r.schemas[name]; !ok {
+			// 自己参照に備えて先に登録する
+			schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+			r.schemas[name] = schema
+			r.addProperties(schema, t)
+		}
+		return &Schema{Ref: "#/components/schemas/" + name}
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		return &Schema{Type: "array", Items: r.schemaOf(t.Elem())}
+	case t.Kind() == reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: r.schemaOf(t.Elem())}
+	case t.Kind() == reflect.String:
+		return &Schema{Type: "string"}
+	case t.Kind() == reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case t.Kind() == reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		return &Schema{Type: "number"}
+	default:
+		return &Schema{}
+	}
+}
+
+func (r *schemaRegistry) addProperties(schema *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		// json タグのない埋め込み構造体は encoding/json と同じくフィールドを展開する
+		if field.Anonymous && field.Tag.Get("json") == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				r.addProperties(schema, embedded)
+				continue
+			}
+		}
+
+		name, ok := jsonFieldName(field)
+		if !ok {
+			continue
+		}
+		schema.Properties[name] = r.schemaOf(field.Type)
+	}
+}
+
+// json タグからフィールド名を取り出す。"-" の場合は ok=false
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	name := strings.Split(tag, ",")[0]
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
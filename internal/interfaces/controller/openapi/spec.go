@@ -0,0 +1,594 @@
+package openapi
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+
+	"Aicon-assignment/internal/domain/entity"
+	controller "Aicon-assignment/internal/interfaces/controller/items"
+	"Aicon-assignment/internal/usecase"
+)
+
+const (
+	title   = "所持品管理API"
+	version = "1.0.0"
+)
+
+var pathParamPattern = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// 書き込み系のアイテム・カテゴリーのエンドポイントで要求する JWT 認証のスキーム名
+const bearerAuth = "bearerAuth"
+
+// /admin/maintenance で要求する管理者トークン（ADMIN_TOKEN）のスキーム名
+const adminAuth = "adminAuth"
+
+// Build はルーターに登録されたルートから OpenAPI ドキュメントを生成する
+// パスとメソッドは routes から取得し、説明・パラメータ・スキーマは operations の定義で補う
+func Build(routes []*echo.Route) *Document {
+	registry := newSchemaRegistry()
+	defined := operations(registry)
+
+	paths := make(map[string]PathItem)
+	for _, route := range sortedRoutes(routes) {
+		key := route.Method + " " + route.Path
+		op, ok := defined[key]
+		if !ok {
+			// 定義のないルートも一覧には載せる
+			op = &Operation{Responses: map[string]Response{"200": {Description: "OK"}}}
+		}
+
+		path := toOpenAPIPath(route.Path)
+		if paths[path] == nil {
+			paths[path] = PathItem{}
+		}
+		if params := pathParamPattern.FindAllStringSubmatch(route.Path, -1); len(params) > 0 {
+			op = withPathParams(op, params)
+		}
+		paths[path][strings.ToLower(route.Method)] = op
+	}
+
+	return &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Version: version},
+		Paths:   paths,
+		Components: Components{
+			Schemas: registry.schemas,
+			SecuritySchemes: map[string]*SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+				adminAuth:  {Type: "http", Scheme: "bearer"},
+			},
+		},
+	}
+}
+
+// NewHandler は e に登録済みのルートからドキュメントを生成して返すハンドラーを作る
+// ルートはリクエスト時に取得するため、登録順に関係なく全ルートが含まれる
+func NewHandler(e *echo.Echo) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return c.JSON(http.StatusOK, Build(e.Routes()))
+	}
+}
+
+// Echo の内部ルート（RouteNotFound）を除き、出力が安定するように並べ替える
+func sortedRoutes(routes []*echo.Route) []*echo.Route {
+	sorted := make([]*echo.Route, 0, len(routes))
+	for _, route := range routes {
+		if route.Method == echo.RouteNotFound {
+			continue
+		}
+		sorted = append(sorted, route)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Path != sorted[j].Path {
+			return sorted[i].Path < sorted[j].Path
+		}
+		return sorted[i].Method < sorted[j].Method
+	})
+	return sorted
+}
+
+// /items/:id -> /items/{id}
+func toOpenAPIPath(path string) string {
+	return pathParamPattern.ReplaceAllString(path, "{$1}")
+}
+
+func withPathParams(op *Operation, params [][]string) *Operation {
+	copied := *op
+	copied.Parameters = nil
+	for _, param := range params {
+		copied.Parameters = append(copied.Parameters, Parameter{
+			Name:     param[1],
+			In:       "path",
+			Required: true,
+			Schema:   &Schema{Type: "integer", Format: "int64"},
+		})
+	}
+	copied.Parameters = append(copied.Parameters, op.Parameters...)
+	return &copied
+}
+
+// operations は "METHOD /path"（Echo の形式）ごとのオペレーション定義
+func operations(r *schemaRegistry) map[string]*Operation {
+	item := r.ref(entity.Item{})
+	items := r.arrayOf(entity.Item{})
+	problem := r.ref(controller.Problem{})
+
+	jsonBody := func(schema *Schema) *RequestBody {
+		return &RequestBody{Required: true, Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: schema}}}
+	}
+	jsonResponse := func(description string, schema *Schema) Response {
+		return Response{Description: description, Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: schema}}}
+	}
+	problemResponse := func(description string) Response {
+		return Response{Description: description, Content: map[string]MediaType{controller.MIMEApplicationProblemJSON: {Schema: problem}}}
+	}
+	badRequest := problemResponse("Bad Request")
+	notFound := problemResponse("Not Found")
+	conflict := problemResponse("Conflict")
+	tooManyRequests := Response{
+		Description: "Too Many Requests（レート制限）",
+		Headers:     map[string]Header{"Retry-After": {Description: "再試行できるまでの秒数", Schema: &Schema{Type: "integer"}}},
+		Content:     map[string]MediaType{controller.MIMEApplicationProblemJSON: {Schema: problem}},
+	}
+	maintenanceStatus := &Schema{Type: "object", Properties: map[string]*Schema{"enabled": {Type: "boolean"}}}
+	totalCount := map[string]Header{
+		"X-Total-Count": {Description: "条件に一致する全件数", Schema: &Schema{Type: "integer"}},
+	}
+
+	pagination := []Parameter{
+		query("limit", "取得件数", &Schema{Type: "integer"}),
+		query("offset", "取得開始位置", &Schema{Type: "integer"}),
+	}
+	filters := []Parameter{
+		query("category", "カテゴリーで絞り込み（複数指定・カンマ区切りでいずれかに一致）", &Schema{Type: "string"}),
+		query("category_not", "除外するカテゴリー（複数指定・カンマ区切り）", &Schema{Type: "string"}),
+		query("brand", "ブランドで絞り込み（完全一致）", &Schema{Type: "string"}),
+		query("tag", "タグで絞り込み（複数指定・カンマ区切り）", &Schema{Type: "string"}),
+		query("tag_mode", "複数のタグの組み合わせ方（all または any、既定は all）", &Schema{Type: "string"}),
+		query("attr", "追加情報の値で絞り込み（key:value、入れ子は . 区切り、繰り返し指定で AND）", &Schema{Type: "string"}),
+		query("q", "名前の部分一致検索", &Schema{Type: "string"}),
+		query("min_price", "購入価格の下限（両端を含む）", &Schema{Type: "integer"}),
+		query("max_price", "購入価格の上限（両端を含む）", &Schema{Type: "integer"}),
+		query("purchased_after", "購入日の下限（RFC3339 または YYYY-MM-DD）", &Schema{Type: "string"}),
+		query("purchased_before", "購入日の上限（RFC3339 または YYYY-MM-DD）", &Schema{Type: "string"}),
+	}
+	fields := query("fields", "返すフィールド（カンマ区切り）", &Schema{Type: "string"})
+
+	listParams := append(append([]Parameter{}, pagination...), filters...)
+	listParams = append(listParams,
+		query("sort", "並び替え（purchase_date / purchase_price / created_at / category、先頭の - で降順。カンマ区切りで複数指定すると前から順に優先）", &Schema{Type: "string"}),
+		query("cursor", "キーセットページングのカーソル（指定時はレスポンスが ItemPageResponse になる）", &Schema{Type: "string"}),
+		query("ids", "取得する ID（カンマ区切り、最大 100 件）。指定順で返し、見つからない ID は除く。fields 以外のパラメータは無視する", &Schema{Type: "string"}),
+		fields,
+	)
+
+	// ItemPageResponse をスキーマに登録しておく（cursor 指定時のレスポンス）
+	r.ref(controller.ItemPageResponse{})
+
+	ops := map[string]*Operation{
+		"GET /health": {
+			Summary:   "ヘルスチェック",
+			Responses: map[string]Response{"200": {Description: "OK"}},
+		},
+		"GET /docs": {
+			Summary:   "Swagger UI",
+			Responses: map[string]Response{"200": {Description: "OK", Content: map[string]MediaType{echo.MIMETextHTML: {Schema: &Schema{Type: "string"}}}}},
+		},
+		"GET /metrics": {
+			Summary:   "Prometheus メトリクス",
+			Responses: map[string]Response{"200": {Description: "OK", Content: map[string]MediaType{"text/plain": {Schema: &Schema{Type: "string"}}}}},
+		},
+		"GET /healthz": {
+			Summary:   "liveness プローブ",
+			Responses: map[string]Response{"200": {Description: "OK"}},
+		},
+		"GET /readyz": {
+			Summary: "readiness プローブ（データベースに接続できない場合は 503）",
+			Responses: map[string]Response{
+				"200": {Description: "OK"},
+				"503": {Description: "Service Unavailable"},
+			},
+		},
+		"GET /admin/maintenance": {
+			Summary:  "メンテナンスモードの状態",
+			Security: []map[string][]string{{adminAuth: {}}},
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", maintenanceStatus),
+				"401": problemResponse("Unauthorized"),
+				"403": problemResponse("Forbidden"),
+			},
+		},
+		"PUT /admin/maintenance": {
+			Summary:     "メンテナンスモードの切り替え（メンテナンス中は書き込み系のエンドポイントが 503 になる）",
+			Security:    []map[string][]string{{adminAuth: {}}},
+			RequestBody: jsonBody(maintenanceStatus),
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", maintenanceStatus),
+				"400": badRequest,
+				"401": problemResponse("Unauthorized"),
+				"403": problemResponse("Forbidden"),
+			},
+		},
+		"GET /openapi.json": {
+			Summary:   "この API の OpenAPI ドキュメント",
+			Responses: map[string]Response{"200": {Description: "OK"}},
+		},
+		"GET /categories": {
+			Summary:   "登録できるカテゴリーの一覧（ID 順）",
+			Responses: map[string]Response{"200": jsonResponse("OK", r.arrayOf(entity.Category{}))},
+		},
+		"POST /categories": {
+			Summary:     "カテゴリー追加（大文字・小文字だけが異なる名前も重複として 409）",
+			RequestBody: jsonBody(r.ref(usecase.CreateCategoryInput{})),
+			Responses: map[string]Response{
+				"201": jsonResponse("Created", r.ref(entity.Category{})),
+				"400": badRequest,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"DELETE /categories/:id": {
+			Summary: "カテゴリー削除（論理削除されていないアイテムが登録されている場合は 409）",
+			Responses: map[string]Response{
+				"204": {Description: "No Content"},
+				"400": badRequest,
+				"404": notFound,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"POST /categories/rename": {
+			Summary:     "カテゴリー名の変更（論理削除されていないアイテムのカテゴリーも変更する。別の既存のカテゴリーへの統合はせず 409）",
+			RequestBody: jsonBody(r.ref(usecase.RenameCategoryInput{})),
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.ref(usecase.RenameCategoryResult{})),
+				"400": badRequest,
+				"404": notFound,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"GET /items": {
+			Summary:    "アイテム一覧",
+			Parameters: listParams,
+			Responses: map[string]Response{
+				"200": {Description: "OK", Headers: totalCount, Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: items}}},
+				"400": badRequest,
+			},
+		},
+		"POST /items": {
+			Summary: "アイテム登録",
+			Parameters: []Parameter{
+				{Name: "Idempotency-Key", In: "header", Description: "同じキーでの再送は最初に作成したアイテムを返す", Schema: &Schema{Type: "string"}},
+				query("dry_run", "true の場合はバリデーションのみ行い、保存せずに 200 で登録される内容を返す（X-Dry-Run ヘッダーでも指定可）", &Schema{Type: "boolean"}),
+			},
+			RequestBody: jsonBody(r.ref(usecase.CreateItemInput{})),
+			Responses: map[string]Response{
+				"200": jsonResponse("OK（dry run、保存していない）", r.ref(controller.DryRunResult{})),
+				"201": {Description: "Created", Headers: map[string]Header{"Location": {Description: "作成したアイテムの URL", Schema: &Schema{Type: "string"}}}, Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: item}}},
+				"400": badRequest,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"DELETE /items": {
+			Summary:     "アイテム一括削除（論理削除）",
+			RequestBody: jsonBody(r.ref(controller.DeleteItemsRequest{})),
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.ref(usecase.BulkDeleteResult{})),
+				"400": badRequest,
+				"404": notFound,
+				"429": tooManyRequests,
+			},
+		},
+		"POST /items/move": {
+			Summary:     "アイテムのカテゴリー一括変更（存在しない ID は not_found、1 件も存在しない場合は 404、変更先で名前が重複する場合は 409 で 1 件も変更しない）",
+			RequestBody: jsonBody(r.ref(usecase.MoveItemsInput{})),
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.ref(usecase.BulkMoveResult{})),
+				"400": badRequest,
+				"404": notFound,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"POST /items/bulk": {
+			Summary:     "アイテム一括登録",
+			RequestBody: jsonBody(r.arrayOf(usecase.CreateItemInput{})),
+			Responses: map[string]Response{
+				"201": jsonResponse("Created", items),
+				"400": badRequest,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"POST /items/validate": {
+			Summary:     "アイテム一括バリデーション（保存しない）",
+			RequestBody: jsonBody(r.arrayOf(usecase.CreateItemInput{})),
+			Responses: map[string]Response{
+				"200": jsonResponse("OK（入力ごとの結果）", r.ref(usecase.BatchValidationResult{})),
+				"400": badRequest,
+				"429": tooManyRequests,
+			},
+		},
+		"POST /items/import": {
+			Summary: "CSV インポート",
+			RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{
+				"text/csv":             {Schema: &Schema{Type: "string"}},
+				echo.MIMEMultipartForm: {Schema: &Schema{Type: "object", Properties: map[string]*Schema{"file": {Type: "string", Format: "binary"}}}},
+			}},
+			Responses: map[string]Response{
+				"201": jsonResponse("Created", items),
+				"400": badRequest,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"POST /items/import-ndjson": {
+			Summary: "NDJSON インポート（1 行に 1 つのアイテムを読みながら 100 行ずつ登録し、不正な行は行番号とともに errors で返す）",
+			RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{
+				controller.MIMEApplicationNDJSON: {Schema: &Schema{Type: "string"}},
+			}},
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.ref(controller.NDJSONImportResult{})),
+				"429": tooManyRequests,
+			},
+		},
+		"GET /items/export.csv": {
+			Summary:    "CSV エクスポート",
+			Parameters: filters,
+			Responses: map[string]Response{
+				"200": {Description: "OK", Content: map[string]MediaType{"text/csv": {Schema: &Schema{Type: "string"}}}},
+				"400": badRequest,
+			},
+		},
+		"GET /items/export.ndjson": {
+			Summary: "NDJSON エクスポート（ID 順に 1 行に 1 件）",
+			Responses: map[string]Response{
+				"200": {Description: "OK", Content: map[string]MediaType{controller.MIMEApplicationNDJSON: {Schema: &Schema{Type: "string"}}}},
+			},
+		},
+		"GET /items/deleted": {
+			Summary:    "論理削除されたアイテム一覧",
+			Parameters: pagination,
+			Responses: map[string]Response{
+				"200": {Description: "OK", Headers: totalCount, Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: items}}},
+				"400": badRequest,
+			},
+		},
+		"GET /items/changes": {
+			Summary: "since 以降に登録・更新・論理削除・復元されたアイテム（差分同期用、更新日時の古い順）",
+			Parameters: []Parameter{
+				{Name: "since", In: "query", Description: "変更日時の下限（RFC3339、この日時ちょうどの変更を含む）", Required: true, Schema: &Schema{Type: "string", Format: "date-time"}},
+			},
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.arrayOf(controller.ItemChange{})),
+				"400": badRequest,
+			},
+		},
+		"GET /items/count": {
+			Summary:    "アイテム数（一覧と同じ絞り込み条件）",
+			Parameters: filters,
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.ref(controller.ItemCountResponse{})),
+				"400": badRequest,
+			},
+		},
+		"GET /items/events": {
+			Summary: "アイテムの作成・更新・削除を Server-Sent Events で配信（イベント名は item.created / item.updated / item.deleted、data はイベントの JSON）",
+			Responses: map[string]Response{
+				"200": {Description: "イベントストリーム（15 秒ごとにコメント行のハートビートを送る）", Content: map[string]MediaType{controller.MIMETextEventStream: {Schema: &Schema{Type: "string"}}}},
+				"503": problemResponse("イベントの配信が無効"),
+			},
+		},
+		"GET /items/recent": {
+			Summary: "最近登録したアイテム（登録日時の新しい順）",
+			Parameters: []Parameter{
+				query("limit", "取得件数（デフォルト 5、50 を超える値は 50）", &Schema{Type: "integer"}),
+				fields,
+			},
+			Responses: map[string]Response{
+				"200": {Description: "OK", Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: items}}},
+				"400": badRequest,
+			},
+		},
+		"GET /items/top": {
+			Summary: "購入価格の高いアイテム（価格の高い順、同じ価格は ID 順）",
+			Parameters: []Parameter{
+				query("n", "取得件数（デフォルト 10、100 を超える値は 100）", &Schema{Type: "integer"}),
+				query("category", "カテゴリーで絞り込み", &Schema{Type: "string"}),
+				fields,
+			},
+			Responses: map[string]Response{
+				"200": {Description: "OK", Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: items}}},
+				"400": badRequest,
+			},
+		},
+		"GET /items/warranty/expiring": {
+			Summary: "保証期限が今日から days 日後までのアイテム（保証期限の近い順、期限切れは含まない）",
+			Parameters: []Parameter{
+				query("days", "日数（デフォルト 30、0〜365）", &Schema{Type: "integer"}),
+				fields,
+			},
+			Responses: map[string]Response{
+				"200": {Description: "OK", Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: items}}},
+				"400": badRequest,
+			},
+		},
+		"GET /items/summary": {
+			Summary: "カテゴリー別集計",
+			Parameters: []Parameter{
+				query("from", "購入日の下限（RFC3339 または YYYY-MM-DD）", &Schema{Type: "string"}),
+				query("to", "購入日の上限（RFC3339 または YYYY-MM-DD）", &Schema{Type: "string"}),
+			},
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.ref(usecase.CategorySummary{})),
+				"400": badRequest,
+			},
+		},
+		"GET /items/analytics/average-price": {
+			Summary: "カテゴリー別の平均購入価格（アイテムのないカテゴリーは average_price が null）",
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.ref(usecase.AveragePriceAnalytics{})),
+			},
+		},
+		"GET /items/analytics/price-range": {
+			Summary: "カテゴリー別の最低・最高購入価格（アイテムのないカテゴリーは min_price / max_price が null）",
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.ref(usecase.PriceRangeAnalytics{})),
+			},
+		},
+		"GET /items/analytics/timeline": {
+			Summary: "購入月（YYYY-MM）ごとの件数と購入価格の合計（古い月から順、アイテムのない月は含めない）",
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.arrayOf(usecase.MonthlyStats{})),
+			},
+		},
+		"HEAD /items/:id": {
+			Summary: "アイテムが存在するかの確認（ボディなし）",
+			Responses: map[string]Response{
+				"200": {Description: "OK"},
+				"400": {Description: "Bad Request"},
+				"404": {Description: "Not Found"},
+			},
+		},
+		"GET /items/:id": {
+			Summary: "特定アイテム取得",
+			Parameters: []Parameter{
+				fields,
+				{Name: "If-None-Match", In: "header", Description: "前回の ETag。一致すれば 304", Schema: &Schema{Type: "string"}},
+			},
+			Responses: map[string]Response{
+				"200": {Description: "OK", Headers: map[string]Header{"ETag": {Schema: &Schema{Type: "string"}}}, Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: item}}},
+				"304": {Description: "Not Modified"},
+				"400": badRequest,
+				"404": notFound,
+			},
+		},
+		"PUT /items/:id": {
+			Summary:     "アイテム全体の置き換え",
+			RequestBody: jsonBody(r.ref(usecase.ReplaceItemInput{})),
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", item),
+				"400": badRequest,
+				"404": notFound,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"PATCH /items/:id": {
+			Summary: "アイテムの部分更新",
+			RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{
+				echo.MIMEApplicationJSON:                 {Schema: r.ref(usecase.UpdateItemInput{})},
+				controller.MIMEApplicationMergePatchJSON: {Schema: r.ref(usecase.UpdateItemInput{})},
+				controller.MIMEApplicationJSONPatchJSON:  {Schema: r.arrayOf(controller.JSONPatchOperation{})},
+			}},
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", item),
+				"400": badRequest,
+				"404": notFound,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"DELETE /items/:id": {
+			Summary: "アイテム削除（論理削除）",
+			Responses: map[string]Response{
+				"204": {Description: "No Content"},
+				"400": badRequest,
+				"404": notFound,
+				"429": tooManyRequests,
+			},
+		},
+		"POST /items/:id/clone": {
+			Summary: "アイテムの複製（名前に \" (copy)\" を付けて新しい ID で登録）",
+			Responses: map[string]Response{
+				"201": {Description: "Created", Headers: map[string]Header{"Location": {Description: "作成したアイテムの URL", Schema: &Schema{Type: "string"}}}, Content: map[string]MediaType{echo.MIMEApplicationJSON: {Schema: item}}},
+				"400": badRequest,
+				"404": notFound,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"POST /items/:id/image": {
+			Summary: "アイテムの画像のアップロード（image_url を保存先の URL にする）",
+			RequestBody: &RequestBody{Required: true, Content: map[string]MediaType{
+				echo.MIMEMultipartForm: {Schema: &Schema{Type: "object", Properties: map[string]*Schema{
+					"image": {Type: "string", Format: "binary"},
+				}}},
+			}},
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", item),
+				"400": badRequest,
+				"404": notFound,
+				"429": tooManyRequests,
+			},
+		},
+		"POST /items/:id/restore": {
+			Summary: "論理削除されたアイテムの復元",
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", item),
+				"400": badRequest,
+				"404": notFound,
+				"409": conflict,
+				"429": tooManyRequests,
+			},
+		},
+		"GET /items/:id/price-history": {
+			Summary: "アイテムの購入価格の変更履歴（変更日時の古い順）",
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.arrayOf(entity.PriceChange{})),
+				"400": badRequest,
+				"404": notFound,
+			},
+		},
+		"GET /items/:id/versions": {
+			Summary: "アイテムの記録したバージョンと現在のバージョン（バージョンの古い順）",
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.arrayOf(entity.ItemVersion{})),
+				"400": badRequest,
+				"404": notFound,
+			},
+		},
+		"GET /items/:id/versions/:version": {
+			Summary: "指定したバージョン（1 以上）の時点のアイテム",
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", item),
+				"400": badRequest,
+				"404": notFound,
+			},
+		},
+		"GET /items/:id/diff": {
+			Summary: "2 つのバージョンの間で値が変わったフィールドと変更前後の値",
+			Parameters: []Parameter{
+				{Name: "from", In: "query", Description: "比べる元のバージョン（1 以上）", Required: true, Schema: &Schema{Type: "integer"}},
+				{Name: "to", In: "query", Description: "比べる先のバージョン（1 以上）", Required: true, Schema: &Schema{Type: "integer"}},
+			},
+			Responses: map[string]Response{
+				"200": jsonResponse("OK", r.ref(usecase.ItemDiff{})),
+				"400": badRequest,
+				"404": notFound,
+			},
+		},
+	}
+
+	// 書き込み系のアイテム・カテゴリーのエンドポイントは JWT 認証が必要
+	unauthorized := problemResponse("Unauthorized")
+	for key, op := range ops {
+		method, path, _ := strings.Cut(key, " ")
+		if method != http.MethodGet && method != http.MethodHead && (strings.HasPrefix(path, "/items") || strings.HasPrefix(path, "/categories")) {
+			op.Security = []map[string][]string{{bearerAuth: {}}}
+			op.Responses["401"] = unauthorized
+		}
+	}
+
+	return ops
+}
+
+func query(name, description string, schema *Schema) Parameter {
+	return Parameter{Name: name, In: "query", Description: description, Schema: schema}
+}
@@ -0,0 +1,18 @@
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Swagger UI のページ（/openapi.json を読み込む）。スクリプトとスタイルは CDN から取得する
+//
+//go:embed swagger.html
+var swaggerHTML []byte
+
+// SwaggerUI は埋め込んだ Swagger UI のページを返す
+func SwaggerUI(c echo.Context) error {
+	return c.HTMLBlob(http.StatusOK, swaggerHTML)
+}
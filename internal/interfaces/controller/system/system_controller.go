@@ -1,17 +1,72 @@
 package system
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
-type SystemHandler struct{}
+// readiness チェックで DB の応答を待つ上限
+const defaultReadyTimeout = 2 * time.Second
+
+// Pinger は依存先（データベースなど）に接続できるかを確認する
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+type SystemHandler struct {
+	pinger       Pinger
+	readyTimeout time.Duration
+}
+
+type StatusResponse struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
 
 func (handler *SystemHandler) Health(ctx echo.Context) {
 	ctx.NoContent(http.StatusOK)
 }
 
-func NewSystemHandler() *SystemHandler {
-	return &SystemHandler{}
+// Liveness はプロセスが応答できれば常に 200 を返す
+func (handler *SystemHandler) Liveness(c echo.Context) error {
+	return c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// Readiness は DB に ping し、失敗またはタイムアウトした場合は 503 を返す
+func (handler *SystemHandler) Readiness(c echo.Context) error {
+	if handler.pinger == nil {
+		return c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request().Context(), handler.readyTimeout)
+	defer cancel()
+
+	// ドライバーが context を無視して固まった場合もタイムアウトで応答する
+	done := make(chan error, 1)
+	go func() {
+		done <- handler.pinger.Ping(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		return c.JSON(http.StatusServiceUnavailable, StatusResponse{Status: "unavailable", Error: err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, StatusResponse{Status: "ok"})
+}
+
+// NewSystemHandler は pinger を readiness チェックに使うハンドラーを返す（nil の場合は常に ready）
+func NewSystemHandler(pinger Pinger) *SystemHandler {
+	return &SystemHandler{
+		pinger:       pinger,
+		readyTimeout: defaultReadyTimeout,
+	}
 }
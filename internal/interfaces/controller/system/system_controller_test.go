@@ -0,0 +1,77 @@
+package system
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePinger struct {
+	ping func(ctx context.Context) error
+}
+
+func (p *fakePinger) Ping(ctx context.Context) error {
+	return p.ping(ctx)
+}
+
+func TestSystemHandler_Liveness(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/healthz", nil), rec)
+
+	pinger := &fakePinger{ping: func(ctx context.Context) error { return errors.New("db down") }}
+	assert.NoError(t, NewSystemHandler(pinger).Liveness(c))
+
+	// DB の状態に関係なく 200
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestSystemHandler_Readiness(t *testing.T) {
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		ping           func(ctx context.Context) error
+		expectedStatus int
+	}{
+		{
+			name:           "healthy database",
+			ping:           func(ctx context.Context) error { return nil },
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "database failure",
+			ping:           func(ctx context.Context) error { return errors.New("connection refused") },
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name: "hung database times out",
+			ping: func(ctx context.Context) error {
+				time.Sleep(time.Second)
+				return nil
+			},
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewSystemHandler(&fakePinger{ping: tt.ping})
+			handler.readyTimeout = 50 * time.Millisecond
+
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodGet, "/readyz", nil), rec)
+
+			start := time.Now()
+			assert.NoError(t, handler.Readiness(c))
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			assert.Less(t, time.Since(start), 500*time.Millisecond)
+		})
+	}
+}
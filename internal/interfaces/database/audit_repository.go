@@ -0,0 +1,23 @@
+package database
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// CreateAudit は監査ログを 1 件登録する（usecase.AuditRepository の実装）
+// WithinTransaction の context で呼び出すと、同じトランザクションのアイテムの変更と一緒にコミット・ロールバックされる
+func (r *ItemRepository) CreateAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+        INSERT INTO item_audits (item_id, action, actor, request_id)
+        VALUES (?, ?, ?, ?)
+    `
+	if _, err := r.Execute(ctx, query, entry.ItemID, entry.Action, entry.Actor, entry.RequestID); err != nil {
+		return dbError(ctx, err)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// FindCategories はカテゴリーを ID 順にすべて返す（usecase.CategoryRepository の実装）
+func (r *ItemRepository) FindCategories(ctx context.Context) ([]*entity.Category, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.Query(ctx, `SELECT id, name, created_at FROM categories ORDER BY id`)
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+	defer rows.Close()
+
+	categories := []*entity.Category{}
+	for rows.Next() {
+		var category entity.Category
+		if err := rows.Scan(&category.ID, &category.Name, &category.CreatedAt); err != nil {
+			return nil, dbError(ctx, err)
+		}
+		categories = append(categories, &category)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return categories, nil
+}
+
+// CreateCategory はカテゴリーを登録する（名前の一意制約は大文字・小文字を区別しない）
+func (r *ItemRepository) CreateCategory(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `INSERT INTO categories (name) VALUES (?)`
+
+	var id int64
+	if r.Dialect == DialectPostgres {
+		if err := r.QueryRow(ctx, query+" RETURNING id", category.Name).Scan(&id); err != nil {
+			return nil, categoryError(ctx, err)
+		}
+	} else {
+		result, err := r.Execute(ctx, query, category.Name)
+		if err != nil {
+			return nil, categoryError(ctx, err)
+		}
+		if id, err = result.LastInsertId(); err != nil {
+			return nil, dbError(ctx, fmt.Errorf("failed to get last insert id: %w", err))
+		}
+	}
+
+	created := entity.Category{ID: id}
+	query = `SELECT name, created_at FROM categories WHERE id = ?`
+	if err := r.QueryRow(ctx, query, id).Scan(&created.Name, &created.CreatedAt); err != nil {
+		return nil, dbError(ctx, err)
+	}
+	return &created, nil
+}
+
+// DeleteCategory は論理削除されていないアイテムが登録されていないカテゴリーを削除する
+// 参照の確認と削除を 1 つの文で行い、確認の後に登録されたアイテムのカテゴリーを消さない
+func (r *ItemRepository) DeleteCategory(ctx context.Context, id int64) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+        DELETE FROM categories
+        WHERE id = ? AND NOT EXISTS (SELECT 1 FROM items WHERE items.category = categories.name AND items.deleted_at IS NULL)
+    `
+	result, err := r.Execute(ctx, query, id)
+	if err != nil {
+		return dbError(ctx, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dbError(ctx, fmt.Errorf("failed to get rows affected: %w", err))
+	}
+	if rowsAffected > 0 {
+		return nil
+	}
+
+	// 削除されなかった場合は、存在しないのかアイテムが登録されているのかを区別する
+	var found int
+	if err := r.QueryRow(ctx, `SELECT 1 FROM categories WHERE id = ?`, id).Scan(&found); err != nil {
+		if err == sql.ErrNoRows {
+			return domainErrors.ErrCategoryNotFound
+		}
+		return dbError(ctx, err)
+	}
+	return domainErrors.ErrCategoryInUse
+}
+
+// RenameCategory はカテゴリー名と、そのカテゴリーの論理削除されていないアイテムのカテゴリーを 1 つのトランザクションで変更する
+// 変更したアイテムはバージョンと更新日時を上げ、古いバージョンを指定した更新を楽観ロックで失敗させる
+// 論理削除されたアイテムは MoveItems と同じく変更せず、削除時点のカテゴリーのまま残す
+// 変更前のアイテムは同じトランザクションでそのバージョンとして item_versions に記録する
+func (r *ItemRepository) RenameCategory(ctx context.Context, from, to string) (*entity.Category, []int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var category entity.Category
+	var ids []int64
+	err := r.WithinTransaction(ctx, func(ctx context.Context) error {
+		tx := r.executor(ctx)
+		query := `SELECT id, created_at FROM categories WHERE name = ?`
+		if err := tx.QueryRow(ctx, query, from).Scan(&category.ID, &category.CreatedAt); err != nil {
+			if err == sql.ErrNoRows {
+				return domainErrors.ErrCategoryNotFound
+			}
+			return dbError(ctx, err)
+		}
+
+		// 先にカテゴリーの名前を変更し、名前の重複ではアイテムを変更しない
+		query = `UPDATE categories SET name = ? WHERE id = ?`
+		if _, err := tx.Execute(ctx, query, to, category.ID); err != nil {
+			return categoryError(ctx, err)
+		}
+		category.Name = to
+
+		items, err := r.FindAll(ctx, usecase.ItemFilter{Categories: []string{from}})
+		if err != nil {
+			return err
+		}
+
+		query = `UPDATE items SET category = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE category = ? AND deleted_at IS NULL`
+		if _, err := tx.Execute(ctx, query, to, from); err != nil {
+			return dbError(ctx, err)
+		}
+
+		ids = make([]int64, len(items))
+		for i, item := range items {
+			if err := r.CreateItemVersion(ctx, item); err != nil {
+				return err
+			}
+			ids[i] = item.ID
+		}
+		slices.Sort(ids)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &category, ids, nil
+}
+
+// categoryError は categories の一意制約違反を ErrDuplicateCategory にする（それ以外は dbError と同じ）
+func categoryError(ctx context.Context, err error) error {
+	if errors.Is(err, ErrUniqueViolation) {
+		return fmt.Errorf("%w: a category with the same name already exists", domainErrors.ErrDuplicateCategory)
+	}
+	return dbError(ctx, err)
+}
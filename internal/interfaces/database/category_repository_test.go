@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+func TestItemRepository_Categories(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("find orders by id", func(t *testing.T) {
+		handler := &fakeSqlHandler{rows: [][]interface{}{{int64(1), "時計", now}, {int64(2), "バッグ", now}}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		categories, err := repo.FindCategories(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.Category{{ID: 1, Name: "時計", CreatedAt: now}, {ID: 2, Name: "バッグ", CreatedAt: now}}, categories)
+		assert.Equal(t, "SELECT id, name, created_at FROM categories ORDER BY id", handler.lastStatement())
+	})
+
+	t.Run("create reads back the inserted row", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{lastInsertID: 6}, row: []interface{}{"家具", now}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		created, err := repo.CreateCategory(ctx, &entity.Category{Name: "家具"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &entity.Category{ID: 6, Name: "家具", CreatedAt: now}, created)
+		assert.Equal(t, "INSERT INTO categories (name) VALUES (?)", handler.statements[0])
+		assert.Equal(t, []interface{}{int64(6)}, handler.lastArgs())
+	})
+
+	t.Run("create translates the unique violation", func(t *testing.T) {
+		handler := &fakeSqlHandler{err: fmt.Errorf("%w: Error 1062: Duplicate entry", ErrUniqueViolation)}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.CreateCategory(ctx, &entity.Category{Name: "時計"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateCategory)
+		assert.False(t, domainErrors.IsDuplicateItemError(err))
+	})
+
+	t.Run("delete skips categories with items", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.DeleteCategory(ctx, 6)
+
+		require.NoError(t, err)
+		assert.Equal(t, "DELETE FROM categories WHERE id = ? AND NOT EXISTS (SELECT 1 FROM items WHERE items.category = categories.name AND items.deleted_at IS NULL)", handler.lastStatement())
+		assert.Len(t, handler.statements, 1)
+	})
+
+	t.Run("delete of a referenced category is in use", func(t *testing.T) {
+		handler := &fakeSqlHandler{row: []interface{}{1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.DeleteCategory(ctx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryInUse)
+	})
+
+	t.Run("delete of a missing category is not found", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.DeleteCategory(ctx, 99)
+
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryNotFound)
+	})
+
+	t.Run("postgres insert returns the id", func(t *testing.T) {
+		handler := &fakeSqlHandler{row: []interface{}{int64(6)}}
+		repo := &ItemRepository{SqlHandler: handler, Dialect: DialectPostgres}
+
+		// 読み直しの行も同じ値を返すため失敗するが、発行した SQL は確認できる
+		_, err := repo.CreateCategory(ctx, &entity.Category{Name: "家具"})
+
+		assert.Error(t, err)
+		assert.Equal(t, "INSERT INTO categories (name) VALUES ($1) RETURNING id", handler.statements[0])
+		assert.Equal(t, "SELECT name, created_at FROM categories WHERE id = $1", handler.lastStatement())
+	})
+
+	t.Run("rename updates the category and its items in one transaction", func(t *testing.T) {
+		handler := &fakeSqlHandler{row: []interface{}{int64(1), now}, rows: [][]interface{}{
+			itemRow(5, "時計2", "時計", "OMEGA", 500000, "2023-01-02"),
+			itemRow(3, "時計1", "時計", "ROLEX", 1000000, "2023-01-01"),
+		}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		category, ids, err := repo.RenameCategory(ctx, "時計", "腕時計")
+
+		require.NoError(t, err)
+		assert.Equal(t, &entity.Category{ID: 1, Name: "腕時計", CreatedAt: now}, category)
+		assert.Equal(t, []int64{3, 5}, ids)
+		require.Len(t, handler.statements, 6)
+		assert.Equal(t, "SELECT id, created_at FROM categories WHERE name = ?", handler.statements[0])
+		assert.Equal(t, "UPDATE categories SET name = ? WHERE id = ?", handler.statements[1])
+		assert.Contains(t, handler.statements[2], "FROM items WHERE deleted_at IS NULL AND category = ?")
+		assert.Equal(t, []interface{}{"時計"}, handler.args[2])
+		assert.Equal(t, "UPDATE items SET category = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE category = ? AND deleted_at IS NULL", handler.statements[3])
+		assert.Equal(t, []interface{}{"腕時計", "時計"}, handler.args[3])
+		// 変更前のアイテムをそれぞれのバージョンとして記録する
+		assert.Equal(t, "INSERT INTO item_versions (item_id, version, snapshot, updated_at) VALUES (?, ?, ?, ?)", handler.statements[4])
+		assert.Equal(t, []interface{}{int64(5), 1}, handler.args[4][:2])
+		assert.Equal(t, []interface{}{int64(3), 1}, handler.args[5][:2])
+		assert.True(t, handler.tx.committed)
+	})
+
+	t.Run("rename rolls back the category when the items update fails", func(t *testing.T) {
+		handler := &fakeSqlHandler{row: []interface{}{int64(1), now}}
+		repo := &ItemRepository{SqlHandler: &failingBeginHandler{fakeSqlHandler: handler, failOnExecute: 2}}
+
+		_, _, err := repo.RenameCategory(ctx, "時計", "腕時計")
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.False(t, handler.tx.committed)
+		assert.True(t, handler.tx.rolledBack)
+	})
+
+	t.Run("rename of a missing category is not found", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, _, err := repo.RenameCategory(ctx, "家具", "インテリア")
+
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryNotFound)
+		assert.Len(t, handler.statements, 1)
+		assert.True(t, handler.tx.rolledBack)
+	})
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Dialect はデータベースごとの SQL の差異を表す（値は database/sql のドライバー名と同じ）
+// リポジトリのクエリは ? のプレースホルダーで書き、実行時に方言の形式へ置き換える
+type Dialect string
+
+const (
+	// ItemRepository の Dialect が空の場合も MySQL として扱う
+	DialectMySQL    Dialect = "mysql"
+	DialectPostgres Dialect = "postgres"
+)
+
+// IsValid は対応している方言かを返す
+func (d Dialect) IsValid() bool {
+	return d == DialectMySQL || d == DialectPostgres
+}
+
+// rebind は ? のプレースホルダーを方言の形式に置き換える（PostgreSQL は $1, $2, ...）
+// 文字列リテラル内の ? は置き換えない
+func (d Dialect) rebind(query string) string {
+	if d != DialectPostgres || !strings.Contains(query, "?") {
+		return query
+	}
+
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n := 0
+	inLiteral := false
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			inLiteral = !inLiteral
+		case r == '?' && !inLiteral:
+			n++
+			b.WriteByte('$')
+			b.WriteString(strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// likeEscape は LIKE の ESCAPE 句に指定する、バックスラッシュ 1 文字の文字列リテラル
+// MySQL の文字列リテラルではバックスラッシュ自体をエスケープする必要がある
+func (d Dialect) likeEscape() string {
+	if d == DialectPostgres {
+		return `'\'`
+	}
+	return `'\\'`
+}
+
+// yearMonth は DATE 型のカラムを YYYY-MM 形式の文字列にする式を返す
+// DATE はタイムゾーンを持たないため、サーバーやセッションのタイムゾーンによらず同じ月になる
+func (d Dialect) yearMonth(column string) string {
+	if d == DialectPostgres {
+		return "TO_CHAR(" + column + ", 'YYYY-MM')"
+	}
+	return "DATE_FORMAT(" + column + ", '%Y-%m')"
+}
+
+// stringAgg はグループ内の column を名前順にカンマ区切りで連結する集約式を返す
+func (d Dialect) stringAgg(column string) string {
+	if d == DialectPostgres {
+		return "STRING_AGG(" + column + ", ',' ORDER BY " + column + ")"
+	}
+	return "GROUP_CONCAT(" + column + " ORDER BY " + column + " SEPARATOR ',')"
+}
+
+// insertIgnore は一意制約に違反する行を挿入せずに無視する INSERT 文を返す（values は VALUES 以降）
+func (d Dialect) insertIgnore(table, values string) string {
+	if d == DialectPostgres {
+		return "INSERT INTO " + table + " VALUES " + values + " ON CONFLICT DO NOTHING"
+	}
+	return "INSERT IGNORE INTO " + table + " VALUES " + values
+}
+
+// jsonText は JSON のカラムから、パスの位置の値を文字列として取り出す式を返す（パスは ? でバインドする）
+// 文字列は引用符を除いた値、数値・真偽値は JSON の表記になる
+func (d Dialect) jsonText(column string) string {
+	if d == DialectPostgres {
+		return column + " #>> ?"
+	}
+	return "JSON_UNQUOTE(JSON_EXTRACT(" + column + ", ?))"
+}
+
+// jsonPath は jsonText にバインドするパスを返す（MySQL は $."a"."b"、PostgreSQL はテキスト配列の {"a","b"}）
+// メンバー名はコントローラーで文字・数字・_・- に限っているため、引用符のエスケープは不要
+func (d Dialect) jsonPath(path []string) string {
+	if d == DialectPostgres {
+		return `{"` + strings.Join(path, `","`) + `"}`
+	}
+	return `$."` + strings.Join(path, `"."`) + `"`
+}
+
+// rebindExecutor はプレースホルダーを方言の形式に置き換えてから SQL を実行する
+type rebindExecutor struct {
+	Executor
+	dialect Dialect
+}
+
+func (e rebindExecutor) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	return e.Executor.Execute(ctx, e.dialect.rebind(statement), args...)
+}
+
+func (e rebindExecutor) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	return e.Executor.Query(ctx, e.dialect.rebind(statement), args...)
+}
+
+func (e rebindExecutor) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	return e.Executor.QueryRow(ctx, e.dialect.rebind(statement), args...)
+}
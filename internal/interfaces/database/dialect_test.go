@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	"Aicon-assignment/internal/usecase"
+)
+
+func TestDialect_Rebind(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect Dialect
+		query   string
+		want    string
+	}{
+		{name: "mysql keeps ?", dialect: DialectMySQL, query: "WHERE id = ? AND version = ?", want: "WHERE id = ? AND version = ?"},
+		{name: "empty dialect is mysql", dialect: "", query: "WHERE id = ?", want: "WHERE id = ?"},
+		{name: "postgres numbers placeholders", dialect: DialectPostgres, query: "WHERE id = ? AND version = ? LIMIT ?", want: "WHERE id = $1 AND version = $2 LIMIT $3"},
+		{name: "postgres skips string literals", dialect: DialectPostgres, query: "WHERE name = '?' AND id = ?", want: "WHERE name = '?' AND id = $1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.dialect.rebind(tt.query))
+		})
+	}
+}
+
+func TestItemRepository_PostgresDialect(t *testing.T) {
+	t.Run("filter placeholders and like escape", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler, Dialect: DialectPostgres}
+		category := "時計"
+
+		_, err := repo.GetItems(context.Background(), usecase.ItemFilter{Categories: []string{category}, Search: "50%"}, usecase.SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), `WHERE deleted_at IS NULL AND category = $1 AND LOWER(name) LIKE $2 ESCAPE '\' ORDER BY created_at DESC LIMIT $3 OFFSET $4`)
+		assert.Equal(t, []interface{}{"時計", `%50\%%`, 20, 0}, handler.lastArgs())
+	})
+
+	t.Run("insert returns the id", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler, Dialect: DialectPostgres}
+
+		// fakeSqlHandler は行を用意していないため ID の取得で失敗するが、発行した SQL は確認できる
+		_, err := repo.Create(context.Background(), &entity.Item{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"})
+
+		assert.Error(t, err)
+		assert.Equal(t, "INSERT INTO items (name, category, brand, purchase_price, purchase_date, warranty_expiry, notes, image_url, attributes, user_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id", handler.lastStatement())
+	})
+
+	t.Run("tags use string_agg and on conflict", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}, row: itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")}
+		repo := &ItemRepository{SqlHandler: handler, Dialect: DialectPostgres}
+
+		_, err := repo.Update(context.Background(), &entity.Item{ID: 1, Version: 1, Tags: []string{"gift"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, "INSERT INTO tags (name) VALUES ($1) ON CONFLICT DO NOTHING", handler.statements[2])
+		assert.Contains(t, handler.lastStatement(), "STRING_AGG(t.name, ',' ORDER BY t.name)")
+	})
+
+	t.Run("attributes use the #>> operator", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler, Dialect: DialectPostgres}
+
+		_, err := repo.GetItems(context.Background(), usecase.ItemFilter{Attributes: []usecase.AttributeFilter{{Path: []string{"case", "material"}, Value: "steel"}}}, usecase.SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND attributes #>> $1 = $2 ORDER BY")
+		assert.Equal(t, []interface{}{`{"case","material"}`, "steel", 20, 0}, handler.lastArgs())
+	})
+
+	t.Run("timeline groups by year-month", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler, Dialect: DialectPostgres}
+
+		timeline, err := repo.GetMonthlyTimeline(context.Background(), usecase.ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Empty(t, timeline)
+		assert.Contains(t, handler.lastStatement(), "GROUP BY TO_CHAR(purchase_date, 'YYYY-MM') ORDER BY month")
+	})
+
+	t.Run("transaction statements are rebound", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}}
+		repo := &ItemRepository{SqlHandler: handler, Dialect: DialectPostgres}
+
+		_, err := repo.DeleteItems(context.Background(), []int64{1})
+
+		require.NoError(t, err)
+		assert.Equal(t, "UPDATE items SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1 AND deleted_at IS NULL", handler.lastStatement())
+		assert.True(t, handler.tx.committed)
+	})
+}
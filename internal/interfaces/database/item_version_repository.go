@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// CreateItemVersion は更新前のアイテムを JSON にして、そのバージョンとして登録する（usecase.ItemVersionRepository の実装）
+// WithinTransaction の context で呼び出すと、同じトランザクションのアイテムの更新と一緒にコミット・ロールバックされる
+func (r *ItemRepository) CreateItemVersion(ctx context.Context, item *entity.Item) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	snapshot, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to encode item version: %w", err)
+	}
+
+	query := `
+        INSERT INTO item_versions (item_id, version, snapshot, updated_at)
+        VALUES (?, ?, ?, ?)
+    `
+	if _, err := r.Execute(ctx, query, item.ID, item.Version, string(snapshot), item.UpdatedAt); err != nil {
+		return dbError(ctx, err)
+	}
+	return nil
+}
+
+// FindItemVersions はアイテムの記録したバージョンを古い順に返す
+func (r *ItemRepository) FindItemVersions(ctx context.Context, itemID int64) ([]*entity.ItemVersion, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.Query(ctx, `SELECT version, updated_at FROM item_versions WHERE item_id = ? ORDER BY version`, itemID)
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+	defer rows.Close()
+
+	versions := []*entity.ItemVersion{}
+	for rows.Next() {
+		var version entity.ItemVersion
+		if err := rows.Scan(&version.Version, &version.UpdatedAt); err != nil {
+			return nil, dbError(ctx, err)
+		}
+		versions = append(versions, &version)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return versions, nil
+}
+
+// FindItemVersion は version の時点のアイテムを返す
+func (r *ItemRepository) FindItemVersion(ctx context.Context, itemID int64, version int) (*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	var snapshot string
+	query := `SELECT snapshot FROM item_versions WHERE item_id = ? AND version = ?`
+	if err := r.QueryRow(ctx, query, itemID, version).Scan(&snapshot); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domainErrors.ErrItemVersionNotFound
+		}
+		return nil, dbError(ctx, err)
+	}
+
+	var item entity.Item
+	if err := json.Unmarshal([]byte(snapshot), &item); err != nil {
+		return nil, fmt.Errorf("failed to decode item version: %w", err)
+	}
+	return &item, nil
+}
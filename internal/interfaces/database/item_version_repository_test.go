@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+var _ usecase.ItemVersionRepository = (*ItemRepository)(nil)
+
+func TestItemRepository_ItemVersions(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	item := &entity.Item{ID: 3, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", CreatedAt: now, UpdatedAt: now, Version: 2, Tags: []string{"vintage"}}
+
+	t.Run("create stores the item as json", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.CreateItemVersion(ctx, item)
+
+		require.NoError(t, err)
+		assert.Equal(t, "INSERT INTO item_versions (item_id, version, snapshot, updated_at) VALUES (?, ?, ?, ?)", handler.lastStatement())
+		args := handler.lastArgs()
+		assert.Equal(t, []interface{}{int64(3), 2}, args[:2])
+		assert.Equal(t, now, args[3])
+		var stored entity.Item
+		require.NoError(t, json.Unmarshal([]byte(args[2].(string)), &stored))
+		assert.Equal(t, *item, stored)
+	})
+
+	t.Run("create translates database errors", func(t *testing.T) {
+		repo := &ItemRepository{SqlHandler: &fakeSqlHandler{err: assert.AnError}}
+
+		err := repo.CreateItemVersion(ctx, item)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+
+	t.Run("find lists versions in order", func(t *testing.T) {
+		later := now.Add(time.Hour)
+		handler := &fakeSqlHandler{rows: [][]interface{}{{1, now}, {2, later}}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		versions, err := repo.FindItemVersions(ctx, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.ItemVersion{{Version: 1, UpdatedAt: now}, {Version: 2, UpdatedAt: later}}, versions)
+		assert.Equal(t, "SELECT version, updated_at FROM item_versions WHERE item_id = ? ORDER BY version", handler.lastStatement())
+		assert.Equal(t, []interface{}{int64(3)}, handler.lastArgs())
+	})
+
+	t.Run("find one decodes the snapshot", func(t *testing.T) {
+		snapshot, err := json.Marshal(item)
+		require.NoError(t, err)
+		handler := &fakeSqlHandler{row: []interface{}{string(snapshot)}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		found, err := repo.FindItemVersion(ctx, 3, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, item, found)
+		assert.Equal(t, "SELECT snapshot FROM item_versions WHERE item_id = ? AND version = ?", handler.lastStatement())
+		assert.Equal(t, []interface{}{int64(3), 2}, handler.lastArgs())
+	})
+
+	t.Run("find one of a missing version is not found", func(t *testing.T) {
+		repo := &ItemRepository{SqlHandler: &fakeSqlHandler{}}
+
+		_, err := repo.FindItemVersion(ctx, 3, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemVersionNotFound)
+	})
+}
@@ -3,51 +3,197 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
 )
 
 type ItemRepository struct {
 	SqlHandler
+	// SqlHandler のデータベースの方言（空の場合は MySQL）
+	Dialect Dialect
+	// メソッド 1 回あたりの SQL の実行時間の上限（0 の場合は呼び出し元の context のみに従う）
+	QueryTimeout time.Duration
 }
 
-func (r *ItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
-	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+// withTimeout は QueryTimeout を期限とする子の context を返す
+func (r *ItemRepository) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.QueryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, r.QueryTimeout)
+}
+
+// Execute / Query / QueryRow は SqlHandler（ctx が WithinTransaction のトランザクションを持つ場合はそのトランザクション）の
+// 同名メソッドを、プレースホルダーを Dialect の形式にして呼び出す
+func (r *ItemRepository) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	return r.executor(ctx).Execute(ctx, statement, args...)
+}
+
+func (r *ItemRepository) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	return r.executor(ctx).Query(ctx, statement, args...)
+}
+
+func (r *ItemRepository) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	return r.executor(ctx).QueryRow(ctx, statement, args...)
+}
+
+func (r *ItemRepository) executor(ctx context.Context) Executor {
+	if tx, ok := txFromContext(ctx); ok {
+		return rebindExecutor{Executor: tx, dialect: r.Dialect}
+	}
+	return rebindExecutor{Executor: r.SqlHandler, dialect: r.Dialect}
+}
+
+// scanItem で読み込むカラム（順序は scanItem と一致させる）
+const itemColumns = "id, name, category, brand, purchase_price, purchase_date, created_at, updated_at, deleted_at, version, warranty_expiry, notes, image_url, attributes, user_id"
+
+// itemColumns にタグを加えたカラム
+// タグは相関サブクエリでアイテムごとにカンマ区切りの 1 カラムにするため、一覧でもタグ用のクエリは発行しない
+func (r *ItemRepository) itemColumns() string {
+	return itemColumns + `, (
+            SELECT ` + r.Dialect.stringAgg("t.name") + `
+            FROM item_tags it JOIN tags t ON t.id = it.tag_id
+            WHERE it.item_id = items.id
+        ) AS tags`
+}
+
+func (r *ItemRepository) FindAll(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where, args := buildItemFilter(filter, r.Dialect)
+	query := fmt.Sprintf(`
+        SELECT %s
         FROM items
+        %s
         ORDER BY created_at DESC
-    `
+    `, r.itemColumns(), where)
+
+	return r.queryItems(ctx, query, args...)
+}
 
-	rows, err := r.Query(ctx, query)
+func (r *ItemRepository) GetItems(ctx context.Context, filter usecase.ItemFilter, sort usecase.SortOption, limit, offset int) ([]*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	orderBy, err := buildOrderBy(sort)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, err
 	}
-	defer rows.Close()
 
-	var items []*entity.Item
-	for rows.Next() {
-		item, err := scanItem(rows)
-		if err != nil {
-			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
-		}
-		items = append(items, item)
+	where, args := buildItemFilter(filter, r.Dialect)
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        %s
+        ORDER BY %s
+        LIMIT ? OFFSET ?
+    `, r.itemColumns(), where, orderBy)
+
+	args = append(args, limit, offset)
+	return r.queryItems(ctx, query, args...)
+}
+
+func (r *ItemRepository) GetItemsAfter(ctx context.Context, filter usecase.ItemFilter, afterID int64, limit int) ([]*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where, args := buildItemFilter(filter, r.Dialect)
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        %s AND id > ?
+        ORDER BY id
+        LIMIT ?
+    `, r.itemColumns(), where)
+
+	args = append(args, afterID, limit)
+	return r.queryItems(ctx, query, args...)
+}
+
+// updated_at が since 以降のアイテムを論理削除済みのものも含めて返す
+// MySQL は ON UPDATE、PostgreSQL はトリガーで、論理削除・復元時にも updated_at が更新される
+// userID を指定した場合はそのユーザーのアイテムに限る
+func (r *ItemRepository) FindChangedSince(ctx context.Context, userID *string, since time.Time) ([]*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	// 論理削除済みのものも含めるため buildItemFilter（deleted_at で絞り込む）は使わない
+	where := "WHERE updated_at >= ?"
+	args := []interface{}{since.UTC()}
+	if userID != nil {
+		where += " AND user_id = ?"
+		args = append(args, *userID)
 	}
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        %s
+        ORDER BY updated_at, id
+    `, r.itemColumns(), where)
 
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+	return r.queryItems(ctx, query, args...)
+}
+
+// 保証期限が from から to まで（両端を含む）の未削除のアイテムを、保証期限・ID の順に返す
+// userID を指定した場合はそのユーザーのアイテムに限る
+func (r *ItemRepository) FindWarrantyExpiring(ctx context.Context, userID *string, from, to time.Time) ([]*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where, args := buildItemFilter(usecase.ItemFilter{UserID: userID}, r.Dialect)
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        %s AND warranty_expiry BETWEEN ? AND ?
+        ORDER BY warranty_expiry, id
+    `, r.itemColumns(), where)
+
+	args = append(args, from.Format("2006-01-02"), to.Format("2006-01-02"))
+	return r.queryItems(ctx, query, args...)
+}
+
+func (r *ItemRepository) Count(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where, args := buildItemFilter(filter, r.Dialect)
+	query := fmt.Sprintf(`SELECT COUNT(*) FROM items %s`, where)
+
+	var count int
+	if err := r.QueryRow(ctx, query, args...).Scan(&count); err != nil {
+		return 0, dbError(ctx, err)
 	}
 
-	return items, nil
+	return count, nil
+}
+
+// Ping はデータベースに接続できるかを確認する（readiness チェック用）
+func (r *ItemRepository) Ping(ctx context.Context) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	if err := r.SqlHandler.Ping(ctx); err != nil {
+		return dbError(ctx, err)
+	}
+	return nil
 }
 
 func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	query := `
-        SELECT id, name, category, brand, purchase_price, purchase_date, created_at, updated_at
+        SELECT ` + r.itemColumns() + `
         FROM items
-        WHERE id = ?
+        WHERE id = ? AND deleted_at IS NULL
     `
 
 	row := r.QueryRow(ctx, query, id)
@@ -57,48 +203,217 @@ func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item,
 		if err == sql.ErrNoRows {
 			return nil, domainErrors.ErrItemNotFound
 		}
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, dbError(ctx, err)
 	}
 
 	return item, nil
 }
 
+// 論理削除されていないアイテムが存在するかを、カラムを読み込まずに確認する
+func (r *ItemRepository) Exists(ctx context.Context, id int64) (bool, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `SELECT 1 FROM items WHERE id = ? AND deleted_at IS NULL`
+
+	var found int
+	if err := r.QueryRow(ctx, query, id).Scan(&found); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, dbError(ctx, err)
+	}
+
+	return true, nil
+}
+
+// 指定した ID のうち、論理削除されていないアイテムを返す（順序は不定）
+func (r *ItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	if len(ids) == 0 {
+		return []*entity.Item{}, nil
+	}
+
+	args := make([]interface{}, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+	query := fmt.Sprintf(`
+        SELECT %s
+        FROM items
+        WHERE id IN (%s) AND deleted_at IS NULL
+    `, r.itemColumns(), placeholders(len(ids)))
+
+	return r.queryItems(ctx, query, args...)
+}
+
 func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	// アイテムとタグを同じトランザクションで登録する
+	var id int64
+	err := r.withTx(ctx, func(tx Executor) error {
+		var err error
+		id, err = r.insertItem(ctx, tx, item)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.FindByID(ctx, id)
+}
+
+// 複数のアイテムを 1 つのトランザクションで登録する（1 件でも失敗した場合はすべてロールバック）
+func (r *ItemRepository) CreateItems(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	ids := make([]int64, 0, len(items))
+	err := r.withTx(ctx, func(tx Executor) error {
+		for _, item := range items {
+			id, err := r.insertItem(ctx, tx, item)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]*entity.Item, 0, len(ids))
+	for _, id := range ids {
+		item, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, item)
+	}
+
+	return created, nil
+}
+
+// アイテムとタグを挿入し、アイテムの ID を返す（PostgreSQL は LastInsertId に対応していないため RETURNING id で取得する）
+func (r *ItemRepository) insertItem(ctx context.Context, exec Executor, item *entity.Item) (int64, error) {
 	query := `
-        INSERT INTO items (name, category, brand, purchase_price, purchase_date)
-        VALUES (?, ?, ?, ?, ?)
+        INSERT INTO items (name, category, brand, purchase_price, purchase_date, warranty_expiry, notes, image_url, attributes, user_id)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
     `
-
-	result, err := r.Execute(ctx, query,
+	attributes, err := attributesJSON(item.Attributes)
+	if err != nil {
+		return 0, err
+	}
+	args := []interface{}{
 		item.Name,
 		item.Category,
 		item.Brand,
 		item.PurchasePrice,
 		item.PurchaseDate,
-	)
+		nullableString(item.WarrantyExpiry),
+		emptyAsNull(item.Notes),
+		emptyAsNull(item.ImageURL),
+		attributes,
+		item.UserID,
+	}
+
+	if r.Dialect == DialectPostgres {
+		var id int64
+		if err := exec.QueryRow(ctx, query+" RETURNING id", args...).Scan(&id); err != nil {
+			return 0, dbError(ctx, err)
+		}
+		return id, r.insertTags(ctx, exec, id, item.Tags)
+	}
+
+	result, err := exec.Execute(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return 0, dbError(ctx, err)
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get last insert id: %s", domainErrors.ErrDatabaseError, err.Error())
+		return 0, dbError(ctx, fmt.Errorf("failed to get last insert id: %w", err))
 	}
 
-	return r.FindByID(ctx, id)
+	return id, r.insertTags(ctx, exec, id, item.Tags)
+}
+
+// タグを tags に登録し（登録済みのタグはそのまま使う）、item_tags でアイテムに関連付ける
+func (r *ItemRepository) insertTags(ctx context.Context, exec Executor, itemID int64, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	values := make([]string, len(tags))
+	args := make([]interface{}, len(tags))
+	for i, tag := range tags {
+		values[i] = "(?)"
+		args[i] = tag
+	}
+	if _, err := exec.Execute(ctx, r.Dialect.insertIgnore("tags (name)", strings.Join(values, ", ")), args...); err != nil {
+		return dbError(ctx, err)
+	}
+
+	query := `INSERT INTO item_tags (item_id, tag_id) SELECT ?, id FROM tags WHERE name IN (` + placeholders(len(tags)) + `)`
+	if _, err := exec.Execute(ctx, query, append([]interface{}{itemID}, args...)...); err != nil {
+		return dbError(ctx, err)
+	}
+
+	return nil
+}
+
+// アイテムのタグを tags の一覧で置き換える
+func (r *ItemRepository) replaceTags(ctx context.Context, exec Executor, itemID int64, tags []string) error {
+	if _, err := exec.Execute(ctx, `DELETE FROM item_tags WHERE item_id = ?`, itemID); err != nil {
+		return dbError(ctx, err)
+	}
+	return r.insertTags(ctx, exec, itemID, tags)
 }
 
+// 論理削除（deleted_at に削除日時を設定し、行は残す）
 func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
-	query := `DELETE FROM items WHERE id = ?`
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE items SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`
+
+	result, err := r.Execute(ctx, query, id)
+	if err != nil {
+		return dbError(ctx, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return dbError(ctx, fmt.Errorf("failed to get rows affected: %w", err))
+	}
+
+	if rowsAffected == 0 {
+		return domainErrors.ErrItemNotFound
+	}
+
+	return nil
+}
+
+// 論理削除されたアイテムを復元する（論理削除されていない行には一致しない）
+func (r *ItemRepository) Restore(ctx context.Context, id int64) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE items SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL`
 
 	result, err := r.Execute(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return dbError(ctx, err)
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		return dbError(ctx, fmt.Errorf("failed to get rows affected: %w", err))
 	}
 
 	if rowsAffected == 0 {
@@ -108,63 +423,461 @@ func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
 	return nil
 }
 
+// 複数のアイテムを 1 つのトランザクションで論理削除し、実際に削除された ID を返す
+func (r *ItemRepository) DeleteItems(ctx context.Context, ids []int64) ([]int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE items SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`
+
+	deleted := make([]int64, 0, len(ids))
+	err := r.withTx(ctx, func(tx Executor) error {
+		for _, id := range ids {
+			result, err := tx.Execute(ctx, query, id)
+			if err != nil {
+				return dbError(ctx, err)
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return dbError(ctx, fmt.Errorf("failed to get rows affected: %w", err))
+			}
+			if rowsAffected > 0 {
+				deleted = append(deleted, id)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
+
+// MoveItems は論理削除されていないアイテムのカテゴリーを 1 つのトランザクションで変更する
+// 変更前のアイテムは同じトランザクションでそのバージョンとして item_versions に記録する
+// 変更先のカテゴリーで名前が重複する場合は一意制約違反でロールバックする
+func (r *ItemRepository) MoveItems(ctx context.Context, ids []int64, category string) ([]int64, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `UPDATE items SET category = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL`
+
+	moved := make([]int64, 0, len(ids))
+	err := r.WithinTransaction(ctx, func(ctx context.Context) error {
+		items, err := r.FindByIDs(ctx, ids)
+		if err != nil {
+			return err
+		}
+		previous := make(map[int64]*entity.Item, len(items))
+		for _, item := range items {
+			previous[item.ID] = item
+		}
+
+		tx := r.executor(ctx)
+		for _, id := range ids {
+			item, ok := previous[id]
+			if !ok {
+				continue
+			}
+			result, err := tx.Execute(ctx, query, category, id)
+			if err != nil {
+				return dbError(ctx, err)
+			}
+
+			rowsAffected, err := result.RowsAffected()
+			if err != nil {
+				return dbError(ctx, fmt.Errorf("failed to get rows affected: %w", err))
+			}
+			if rowsAffected == 0 {
+				continue
+			}
+			if err := r.CreateItemVersion(ctx, item); err != nil {
+				return err
+			}
+			moved = append(moved, id)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+}
+
 func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
 	query := `
 		UPDATE items
-		SET name = ?, brand = ?, purchase_price = ?
-		WHERE id = ?
+		SET name = ?, category = ?, brand = ?, purchase_price = ?, purchase_date = ?, warranty_expiry = ?, notes = ?, image_url = ?, attributes = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP
+		WHERE id = ? AND version = ? AND deleted_at IS NULL
 	`
 
-	result, err := r.Execute(ctx, query,
-		item.Name,
-		item.Brand,
-		item.PurchasePrice,
-		item.ID,
-	)
+	attributes, err := attributesJSON(item.Attributes)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, err
 	}
 
-	rowsAffected, err := result.RowsAffected()
+	// アイテムとタグを同じトランザクションで更新する（更新対象がない場合はタグも変更しない）
+	var rowsAffected int64
+	err = r.withTx(ctx, func(tx Executor) error {
+		result, err := tx.Execute(ctx, query,
+			item.Name,
+			item.Category,
+			item.Brand,
+			item.PurchasePrice,
+			item.PurchaseDate,
+			nullableString(item.WarrantyExpiry),
+			emptyAsNull(item.Notes),
+			emptyAsNull(item.ImageURL),
+			attributes,
+			item.ID,
+			item.Version,
+		)
+		if err != nil {
+			return dbError(ctx, err)
+		}
+
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return dbError(ctx, fmt.Errorf("failed to get rows affected: %w", err))
+		}
+		if rowsAffected == 0 {
+			return nil
+		}
+		return r.replaceTags(ctx, tx, item.ID, item.Tags)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%w: failed to get rows affected: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, err
 	}
 
+	// 更新対象がない場合、アイテム自体が存在すればバージョン不一致
 	if rowsAffected == 0 {
-		return nil, domainErrors.ErrItemNotFound
+		if _, err := r.FindByID(ctx, item.ID); err != nil {
+			return nil, err
+		}
+		return nil, domainErrors.ErrVersionConflict
 	}
 
 	return r.FindByID(ctx, item.ID)
 }
 
-func (r *ItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context, filter usecase.ItemFilter) (map[string]usecase.CategoryStats, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where, args := buildItemFilter(filter, r.Dialect)
+	query := fmt.Sprintf(`
+        SELECT category, COUNT(*) as count, SUM(purchase_price) as total_price
+        FROM items
+        %s
+        GROUP BY category
+    `, where)
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+	defer rows.Close()
+
+	summary := make(map[string]usecase.CategoryStats)
+	for rows.Next() {
+		var category string
+		var stats usecase.CategoryStats
+		if err := rows.Scan(&category, &stats.Count, &stats.TotalPrice); err != nil {
+			return nil, dbError(ctx, err)
+		}
+		summary[category] = stats
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return summary, nil
+}
+
+// AVG の結果は MySQL では DECIMAL、PostgreSQL では numeric のため float64 として読み取る
+func (r *ItemRepository) GetAveragePriceByCategory(ctx context.Context, filter usecase.ItemFilter) (map[string]usecase.CategoryAveragePrice, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where, args := buildItemFilter(filter, r.Dialect)
 	query := `
-        SELECT category, COUNT(*) as count
+        SELECT category, COUNT(*) as count, AVG(purchase_price) as average_price
         FROM items
+        ` + where + `
         GROUP BY category
     `
 
-	rows, err := r.Query(ctx, query)
+	rows, err := r.Query(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, dbError(ctx, err)
 	}
 	defer rows.Close()
 
-	summary := make(map[string]int)
+	averages := make(map[string]usecase.CategoryAveragePrice)
 	for rows.Next() {
 		var category string
 		var count int
-		if err := rows.Scan(&category, &count); err != nil {
-			return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		var average float64
+		if err := rows.Scan(&category, &count, &average); err != nil {
+			return nil, dbError(ctx, err)
 		}
-		summary[category] = count
+		averages[category] = usecase.CategoryAveragePrice{Count: count, AveragePrice: &average}
 	}
 
 	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
+		return nil, dbError(ctx, err)
 	}
 
-	return summary, nil
+	return averages, nil
+}
+
+func (r *ItemRepository) GetPriceRangeByCategory(ctx context.Context, filter usecase.ItemFilter) (map[string]usecase.CategoryPriceRange, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	where, args := buildItemFilter(filter, r.Dialect)
+	query := `
+        SELECT category, COUNT(*) as count, MIN(purchase_price) as min_price, MAX(purchase_price) as max_price
+        FROM items
+        ` + where + `
+        GROUP BY category
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+	defer rows.Close()
+
+	ranges := make(map[string]usecase.CategoryPriceRange)
+	for rows.Next() {
+		var category string
+		var count, minPrice, maxPrice int
+		if err := rows.Scan(&category, &count, &minPrice, &maxPrice); err != nil {
+			return nil, dbError(ctx, err)
+		}
+		ranges[category] = usecase.CategoryPriceRange{Count: count, MinPrice: &minPrice, MaxPrice: &maxPrice}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return ranges, nil
+}
+
+// 購入月ごとの集計は SQL で行う（月の表現は方言ごとに異なる）
+func (r *ItemRepository) GetMonthlyTimeline(ctx context.Context, filter usecase.ItemFilter) ([]usecase.MonthlyStats, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	month := r.Dialect.yearMonth("purchase_date")
+	where, args := buildItemFilter(filter, r.Dialect)
+	query := `
+        SELECT ` + month + ` as month, COUNT(*) as count, SUM(purchase_price) as total_value
+        FROM items
+        ` + where + `
+        GROUP BY ` + month + `
+        ORDER BY month
+    `
+
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+	defer rows.Close()
+
+	timeline := []usecase.MonthlyStats{}
+	for rows.Next() {
+		var stats usecase.MonthlyStats
+		if err := rows.Scan(&stats.Month, &stats.Count, &stats.TotalValue); err != nil {
+			return nil, dbError(ctx, err)
+		}
+		timeline = append(timeline, stats)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return timeline, nil
+}
+
+// 並び替え可能なフィールドとカラムの対応（ORDER BY に使えるのはここに定義したカラムのみ）
+var sortColumns = map[usecase.SortField]string{
+	usecase.SortByPurchaseDate:  "purchase_date",
+	usecase.SortByPurchasePrice: "purchase_price",
+	usecase.SortByCreatedAt:     "created_at",
+	usecase.SortByCategory:      "category",
+}
+
+// 並び替え条件から ORDER BY 句を組み立てる（キーを指定順に並べる）
+// ユーザー入力を SQL に埋め込まないよう、カラム名は許可リストから取得する
+func buildOrderBy(sort usecase.SortOption) (string, error) {
+	if len(sort.Keys) == 0 {
+		return "created_at DESC", nil
+	}
+
+	terms := make([]string, 0, len(sort.Keys)+1)
+	for _, key := range sort.Keys {
+		column, ok := sortColumns[key.Field]
+		if !ok {
+			return "", fmt.Errorf("%w: unsupported sort field: %s", domainErrors.ErrInvalidInput, key.Field)
+		}
+
+		direction := "ASC"
+		if key.Descending {
+			direction = "DESC"
+		}
+		terms = append(terms, column+" "+direction)
+	}
+
+	// すべてのキーが同じ値のアイテムの順序が安定するよう ID を最後のキーにする
+	terms = append(terms, "id ASC")
+	return strings.Join(terms, ", "), nil
+}
+
+// フィルター条件から WHERE 句とバインド引数を組み立てる
+// 値は必ずプレースホルダー経由で渡し、SQL に埋め込まない
+// 論理削除されたアイテムは Deleted が指定された場合のみ対象にする
+func buildItemFilter(filter usecase.ItemFilter, dialect Dialect) (string, []interface{}) {
+	conditions := []string{"deleted_at IS NULL"}
+	if filter.Deleted {
+		conditions = []string{"deleted_at IS NOT NULL"}
+	}
+	var args []interface{}
+
+	switch len(filter.Categories) {
+	case 0:
+	case 1:
+		conditions = append(conditions, "category = ?")
+		args = append(args, filter.Categories[0])
+	default:
+		conditions = append(conditions, "category IN ("+placeholders(len(filter.Categories))+")")
+		for _, category := range filter.Categories {
+			args = append(args, category)
+		}
+	}
+	if len(filter.ExcludedCategories) > 0 {
+		conditions = append(conditions, "category NOT IN ("+placeholders(len(filter.ExcludedCategories))+")")
+		for _, category := range filter.ExcludedCategories {
+			args = append(args, category)
+		}
+	}
+	if filter.Brand != nil {
+		conditions = append(conditions, "brand = ?")
+		args = append(args, *filter.Brand)
+	}
+	if filter.UserID != nil {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, *filter.UserID)
+	}
+	if len(filter.IDs) > 0 {
+		conditions = append(conditions, "id IN ("+placeholders(len(filter.IDs))+")")
+		for _, id := range filter.IDs {
+			args = append(args, id)
+		}
+	}
+	// タグは item_tags と tags を結合して絞り込む
+	// すべてのタグに一致させる場合は、一致したタグの数がタグの数と等しいアイテムに限る（item_tags の主キーで重複しない）
+	if len(filter.Tags) > 0 {
+		tagged := "SELECT it.item_id FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE t.name IN (" + placeholders(len(filter.Tags)) + ")"
+		for _, tag := range filter.Tags {
+			args = append(args, tag)
+		}
+		if !filter.TagsMatchAny && len(filter.Tags) > 1 {
+			tagged += " GROUP BY it.item_id HAVING COUNT(*) = ?"
+			args = append(args, len(filter.Tags))
+		}
+		conditions = append(conditions, "id IN ("+tagged+")")
+	}
+	for _, attribute := range filter.Attributes {
+		conditions = append(conditions, dialect.jsonText("attributes")+" = ?")
+		args = append(args, dialect.jsonPath(attribute.Path), attribute.Value)
+	}
+	switch {
+	case filter.MinPrice != nil && filter.MaxPrice != nil:
+		conditions = append(conditions, "purchase_price BETWEEN ? AND ?")
+		args = append(args, *filter.MinPrice, *filter.MaxPrice)
+	case filter.MinPrice != nil:
+		conditions = append(conditions, "purchase_price >= ?")
+		args = append(args, *filter.MinPrice)
+	case filter.MaxPrice != nil:
+		conditions = append(conditions, "purchase_price <= ?")
+		args = append(args, *filter.MaxPrice)
+	}
+	switch {
+	case filter.PurchasedAfter != nil && filter.PurchasedBefore != nil:
+		conditions = append(conditions, "purchase_date BETWEEN ? AND ?")
+		args = append(args, filter.PurchasedAfter.Format("2006-01-02"), filter.PurchasedBefore.Format("2006-01-02"))
+	case filter.PurchasedAfter != nil:
+		conditions = append(conditions, "purchase_date >= ?")
+		args = append(args, filter.PurchasedAfter.Format("2006-01-02"))
+	case filter.PurchasedBefore != nil:
+		conditions = append(conditions, "purchase_date <= ?")
+		args = append(args, filter.PurchasedBefore.Format("2006-01-02"))
+	}
+	if filter.Search != "" {
+		conditions = append(conditions, "LOWER(name) LIKE ? ESCAPE "+dialect.likeEscape())
+		args = append(args, "%"+escapeLike(strings.ToLower(filter.Search))+"%")
+	}
+
+	return "WHERE " + strings.Join(conditions, " AND "), args
+}
+
+// IN 句に使う n 個のプレースホルダー（"?, ?, ?"）
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+// LIKE のワイルドカード文字をエスケープし、文字どおりに一致させる
+func escapeLike(value string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+	return replacer.Replace(value)
+}
+
+func (r *ItemRepository) queryItems(ctx context.Context, query string, args ...interface{}) ([]*entity.Item, error) {
+	rows, err := r.Query(ctx, query, args...)
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+	defer rows.Close()
+
+	items := []*entity.Item{}
+	for rows.Next() {
+		item, err := scanItem(rows)
+		if err != nil {
+			return nil, dbError(ctx, err)
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return items, nil
+}
+
+// dbError は SQL の実行エラーをドメインエラーにする（一意制約違反は ErrDuplicateItem、期限切れは ErrTimeout、それ以外は ErrDatabaseError）
+// ドライバーによっては期限切れを独自のエラーで返すため、context の状態も確認する
+func dbError(ctx context.Context, err error) error {
+	// アイテムの変更で違反しうる一意制約は items の (category, name) のみ（tags は登録済みの名前を無視して挿入する）
+	// categories の一意制約違反は categoryError で ErrDuplicateCategory にする
+	if errors.Is(err, ErrUniqueViolation) {
+		return fmt.Errorf("%w: an item with the same name already exists in the category", domainErrors.ErrDuplicateItem)
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %s", domainErrors.ErrTimeout, err.Error())
+	}
+	return fmt.Errorf("%w: %s", domainErrors.ErrDatabaseError, err.Error())
 }
 
 func scanItem(scanner interface {
@@ -173,6 +886,8 @@ func scanItem(scanner interface {
 	var item entity.Item
 	var purchaseDate string
 	var createdAt, updatedAt time.Time
+	var deletedAt sql.NullTime
+	var warrantyExpiry, notes, imageURL, attributes, tags sql.NullString
 
 	err := scanner.Scan(
 		&item.ID,
@@ -183,6 +898,14 @@ func scanItem(scanner interface {
 		&purchaseDate,
 		&createdAt,
 		&updatedAt,
+		&deletedAt,
+		&item.Version,
+		&warrantyExpiry,
+		&notes,
+		&imageURL,
+		&attributes,
+		&item.UserID,
+		&tags,
 	)
 	if err != nil {
 		return nil, err
@@ -194,10 +917,53 @@ func scanItem(scanner interface {
 
 	item.CreatedAt = createdAt
 	item.UpdatedAt = updatedAt
+	if deletedAt.Valid {
+		item.DeletedAt = &deletedAt.Time
+	}
+	if warrantyExpiry.Valid {
+		expiry := normalizeDateString(warrantyExpiry.String)
+		item.WarrantyExpiry = &expiry
+	}
+	item.Notes = notes.String
+	item.ImageURL = imageURL.String
+	if attributes.Valid {
+		if err := json.Unmarshal([]byte(attributes.String), &item.Attributes); err != nil {
+			return nil, fmt.Errorf("failed to decode attributes: %w", err)
+		}
+	}
+	item.Tags = []string{}
+	if tags.String != "" {
+		item.Tags = strings.Split(tags.String, ",")
+	}
 
 	return &item, nil
 }
 
+// nil の場合は NULL としてバインドする
+func nullableString(value *string) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: *value, Valid: true}
+}
+
+// 空文字の場合は NULL としてバインドする（メモなし・画像なしは NULL で保存する）
+func emptyAsNull(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+// 追加情報を JSON の文字列としてバインドする（追加情報なしは NULL で保存する）
+func attributesJSON(attributes map[string]interface{}) (sql.NullString, error) {
+	if len(attributes) == 0 {
+		return sql.NullString{}, nil
+	}
+	data, err := json.Marshal(attributes)
+	if err != nil {
+		return sql.NullString{}, fmt.Errorf("failed to encode attributes: %w", err)
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
 func normalizeDateString(value string) string {
 	layouts := []string{
 		"2006-01-02",
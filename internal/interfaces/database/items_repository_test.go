@@ -0,0 +1,1280 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// fakeSqlHandler は発行された SQL と引数を記録し、用意した行を返すテスト用の SqlHandler
+type fakeSqlHandler struct {
+	statements []string
+	args       [][]interface{}
+	rows       [][]interface{}
+	row        []interface{}
+	result     fakeResult
+	err        error
+	tx         *fakeTx
+}
+
+// fakeTx は fakeSqlHandler に SQL を委譲し、コミット・ロールバックの呼び出しを記録する
+// failOnExecute 回目の Execute でエラーを返す（0 の場合は失敗しない）
+type fakeTx struct {
+	handler       *fakeSqlHandler
+	executions    int
+	failOnExecute int
+	committed     bool
+	rolledBack    bool
+}
+
+func (t *fakeTx) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	t.executions++
+	if t.failOnExecute > 0 && t.executions == t.failOnExecute {
+		t.handler.record(statement, args)
+		return nil, fmt.Errorf("simulated failure")
+	}
+	result, err := t.handler.Execute(ctx, statement, args...)
+	if err == nil {
+		t.handler.result.lastInsertID++
+	}
+	return result, err
+}
+
+func (t *fakeTx) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	return t.handler.Query(ctx, statement, args...)
+}
+
+func (t *fakeTx) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	return t.handler.QueryRow(ctx, statement, args...)
+}
+
+func (t *fakeTx) Commit() error {
+	t.committed = true
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.rolledBack = true
+	return nil
+}
+
+func (h *fakeSqlHandler) record(statement string, args []interface{}) {
+	h.statements = append(h.statements, normalizeSQL(statement))
+	h.args = append(h.args, args)
+}
+
+func (h *fakeSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	h.record(statement, args)
+	if h.err != nil {
+		return nil, h.err
+	}
+	return h.result, nil
+}
+
+func (h *fakeSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	h.record(statement, args)
+	if h.err != nil {
+		return nil, h.err
+	}
+	return &fakeRows{rows: h.rows, index: -1}, nil
+}
+
+func (h *fakeSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	h.record(statement, args)
+	return &fakeRow{values: h.row, err: h.err}
+}
+
+func (h *fakeSqlHandler) Begin(ctx context.Context) (Tx, error) {
+	h.tx = &fakeTx{handler: h}
+	return h.tx, nil
+}
+
+func (h *fakeSqlHandler) Ping(ctx context.Context) error {
+	return h.err
+}
+
+func (h *fakeSqlHandler) Close() error {
+	return nil
+}
+
+func (h *fakeSqlHandler) lastStatement() string {
+	return h.statements[len(h.statements)-1]
+}
+
+func (h *fakeSqlHandler) lastArgs() []interface{} {
+	return h.args[len(h.args)-1]
+}
+
+type fakeResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r fakeResult) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r fakeResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+type fakeRows struct {
+	rows  [][]interface{}
+	index int
+}
+
+func (r *fakeRows) Next() bool {
+	r.index++
+	return r.index < len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...interface{}) error {
+	return assignValues(r.rows[r.index], dest)
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Err() error {
+	return nil
+}
+
+type fakeRow struct {
+	values []interface{}
+	err    error
+}
+
+func (r *fakeRow) Scan(dest ...interface{}) error {
+	if r.err != nil {
+		return r.err
+	}
+	if r.values == nil {
+		return sql.ErrNoRows
+	}
+	return assignValues(r.values, dest)
+}
+
+func assignValues(values []interface{}, dest []interface{}) error {
+	if len(values) != len(dest) {
+		return fmt.Errorf("expected %d columns, got %d", len(dest), len(values))
+	}
+	for i, value := range values {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+// 空白の差異を無視して SQL を比較できるように正規化する
+func normalizeSQL(statement string) string {
+	return strings.Join(strings.Fields(statement), " ")
+}
+
+// items テーブルの 1 行分のカラム値
+func itemRow(id int64, name, category, brand string, price int, purchaseDate string) []interface{} {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []interface{}{id, name, category, brand, price, purchaseDate, now, now, sql.NullTime{}, 1, sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, "", sql.NullString{}}
+}
+
+func TestItemRepository_GetItems(t *testing.T) {
+	t.Run("no filter", func(t *testing.T) {
+		handler := &fakeSqlHandler{rows: [][]interface{}{
+			itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01"),
+			itemRow(2, "バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02"),
+		}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		items, err := repo.GetItems(context.Background(), usecase.ItemFilter{}, usecase.SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL ORDER BY")
+		assert.Equal(t, []interface{}{20, 0}, handler.lastArgs())
+	})
+
+	t.Run("category filter", func(t *testing.T) {
+		handler := &fakeSqlHandler{rows: [][]interface{}{
+			itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01"),
+		}}
+		repo := &ItemRepository{SqlHandler: handler}
+		category := "時計"
+
+		items, err := repo.GetItems(context.Background(), usecase.ItemFilter{Categories: []string{category}}, usecase.SortOption{}, 10, 5)
+
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND category = ?")
+		assert.Equal(t, []interface{}{"時計", 10, 5}, handler.lastArgs())
+	})
+}
+
+func TestItemRepository_GetItemsWithCombinedFilter(t *testing.T) {
+	handler := &fakeSqlHandler{}
+	repo := &ItemRepository{SqlHandler: handler}
+	category, brand := "時計", "ROLEX"
+
+	_, err := repo.GetItems(context.Background(), usecase.ItemFilter{Categories: []string{category}, Brand: &brand}, usecase.SortOption{}, 20, 0)
+
+	require.NoError(t, err)
+	assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND category = ? AND brand = ?")
+	assert.Equal(t, []interface{}{"時計", "ROLEX", 20, 0}, handler.lastArgs())
+}
+
+func TestItemRepository_GetItemsWithMultipleCategories(t *testing.T) {
+	handler := &fakeSqlHandler{}
+	repo := &ItemRepository{SqlHandler: handler}
+	brand := "ROLEX"
+
+	_, err := repo.GetItems(context.Background(), usecase.ItemFilter{Categories: []string{"時計", "バッグ"}, Brand: &brand}, usecase.SortOption{}, 20, 0)
+
+	require.NoError(t, err)
+	assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND category IN (?, ?) AND brand = ?")
+	assert.Equal(t, []interface{}{"時計", "バッグ", "ROLEX", 20, 0}, handler.lastArgs())
+}
+
+func TestItemRepository_GetItemsWithExcludedCategories(t *testing.T) {
+	handler := &fakeSqlHandler{}
+	repo := &ItemRepository{SqlHandler: handler}
+	minPrice := 100000
+
+	_, err := repo.GetItems(context.Background(), usecase.ItemFilter{ExcludedCategories: []string{"その他", "靴"}, MinPrice: &minPrice}, usecase.SortOption{}, 20, 0)
+
+	require.NoError(t, err)
+	assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND category NOT IN (?, ?) AND purchase_price >= ?")
+	assert.Equal(t, []interface{}{"その他", "靴", 100000, 20, 0}, handler.lastArgs())
+}
+
+func TestItemRepository_GetItemsWithTags(t *testing.T) {
+	tests := []struct {
+		name         string
+		filter       usecase.ItemFilter
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{
+			name:         "single tag",
+			filter:       usecase.ItemFilter{Tags: []string{"gift"}},
+			expectedSQL:  "WHERE deleted_at IS NULL AND id IN (SELECT it.item_id FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE t.name IN (?)) ORDER BY",
+			expectedArgs: []interface{}{"gift", 20, 0},
+		},
+		{
+			name:         "all tags",
+			filter:       usecase.ItemFilter{Tags: []string{"gift", "vintage"}},
+			expectedSQL:  "WHERE t.name IN (?, ?) GROUP BY it.item_id HAVING COUNT(*) = ?) ORDER BY",
+			expectedArgs: []interface{}{"gift", "vintage", 2, 20, 0},
+		},
+		{
+			name:         "any tag",
+			filter:       usecase.ItemFilter{Tags: []string{"gift", "vintage"}, TagsMatchAny: true},
+			expectedSQL:  "WHERE t.name IN (?, ?)) ORDER BY",
+			expectedArgs: []interface{}{"gift", "vintage", 20, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &fakeSqlHandler{}
+			repo := &ItemRepository{SqlHandler: handler}
+
+			_, err := repo.GetItems(context.Background(), tt.filter, usecase.SortOption{}, 20, 0)
+
+			require.NoError(t, err)
+			assert.Contains(t, handler.lastStatement(), tt.expectedSQL)
+			assert.Equal(t, tt.expectedArgs, handler.lastArgs())
+		})
+	}
+}
+
+func TestItemRepository_GetItemsWithAttributes(t *testing.T) {
+	tests := []struct {
+		name         string
+		filter       usecase.ItemFilter
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{
+			name:         "top-level member",
+			filter:       usecase.ItemFilter{Attributes: []usecase.AttributeFilter{{Path: []string{"material"}, Value: "leather"}}},
+			expectedSQL:  "WHERE deleted_at IS NULL AND JSON_UNQUOTE(JSON_EXTRACT(attributes, ?)) = ? ORDER BY",
+			expectedArgs: []interface{}{`$."material"`, "leather", 20, 0},
+		},
+		{
+			name: "nested members are combined by AND",
+			filter: usecase.ItemFilter{Attributes: []usecase.AttributeFilter{
+				{Path: []string{"case", "material"}, Value: "steel"},
+				{Path: []string{"size_mm"}, Value: "40"},
+			}},
+			expectedSQL:  "JSON_UNQUOTE(JSON_EXTRACT(attributes, ?)) = ? AND JSON_UNQUOTE(JSON_EXTRACT(attributes, ?)) = ? ORDER BY",
+			expectedArgs: []interface{}{`$."case"."material"`, "steel", `$."size_mm"`, "40", 20, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &fakeSqlHandler{}
+			repo := &ItemRepository{SqlHandler: handler}
+
+			_, err := repo.GetItems(context.Background(), tt.filter, usecase.SortOption{}, 20, 0)
+
+			require.NoError(t, err)
+			assert.Contains(t, handler.lastStatement(), tt.expectedSQL)
+			assert.Equal(t, tt.expectedArgs, handler.lastArgs())
+		})
+	}
+}
+
+func TestItemRepository_GetItemsWithSearch(t *testing.T) {
+	tests := []struct {
+		name            string
+		search          string
+		expectedPattern string
+	}{
+		{name: "partial match", search: "デイトナ", expectedPattern: "%デイトナ%"},
+		{name: "case-insensitive", search: "RoLeX", expectedPattern: "%rolex%"},
+		{name: "percent is escaped", search: "100%", expectedPattern: `%100\%%`},
+		{name: "underscore is escaped", search: "a_b", expectedPattern: `%a\_b%`},
+		{name: "backslash is escaped", search: `a\b`, expectedPattern: `%a\\b%`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &fakeSqlHandler{}
+			repo := &ItemRepository{SqlHandler: handler}
+
+			_, err := repo.GetItems(context.Background(), usecase.ItemFilter{Search: tt.search}, usecase.SortOption{}, 20, 0)
+
+			require.NoError(t, err)
+			assert.Contains(t, handler.lastStatement(), `WHERE deleted_at IS NULL AND LOWER(name) LIKE ? ESCAPE '\\'`)
+			assert.Equal(t, []interface{}{tt.expectedPattern, 20, 0}, handler.lastArgs())
+		})
+	}
+
+	t.Run("empty search is not applied", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.GetItems(context.Background(), usecase.ItemFilter{}, usecase.SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		assert.NotContains(t, handler.lastStatement(), "LIKE")
+	})
+}
+
+func TestItemRepository_GetItemsWithPriceRange(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+
+	tests := []struct {
+		name         string
+		filter       usecase.ItemFilter
+		expected     string
+		expectedArgs []interface{}
+	}{
+		{
+			name:         "both bounds",
+			filter:       usecase.ItemFilter{MinPrice: intPtr(1000), MaxPrice: intPtr(5000)},
+			expected:     "WHERE deleted_at IS NULL AND purchase_price BETWEEN ? AND ?",
+			expectedArgs: []interface{}{1000, 5000, 20, 0},
+		},
+		{
+			name:         "min only",
+			filter:       usecase.ItemFilter{MinPrice: intPtr(1000)},
+			expected:     "WHERE deleted_at IS NULL AND purchase_price >= ?",
+			expectedArgs: []interface{}{1000, 20, 0},
+		},
+		{
+			name:         "max only",
+			filter:       usecase.ItemFilter{MaxPrice: intPtr(5000)},
+			expected:     "WHERE deleted_at IS NULL AND purchase_price <= ?",
+			expectedArgs: []interface{}{5000, 20, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &fakeSqlHandler{}
+			repo := &ItemRepository{SqlHandler: handler}
+
+			_, err := repo.GetItems(context.Background(), tt.filter, usecase.SortOption{}, 20, 0)
+
+			require.NoError(t, err)
+			assert.Contains(t, handler.lastStatement(), tt.expected)
+			assert.Equal(t, tt.expectedArgs, handler.lastArgs())
+		})
+	}
+}
+
+func TestItemRepository_GetItemsWithDateRange(t *testing.T) {
+	after := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+	category := "時計"
+
+	tests := []struct {
+		name         string
+		filter       usecase.ItemFilter
+		expected     string
+		expectedArgs []interface{}
+	}{
+		{
+			name:         "bounded range",
+			filter:       usecase.ItemFilter{PurchasedAfter: &after, PurchasedBefore: &before},
+			expected:     "WHERE deleted_at IS NULL AND purchase_date BETWEEN ? AND ?",
+			expectedArgs: []interface{}{"2023-01-01", "2023-12-31", 20, 0},
+		},
+		{
+			name:         "open-ended range",
+			filter:       usecase.ItemFilter{PurchasedAfter: &after},
+			expected:     "WHERE deleted_at IS NULL AND purchase_date >= ?",
+			expectedArgs: []interface{}{"2023-01-01", 20, 0},
+		},
+		{
+			name:         "composed with category",
+			filter:       usecase.ItemFilter{Categories: []string{category}, PurchasedBefore: &before},
+			expected:     "WHERE deleted_at IS NULL AND category = ? AND purchase_date <= ?",
+			expectedArgs: []interface{}{"時計", "2023-12-31", 20, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &fakeSqlHandler{}
+			repo := &ItemRepository{SqlHandler: handler}
+
+			_, err := repo.GetItems(context.Background(), tt.filter, usecase.SortOption{}, 20, 0)
+
+			require.NoError(t, err)
+			assert.Contains(t, handler.lastStatement(), tt.expected)
+			assert.Equal(t, tt.expectedArgs, handler.lastArgs())
+		})
+	}
+}
+
+func TestItemRepository_GetItemsWithSort(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     usecase.SortOption
+		expected string
+	}{
+		{name: "default order", sort: usecase.SortOption{}, expected: "ORDER BY created_at DESC"},
+		{name: "purchase_date ascending", sort: usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByPurchaseDate}}}, expected: "ORDER BY purchase_date ASC, id ASC"},
+		{name: "purchase_date descending", sort: usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByPurchaseDate, Descending: true}}}, expected: "ORDER BY purchase_date DESC, id ASC"},
+		{name: "purchase_price ascending", sort: usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByPurchasePrice}}}, expected: "ORDER BY purchase_price ASC, id ASC"},
+		{name: "purchase_price descending with id tie-break", sort: usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByPurchasePrice, Descending: true}}}, expected: "ORDER BY purchase_price DESC, id ASC"},
+		{name: "created_at ascending", sort: usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByCreatedAt}}}, expected: "ORDER BY created_at ASC, id ASC"},
+		{name: "created_at descending", sort: usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByCreatedAt, Descending: true}}}, expected: "ORDER BY created_at DESC, id ASC"},
+		{
+			name:     "category then purchase_price descending",
+			sort:     usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByCategory}, {Field: usecase.SortByPurchasePrice, Descending: true}}},
+			expected: "ORDER BY category ASC, purchase_price DESC, id ASC",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := &fakeSqlHandler{}
+			repo := &ItemRepository{SqlHandler: handler}
+
+			_, err := repo.GetItems(context.Background(), usecase.ItemFilter{}, tt.sort, 20, 0)
+
+			require.NoError(t, err)
+			assert.Contains(t, handler.lastStatement(), tt.expected)
+		})
+	}
+
+	t.Run("arbitrary column names are rejected", func(t *testing.T) {
+		for _, field := range []string{"name", "id; DROP TABLE items", "purchase_date DESC, (SELECT 1)"} {
+			handler := &fakeSqlHandler{}
+			repo := &ItemRepository{SqlHandler: handler}
+
+			// 許可されたキーの後ろに指定した場合も拒否する
+			for _, keys := range [][]usecase.SortKey{
+				{{Field: usecase.SortField(field)}},
+				{{Field: usecase.SortByCategory}, {Field: usecase.SortField(field)}},
+			} {
+				_, err := repo.GetItems(context.Background(), usecase.ItemFilter{}, usecase.SortOption{Keys: keys}, 20, 0)
+
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput, field)
+				assert.Empty(t, handler.statements, "no query should be issued for %q", field)
+			}
+		}
+	})
+}
+
+func TestItemRepository_Count(t *testing.T) {
+	handler := &fakeSqlHandler{row: []interface{}{3}}
+	repo := &ItemRepository{SqlHandler: handler}
+	category := "バッグ"
+
+	count, err := repo.Count(context.Background(), usecase.ItemFilter{Categories: []string{category}})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.Equal(t, "SELECT COUNT(*) FROM items WHERE deleted_at IS NULL AND category = ?", handler.lastStatement())
+	assert.Equal(t, []interface{}{"バッグ"}, handler.lastArgs())
+}
+
+func TestItemRepository_Exists(t *testing.T) {
+	t.Run("exists", func(t *testing.T) {
+		handler := &fakeSqlHandler{row: []interface{}{1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		exists, err := repo.Exists(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.True(t, exists)
+		// カラムは読み込まない
+		assert.Equal(t, "SELECT 1 FROM items WHERE id = ? AND deleted_at IS NULL", handler.lastStatement())
+		assert.Equal(t, []interface{}{int64(1)}, handler.lastArgs())
+	})
+
+	t.Run("does not exist", func(t *testing.T) {
+		repo := &ItemRepository{SqlHandler: &fakeSqlHandler{}}
+
+		exists, err := repo.Exists(context.Background(), 2)
+
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		repo := &ItemRepository{SqlHandler: &fakeSqlHandler{err: fmt.Errorf("connection refused")}}
+
+		_, err := repo.Exists(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+}
+
+func TestItemRepository_FindByIDs(t *testing.T) {
+	t.Run("ids are bound as placeholders", func(t *testing.T) {
+		handler := &fakeSqlHandler{rows: [][]interface{}{
+			itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01"),
+			itemRow(3, "靴1", "靴", "Christian Louboutin", 150000, "2023-01-03"),
+		}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		items, err := repo.FindByIDs(context.Background(), []int64{3, 2, 1})
+
+		require.NoError(t, err)
+		assert.Len(t, items, 2)
+		assert.Contains(t, handler.lastStatement(), "WHERE id IN (?, ?, ?) AND deleted_at IS NULL")
+		assert.Equal(t, []interface{}{int64(3), int64(2), int64(1)}, handler.lastArgs())
+	})
+
+	t.Run("empty ids do not query", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		items, err := repo.FindByIDs(context.Background(), nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, items)
+		assert.Empty(t, handler.statements)
+	})
+}
+
+func TestItemRepository_CreateItems(t *testing.T) {
+	newItems := func() []*entity.Item {
+		item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02")
+		return []*entity.Item{item1, item2}
+	}
+
+	t.Run("commits when every insert succeeds", func(t *testing.T) {
+		handler := &fakeSqlHandler{row: itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		created, err := repo.CreateItems(context.Background(), newItems())
+
+		require.NoError(t, err)
+		assert.Len(t, created, 2)
+		assert.True(t, handler.tx.committed)
+		assert.False(t, handler.tx.rolledBack)
+	})
+
+	t.Run("rolls back when an insert fails", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: &failingBeginHandler{fakeSqlHandler: handler, failOnExecute: 2}}
+
+		created, err := repo.CreateItems(context.Background(), newItems())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, created)
+		assert.False(t, handler.tx.committed)
+		assert.True(t, handler.tx.rolledBack)
+	})
+}
+
+// failingBeginHandler は開始したトランザクションの failOnExecute 回目の Execute を失敗させる
+type failingBeginHandler struct {
+	*fakeSqlHandler
+	failOnExecute int
+}
+
+func (h *failingBeginHandler) Begin(ctx context.Context) (Tx, error) {
+	tx, _ := h.fakeSqlHandler.Begin(ctx)
+	tx.(*fakeTx).failOnExecute = h.failOnExecute
+	return tx, nil
+}
+
+func TestItemRepository_DeleteItems(t *testing.T) {
+	handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	deleted, err := repo.DeleteItems(context.Background(), []int64{1, 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, deleted)
+	assert.Equal(t, "UPDATE items SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL", handler.lastStatement())
+	assert.True(t, handler.tx.committed)
+}
+
+func TestItemRepository_MoveItems(t *testing.T) {
+	rows := [][]interface{}{
+		itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01"),
+		itemRow(2, "時計2", "時計", "OMEGA", 500000, "2023-01-02"),
+	}
+
+	t.Run("moves the items and records the previous versions in one transaction", func(t *testing.T) {
+		handler := &fakeSqlHandler{rows: rows, result: fakeResult{rowsAffected: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		moved, err := repo.MoveItems(context.Background(), []int64{1, 2, 3}, "バッグ")
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, moved)
+		require.Len(t, handler.statements, 5)
+		assert.Contains(t, handler.statements[0], "FROM items WHERE id IN (?, ?, ?) AND deleted_at IS NULL")
+		assert.Equal(t, "UPDATE items SET category = ?, version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL", handler.statements[1])
+		assert.Equal(t, []interface{}{"バッグ", int64(1)}, handler.args[1])
+		assert.Equal(t, "INSERT INTO item_versions (item_id, version, snapshot, updated_at) VALUES (?, ?, ?, ?)", handler.statements[2])
+		assert.Equal(t, []interface{}{int64(1), 1}, handler.args[2][:2])
+		var snapshot entity.Item
+		require.NoError(t, json.Unmarshal([]byte(handler.args[2][2].(string)), &snapshot))
+		assert.Equal(t, "時計", snapshot.Category)
+		assert.Equal(t, []interface{}{"バッグ", int64(2)}, handler.args[3])
+		assert.Equal(t, []interface{}{int64(2), 1}, handler.args[4][:2])
+		assert.True(t, handler.tx.committed)
+	})
+
+	t.Run("rolls back when an update fails", func(t *testing.T) {
+		handler := &fakeSqlHandler{rows: rows, result: fakeResult{rowsAffected: 1}}
+		repo := &ItemRepository{SqlHandler: &failingBeginHandler{fakeSqlHandler: handler, failOnExecute: 3}}
+
+		moved, err := repo.MoveItems(context.Background(), []int64{1, 2}, "バッグ")
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Nil(t, moved)
+		assert.False(t, handler.tx.committed)
+		assert.True(t, handler.tx.rolledBack)
+	})
+}
+
+func TestItemRepository_SoftDelete(t *testing.T) {
+	t.Run("delete keeps the row and sets deleted_at", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.Delete(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.NotContains(t, handler.lastStatement(), "DELETE FROM")
+		assert.Equal(t, "UPDATE items SET deleted_at = CURRENT_TIMESTAMP WHERE id = ? AND deleted_at IS NULL", handler.lastStatement())
+	})
+
+	t.Run("deleting an already deleted item is not found", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 0}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.Delete(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("reads exclude soft-deleted rows", func(t *testing.T) {
+		handler := &fakeSqlHandler{row: itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")}
+		repo := &ItemRepository{SqlHandler: handler}
+		ctx := context.Background()
+
+		_, _ = repo.FindAll(ctx, usecase.ItemFilter{})
+		_, _ = repo.GetItems(ctx, usecase.ItemFilter{}, usecase.SortOption{}, 20, 0)
+		_, _ = repo.GetItemsAfter(ctx, usecase.ItemFilter{}, 0, 20)
+		_, _ = repo.Count(ctx, usecase.ItemFilter{})
+		_, _ = repo.FindByID(ctx, 1)
+		_, _ = repo.GetSummaryByCategory(ctx, usecase.ItemFilter{})
+
+		require.Len(t, handler.statements, 6)
+		for _, statement := range handler.statements {
+			assert.Contains(t, statement, "deleted_at IS NULL")
+		}
+	})
+
+	t.Run("deleted_at is scanned into the entity", func(t *testing.T) {
+		row := itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		row[8] = sql.NullTime{Time: deletedAt, Valid: true}
+		handler := &fakeSqlHandler{row: row}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		item, err := repo.FindByID(context.Background(), 1)
+
+		require.NoError(t, err)
+		if assert.NotNil(t, item.DeletedAt) {
+			assert.Equal(t, deletedAt, *item.DeletedAt)
+		}
+	})
+}
+
+func TestItemRepository_UpdateVersion(t *testing.T) {
+	item := &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", Version: 3}
+
+	t.Run("update checks and increments version", func(t *testing.T) {
+		row := itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		row[9] = 4
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}, row: row}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		updated, err := repo.Update(context.Background(), item)
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, updated.Version)
+		assert.Contains(t, handler.statements[0], "version = version + 1, updated_at = CURRENT_TIMESTAMP WHERE id = ? AND version = ? AND deleted_at IS NULL")
+		// created_at は更新しない
+		assert.NotContains(t, handler.statements[0], "created_at")
+		assert.Equal(t, []interface{}{"時計1", "時計", "ROLEX", 1000000, "2023-01-01", sql.NullString{}, sql.NullString{}, sql.NullString{}, sql.NullString{}, int64(1), 3}, handler.args[0])
+	})
+
+	t.Run("stale version is a conflict", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 0}, row: itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.Update(context.Background(), item)
+
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+	})
+
+	t.Run("missing item is not found", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 0}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.Update(context.Background(), item)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestItemRepository_Restore(t *testing.T) {
+	t.Run("only soft-deleted rows are matched", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.Restore(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, "UPDATE items SET deleted_at = NULL WHERE id = ? AND deleted_at IS NOT NULL", handler.lastStatement())
+	})
+
+	t.Run("no soft-deleted row is not found", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 0}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.Restore(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+func TestItemRepository_GetDeletedItems(t *testing.T) {
+	handler := &fakeSqlHandler{row: []interface{}{0}}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	_, err := repo.GetItems(context.Background(), usecase.ItemFilter{Deleted: true}, usecase.SortOption{}, 20, 0)
+	require.NoError(t, err)
+	_, err = repo.Count(context.Background(), usecase.ItemFilter{Deleted: true})
+	require.NoError(t, err)
+
+	for _, statement := range handler.statements {
+		assert.Contains(t, statement, "WHERE deleted_at IS NOT NULL")
+		assert.NotContains(t, statement, "deleted_at IS NULL")
+	}
+}
+
+func TestItemRepository_FindChangedSince(t *testing.T) {
+	deleted := itemRow(2, "バッグ1", "バッグ", "HERMÈS", 2000000, "2023-02-20")
+	deleted[8] = sql.NullTime{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Valid: true}
+	handler := &fakeSqlHandler{rows: [][]interface{}{
+		itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01"),
+		deleted,
+	}}
+	repo := &ItemRepository{SqlHandler: handler}
+	since := time.Date(2024, 1, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	items, err := repo.FindChangedSince(context.Background(), nil, since)
+
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Nil(t, items[0].DeletedAt)
+	assert.NotNil(t, items[1].DeletedAt)
+	// 論理削除されたアイテムも対象にする
+	assert.NotContains(t, handler.lastStatement(), "deleted_at IS")
+	assert.Contains(t, handler.lastStatement(), "WHERE updated_at >= ?")
+	assert.Contains(t, handler.lastStatement(), "ORDER BY updated_at, id")
+	assert.Equal(t, []interface{}{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}, handler.lastArgs())
+}
+
+func TestItemRepository_WarrantyExpiry(t *testing.T) {
+	t.Run("warranty_expiry is stored and scanned", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{lastInsertID: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+		expiry := "2025-01-15"
+		item := &entity.Item{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-15", WarrantyExpiry: &expiry}
+
+		_, err := repo.insertItem(context.Background(), handler, item)
+
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{"時計1", "時計", "ROLEX", 1000000, "2023-01-15", sql.NullString{String: "2025-01-15", Valid: true}, sql.NullString{}, sql.NullString{}, sql.NullString{}, ""}, handler.lastArgs())
+
+		row := itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-15")
+		row[10] = sql.NullString{String: "2025-01-15T00:00:00Z", Valid: true}
+		scanned, err := scanItem(&fakeRow{values: row})
+		require.NoError(t, err)
+		if assert.NotNil(t, scanned.WarrantyExpiry) {
+			assert.Equal(t, "2025-01-15", *scanned.WarrantyExpiry)
+		}
+	})
+
+	t.Run("expiring query is bounded by dates", func(t *testing.T) {
+		handler := &fakeSqlHandler{rows: [][]interface{}{itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-15")}}
+		repo := &ItemRepository{SqlHandler: handler}
+		from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		items, err := repo.FindWarrantyExpiring(context.Background(), nil, from, from.AddDate(0, 0, 30))
+
+		require.NoError(t, err)
+		assert.Len(t, items, 1)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND warranty_expiry BETWEEN ? AND ? ORDER BY warranty_expiry, id")
+		assert.Equal(t, []interface{}{"2024-01-01", "2024-01-31"}, handler.lastArgs())
+	})
+}
+
+func TestItemRepository_Notes(t *testing.T) {
+	t.Run("empty notes are stored as NULL", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}, row: itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.Update(context.Background(), &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", Version: 1})
+		require.NoError(t, err)
+		assert.Contains(t, handler.statements[0], "warranty_expiry = ?, notes = ?, image_url = ?, attributes = ?, version = version + 1")
+		assert.Equal(t, sql.NullString{}, handler.args[0][6])
+
+		_, err = repo.insertItem(context.Background(), handler, &entity.Item{Name: "時計1", Notes: "正規店で購入"})
+		require.NoError(t, err)
+		assert.Equal(t, sql.NullString{String: "正規店で購入", Valid: true}, handler.lastArgs()[6])
+	})
+
+	t.Run("NULL notes are scanned as empty", func(t *testing.T) {
+		row := itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		item, err := scanItem(&fakeRow{values: row})
+		require.NoError(t, err)
+		assert.Equal(t, "", item.Notes)
+
+		row[11] = sql.NullString{String: "正規店で購入", Valid: true}
+		item, err = scanItem(&fakeRow{values: row})
+		require.NoError(t, err)
+		assert.Equal(t, "正規店で購入", item.Notes)
+	})
+}
+
+func TestItemRepository_ImageURL(t *testing.T) {
+	t.Run("image_url is stored and scanned", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{lastInsertID: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.insertItem(context.Background(), handler, &entity.Item{Name: "時計1", ImageURL: "https://example.com/1.jpg"})
+		require.NoError(t, err)
+		assert.Equal(t, sql.NullString{String: "https://example.com/1.jpg", Valid: true}, handler.lastArgs()[7])
+
+		row := itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		row[12] = sql.NullString{String: "https://example.com/1.jpg", Valid: true}
+		item, err := scanItem(&fakeRow{values: row})
+		require.NoError(t, err)
+		assert.Equal(t, "https://example.com/1.jpg", item.ImageURL)
+	})
+}
+
+func TestItemRepository_Attributes(t *testing.T) {
+	t.Run("attributes are stored as JSON and scanned", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{lastInsertID: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+		attributes := map[string]interface{}{"movement": "automatic", "case": map[string]interface{}{"size_mm": 40.0}}
+
+		_, err := repo.insertItem(context.Background(), handler, &entity.Item{Name: "時計1", Attributes: attributes})
+		require.NoError(t, err)
+		assert.Equal(t, sql.NullString{String: `{"case":{"size_mm":40},"movement":"automatic"}`, Valid: true}, handler.lastArgs()[8])
+
+		row := itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		row[13] = sql.NullString{String: `{"movement": "automatic", "case": {"size_mm": 40}}`, Valid: true}
+		item, err := scanItem(&fakeRow{values: row})
+		require.NoError(t, err)
+		assert.Equal(t, attributes, item.Attributes)
+	})
+
+	t.Run("empty attributes are stored as NULL", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}, row: itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		updated, err := repo.Update(context.Background(), &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", Version: 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, sql.NullString{}, handler.args[0][8])
+		assert.Nil(t, updated.Attributes)
+	})
+}
+
+func TestItemRepository_DuplicateItem(t *testing.T) {
+	uniqueViolation := fmt.Errorf("%w: Error 1062: Duplicate entry", ErrUniqueViolation)
+
+	t.Run("create translates the unique violation", func(t *testing.T) {
+		handler := &fakeSqlHandler{err: uniqueViolation}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.Create(context.Background(), &entity.Item{Name: "rolex", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+		assert.False(t, domainErrors.IsDatabaseError(err))
+		// ドライバーのエラー内容は含めない
+		assert.NotContains(t, err.Error(), "1062")
+		assert.True(t, handler.tx.rolledBack)
+	})
+
+	t.Run("update and restore translate the unique violation", func(t *testing.T) {
+		handler := &fakeSqlHandler{err: uniqueViolation}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.Update(context.Background(), &entity.Item{ID: 1, Name: "rolex", Category: "時計", Version: 1})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+
+		err = repo.Restore(context.Background(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+	})
+
+	t.Run("other errors stay database errors", func(t *testing.T) {
+		handler := &fakeSqlHandler{err: fmt.Errorf("connection refused")}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.Create(context.Background(), &entity.Item{Name: "rolex", Category: "時計"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.False(t, domainErrors.IsDuplicateItemError(err))
+	})
+}
+
+func TestItemRepository_UserID(t *testing.T) {
+	t.Run("user_id is stored and scanned", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{lastInsertID: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.insertItem(context.Background(), handler, &entity.Item{Name: "時計1", UserID: "alice"})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", handler.lastArgs()[9])
+
+		row := itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		row[14] = "alice"
+		item, err := scanItem(&fakeRow{values: row})
+		require.NoError(t, err)
+		assert.Equal(t, "alice", item.UserID)
+	})
+
+	t.Run("filter by user and ids", func(t *testing.T) {
+		handler := &fakeSqlHandler{row: []interface{}{2}}
+		repo := &ItemRepository{SqlHandler: handler}
+		userID := "alice"
+
+		_, err := repo.Count(context.Background(), usecase.ItemFilter{UserID: &userID, IDs: []int64{1, 2}})
+
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND user_id = ? AND id IN (?, ?)")
+		assert.Equal(t, []interface{}{"alice", int64(1), int64(2)}, handler.lastArgs())
+	})
+
+	t.Run("keyset pagination applies the filter", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler}
+		userID := "alice"
+
+		_, err := repo.GetItemsAfter(context.Background(), usecase.ItemFilter{UserID: &userID}, 5, 20)
+
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND user_id = ? AND id > ? ORDER BY id LIMIT ?")
+		assert.Equal(t, []interface{}{"alice", int64(5), 20}, handler.lastArgs())
+	})
+
+	t.Run("changes feed and expiring warranties are filtered in SQL", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler}
+		userID := "alice"
+		since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		_, err := repo.FindChangedSince(context.Background(), &userID, since)
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), "WHERE updated_at >= ? AND user_id = ? ORDER BY updated_at, id")
+		assert.NotContains(t, handler.lastStatement(), "deleted_at IS")
+		assert.Equal(t, []interface{}{since, "alice"}, handler.lastArgs())
+
+		_, err = repo.FindWarrantyExpiring(context.Background(), &userID, since, since.AddDate(0, 0, 30))
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND user_id = ? AND warranty_expiry BETWEEN ? AND ? ORDER BY warranty_expiry, id")
+		assert.Equal(t, []interface{}{"alice", "2024-01-01", "2024-01-31"}, handler.lastArgs())
+	})
+
+	t.Run("analytics aggregate only the filtered user's items", func(t *testing.T) {
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler}
+		userID := "alice"
+		filter := usecase.ItemFilter{UserID: &userID}
+
+		_, err := repo.GetAveragePriceByCategory(context.Background(), filter)
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND user_id = ? GROUP BY category")
+		assert.Equal(t, []interface{}{"alice"}, handler.lastArgs())
+
+		_, err = repo.GetPriceRangeByCategory(context.Background(), filter)
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND user_id = ? GROUP BY category")
+		assert.Equal(t, []interface{}{"alice"}, handler.lastArgs())
+
+		_, err = repo.GetMonthlyTimeline(context.Background(), filter)
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND user_id = ? GROUP BY")
+		assert.Equal(t, []interface{}{"alice"}, handler.lastArgs())
+	})
+}
+
+func TestItemRepository_Tags(t *testing.T) {
+	t.Run("tags are loaded in the same query", func(t *testing.T) {
+		row := itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		row[15] = sql.NullString{String: "gift,vintage", Valid: true}
+		handler := &fakeSqlHandler{rows: [][]interface{}{row, itemRow(2, "時計2", "時計", "OMEGA", 500000, "2023-02-01")}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		items, err := repo.GetItems(context.Background(), usecase.ItemFilter{}, usecase.SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		require.Len(t, items, 2)
+		assert.Equal(t, []string{"gift", "vintage"}, items[0].Tags)
+		assert.Equal(t, []string{}, items[1].Tags)
+		// アイテムごとにタグを取得するクエリは発行しない
+		assert.Len(t, handler.statements, 1)
+		assert.Contains(t, handler.lastStatement(), "SELECT GROUP_CONCAT(t.name ORDER BY t.name SEPARATOR ',') FROM item_tags it JOIN tags t ON t.id = it.tag_id WHERE it.item_id = items.id")
+	})
+
+	t.Run("create assigns tags in the transaction", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{lastInsertID: 1}, row: itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.Create(context.Background(), &entity.Item{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", Tags: []string{"gift", "vintage"}})
+
+		require.NoError(t, err)
+		require.Len(t, handler.statements, 4)
+		assert.Equal(t, "INSERT IGNORE INTO tags (name) VALUES (?), (?)", handler.statements[1])
+		assert.Equal(t, []interface{}{"gift", "vintage"}, handler.args[1])
+		assert.Equal(t, "INSERT INTO item_tags (item_id, tag_id) SELECT ?, id FROM tags WHERE name IN (?, ?)", handler.statements[2])
+		assert.Equal(t, []interface{}{int64(1), "gift", "vintage"}, handler.args[2])
+		assert.True(t, handler.tx.committed)
+	})
+
+	t.Run("update replaces the tag set", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}, row: itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.Update(context.Background(), &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", Version: 1, Tags: []string{"gift"}})
+
+		require.NoError(t, err)
+		require.Len(t, handler.statements, 5)
+		assert.Equal(t, "DELETE FROM item_tags WHERE item_id = ?", handler.statements[1])
+		assert.Equal(t, []interface{}{int64(1)}, handler.args[1])
+		assert.Equal(t, "INSERT IGNORE INTO tags (name) VALUES (?)", handler.statements[2])
+		assert.True(t, handler.tx.committed)
+	})
+
+	t.Run("empty tags only clear the set", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}, row: itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.Update(context.Background(), &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", Version: 1, Tags: []string{}})
+
+		require.NoError(t, err)
+		require.Len(t, handler.statements, 3)
+		assert.Equal(t, "DELETE FROM item_tags WHERE item_id = ?", handler.statements[1])
+	})
+
+	t.Run("stale version leaves tags untouched", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 0}, row: itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01")}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.Update(context.Background(), &entity.Item{ID: 1, Version: 1, Tags: []string{"gift"}})
+
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+		for _, statement := range handler.statements {
+			assert.NotContains(t, statement, "item_tags (item_id")
+		}
+	})
+}
+
+func TestItemRepository_GetSummaryByCategory(t *testing.T) {
+	t.Run("sums per category", func(t *testing.T) {
+		handler := &fakeSqlHandler{rows: [][]interface{}{
+			{"時計", 2, 2500000},
+			{"バッグ", 1, 2000000},
+		}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		summary, err := repo.GetSummaryByCategory(context.Background(), usecase.ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), "SELECT category, COUNT(*) as count, SUM(purchase_price) as total_price")
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL GROUP BY category")
+		assert.Empty(t, handler.lastArgs())
+		assert.Equal(t, map[string]usecase.CategoryStats{
+			"時計":  {Count: 2, TotalPrice: 2500000},
+			"バッグ": {Count: 1, TotalPrice: 2000000},
+		}, summary)
+	})
+
+	t.Run("purchase date window", func(t *testing.T) {
+		from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+		handler := &fakeSqlHandler{}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		_, err := repo.GetSummaryByCategory(context.Background(), usecase.ItemFilter{PurchasedAfter: &from, PurchasedBefore: &to})
+
+		require.NoError(t, err)
+		assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL AND purchase_date BETWEEN ? AND ? GROUP BY category")
+		assert.Equal(t, []interface{}{"2023-01-01", "2023-12-31"}, handler.lastArgs())
+	})
+}
+
+func TestItemRepository_GetAveragePriceByCategory(t *testing.T) {
+	handler := &fakeSqlHandler{rows: [][]interface{}{
+		{"時計", 2, 1150000.0},
+		{"バッグ", 1, 2000000.0},
+	}}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	averages, err := repo.GetAveragePriceByCategory(context.Background(), usecase.ItemFilter{})
+
+	require.NoError(t, err)
+	assert.Contains(t, handler.lastStatement(), "SELECT category, COUNT(*) as count, AVG(purchase_price) as average_price")
+	assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL GROUP BY category")
+	watch, bag := 1150000.0, 2000000.0
+	assert.Equal(t, map[string]usecase.CategoryAveragePrice{
+		"時計":  {Count: 2, AveragePrice: &watch},
+		"バッグ": {Count: 1, AveragePrice: &bag},
+	}, averages)
+}
+
+func TestItemRepository_GetPriceRangeByCategory(t *testing.T) {
+	handler := &fakeSqlHandler{rows: [][]interface{}{
+		{"時計", 2, 800000, 1500000},
+		{"バッグ", 1, 2000000, 2000000},
+	}}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	ranges, err := repo.GetPriceRangeByCategory(context.Background(), usecase.ItemFilter{})
+
+	require.NoError(t, err)
+	assert.Contains(t, handler.lastStatement(), "SELECT category, COUNT(*) as count, MIN(purchase_price) as min_price, MAX(purchase_price) as max_price")
+	assert.Contains(t, handler.lastStatement(), "WHERE deleted_at IS NULL GROUP BY category")
+	price := func(v int) *int { return &v }
+	assert.Equal(t, map[string]usecase.CategoryPriceRange{
+		"時計":  {Count: 2, MinPrice: price(800000), MaxPrice: price(1500000)},
+		"バッグ": {Count: 1, MinPrice: price(2000000), MaxPrice: price(2000000)},
+	}, ranges)
+}
+
+func TestItemRepository_GetMonthlyTimeline(t *testing.T) {
+	handler := &fakeSqlHandler{rows: [][]interface{}{
+		{"2023-01", 2, 2300000},
+		{"2023-03", 1, 300000},
+	}}
+	repo := &ItemRepository{SqlHandler: handler}
+
+	timeline, err := repo.GetMonthlyTimeline(context.Background(), usecase.ItemFilter{})
+
+	require.NoError(t, err)
+	assert.Equal(t, "SELECT DATE_FORMAT(purchase_date, '%Y-%m') as month, COUNT(*) as count, SUM(purchase_price) as total_value "+
+		"FROM items WHERE deleted_at IS NULL GROUP BY DATE_FORMAT(purchase_date, '%Y-%m') ORDER BY month", handler.lastStatement())
+	assert.Equal(t, []usecase.MonthlyStats{
+		{Month: "2023-01", Count: 2, TotalValue: 2300000},
+		{Month: "2023-03", Count: 1, TotalValue: 300000},
+	}, timeline)
+}
+
+// slowSqlHandler は context が終了するまで SQL の実行を待たせ、context のエラーを返す
+type slowSqlHandler struct {
+	*fakeSqlHandler
+}
+
+func (h *slowSqlHandler) Execute(ctx context.Context, statement string, args ...interface{}) (Result, error) {
+	h.record(statement, args)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (h *slowSqlHandler) Query(ctx context.Context, statement string, args ...interface{}) (Rows, error) {
+	h.record(statement, args)
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func (h *slowSqlHandler) QueryRow(ctx context.Context, statement string, args ...interface{}) Row {
+	h.record(statement, args)
+	<-ctx.Done()
+	return &fakeRow{err: ctx.Err()}
+}
+
+func TestItemRepository_QueryTimeout(t *testing.T) {
+	repo := &ItemRepository{SqlHandler: &slowSqlHandler{fakeSqlHandler: &fakeSqlHandler{}}, QueryTimeout: 20 * time.Millisecond}
+
+	t.Run("query", func(t *testing.T) {
+		start := time.Now()
+		_, err := repo.GetItems(context.Background(), usecase.ItemFilter{}, usecase.SortOption{}, 20, 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrTimeout)
+		assert.False(t, domainErrors.IsDatabaseError(err))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("query row", func(t *testing.T) {
+		_, err := repo.FindByID(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrTimeout)
+	})
+
+	t.Run("execute", func(t *testing.T) {
+		err := repo.Delete(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrTimeout)
+	})
+
+	t.Run("other errors are database errors", func(t *testing.T) {
+		repo := &ItemRepository{SqlHandler: &fakeSqlHandler{err: fmt.Errorf("connection refused")}, QueryTimeout: time.Second}
+
+		_, err := repo.GetItems(context.Background(), usecase.ItemFilter{}, usecase.SortOption{}, 20, 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.False(t, domainErrors.IsTimeoutError(err))
+	})
+}
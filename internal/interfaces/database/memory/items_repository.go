@@ -0,0 +1,853 @@
+package memory
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+// ItemRepository は usecase.ItemRepository をメモリ上の map で実装する（テストやローカル確認用）
+// ID の採番・論理削除・楽観ロック・見つからない場合のエラーは SQL のリポジトリと同じように振る舞う
+// 保持するアイテムと返すアイテムはコピーのため、呼び出し側で変更しても保存内容には影響しない
+// 監査ログ（usecase.AuditRepository）・価格の履歴（usecase.PriceHistoryRepository）・バージョン（usecase.ItemVersionRepository）・トランザクション（usecase.Transactor）・カテゴリー（usecase.CategoryRepository）も同じ値で扱う
+type ItemRepository struct {
+	mu     sync.RWMutex
+	items  map[int64]*entity.Item
+	nextID int64
+	audits []*entity.AuditEntry
+	// 登録順の購入価格の変更
+	priceHistory []*entity.PriceChange
+	// 登録順の更新前のアイテム
+	versions []*entity.Item
+	// ID 順のカテゴリー（初期のカテゴリーを登録した状態で始める）
+	categories     []*entity.Category
+	nextCategoryID int64
+	now            func() time.Time
+	// 同時に実行するトランザクションを 1 つにする
+	txMu sync.Mutex
+}
+
+func NewItemRepository() *ItemRepository {
+	r := &ItemRepository{
+		items:          make(map[int64]*entity.Item),
+		nextID:         1,
+		nextCategoryID: 1,
+		now:            time.Now,
+	}
+	for _, name := range entity.DefaultCategories {
+		r.insertCategory(name)
+	}
+	return r
+}
+
+type txKey struct{}
+
+// SQL のリポジトリが一意制約の違反を変換したエラーと同じ内容
+var (
+	errDuplicateItem     = fmt.Errorf("%w: an item with the same name already exists in the category", domainErrors.ErrDuplicateItem)
+	errDuplicateCategory = fmt.Errorf("%w: a category with the same name already exists", domainErrors.ErrDuplicateCategory)
+)
+
+// WithinTransaction は fn を実行し、fn がエラーを返した場合は開始前の状態に戻す
+// トランザクション同士は順に実行するが、トランザクション外からの同時の書き込みは分離しない（テスト用のため）
+func (r *ItemRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(txKey{}) != nil {
+		return fn(ctx)
+	}
+
+	r.txMu.Lock()
+	defer r.txMu.Unlock()
+
+	r.mu.RLock()
+	items := make(map[int64]*entity.Item, len(r.items))
+	for id, item := range r.items {
+		items[id] = copyItem(item)
+	}
+	nextID, audits, priceHistory, versions := r.nextID, len(r.audits), len(r.priceHistory), len(r.versions)
+	categories, nextCategoryID := slices.Clone(r.categories), r.nextCategoryID
+	r.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, txKey{}, true)); err != nil {
+		r.mu.Lock()
+		r.items, r.nextID, r.audits = items, nextID, r.audits[:audits]
+		r.priceHistory, r.versions = r.priceHistory[:priceHistory], r.versions[:versions]
+		r.categories, r.nextCategoryID = categories, nextCategoryID
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (r *ItemRepository) CreateAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *entry
+	stored.ID = int64(len(r.audits) + 1)
+	stored.CreatedAt = r.now()
+	r.audits = append(r.audits, &stored)
+	return nil
+}
+
+// Audits は登録された監査ログを登録順に返す
+func (r *ItemRepository) Audits() []entity.AuditEntry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	audits := make([]entity.AuditEntry, 0, len(r.audits))
+	for _, entry := range r.audits {
+		audits = append(audits, *entry)
+	}
+	return audits
+}
+
+func (r *ItemRepository) CreatePriceChange(ctx context.Context, change *entity.PriceChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *change
+	stored.ID = int64(len(r.priceHistory) + 1)
+	stored.ChangedAt = r.now()
+	r.priceHistory = append(r.priceHistory, &stored)
+	return nil
+}
+
+// FindPriceHistory は登録順（変更日時の古い順）に返す
+func (r *ItemRepository) FindPriceHistory(ctx context.Context, itemID int64) ([]*entity.PriceChange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	changes := []*entity.PriceChange{}
+	for _, change := range r.priceHistory {
+		if change.ItemID == itemID {
+			copied := *change
+			changes = append(changes, &copied)
+		}
+	}
+	return changes, nil
+}
+
+func (r *ItemRepository) CreateItemVersion(ctx context.Context, item *entity.Item) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.versions = append(r.versions, copyItem(item))
+	return nil
+}
+
+// FindItemVersions は登録順（アイテムごとにはバージョンの古い順）に返す
+func (r *ItemRepository) FindItemVersions(ctx context.Context, itemID int64) ([]*entity.ItemVersion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	versions := []*entity.ItemVersion{}
+	for _, snapshot := range r.versions {
+		if snapshot.ID == itemID {
+			versions = append(versions, &entity.ItemVersion{Version: snapshot.Version, UpdatedAt: snapshot.UpdatedAt})
+		}
+	}
+	return versions, nil
+}
+
+func (r *ItemRepository) FindItemVersion(ctx context.Context, itemID int64, version int) (*entity.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, snapshot := range r.versions {
+		if snapshot.ID == itemID && snapshot.Version == version {
+			return copyItem(snapshot), nil
+		}
+	}
+	return nil, domainErrors.ErrItemVersionNotFound
+}
+
+func (r *ItemRepository) FindCategories(ctx context.Context) ([]*entity.Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	categories := make([]*entity.Category, len(r.categories))
+	for i, category := range r.categories {
+		copied := *category
+		categories[i] = &copied
+	}
+	return categories, nil
+}
+
+func (r *ItemRepository) CreateCategory(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.categories {
+		if strings.EqualFold(existing.Name, category.Name) {
+			return nil, errDuplicateCategory
+		}
+	}
+	created := *r.insertCategory(category.Name)
+	return &created, nil
+}
+
+// 論理削除されていないアイテムが登録されているカテゴリーは削除できない
+func (r *ItemRepository) DeleteCategory(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.IndexFunc(r.categories, func(category *entity.Category) bool { return category.ID == id })
+	if i < 0 {
+		return domainErrors.ErrCategoryNotFound
+	}
+	for _, item := range r.items {
+		if item.DeletedAt == nil && item.Category == r.categories[i].Name {
+			return domainErrors.ErrCategoryInUse
+		}
+	}
+	r.categories = slices.Delete(r.categories, i, i+1)
+	return nil
+}
+
+// カテゴリーの論理削除されていないアイテムを同じロックの中で変更し、変更前のアイテムをそのバージョンとして記録する
+// 別のカテゴリーと名前が重複する場合は何も変更しない
+func (r *ItemRepository) RenameCategory(ctx context.Context, from, to string) (*entity.Category, []int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.IndexFunc(r.categories, func(category *entity.Category) bool { return category.Name == from })
+	if i < 0 {
+		return nil, nil, domainErrors.ErrCategoryNotFound
+	}
+	for j, other := range r.categories {
+		if j != i && strings.EqualFold(other.Name, to) {
+			return nil, nil, errDuplicateCategory
+		}
+	}
+
+	// トランザクションの退避はポインタを複製するため、値を書き換えずに置き換える
+	renamed := *r.categories[i]
+	renamed.Name = to
+	r.categories[i] = &renamed
+
+	ids := []int64{}
+	for id, item := range r.items {
+		if item.DeletedAt == nil && item.Category == from {
+			r.versions = append(r.versions, copyItem(item))
+			item.Category = to
+			item.Version++
+			item.UpdatedAt = r.now()
+			ids = append(ids, id)
+		}
+	}
+	slices.Sort(ids)
+
+	copied := renamed
+	return &copied, ids, nil
+}
+
+func (r *ItemRepository) insertCategory(name string) *entity.Category {
+	category := &entity.Category{ID: r.nextCategoryID, Name: name, CreatedAt: r.now()}
+	r.nextCategoryID++
+	r.categories = append(r.categories, category)
+	return category
+}
+
+func (r *ItemRepository) FindAll(ctx context.Context, filter usecase.ItemFilter) ([]*entity.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := r.filter(filter)
+	sortItems(items, usecase.SortOption{})
+	return copyItems(items), nil
+}
+
+func (r *ItemRepository) GetItems(ctx context.Context, filter usecase.ItemFilter, sortOption usecase.SortOption, limit, offset int) ([]*entity.Item, error) {
+	if err := validateSortOption(sortOption); err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := r.filter(filter)
+	sortItems(items, sortOption)
+	return copyItems(paginate(items, limit, offset)), nil
+}
+
+func (r *ItemRepository) GetItemsAfter(ctx context.Context, filter usecase.ItemFilter, afterID int64, limit int) ([]*entity.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var items []*entity.Item
+	for _, item := range r.filter(filter) {
+		if item.ID > afterID {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].ID < items[j].ID })
+	return copyItems(paginate(items, limit, 0)), nil
+}
+
+// 論理削除されたアイテムも含め、UpdatedAt が since 以降のアイテムを UpdatedAt・ID の順に返す
+// userID を指定した場合はそのユーザーのアイテムに限る
+func (r *ItemRepository) FindChangedSince(ctx context.Context, userID *string, since time.Time) ([]*entity.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []*entity.Item{}
+	for _, item := range r.items {
+		if userID != nil && item.UserID != *userID {
+			continue
+		}
+		if !item.UpdatedAt.Before(since) {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if !a.UpdatedAt.Equal(b.UpdatedAt) {
+			return a.UpdatedAt.Before(b.UpdatedAt)
+		}
+		return a.ID < b.ID
+	})
+	return copyItems(items), nil
+}
+
+// 未削除のアイテムのうち、保証期限が from から to までのものを保証期限・ID の順に返す
+// userID を指定した場合はそのユーザーのアイテムに限る
+func (r *ItemRepository) FindWarrantyExpiring(ctx context.Context, userID *string, from, to time.Time) ([]*entity.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// 保証期限は YYYY-MM-DD のため文字列の大小で比較できる
+	fromDate, toDate := from.Format("2006-01-02"), to.Format("2006-01-02")
+	items := []*entity.Item{}
+	for _, item := range r.filter(usecase.ItemFilter{UserID: userID}) {
+		if item.WarrantyExpiry == nil {
+			continue
+		}
+		if expiry := *item.WarrantyExpiry; expiry >= fromDate && expiry <= toDate {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		a, b := *items[i].WarrantyExpiry, *items[j].WarrantyExpiry
+		if a != b {
+			return a < b
+		}
+		return items[i].ID < items[j].ID
+	})
+	return copyItems(items), nil
+}
+
+func (r *ItemRepository) Count(ctx context.Context, filter usecase.ItemFilter) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.filter(filter)), nil
+}
+
+// Ping は常に成功する（readiness チェック用）
+func (r *ItemRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (r *ItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	item, ok := r.active(id)
+	if !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	return copyItem(item), nil
+}
+
+func (r *ItemRepository) Exists(ctx context.Context, id int64) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.active(id)
+	return ok, nil
+}
+
+// 指定した ID のうち、論理削除されていないアイテムを返す（順序は不定）
+func (r *ItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	items := []*entity.Item{}
+	for _, id := range ids {
+		if item, ok := r.active(id); ok {
+			items = append(items, copyItem(item))
+		}
+	}
+	return items, nil
+}
+
+func (r *ItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.duplicate(item.UserID, item.Category, item.Name, 0) {
+		return nil, errDuplicateItem
+	}
+	return copyItem(r.insert(item)), nil
+}
+
+// 複数のアイテムをまとめて登録する（ロックを取ったまま登録するため、途中の状態は他から見えない）
+func (r *ItemRepository) CreateItems(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// SQL のリポジトリと同じく、1 件でも重複する場合はどれも登録しない（同じ一覧内での重複を含む）
+	for i, item := range items {
+		clash := slices.ContainsFunc(items[:i], func(other *entity.Item) bool {
+			return other.UserID == item.UserID && other.Category == item.Category && strings.EqualFold(other.Name, item.Name)
+		})
+		if clash || r.duplicate(item.UserID, item.Category, item.Name, 0) {
+			return nil, errDuplicateItem
+		}
+	}
+
+	created := make([]*entity.Item, 0, len(items))
+	for _, item := range items {
+		created = append(created, copyItem(r.insert(item)))
+	}
+	return created, nil
+}
+
+// 論理削除（deleted_at に削除日時を設定し、アイテムは残す）
+func (r *ItemRepository) Delete(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.softDelete(id) {
+		return domainErrors.ErrItemNotFound
+	}
+	return nil
+}
+
+// 論理削除されたアイテムを復元する（論理削除されていないアイテムには一致しない）
+func (r *ItemRepository) Restore(ctx context.Context, id int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	item, ok := r.items[id]
+	if !ok || item.DeletedAt == nil {
+		return domainErrors.ErrItemNotFound
+	}
+	if r.duplicate(item.UserID, item.Category, item.Name, id) {
+		return errDuplicateItem
+	}
+	item.DeletedAt = nil
+	item.UpdatedAt = r.now()
+	return nil
+}
+
+// 複数のアイテムを論理削除し、実際に削除された ID を返す
+func (r *ItemRepository) DeleteItems(ctx context.Context, ids []int64) ([]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if r.softDelete(id) {
+			deleted = append(deleted, id)
+		}
+	}
+	return deleted, nil
+}
+
+// 名前の重複を先にすべて確認し、重複する場合は 1 件も変更しない
+// 変更前のアイテムはそのバージョンとして記録する
+func (r *ItemRepository) MoveItems(ctx context.Context, ids []int64, category string) ([]int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	moved := make([]int64, 0, len(ids))
+	names := make(map[[2]string]bool, len(ids))
+	for _, id := range ids {
+		item, ok := r.active(id)
+		if !ok {
+			continue
+		}
+		name := [2]string{item.UserID, strings.ToLower(item.Name)}
+		if names[name] || r.duplicate(item.UserID, category, item.Name, id) {
+			return nil, errDuplicateItem
+		}
+		names[name] = true
+		moved = append(moved, id)
+	}
+
+	for _, id := range moved {
+		item := r.items[id]
+		r.versions = append(r.versions, copyItem(item))
+		item.Category = category
+		item.Version++
+		item.UpdatedAt = r.now()
+	}
+	return moved, nil
+}
+
+func (r *ItemRepository) GetSummaryByCategory(ctx context.Context, filter usecase.ItemFilter) (map[string]usecase.CategoryStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	summary := make(map[string]usecase.CategoryStats)
+	for _, item := range r.filter(filter) {
+		stats := summary[item.Category]
+		stats.Count++
+		stats.TotalPrice += item.PurchasePrice
+		summary[item.Category] = stats
+	}
+	return summary, nil
+}
+
+func (r *ItemRepository) GetAveragePriceByCategory(ctx context.Context, filter usecase.ItemFilter) (map[string]usecase.CategoryAveragePrice, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	totals := make(map[string]usecase.CategoryStats)
+	for _, item := range r.filter(filter) {
+		stats := totals[item.Category]
+		stats.Count++
+		stats.TotalPrice += item.PurchasePrice
+		totals[item.Category] = stats
+	}
+
+	averages := make(map[string]usecase.CategoryAveragePrice, len(totals))
+	for category, stats := range totals {
+		average := float64(stats.TotalPrice) / float64(stats.Count)
+		averages[category] = usecase.CategoryAveragePrice{Count: stats.Count, AveragePrice: &average}
+	}
+	return averages, nil
+}
+
+func (r *ItemRepository) GetPriceRangeByCategory(ctx context.Context, filter usecase.ItemFilter) (map[string]usecase.CategoryPriceRange, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ranges := make(map[string]usecase.CategoryPriceRange)
+	for _, item := range r.filter(filter) {
+		price := item.PurchasePrice
+		current, ok := ranges[item.Category]
+		if !ok {
+			minPrice, maxPrice := price, price
+			ranges[item.Category] = usecase.CategoryPriceRange{Count: 1, MinPrice: &minPrice, MaxPrice: &maxPrice}
+			continue
+		}
+		current.Count++
+		*current.MinPrice = min(*current.MinPrice, price)
+		*current.MaxPrice = max(*current.MaxPrice, price)
+		ranges[item.Category] = current
+	}
+	return ranges, nil
+}
+
+// 購入日は YYYY-MM-DD のため、先頭 7 文字を購入月とする
+func (r *ItemRepository) GetMonthlyTimeline(ctx context.Context, filter usecase.ItemFilter) ([]usecase.MonthlyStats, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	byMonth := make(map[string]usecase.MonthlyStats)
+	for _, item := range r.filter(filter) {
+		month := item.PurchaseDate[:min(len(item.PurchaseDate), 7)]
+		stats := byMonth[month]
+		stats.Month = month
+		stats.Count++
+		stats.TotalValue += item.PurchasePrice
+		byMonth[month] = stats
+	}
+
+	timeline := make([]usecase.MonthlyStats, 0, len(byMonth))
+	for _, stats := range byMonth {
+		timeline = append(timeline, stats)
+	}
+	sort.Slice(timeline, func(i, j int) bool { return timeline[i].Month < timeline[j].Month })
+	return timeline, nil
+}
+
+// 保存されているバージョンが item.Version と一致する場合のみ更新し、バージョンを 1 増やす
+func (r *ItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.active(item.ID)
+	if !ok {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	if stored.Version != item.Version {
+		return nil, domainErrors.ErrVersionConflict
+	}
+	if r.duplicate(stored.UserID, item.Category, item.Name, item.ID) {
+		return nil, errDuplicateItem
+	}
+
+	stored.Name = item.Name
+	stored.Category = item.Category
+	stored.Brand = item.Brand
+	stored.PurchasePrice = item.PurchasePrice
+	stored.PurchaseDate = item.PurchaseDate
+	stored.SetWarrantyExpiry(item.WarrantyExpiry)
+	stored.Notes = item.Notes
+	stored.ImageURL = item.ImageURL
+	stored.Tags = append([]string{}, item.Tags...)
+	stored.Attributes = copyAttributes(item.Attributes)
+	stored.Version++
+	stored.UpdatedAt = r.now()
+	return copyItem(stored), nil
+}
+
+// 以下は r.mu を取得した状態で呼び出す
+
+func (r *ItemRepository) insert(item *entity.Item) *entity.Item {
+	now := r.now()
+	stored := copyItem(item)
+	stored.ID = r.nextID
+	stored.CreatedAt = now
+	stored.UpdatedAt = now
+	stored.DeletedAt = nil
+	stored.Version = 1
+
+	r.items[stored.ID] = stored
+	r.nextID++
+	return stored
+}
+
+// duplicate は exceptID 以外に、userID のアイテムで category の名前が name と大文字・小文字を区別せずに一致する未削除のアイテムがあるかを返す
+// SQL のリポジトリの一意制約 (user_id, category, name) と同じ判定
+func (r *ItemRepository) duplicate(userID, category, name string, exceptID int64) bool {
+	for id, item := range r.items {
+		if id != exceptID && item.DeletedAt == nil && item.UserID == userID && item.Category == category && strings.EqualFold(item.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *ItemRepository) active(id int64) (*entity.Item, bool) {
+	item, ok := r.items[id]
+	if !ok || item.DeletedAt != nil {
+		return nil, false
+	}
+	return item, true
+}
+
+func (r *ItemRepository) softDelete(id int64) bool {
+	item, ok := r.active(id)
+	if !ok {
+		return false
+	}
+	// SQL のリポジトリと同じく、論理削除でも更新日時を更新する
+	now := r.now()
+	item.DeletedAt = &now
+	item.UpdatedAt = now
+	return true
+}
+
+func (r *ItemRepository) filter(filter usecase.ItemFilter) []*entity.Item {
+	items := []*entity.Item{}
+	for _, item := range r.items {
+		if matches(item, filter) {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// SQL のリポジトリの WHERE 句と同じ条件で絞り込む
+func matches(item *entity.Item, filter usecase.ItemFilter) bool {
+	if (item.DeletedAt != nil) != filter.Deleted {
+		return false
+	}
+	if len(filter.Categories) > 0 && !slices.Contains(filter.Categories, item.Category) {
+		return false
+	}
+	if slices.Contains(filter.ExcludedCategories, item.Category) {
+		return false
+	}
+	if filter.Brand != nil && item.Brand != *filter.Brand {
+		return false
+	}
+	if filter.UserID != nil && item.UserID != *filter.UserID {
+		return false
+	}
+	if len(filter.IDs) > 0 && !slices.Contains(filter.IDs, item.ID) {
+		return false
+	}
+	if len(filter.Tags) > 0 && !matchesTags(item.Tags, filter) {
+		return false
+	}
+	for _, attribute := range filter.Attributes {
+		if !matchesAttribute(item.Attributes, attribute) {
+			return false
+		}
+	}
+	if filter.MinPrice != nil && item.PurchasePrice < *filter.MinPrice {
+		return false
+	}
+	if filter.MaxPrice != nil && item.PurchasePrice > *filter.MaxPrice {
+		return false
+	}
+	// 購入日は YYYY-MM-DD のため文字列の大小で比較できる
+	if filter.PurchasedAfter != nil && item.PurchaseDate < filter.PurchasedAfter.Format("2006-01-02") {
+		return false
+	}
+	if filter.PurchasedBefore != nil && item.PurchaseDate > filter.PurchasedBefore.Format("2006-01-02") {
+		return false
+	}
+	if filter.Search != "" && !strings.Contains(strings.ToLower(item.Name), strings.ToLower(filter.Search)) {
+		return false
+	}
+	return true
+}
+
+// すべてのタグ（TagsMatchAny の場合はいずれかのタグ）を持つかを返す
+func matchesTags(tags []string, filter usecase.ItemFilter) bool {
+	for _, tag := range filter.Tags {
+		found := slices.Contains(tags, tag)
+		if filter.TagsMatchAny && found {
+			return true
+		}
+		if !filter.TagsMatchAny && !found {
+			return false
+		}
+	}
+	return !filter.TagsMatchAny
+}
+
+// パスの位置の値が一致するかを返す（SQL のリポジトリと同じく、文字列はそのまま、数値・真偽値は JSON の表記で比較する）
+func matchesAttribute(attributes map[string]interface{}, filter usecase.AttributeFilter) bool {
+	var value interface{} = attributes
+	for _, name := range filter.Path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if value, ok = object[name]; !ok {
+			return false
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v == filter.Value
+	case float64, bool:
+		data, _ := json.Marshal(v)
+		return string(data) == filter.Value
+	}
+	return false
+}
+
+func validateSortOption(sortOption usecase.SortOption) error {
+	for _, key := range sortOption.Keys {
+		switch key.Field {
+		case usecase.SortByPurchaseDate, usecase.SortByPurchasePrice, usecase.SortByCreatedAt, usecase.SortByCategory:
+			continue
+		}
+		return fmt.Errorf("%w: unsupported sort field: %s", domainErrors.ErrInvalidInput, key.Field)
+	}
+	return nil
+}
+
+// 並び順は SQL のリポジトリと同じ（既定は登録日時の降順、指定時はキーを順に比較し、すべて同じ値のアイテムを ID 昇順）
+func sortItems(items []*entity.Item, sortOption usecase.SortOption) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if len(sortOption.Keys) == 0 {
+			// 同じ時刻に登録したアイテムは後から登録したものを先にする
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+			return a.ID > b.ID
+		}
+
+		for _, key := range sortOption.Keys {
+			var cmp int
+			switch key.Field {
+			case usecase.SortByPurchaseDate:
+				cmp = strings.Compare(a.PurchaseDate, b.PurchaseDate)
+			case usecase.SortByPurchasePrice:
+				cmp = a.PurchasePrice - b.PurchasePrice
+			case usecase.SortByCreatedAt:
+				cmp = a.CreatedAt.Compare(b.CreatedAt)
+			case usecase.SortByCategory:
+				cmp = strings.Compare(a.Category, b.Category)
+			}
+			if cmp != 0 {
+				if key.Descending {
+					return cmp > 0
+				}
+				return cmp < 0
+			}
+		}
+		return a.ID < b.ID
+	})
+}
+
+func paginate(items []*entity.Item, limit, offset int) []*entity.Item {
+	if offset >= len(items) {
+		return []*entity.Item{}
+	}
+	items = items[offset:]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+func copyItem(item *entity.Item) *entity.Item {
+	copied := *item
+	if item.DeletedAt != nil {
+		deletedAt := *item.DeletedAt
+		copied.DeletedAt = &deletedAt
+	}
+	if item.WarrantyExpiry != nil {
+		warrantyExpiry := *item.WarrantyExpiry
+		copied.WarrantyExpiry = &warrantyExpiry
+	}
+	// SQL のリポジトリと同じく、タグがない場合も空の配列にする
+	copied.Tags = append([]string{}, item.Tags...)
+	copied.Attributes = copyAttributes(item.Attributes)
+	return &copied
+}
+
+// 追加情報は入れ子のオブジェクト・配列も含めて複製する（呼び出し元の変更が保存した値に影響しないように）
+func copyAttributes(attributes map[string]interface{}) map[string]interface{} {
+	if attributes == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(attributes))
+	for key, value := range attributes {
+		copied[key] = copyJSONValue(value)
+	}
+	return copied
+}
+
+func copyJSONValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return copyAttributes(v)
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, element := range v {
+			copied[i] = copyJSONValue(element)
+		}
+		return copied
+	}
+	return value
+}
+
+func copyItems(items []*entity.Item) []*entity.Item {
+	copied := make([]*entity.Item, 0, len(items))
+	for _, item := range items {
+		copied = append(copied, copyItem(item))
+	}
+	return copied
+}
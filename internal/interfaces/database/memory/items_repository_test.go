@@ -0,0 +1,1304 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+var (
+	_ usecase.ItemRepository         = (*ItemRepository)(nil)
+	_ usecase.AuditRepository        = (*ItemRepository)(nil)
+	_ usecase.PriceHistoryRepository = (*ItemRepository)(nil)
+	_ usecase.ItemVersionRepository  = (*ItemRepository)(nil)
+	_ usecase.Transactor             = (*ItemRepository)(nil)
+)
+
+func createInput(name, category string, price int, purchaseDate string) usecase.CreateItemInput {
+	return usecase.CreateItemInput{
+		Name:          name,
+		Category:      category,
+		Brand:         "ブランド",
+		PurchasePrice: price,
+		PurchaseDate:  purchaseDate,
+	}
+}
+
+func TestItemRepository_CRUDThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	created, err := u.CreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), created.ID)
+	assert.Equal(t, 1, created.Version)
+
+	second, err := u.CreateItem(ctx, createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20"))
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), second.ID)
+
+	t.Run("取得", func(t *testing.T) {
+		item, err := u.GetItemByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ", item.Name)
+
+		_, err = u.GetItemByID(ctx, 999)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+		exists, err := u.ItemExists(ctx, created.ID)
+		require.NoError(t, err)
+		assert.True(t, exists)
+		exists, err = u.ItemExists(ctx, 999)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("部分更新と楽観ロック", func(t *testing.T) {
+		price := 1600000
+		updated, err := u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{PurchasePrice: &price})
+		require.NoError(t, err)
+		assert.Equal(t, 1600000, updated.PurchasePrice)
+		assert.Equal(t, "ロレックス デイトナ", updated.Name)
+		assert.Equal(t, 2, updated.Version)
+
+		stale := 1
+		_, err = u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{PurchasePrice: &price, Version: &stale})
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+
+		_, err = u.UpdateItem(ctx, 999, usecase.UpdateItemInput{PurchasePrice: &price})
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("返したアイテムを変更しても保存内容は変わらない", func(t *testing.T) {
+		item, err := u.GetItemByID(ctx, second.ID)
+		require.NoError(t, err)
+		item.Name = "changed"
+
+		again, err := u.GetItemByID(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "エルメス バーキン", again.Name)
+	})
+
+	t.Run("論理削除と復元", func(t *testing.T) {
+		require.NoError(t, u.DeleteItem(ctx, second.ID))
+		assert.ErrorIs(t, u.DeleteItem(ctx, second.ID), domainErrors.ErrItemNotFound)
+
+		_, err := u.GetItemByID(ctx, second.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+		deleted, total, err := u.GetDeletedItems(ctx, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, second.ID, deleted[0].ID)
+		assert.NotNil(t, deleted[0].DeletedAt)
+
+		restored, err := u.RestoreItem(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+
+		// 削除されていないアイテムの復元はそのまま返す
+		again, err := u.RestoreItem(ctx, second.ID)
+		require.NoError(t, err)
+		assert.Equal(t, second.ID, again.ID)
+
+		_, err = u.RestoreItem(ctx, 999)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("複製", func(t *testing.T) {
+		cloned, err := u.CloneItem(ctx, created.ID)
+		require.NoError(t, err)
+		assert.NotEqual(t, created.ID, cloned.ID)
+		assert.Equal(t, "ロレックス デイトナ (copy)", cloned.Name)
+		assert.Equal(t, 1, cloned.Version)
+
+		_, err = u.CloneItem(ctx, 999)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("一括削除", func(t *testing.T) {
+		result, err := u.DeleteItems(ctx, []int64{second.ID, 999})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{second.ID}, result.Deleted)
+		assert.Equal(t, []int64{999}, result.NotFound)
+	})
+}
+
+func TestItemRepository_ListThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItemRepository()
+	// 登録日時の順序を決めるため 1 秒ずつ進める
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	u := usecase.NewItemUsecase(repo)
+
+	_, err := u.CreateItems(ctx, []usecase.CreateItemInput{
+		createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"),
+		createInput("オメガ スピードマスター", "時計", 800000, "2023-03-01"),
+		createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20"),
+	})
+	require.NoError(t, err)
+
+	ids := func(items []*entity.Item) []int64 {
+		result := []int64{}
+		for _, item := range items {
+			result = append(result, item.ID)
+		}
+		return result
+	}
+
+	t.Run("既定は登録日時の降順", func(t *testing.T) {
+		items, total, err := u.GetItems(ctx, usecase.ItemFilter{}, usecase.SortOption{}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, []int64{3, 2, 1}, ids(items))
+	})
+
+	t.Run("絞り込み・並び替え・ページング", func(t *testing.T) {
+		category := "時計"
+		items, total, err := u.GetItems(ctx, usecase.ItemFilter{Categories: []string{category}},
+			usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByPurchasePrice}}}, 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, []int64{1}, ids(items))
+
+		items, _, err = u.GetItems(ctx, usecase.ItemFilter{Search: "バーキン"}, usecase.SortOption{}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3}, ids(items))
+	})
+
+	t.Run("複数のカテゴリーとブランド", func(t *testing.T) {
+		items, total, err := u.GetItems(ctx, usecase.ItemFilter{Categories: []string{"時計", "バッグ"}}, usecase.SortOption{}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 3, total)
+		assert.Equal(t, []int64{3, 2, 1}, ids(items))
+
+		brand := "ブランド"
+		items, _, err = u.GetItems(ctx, usecase.ItemFilter{Categories: []string{"バッグ", "靴"}, Brand: &brand}, usecase.SortOption{}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3}, ids(items))
+	})
+
+	t.Run("除外するカテゴリー", func(t *testing.T) {
+		items, total, err := u.GetItems(ctx, usecase.ItemFilter{ExcludedCategories: []string{"バッグ", "その他"}}, usecase.SortOption{}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.Equal(t, []int64{2, 1}, ids(items))
+
+		minPrice := 1000000
+		items, _, err = u.GetItems(ctx, usecase.ItemFilter{ExcludedCategories: []string{"バッグ"}, MinPrice: &minPrice}, usecase.SortOption{}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1}, ids(items))
+	})
+
+	t.Run("複数キーの並び替え", func(t *testing.T) {
+		// カテゴリーの昇順、同じカテゴリー内は購入価格の降順
+		sort := usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByCategory}, {Field: usecase.SortByPurchasePrice, Descending: true}}}
+		items, _, err := u.GetItems(ctx, usecase.ItemFilter{}, sort, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 1, 2}, ids(items))
+
+		sort.Keys = append(sort.Keys, usecase.SortKey{Field: "name"})
+		_, _, err = u.GetItems(ctx, usecase.ItemFilter{}, sort, 20, 0)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("登録日時の並び替え", func(t *testing.T) {
+		items, _, err := u.GetItems(ctx, usecase.ItemFilter{}, usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByCreatedAt, Descending: true}}}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 2, 1}, ids(items))
+
+		items, _, err = u.GetItems(ctx, usecase.ItemFilter{}, usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByCreatedAt}}}, 20, 0)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2, 3}, ids(items))
+	})
+
+	t.Run("最近登録したアイテム", func(t *testing.T) {
+		items, err := u.GetRecentItems(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 2}, ids(items))
+	})
+
+	t.Run("購入価格の高いアイテム", func(t *testing.T) {
+		items, err := u.GetTopItems(ctx, nil, 2)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 1}, ids(items))
+
+		category := "時計"
+		items, err = u.GetTopItems(ctx, &category, 10)
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, ids(items))
+	})
+
+	t.Run("カーソルと ID 指定", func(t *testing.T) {
+		items, hasMore, err := u.GetItemsAfter(ctx, usecase.ItemFilter{}, 1, 1)
+		require.NoError(t, err)
+		assert.True(t, hasMore)
+		assert.Equal(t, []int64{2}, ids(items))
+
+		// カーソルでも一覧と同じ絞り込みを使う
+		items, hasMore, err = u.GetItemsAfter(ctx, usecase.ItemFilter{Categories: []string{"時計"}}, 0, 1)
+		require.NoError(t, err)
+		assert.True(t, hasMore)
+		assert.Equal(t, []int64{1}, ids(items))
+		items, hasMore, err = u.GetItemsAfter(ctx, usecase.ItemFilter{Categories: []string{"時計"}}, 1, 1)
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+		assert.Equal(t, []int64{2}, ids(items))
+
+		items, err = u.GetItemsByIDs(ctx, []int64{3, 99, 1})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 1}, ids(items))
+	})
+}
+
+func TestItemRepository_TimestampsThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItemRepository()
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	u := usecase.NewItemUsecase(repo)
+
+	created, err := u.CreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+	require.NoError(t, err)
+	assert.False(t, created.CreatedAt.IsZero())
+	assert.Equal(t, created.CreatedAt, created.UpdatedAt)
+
+	price := 1600000
+	patched, err := u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{PurchasePrice: &price})
+	require.NoError(t, err)
+	assert.Equal(t, created.CreatedAt, patched.CreatedAt)
+	assert.True(t, patched.UpdatedAt.After(created.UpdatedAt))
+
+	name, category, brand, purchaseDate := "デイトナ", "時計", "ROLEX", "2023-01-15"
+	replaced, err := u.ReplaceItem(ctx, created.ID, usecase.ReplaceItemInput{
+		Name: &name, Category: &category, Brand: &brand, PurchasePrice: &price, PurchaseDate: &purchaseDate,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, created.CreatedAt, replaced.CreatedAt)
+	assert.True(t, replaced.UpdatedAt.After(patched.UpdatedAt))
+
+	// 失敗した更新では変わらない
+	stale := 1
+	_, err = u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{PurchasePrice: &price, Version: &stale})
+	require.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+	item, err := u.GetItemByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, replaced.UpdatedAt, item.UpdatedAt)
+}
+
+func TestItemRepository_ChangesThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItemRepository()
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo.now = func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+	u := usecase.NewItemUsecase(repo)
+
+	unchanged, err := u.CreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+	require.NoError(t, err)
+	updated, err := u.CreateItem(ctx, createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20"))
+	require.NoError(t, err)
+	deleted, err := u.CreateItem(ctx, createInput("ティファニー ネックレス", "ジュエリー", 300000, "2023-03-10"))
+	require.NoError(t, err)
+	since := clock.Add(time.Second)
+
+	price := 2100000
+	_, err = u.UpdateItem(ctx, updated.ID, usecase.UpdateItemInput{PurchasePrice: &price})
+	require.NoError(t, err)
+	require.NoError(t, u.DeleteItem(ctx, deleted.ID))
+	created, err := u.CreateItem(ctx, createInput("ルブタン パンプス", "靴", 150000, "2023-04-05"))
+	require.NoError(t, err)
+
+	// 変更日時の順に、登録・更新・論理削除されたアイテムが含まれる
+	changes, err := u.GetItemChanges(ctx, since)
+	require.NoError(t, err)
+	require.Len(t, changes, 3)
+	assert.Equal(t, []int64{updated.ID, deleted.ID, created.ID}, []int64{changes[0].ID, changes[1].ID, changes[2].ID})
+	assert.Equal(t, price, changes[0].PurchasePrice)
+	assert.NotNil(t, changes[1].DeletedAt)
+	assert.Nil(t, changes[2].DeletedAt)
+
+	// since ちょうどの変更も含む
+	changes, err = u.GetItemChanges(ctx, unchanged.UpdatedAt)
+	require.NoError(t, err)
+	assert.Len(t, changes, 4)
+}
+
+func TestItemRepository_WarrantyThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	today := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	u := usecase.NewItemUsecase(NewItemRepository(), usecase.WithClock(func() time.Time { return today }))
+
+	withWarranty := func(name, warrantyExpiry string) int64 {
+		input := createInput(name, "時計", 100000, "2023-01-15")
+		if warrantyExpiry != "" {
+			input.WarrantyExpiry = &warrantyExpiry
+		}
+		item, err := u.CreateItem(ctx, input)
+		require.NoError(t, err)
+		return item.ID
+	}
+	expired := withWarranty("期限切れ", "2024-01-09")
+	last := withWarranty("30 日後", "2024-02-09")
+	first := withWarranty("今日", "2024-01-10")
+	withWarranty("31 日後", "2024-02-10")
+	withWarranty("保証なし", "")
+	deleted := withWarranty("削除済み", "2024-01-20")
+	require.NoError(t, u.DeleteItem(ctx, deleted))
+
+	// 保証期限は取得したアイテムにも保存されている
+	item, err := u.GetItemByID(ctx, expired)
+	require.NoError(t, err)
+	if assert.NotNil(t, item.WarrantyExpiry) {
+		assert.Equal(t, "2024-01-09", *item.WarrantyExpiry)
+	}
+
+	// 今日から 30 日後までを期限の近い順に返し、期限切れ・範囲外・削除済みは含まない
+	items, err := u.GetExpiringWarranties(ctx, 30)
+	require.NoError(t, err)
+	require.Len(t, items, 2)
+	assert.Equal(t, []int64{first, last}, []int64{items[0].ID, items[1].ID})
+
+	items, err = u.GetExpiringWarranties(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, first, items[0].ID)
+}
+
+func TestItemRepository_NotesThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	input := createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15")
+	input.Notes = "正規店で購入"
+	created, err := u.CreateItem(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, "正規店で購入", created.Notes)
+
+	notes := "並行輸入品"
+	updated, err := u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{Notes: &notes})
+	require.NoError(t, err)
+	assert.Equal(t, "並行輸入品", updated.Notes)
+
+	// 空文字で削除したメモは取得しても空
+	empty := ""
+	_, err = u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{Notes: &empty})
+	require.NoError(t, err)
+	item, err := u.GetItemByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "", item.Notes)
+}
+
+func TestItemRepository_TagsThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	input := createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15")
+	input.Tags = []string{"Vintage", "gift"}
+	created, err := u.CreateItem(ctx, input)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gift", "vintage"}, created.Tags)
+
+	tags := []string{"heirloom"}
+	_, err = u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{Tags: &tags})
+	require.NoError(t, err)
+	item, err := u.GetItemByID(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"heirloom"}, item.Tags)
+
+	// 取得したアイテムのタグを変更しても保存済みのタグには影響しない
+	item.Tags[0] = "changed"
+	items, _, err := u.GetItems(ctx, usecase.ItemFilter{}, usecase.SortOption{}, 20, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"heirloom"}, items[0].Tags)
+}
+
+func TestItemRepository_DryRunThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	item, err := u.DryRunCreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+	require.NoError(t, err)
+	assert.Equal(t, "ロレックス デイトナ", item.Name)
+
+	// dry run では保存しないため、登録されたアイテムはない
+	count, err := u.CountItems(ctx, usecase.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+
+	_, err = u.DryRunCreateItem(ctx, createInput("", "時計", 1500000, "2023-01-15"))
+	assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+}
+
+func TestItemRepository_AttributesThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	t.Run("正常系: 入れ子のオブジェクトをそのまま保存して返す", func(t *testing.T) {
+		attributes := map[string]interface{}{
+			"movement": "automatic",
+			"case":     map[string]interface{}{"material": "steel", "size_mm": 40.0},
+			"bands":    []interface{}{"leather", map[string]interface{}{"type": "bracelet"}},
+		}
+		input := createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15")
+		input.Attributes = attributes
+		created, err := u.CreateItem(ctx, input)
+		require.NoError(t, err)
+
+		item, err := u.GetItemByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, attributes, item.Attributes)
+
+		// 取得したアイテムの入れ子の値を変更しても保存済みの値には影響しない
+		item.Attributes["case"].(map[string]interface{})["material"] = "gold"
+		item, err = u.GetItemByID(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "steel", item.Attributes["case"].(map[string]interface{})["material"])
+	})
+
+	t.Run("正常系: 空のオブジェクトで削除", func(t *testing.T) {
+		input := createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20")
+		input.Attributes = map[string]interface{}{"material": "togo"}
+		created, err := u.CreateItem(ctx, input)
+		require.NoError(t, err)
+
+		updated, err := u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{Attributes: &map[string]interface{}{}})
+		require.NoError(t, err)
+		assert.Nil(t, updated.Attributes)
+	})
+
+	t.Run("異常系: サイズの上限を超える", func(t *testing.T) {
+		input := createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15")
+		input.Attributes = map[string]interface{}{"history": strings.Repeat("a", entity.MaxAttributesSize)}
+
+		_, err := u.CreateItem(ctx, input)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.EqualError(t, err, "attributes must be 4096 bytes or less")
+	})
+}
+
+func TestItemRepository_AttributeFilterThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	inputs := []usecase.CreateItemInput{
+		createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20"),
+		createInput("シャネル マトラッセ", "バッグ", 800000, "2023-03-01"),
+		createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"),
+	}
+	inputs[0].Attributes = map[string]interface{}{"material": "leather", "size": 30.0}
+	inputs[1].Attributes = map[string]interface{}{"material": "canvas"}
+	inputs[2].Attributes = map[string]interface{}{"case": map[string]interface{}{"material": "steel"}, "limited": true}
+	items, err := u.CreateItems(ctx, inputs)
+	require.NoError(t, err)
+
+	ids := func(attributes ...usecase.AttributeFilter) []int64 {
+		found, _, err := u.GetItems(ctx, usecase.ItemFilter{Attributes: attributes}, usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByPurchaseDate}}}, 20, 0)
+		require.NoError(t, err)
+		result := []int64{}
+		for _, item := range found {
+			result = append(result, item.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []int64{items[0].ID}, ids(usecase.AttributeFilter{Path: []string{"material"}, Value: "leather"}))
+	assert.Equal(t, []int64{items[2].ID}, ids(usecase.AttributeFilter{Path: []string{"case", "material"}, Value: "steel"}))
+	// 数値・真偽値は JSON の表記で比較する
+	assert.Equal(t, []int64{items[0].ID}, ids(usecase.AttributeFilter{Path: []string{"size"}, Value: "30"}))
+	assert.Equal(t, []int64{items[2].ID}, ids(usecase.AttributeFilter{Path: []string{"limited"}, Value: "true"}))
+	// 一致しない値・存在しないメンバー・オブジェクトの値には一致しない
+	assert.Equal(t, []int64{}, ids(usecase.AttributeFilter{Path: []string{"material"}, Value: "Leather"}))
+	assert.Equal(t, []int64{}, ids(usecase.AttributeFilter{Path: []string{"color"}, Value: "black"}))
+	assert.Equal(t, []int64{}, ids(usecase.AttributeFilter{Path: []string{"case"}, Value: "steel"}))
+	assert.Equal(t, []int64{}, ids(
+		usecase.AttributeFilter{Path: []string{"material"}, Value: "leather"},
+		usecase.AttributeFilter{Path: []string{"size"}, Value: "40"},
+	))
+}
+
+func TestItemRepository_DuplicateNameThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	rolex, err := u.CreateItem(ctx, createInput("Rolex Daytona", "時計", 1500000, "2023-01-15"))
+	require.NoError(t, err)
+
+	t.Run("異常系: 同じカテゴリの同じ名前（大文字・小文字を区別しない）", func(t *testing.T) {
+		_, err := u.CreateItem(ctx, createInput("rolex daytona", "時計", 1600000, "2023-02-01"))
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+	})
+
+	t.Run("異常系: 全角で入力しても正規化した名前で重複になる", func(t *testing.T) {
+		_, err := u.CreateItem(ctx, createInput("ＲＯＬＥＸ　ＤＡＹＴＯＮＡ", "時計", 1600000, "2023-02-01"))
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+	})
+
+	t.Run("正常系: 別のカテゴリなら同じ名前でも登録できる", func(t *testing.T) {
+		_, err := u.CreateItem(ctx, createInput("Rolex Daytona", "その他", 1000, "2023-02-01"))
+		assert.NoError(t, err)
+	})
+
+	t.Run("異常系: 一括登録は一覧内の重複も含めてすべて登録しない", func(t *testing.T) {
+		_, err := u.CreateItems(ctx, []usecase.CreateItemInput{
+			createInput("オメガ スピードマスター", "時計", 800000, "2023-03-01"),
+			createInput("オメガ スピードマスター", "時計", 800000, "2023-03-01"),
+		})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+
+		count, err := u.CountItems(ctx, usecase.ItemFilter{Categories: []string{"時計"}})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("異常系: 既存のアイテムと同じ名前への変更", func(t *testing.T) {
+		omega, err := u.CreateItem(ctx, createInput("Omega", "時計", 800000, "2023-03-01"))
+		require.NoError(t, err)
+
+		name := "ROLEX DAYTONA"
+		_, err = u.UpdateItem(ctx, omega.ID, usecase.UpdateItemInput{Name: &name})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+
+		// 自分自身の名前の大文字・小文字だけの変更はできる
+		name = "OMEGA"
+		_, err = u.UpdateItem(ctx, omega.ID, usecase.UpdateItemInput{Name: &name})
+		assert.NoError(t, err)
+	})
+
+	t.Run("正常系: 論理削除したアイテムの名前は再登録でき、復元は重複になる", func(t *testing.T) {
+		require.NoError(t, u.DeleteItem(ctx, rolex.ID))
+
+		_, err := u.CreateItem(ctx, createInput("Rolex Daytona", "時計", 1500000, "2023-01-15"))
+		require.NoError(t, err)
+
+		_, err = u.RestoreItem(ctx, rolex.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+	})
+}
+
+func TestItemRepository_MoveItemsThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	rolex, err := u.CreateItem(ctx, createInput("Rolex Daytona", "時計", 1500000, "2023-01-15"))
+	require.NoError(t, err)
+	omega, err := u.CreateItem(ctx, createInput("Omega Speedmaster", "時計", 800000, "2023-03-01"))
+	require.NoError(t, err)
+	deleted, err := u.CreateItem(ctx, createInput("Seiko", "時計", 50000, "2023-04-01"))
+	require.NoError(t, err)
+	require.NoError(t, u.DeleteItem(ctx, deleted.ID))
+
+	t.Run("正常系: 存在しない・論理削除した ID は not_found で返す", func(t *testing.T) {
+		result, err := u.MoveItems(ctx, usecase.MoveItemsInput{IDs: []int64{rolex.ID, 999, deleted.ID}, TargetCategory: "その他"})
+		require.NoError(t, err)
+		assert.Equal(t, []int64{rolex.ID}, result.Moved)
+		assert.Equal(t, []int64{999, deleted.ID}, result.NotFound)
+
+		item, err := u.GetItemByID(ctx, rolex.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "その他", item.Category)
+		assert.Equal(t, rolex.Version+1, item.Version)
+	})
+
+	t.Run("異常系: 登録されていないカテゴリー", func(t *testing.T) {
+		_, err := u.MoveItems(ctx, usecase.MoveItemsInput{IDs: []int64{omega.ID}, TargetCategory: "家具"})
+		assert.True(t, domainErrors.IsValidationError(err))
+	})
+
+	t.Run("異常系: 変更先で名前が重複する場合は 1 件も変更しない", func(t *testing.T) {
+		_, err := u.CreateItem(ctx, createInput("omega speedmaster", "バッグ", 1000, "2023-05-01"))
+		require.NoError(t, err)
+
+		_, err = u.MoveItems(ctx, usecase.MoveItemsInput{IDs: []int64{rolex.ID, omega.ID}, TargetCategory: "バッグ"})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+
+		item, err := u.GetItemByID(ctx, rolex.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "その他", item.Category)
+	})
+}
+
+func TestItemRepository_UserScopeThroughUsecase(t *testing.T) {
+	alice := usecase.ContextWithSubject(context.Background(), "alice")
+	bob := usecase.ContextWithSubject(context.Background(), "bob")
+	u := usecase.NewItemUsecase(NewItemRepository(), usecase.WithUserScope())
+
+	rolex, err := u.CreateItem(alice, createInput("Rolex Daytona", "時計", 1500000, "2023-01-15"))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", rolex.UserID)
+	omega, err := u.CreateItem(bob, createInput("Omega Speedmaster", "時計", 800000, "2023-03-01"))
+	require.NoError(t, err)
+
+	t.Run("正常系: 一覧・件数は自分のアイテムのみ", func(t *testing.T) {
+		items, err := u.GetAllItems(alice, usecase.ItemFilter{})
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, rolex.ID, items[0].ID)
+
+		page, hasMore, err := u.GetItemsAfter(bob, usecase.ItemFilter{}, 0, 10)
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+		require.Len(t, page, 1)
+		assert.Equal(t, omega.ID, page[0].ID)
+
+		count, err := u.CountItems(bob, usecase.ItemFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテムの取得・更新・削除は存在しないものとする", func(t *testing.T) {
+		name := "Rolex Submariner"
+
+		_, err := u.GetItemByID(bob, rolex.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		_, err = u.UpdateItem(bob, rolex.ID, usecase.UpdateItemInput{Name: &name})
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		err = u.DeleteItem(bob, rolex.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+		item, err := u.GetItemByID(alice, rolex.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "Rolex Daytona", item.Name)
+	})
+
+	t.Run("異常系: 他のユーザーの論理削除したアイテムは復元しない", func(t *testing.T) {
+		require.NoError(t, u.DeleteItem(alice, rolex.ID))
+
+		_, err := u.RestoreItem(bob, rolex.ID)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+		restored, err := u.RestoreItem(alice, rolex.ID)
+		require.NoError(t, err)
+		assert.Nil(t, restored.DeletedAt)
+	})
+
+	t.Run("正常系: 名前の重複はユーザーごとに判定する", func(t *testing.T) {
+		_, err := u.CreateItem(bob, createInput("rolex daytona", "時計", 1000, "2023-05-01"))
+		require.NoError(t, err)
+
+		_, err = u.CreateItem(alice, createInput("ROLEX DAYTONA", "時計", 1000, "2023-05-01"))
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+	})
+
+	t.Run("異常系: 認証されていない呼び出し", func(t *testing.T) {
+		_, err := u.GetAllItems(context.Background(), usecase.ItemFilter{})
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+	})
+}
+
+func TestItemRepository_UserScopedFeedsThroughUsecase(t *testing.T) {
+	alice := usecase.ContextWithSubject(context.Background(), "alice")
+	bob := usecase.ContextWithSubject(context.Background(), "bob")
+	u := usecase.NewItemUsecase(NewItemRepository(), usecase.WithUserScope())
+	expiry := time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+
+	input := createInput("Rolex Daytona", "時計", 1500000, "2023-01-15")
+	input.WarrantyExpiry = &expiry
+	rolex, err := u.CreateItem(alice, input)
+	require.NoError(t, err)
+	input = createInput("Omega Speedmaster", "時計", 800000, "2023-03-01")
+	input.WarrantyExpiry = &expiry
+	omega, err := u.CreateItem(bob, input)
+	require.NoError(t, err)
+	require.NoError(t, u.DeleteItem(bob, omega.ID))
+
+	t.Run("正常系: 差分同期は論理削除したものも含めて自分のアイテムのみ", func(t *testing.T) {
+		changes, err := u.GetItemChanges(alice, time.Unix(0, 0))
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, rolex.ID, changes[0].ID)
+
+		changes, err = u.GetItemChanges(bob, time.Unix(0, 0))
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, omega.ID, changes[0].ID)
+		assert.NotNil(t, changes[0].DeletedAt)
+	})
+
+	t.Run("正常系: 保証期限が近いアイテムは自分の未削除のアイテムのみ", func(t *testing.T) {
+		items, err := u.GetExpiringWarranties(alice, 30)
+		require.NoError(t, err)
+		require.Len(t, items, 1)
+		assert.Equal(t, rolex.ID, items[0].ID)
+
+		items, err = u.GetExpiringWarranties(bob, 30)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	})
+}
+
+func TestItemRepository_UserScopedAnalyticsThroughUsecase(t *testing.T) {
+	alice := usecase.ContextWithSubject(context.Background(), "alice")
+	bob := usecase.ContextWithSubject(context.Background(), "bob")
+	u := usecase.NewItemUsecase(NewItemRepository(), usecase.WithUserScope())
+
+	_, err := u.CreateItem(alice, createInput("Rolex Daytona", "時計", 1500000, "2023-01-15"))
+	require.NoError(t, err)
+	_, err = u.CreateItem(bob, createInput("Omega Speedmaster", "時計", 800000, "2023-03-01"))
+	require.NoError(t, err)
+
+	t.Run("正常系: 平均価格は自分のアイテムのみで集計する", func(t *testing.T) {
+		analytics, err := u.GetAveragePriceAnalytics(alice)
+		require.NoError(t, err)
+		average := 1500000.0
+		assert.Equal(t, usecase.CategoryAveragePrice{Count: 1, AveragePrice: &average}, analytics.Categories["時計"])
+
+		analytics, err = u.GetAveragePriceAnalytics(bob)
+		require.NoError(t, err)
+		average = 800000.0
+		assert.Equal(t, usecase.CategoryAveragePrice{Count: 1, AveragePrice: &average}, analytics.Categories["時計"])
+	})
+
+	t.Run("正常系: 価格帯は自分のアイテムのみで集計する", func(t *testing.T) {
+		analytics, err := u.GetPriceRangeAnalytics(alice)
+		require.NoError(t, err)
+		alicePrice := 1500000
+		assert.Equal(t, usecase.CategoryPriceRange{Count: 1, MinPrice: &alicePrice, MaxPrice: &alicePrice}, analytics.Categories["時計"])
+
+		analytics, err = u.GetPriceRangeAnalytics(bob)
+		require.NoError(t, err)
+		bobPrice := 800000
+		assert.Equal(t, usecase.CategoryPriceRange{Count: 1, MinPrice: &bobPrice, MaxPrice: &bobPrice}, analytics.Categories["時計"])
+	})
+
+	t.Run("正常系: タイムラインは自分のアイテムのみで集計する", func(t *testing.T) {
+		timeline, err := u.GetTimelineAnalytics(alice)
+		require.NoError(t, err)
+		assert.Equal(t, []usecase.MonthlyStats{{Month: "2023-01", Count: 1, TotalValue: 1500000}}, timeline)
+
+		timeline, err = u.GetTimelineAnalytics(bob)
+		require.NoError(t, err)
+		assert.Equal(t, []usecase.MonthlyStats{{Month: "2023-03", Count: 1, TotalValue: 800000}}, timeline)
+	})
+
+	t.Run("異常系: 認証されていない呼び出し", func(t *testing.T) {
+		_, err := u.GetAveragePriceAnalytics(context.Background())
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+		_, err = u.GetPriceRangeAnalytics(context.Background())
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+		_, err = u.GetTimelineAnalytics(context.Background())
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+	})
+}
+
+func TestItemRepository_CategoriesThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItemRepository()
+	u := usecase.NewItemUsecase(repo, usecase.WithCategories(repo))
+
+	t.Run("正常系: 初期のカテゴリーを ID 順に返す", func(t *testing.T) {
+		categories, err := u.GetCategories(ctx)
+		require.NoError(t, err)
+		require.Len(t, categories, len(entity.DefaultCategories))
+		for i, category := range categories {
+			assert.Equal(t, int64(i+1), category.ID)
+			assert.Equal(t, entity.DefaultCategories[i], category.Name)
+		}
+	})
+
+	t.Run("正常系: 追加したカテゴリーでアイテムを登録できる", func(t *testing.T) {
+		furniture, err := u.CreateCategory(ctx, usecase.CreateCategoryInput{Name: "Furniture"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), furniture.ID)
+
+		_, err = u.CreateItem(ctx, createInput("Table", "Furniture", 50000, "2023-01-15"))
+		assert.NoError(t, err)
+		_, err = u.CreateCategory(ctx, usecase.CreateCategoryInput{Name: "furniture"})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateCategory)
+	})
+
+	t.Run("異常系: アイテムが登録されているカテゴリーは削除できない", func(t *testing.T) {
+		bags, err := u.CreateCategory(ctx, usecase.CreateCategoryInput{Name: "Bags"})
+		require.NoError(t, err)
+		item, err := u.CreateItem(ctx, createInput("Kelly", "Bags", 1000000, "2023-01-15"))
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, u.DeleteCategory(ctx, bags.ID), domainErrors.ErrCategoryInUse)
+		require.NoError(t, u.DeleteItem(ctx, item.ID))
+		assert.NoError(t, u.DeleteCategory(ctx, bags.ID))
+	})
+
+	t.Run("正常系: 削除したカテゴリーではアイテムを登録できない", func(t *testing.T) {
+		shoes, err := u.CreateCategory(ctx, usecase.CreateCategoryInput{Name: "Shoes"})
+		require.NoError(t, err)
+		require.NoError(t, u.DeleteCategory(ctx, shoes.ID))
+
+		_, err = u.CreateItem(ctx, createInput("Pumps", "Shoes", 100000, "2023-01-15"))
+		assert.True(t, domainErrors.IsValidationError(err))
+		assert.ErrorIs(t, u.DeleteCategory(ctx, shoes.ID), domainErrors.ErrCategoryNotFound)
+
+		summary, err := u.GetCategorySummary(ctx, nil, nil)
+		require.NoError(t, err)
+		assert.NotContains(t, summary.Categories, "Shoes")
+		assert.Contains(t, summary.Categories, "Furniture")
+	})
+}
+
+func TestItemRepository_RenameCategoryThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	repo := NewItemRepository()
+	u := usecase.NewItemUsecase(repo, usecase.WithCategories(repo))
+
+	rolex, err := u.CreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+	require.NoError(t, err)
+	omega, err := u.CreateItem(ctx, createInput("オメガ スピードマスター", "時計", 800000, "2023-03-01"))
+	require.NoError(t, err)
+	birkin, err := u.CreateItem(ctx, createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20"))
+	require.NoError(t, err)
+	require.NoError(t, u.DeleteItem(ctx, omega.ID))
+
+	t.Run("正常系: 論理削除されていないアイテムのカテゴリーを変更する", func(t *testing.T) {
+		result, err := u.RenameCategory(ctx, usecase.RenameCategoryInput{From: "時計", To: "腕時計"})
+		require.NoError(t, err)
+		assert.Equal(t, "腕時計", result.Category.Name)
+		assert.Equal(t, int64(1), result.Category.ID)
+		assert.Equal(t, 1, result.UpdatedItems)
+
+		item, err := u.GetItemByID(ctx, rolex.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "腕時計", item.Category)
+		assert.Equal(t, rolex.Version+1, item.Version)
+		assert.False(t, item.UpdatedAt.Before(rolex.UpdatedAt))
+
+		deleted, _, err := u.GetDeletedItems(ctx, 10, 0)
+		require.NoError(t, err)
+		require.Len(t, deleted, 1)
+		assert.Equal(t, "時計", deleted[0].Category)
+		assert.Equal(t, omega.Version, deleted[0].Version)
+
+		_, err = u.CreateItem(ctx, createInput("グランドセイコー", "時計", 500000, "2023-04-01"))
+		assert.True(t, domainErrors.IsValidationError(err))
+	})
+
+	t.Run("異常系: 既存のカテゴリーには統合せず何も変更しない", func(t *testing.T) {
+		_, err := u.RenameCategory(ctx, usecase.RenameCategoryInput{From: "腕時計", To: "バッグ"})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateCategory)
+
+		item, err := u.GetItemByID(ctx, birkin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "バッグ", item.Category)
+		assert.Equal(t, birkin.Version, item.Version)
+		item, err = u.GetItemByID(ctx, rolex.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "腕時計", item.Category)
+		assert.Equal(t, rolex.Version+1, item.Version)
+	})
+
+	t.Run("正常系: 大文字・小文字だけの変更", func(t *testing.T) {
+		_, err := u.CreateCategory(ctx, usecase.CreateCategoryInput{Name: "shoes"})
+		require.NoError(t, err)
+		_, err = u.CreateCategory(ctx, usecase.CreateCategoryInput{Name: "Furniture"})
+		require.NoError(t, err)
+		_, err = u.CreateItem(ctx, createInput("Pumps", "shoes", 100000, "2023-01-15"))
+		require.NoError(t, err)
+
+		result, err := u.RenameCategory(ctx, usecase.RenameCategoryInput{From: "shoes", To: "Shoes"})
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.UpdatedItems)
+		_, err = u.RenameCategory(ctx, usecase.RenameCategoryInput{From: "Shoes", To: "FURNITURE"})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateCategory)
+	})
+
+	t.Run("異常系: トランザクションが失敗した場合はカテゴリーもアイテムも戻す", func(t *testing.T) {
+		err := repo.WithinTransaction(ctx, func(ctx context.Context) error {
+			if _, _, err := repo.RenameCategory(ctx, "バッグ", "鞄"); err != nil {
+				return err
+			}
+			return errors.New("simulated failure")
+		})
+		require.Error(t, err)
+
+		item, err := u.GetItemByID(ctx, birkin.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "バッグ", item.Category)
+		assert.Equal(t, birkin.Version, item.Version)
+		categories, err := u.GetCategories(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "バッグ", categories[1].Name)
+	})
+
+	t.Run("異常系: 存在しないカテゴリー", func(t *testing.T) {
+		_, err := u.RenameCategory(ctx, usecase.RenameCategoryInput{From: "時計", To: "腕時計"})
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryNotFound)
+	})
+}
+
+func TestItemRepository_TagFilterThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	inputs := []usecase.CreateItemInput{
+		createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"),
+		createInput("オメガ スピードマスター", "時計", 800000, "2023-03-01"),
+		createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20"),
+	}
+	inputs[0].Tags = []string{"gift", "vintage"}
+	inputs[1].Tags = []string{"vintage"}
+	items, err := u.CreateItems(ctx, inputs)
+	require.NoError(t, err)
+
+	ids := func(filter usecase.ItemFilter) []int64 {
+		found, _, err := u.GetItems(ctx, filter, usecase.SortOption{Keys: []usecase.SortKey{{Field: usecase.SortByPurchaseDate}}}, 20, 0)
+		require.NoError(t, err)
+		result := []int64{}
+		for _, item := range found {
+			result = append(result, item.ID)
+		}
+		return result
+	}
+
+	assert.Equal(t, []int64{items[0].ID, items[1].ID}, ids(usecase.ItemFilter{Tags: []string{"Vintage"}}))
+	// 既定はすべてのタグを持つアイテム、TagsMatchAny ではいずれかを持つアイテム
+	assert.Equal(t, []int64{items[0].ID}, ids(usecase.ItemFilter{Tags: []string{"gift", "vintage"}}))
+	assert.Equal(t, []int64{items[0].ID, items[1].ID}, ids(usecase.ItemFilter{Tags: []string{"gift", "vintage"}, TagsMatchAny: true}))
+	assert.Equal(t, []int64{}, ids(usecase.ItemFilter{Tags: []string{"unknown"}}))
+}
+
+func TestItemRepository_AveragePriceThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	items, err := u.CreateItems(ctx, []usecase.CreateItemInput{
+		createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"),
+		createInput("オメガ スピードマスター", "時計", 800000, "2023-03-01"),
+		createInput("カルティエ タンク", "時計", 400001, "2023-04-01"),
+		createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20"),
+		createInput("アップルウォッチ", "その他", 50000, "2023-05-12"),
+	})
+	require.NoError(t, err)
+	// 論理削除されたアイテムは平均に含めない
+	require.NoError(t, u.DeleteItem(ctx, items[4].ID))
+
+	analytics, err := u.GetAveragePriceAnalytics(ctx)
+	require.NoError(t, err)
+
+	watch := analytics.Categories["時計"]
+	assert.Equal(t, 3, watch.Count)
+	if assert.NotNil(t, watch.AveragePrice) {
+		assert.InDelta(t, 2700001.0/3, *watch.AveragePrice, 1e-6)
+	}
+	bag := analytics.Categories["バッグ"]
+	if assert.NotNil(t, bag.AveragePrice) {
+		assert.Equal(t, 2000000.0, *bag.AveragePrice)
+	}
+	for _, category := range []string{"ジュエリー", "靴", "その他"} {
+		assert.Equal(t, usecase.CategoryAveragePrice{}, analytics.Categories[category], category)
+	}
+}
+
+func TestItemRepository_PriceRangeThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	items, err := u.CreateItems(ctx, []usecase.CreateItemInput{
+		createInput("オメガ スピードマスター", "時計", 800000, "2023-03-01"),
+		createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"),
+		createInput("カルティエ タンク", "時計", 400000, "2023-04-01"),
+		createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20"),
+		createInput("パテック フィリップ", "時計", 9000000, "2023-06-01"),
+	})
+	require.NoError(t, err)
+	// 論理削除されたアイテムは含めない
+	require.NoError(t, u.DeleteItem(ctx, items[4].ID))
+
+	analytics, err := u.GetPriceRangeAnalytics(ctx)
+	require.NoError(t, err)
+
+	price := func(v int) *int { return &v }
+	assert.Equal(t, usecase.CategoryPriceRange{Count: 3, MinPrice: price(400000), MaxPrice: price(1500000)}, analytics.Categories["時計"])
+	// 1 件のみのカテゴリーは最低と最高が同じ
+	assert.Equal(t, usecase.CategoryPriceRange{Count: 1, MinPrice: price(2000000), MaxPrice: price(2000000)}, analytics.Categories["バッグ"])
+	for _, category := range []string{"ジュエリー", "靴", "その他"} {
+		assert.Equal(t, usecase.CategoryPriceRange{}, analytics.Categories[category], category)
+	}
+}
+
+func TestItemRepository_TimelineThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	timeline, err := u.GetTimelineAnalytics(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, timeline)
+
+	items, err := u.CreateItems(ctx, []usecase.CreateItemInput{
+		createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20"),
+		createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"),
+		createInput("オメガ スピードマスター", "時計", 800000, "2023-01-31"),
+		createInput("ティファニー ネックレス", "ジュエリー", 300000, "2024-01-01"),
+		createInput("ルブタン パンプス", "靴", 150000, "2023-02-01"),
+	})
+	require.NoError(t, err)
+	// 論理削除されたアイテムは含めない
+	require.NoError(t, u.DeleteItem(ctx, items[4].ID))
+
+	timeline, err = u.GetTimelineAnalytics(ctx)
+	require.NoError(t, err)
+	// 古い月から順に並び、年が違う同じ月は別に集計する
+	assert.Equal(t, []usecase.MonthlyStats{
+		{Month: "2023-01", Count: 2, TotalValue: 2300000},
+		{Month: "2023-02", Count: 1, TotalValue: 2000000},
+		{Month: "2024-01", Count: 1, TotalValue: 300000},
+	}, timeline)
+}
+
+func TestItemRepository_SummaryThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	u := usecase.NewItemUsecase(NewItemRepository())
+
+	_, err := u.CreateItems(ctx, []usecase.CreateItemInput{
+		createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"),
+		createInput("オメガ スピードマスター", "時計", 800000, "2023-03-01"),
+		createInput("エルメス バーキン", "バッグ", 2000000, "2023-02-20"),
+		createInput("ルブタン パンプス", "靴", 150000, "2023-04-05"),
+	})
+	require.NoError(t, err)
+	// 論理削除したアイテムは集計しない
+	require.NoError(t, u.DeleteItem(ctx, 4))
+
+	t.Run("全期間", func(t *testing.T) {
+		summary, err := u.GetCategorySummary(ctx, nil, nil)
+		require.NoError(t, err)
+		assert.Equal(t, usecase.CategoryStats{Count: 2, TotalPrice: 2300000}, summary.Categories["時計"])
+		assert.Equal(t, usecase.CategoryStats{Count: 1, TotalPrice: 2000000}, summary.Categories["バッグ"])
+		assert.Equal(t, usecase.CategoryStats{}, summary.Categories["靴"])
+		assert.Equal(t, 3, summary.TotalCount)
+		assert.Equal(t, 4300000, summary.TotalValue)
+	})
+
+	t.Run("購入日の範囲", func(t *testing.T) {
+		from := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)
+		summary, err := u.GetCategorySummary(ctx, &from, &to)
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.TotalCount)
+		assert.Equal(t, 1, summary.Categories["バッグ"].Count)
+	})
+}
+
+func TestItemRepository_ConcurrentCreate(t *testing.T) {
+	repo := NewItemRepository()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 同じカテゴリで名前が重複しないようにする
+			_, err := repo.Create(context.Background(), &entity.Item{Name: fmt.Sprintf("アイテム%d", i), Category: "その他", Brand: "ブランド", PurchaseDate: "2023-01-01"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	count, err := repo.Count(context.Background(), usecase.ItemFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 50, count)
+
+	// ID は重複せず 1 から連番で採番される
+	items, err := repo.FindAll(context.Background(), usecase.ItemFilter{})
+	require.NoError(t, err)
+	seen := make(map[int64]bool)
+	for _, item := range items {
+		seen[item.ID] = true
+	}
+	for id := int64(1); id <= 50; id++ {
+		assert.True(t, seen[id], "id %d", id)
+	}
+}
+
+// failingAuditRepository は監査ログの記録を常に失敗させる
+type failingAuditRepository struct{}
+
+func (failingAuditRepository) CreateAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	return errors.New("simulated audit failure")
+}
+
+func TestItemRepository_CreateWithAuditThroughUsecase(t *testing.T) {
+	ctx := usecase.ContextWithRequestID(context.Background(), "req-1")
+	input := createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15")
+
+	t.Run("アイテムと監査ログを記録", func(t *testing.T) {
+		repo := NewItemRepository()
+		u := usecase.NewItemUsecase(repo, usecase.WithAudit(repo, repo))
+
+		created, err := u.CreateItem(ctx, input)
+		require.NoError(t, err)
+
+		audits := repo.Audits()
+		require.Len(t, audits, 1)
+		assert.Equal(t, created.ID, audits[0].ItemID)
+		assert.Equal(t, entity.AuditActionCreate, audits[0].Action)
+		assert.Equal(t, "req-1", audits[0].RequestID)
+	})
+
+	t.Run("監査ログの記録に失敗した場合はアイテムも残らない", func(t *testing.T) {
+		repo := NewItemRepository()
+		u := usecase.NewItemUsecase(repo, usecase.WithAudit(repo, failingAuditRepository{}))
+
+		_, err := u.CreateItem(ctx, input)
+		assert.Error(t, err)
+		_, err = u.CreateItems(ctx, []usecase.CreateItemInput{input, input})
+		assert.Error(t, err)
+
+		count, err := repo.Count(ctx, usecase.ItemFilter{})
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+		_, err = repo.FindByID(ctx, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Empty(t, repo.Audits())
+	})
+}
+
+func TestItemRepository_PriceHistoryThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	intPtr := func(v int) *int { return &v }
+	strPtr := func(v string) *string { return &v }
+
+	t.Run("購入価格の変更を古い順に記録", func(t *testing.T) {
+		repo := NewItemRepository()
+		u := usecase.NewItemUsecase(repo, usecase.WithPriceHistory(repo, repo))
+		created, err := u.CreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+		require.NoError(t, err)
+
+		_, err = u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{PurchasePrice: intPtr(1600000)})
+		require.NoError(t, err)
+		_, err = u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{Name: strPtr("デイトナ"), PurchasePrice: intPtr(1400000)})
+		require.NoError(t, err)
+
+		changes, err := u.GetPriceHistory(ctx, created.ID)
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		assert.Equal(t, []int{1500000, 1600000}, []int{changes[0].OldPrice, changes[0].NewPrice})
+		assert.Equal(t, []int{1600000, 1400000}, []int{changes[1].OldPrice, changes[1].NewPrice})
+		assert.False(t, changes[0].ChangedAt.IsZero())
+	})
+
+	t.Run("購入価格以外の更新は記録しない", func(t *testing.T) {
+		repo := NewItemRepository()
+		u := usecase.NewItemUsecase(repo, usecase.WithPriceHistory(repo, repo))
+		created, err := u.CreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+		require.NoError(t, err)
+
+		_, err = u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{Name: strPtr("デイトナ"), Notes: strPtr("箱あり")})
+		require.NoError(t, err)
+
+		changes, err := u.GetPriceHistory(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("更新が競合した場合は記録しない", func(t *testing.T) {
+		repo := NewItemRepository()
+		u := usecase.NewItemUsecase(repo, usecase.WithPriceHistory(repo, repo))
+		created, err := u.CreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+		require.NoError(t, err)
+
+		_, err = u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{PurchasePrice: intPtr(1600000), Version: intPtr(created.Version + 1)})
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+
+		changes, err := u.GetPriceHistory(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+}
+
+func TestItemRepository_ItemVersionsThroughUsecase(t *testing.T) {
+	ctx := context.Background()
+	intPtr := func(v int) *int { return &v }
+	strPtr := func(v string) *string { return &v }
+
+	t.Run("更新のたびに別のバージョンを記録し、古いバージョンを取得できる", func(t *testing.T) {
+		repo := NewItemRepository()
+		u := usecase.NewItemUsecase(repo, usecase.WithVersionHistory(repo, repo))
+		created, err := u.CreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+		require.NoError(t, err)
+
+		_, err = u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{Name: strPtr("デイトナ"), Tags: &[]string{"vintage"}})
+		require.NoError(t, err)
+		_, err = u.ReplaceItem(ctx, created.ID, usecase.ReplaceItemInput{Name: strPtr("デイトナ 116500"), Category: strPtr("時計"), Brand: strPtr("ROLEX"), PurchasePrice: intPtr(1800000), PurchaseDate: strPtr("2023-01-15")})
+		require.NoError(t, err)
+
+		versions, err := u.GetItemVersions(ctx, created.ID)
+		require.NoError(t, err)
+		require.Len(t, versions, 3)
+		assert.Equal(t, []int{1, 2, 3}, []int{versions[0].Version, versions[1].Version, versions[2].Version})
+
+		first, err := u.GetItemVersion(ctx, created.ID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ", first.Name)
+		assert.Equal(t, []string{}, first.Tags)
+		second, err := u.GetItemVersion(ctx, created.ID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "デイトナ", second.Name)
+		assert.Equal(t, []string{"vintage"}, second.Tags)
+		assert.Equal(t, 1500000, second.PurchasePrice)
+		current, err := u.GetItemVersion(ctx, created.ID, 3)
+		require.NoError(t, err)
+		assert.Equal(t, 1800000, current.PurchasePrice)
+
+		_, err = u.GetItemVersion(ctx, created.ID, 4)
+		assert.ErrorIs(t, err, domainErrors.ErrItemVersionNotFound)
+	})
+
+	t.Run("更新が競合した場合は記録しない", func(t *testing.T) {
+		repo := NewItemRepository()
+		u := usecase.NewItemUsecase(repo, usecase.WithVersionHistory(repo, repo))
+		created, err := u.CreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+		require.NoError(t, err)
+
+		_, err = u.UpdateItem(ctx, created.ID, usecase.UpdateItemInput{Name: strPtr("デイトナ"), Version: intPtr(created.Version + 1)})
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+
+		versions, err := u.GetItemVersions(ctx, created.ID)
+		require.NoError(t, err)
+		assert.Len(t, versions, 1)
+	})
+
+	t.Run("カテゴリーの一括変更・名前の変更の前のバージョンも取得できる", func(t *testing.T) {
+		repo := NewItemRepository()
+		u := usecase.NewItemUsecase(repo, usecase.WithCategories(repo), usecase.WithVersionHistory(repo, repo))
+		created, err := u.CreateItem(ctx, createInput("ロレックス デイトナ", "時計", 1500000, "2023-01-15"))
+		require.NoError(t, err)
+
+		_, err = u.MoveItems(ctx, usecase.MoveItemsInput{IDs: []int64{created.ID}, TargetCategory: "バッグ"})
+		require.NoError(t, err)
+		_, err = u.RenameCategory(ctx, usecase.RenameCategoryInput{From: "バッグ", To: "鞄"})
+		require.NoError(t, err)
+
+		versions, err := u.GetItemVersions(ctx, created.ID)
+		require.NoError(t, err)
+		require.Len(t, versions, 3)
+		first, err := u.GetItemVersion(ctx, created.ID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "時計", first.Category)
+		second, err := u.GetItemVersion(ctx, created.ID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, "バッグ", second.Category)
+
+		diff, err := u.DiffItemVersions(ctx, created.ID, 1, 3)
+		require.NoError(t, err)
+		assert.Equal(t, []usecase.FieldChange{{Field: "category", Before: "時計", After: "鞄"}}, diff.Changes)
+	})
+}
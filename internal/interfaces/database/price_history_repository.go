@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// CreatePriceChange は購入価格の変更を 1 件登録する（usecase.PriceHistoryRepository の実装）
+// WithinTransaction の context で呼び出すと、同じトランザクションのアイテムの更新と一緒にコミット・ロールバックされる
+func (r *ItemRepository) CreatePriceChange(ctx context.Context, change *entity.PriceChange) error {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+        INSERT INTO item_price_history (item_id, old_price, new_price)
+        VALUES (?, ?, ?)
+    `
+	if _, err := r.Execute(ctx, query, change.ItemID, change.OldPrice, change.NewPrice); err != nil {
+		return dbError(ctx, err)
+	}
+	return nil
+}
+
+// FindPriceHistory はアイテムの購入価格の変更を変更日時の古い順（同じ日時は ID 順）に返す
+func (r *ItemRepository) FindPriceHistory(ctx context.Context, itemID int64) ([]*entity.PriceChange, error) {
+	ctx, cancel := r.withTimeout(ctx)
+	defer cancel()
+
+	query := `
+        SELECT id, item_id, old_price, new_price, changed_at
+        FROM item_price_history
+        WHERE item_id = ?
+        ORDER BY changed_at, id
+    `
+	rows, err := r.Query(ctx, query, itemID)
+	if err != nil {
+		return nil, dbError(ctx, err)
+	}
+	defer rows.Close()
+
+	changes := []*entity.PriceChange{}
+	for rows.Next() {
+		var change entity.PriceChange
+		if err := rows.Scan(&change.ID, &change.ItemID, &change.OldPrice, &change.NewPrice, &change.ChangedAt); err != nil {
+			return nil, dbError(ctx, err)
+		}
+		changes = append(changes, &change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, dbError(ctx, err)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+	"Aicon-assignment/internal/usecase"
+)
+
+var _ usecase.PriceHistoryRepository = (*ItemRepository)(nil)
+
+func TestItemRepository_PriceHistory(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("create inserts the old and new price", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.CreatePriceChange(ctx, &entity.PriceChange{ItemID: 1, OldPrice: 1000000, NewPrice: 1200000})
+
+		require.NoError(t, err)
+		assert.Equal(t, "INSERT INTO item_price_history (item_id, old_price, new_price) VALUES (?, ?, ?)", handler.lastStatement())
+		assert.Equal(t, []interface{}{int64(1), 1000000, 1200000}, handler.lastArgs())
+	})
+
+	t.Run("create joins the update transaction", func(t *testing.T) {
+		handler := &fakeSqlHandler{result: fakeResult{rowsAffected: 1}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.WithinTransaction(ctx, func(ctx context.Context) error {
+			return repo.CreatePriceChange(ctx, &entity.PriceChange{ItemID: 1, OldPrice: 1000000, NewPrice: 1200000})
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, handler.tx.executions)
+		assert.True(t, handler.tx.committed)
+	})
+
+	t.Run("create translates database errors", func(t *testing.T) {
+		handler := &fakeSqlHandler{err: assert.AnError}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.CreatePriceChange(ctx, &entity.PriceChange{ItemID: 1})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+	})
+
+	t.Run("find orders by change time", func(t *testing.T) {
+		later := now.Add(time.Hour)
+		handler := &fakeSqlHandler{rows: [][]interface{}{
+			{int64(1), int64(3), 1000000, 1200000, now},
+			{int64(2), int64(3), 1200000, 900000, later},
+		}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		changes, err := repo.FindPriceHistory(ctx, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.PriceChange{
+			{ID: 1, ItemID: 3, OldPrice: 1000000, NewPrice: 1200000, ChangedAt: now},
+			{ID: 2, ItemID: 3, OldPrice: 1200000, NewPrice: 900000, ChangedAt: later},
+		}, changes)
+		assert.Equal(t, "SELECT id, item_id, old_price, new_price, changed_at FROM item_price_history WHERE item_id = ? ORDER BY changed_at, id", handler.lastStatement())
+		assert.Equal(t, []interface{}{int64(3)}, handler.lastArgs())
+	})
+
+	t.Run("find without changes is empty", func(t *testing.T) {
+		repo := &ItemRepository{SqlHandler: &fakeSqlHandler{}}
+
+		changes, err := repo.FindPriceHistory(ctx, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.PriceChange{}, changes)
+	})
+}
@@ -1,14 +1,34 @@
 package database
 
-import "context"
+import (
+	"context"
+	"errors"
+)
 
-type SqlHandler interface {
+// ErrUniqueViolation は一意制約に違反したことを表す
+// SqlHandler の実装はドライバーの一意制約違反のエラーをこのエラーでラップして返す
+var ErrUniqueViolation = errors.New("unique constraint violation")
+
+// SQL を実行する操作（SqlHandler と Tx の共通部分）
+type Executor interface {
 	Execute(ctx context.Context, statement string, args ...interface{}) (Result, error)
 	Query(ctx context.Context, statement string, args ...interface{}) (Rows, error)
 	QueryRow(ctx context.Context, statement string, args ...interface{}) Row
+}
+
+type SqlHandler interface {
+	Executor
+	Begin(ctx context.Context) (Tx, error)
+	Ping(ctx context.Context) error
 	Close() error
 }
 
+type Tx interface {
+	Executor
+	Commit() error
+	Rollback() error
+}
+
 type Result interface {
 	LastInsertId() (int64, error)
 	RowsAffected() (int64, error)
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+type txKey struct{}
+
+// txFromContext は WithinTransaction が context に設定したトランザクションを返す
+func txFromContext(ctx context.Context) (Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(Tx)
+	return tx, ok
+}
+
+// WithinTransaction は fn を 1 つのトランザクションで実行する（usecase.Transactor の実装）
+// fn に渡す context で呼び出したメソッドはそのトランザクションで SQL を実行し、fn がエラーを返した場合はすべてロールバックする
+// すでにトランザクション内の場合は新たに開始せず、外側のトランザクションに含める
+func (r *ItemRepository) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := r.Begin(ctx)
+	if err != nil {
+		return dbError(ctx, fmt.Errorf("failed to begin transaction: %w", err))
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return dbError(ctx, fmt.Errorf("failed to commit transaction: %w", err))
+	}
+
+	return nil
+}
+
+// withTx は fn に渡す Executor の SQL を 1 つのトランザクションで実行する
+func (r *ItemRepository) withTx(ctx context.Context, fn func(tx Executor) error) error {
+	return r.WithinTransaction(ctx, func(ctx context.Context) error {
+		return fn(r.executor(ctx))
+	})
+}
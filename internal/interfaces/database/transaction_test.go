@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"Aicon-assignment/internal/usecase"
+)
+
+var (
+	_ usecase.AuditRepository = (*ItemRepository)(nil)
+	_ usecase.Transactor      = (*ItemRepository)(nil)
+)
+
+func TestItemRepository_CreateWithAudit(t *testing.T) {
+	input := usecase.CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"}
+	ctx := usecase.ContextWithRequestID(context.Background(), "req-1")
+
+	t.Run("item and audit are committed together", func(t *testing.T) {
+		handler := &fakeSqlHandler{
+			result: fakeResult{lastInsertID: 1, rowsAffected: 1},
+			row:    itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01"),
+		}
+		repo := &ItemRepository{SqlHandler: handler}
+		u := usecase.NewItemUsecase(repo, usecase.WithAudit(repo, repo))
+
+		created, err := u.CreateItem(ctx, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), created.ID)
+		assert.Equal(t, "INSERT INTO items (name, category, brand, purchase_price, purchase_date, warranty_expiry, notes, image_url, attributes, user_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)", handler.statements[0])
+		// 登録したアイテムの取得も同じトランザクションで行う
+		assert.True(t, strings.HasPrefix(handler.statements[1], "SELECT"))
+		assert.Equal(t, "INSERT INTO item_audits (item_id, action, actor, request_id) VALUES (?, ?, ?, ?)", handler.lastStatement())
+		assert.Equal(t, []interface{}{int64(1), "create", "", "req-1"}, handler.lastArgs())
+		assert.Equal(t, 2, handler.tx.executions)
+		assert.True(t, handler.tx.committed)
+	})
+
+	t.Run("audit failure rolls back the item insert", func(t *testing.T) {
+		handler := &failingBeginHandler{
+			fakeSqlHandler: &fakeSqlHandler{
+				result: fakeResult{lastInsertID: 1, rowsAffected: 1},
+				row:    itemRow(1, "時計1", "時計", "ROLEX", 1000000, "2023-01-01"),
+			},
+			failOnExecute: 2,
+		}
+		repo := &ItemRepository{SqlHandler: handler}
+		u := usecase.NewItemUsecase(repo, usecase.WithAudit(repo, repo))
+
+		created, err := u.CreateItem(ctx, input)
+
+		assert.Error(t, err)
+		assert.Nil(t, created)
+		assert.Contains(t, handler.lastStatement(), "INSERT INTO item_audits")
+		assert.True(t, handler.tx.rolledBack)
+		assert.False(t, handler.tx.committed)
+	})
+
+	t.Run("nested transactions join the outer one", func(t *testing.T) {
+		handler := &countingBeginHandler{fakeSqlHandler: &fakeSqlHandler{result: fakeResult{lastInsertID: 1, rowsAffected: 1}}}
+		repo := &ItemRepository{SqlHandler: handler}
+
+		err := repo.WithinTransaction(ctx, func(ctx context.Context) error {
+			_, err := repo.DeleteItems(ctx, []int64{1})
+			return err
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, handler.begins)
+		assert.Equal(t, 1, handler.tx.executions)
+		assert.True(t, handler.tx.committed)
+	})
+}
+
+// countingBeginHandler はトランザクションを開始した回数を記録する
+type countingBeginHandler struct {
+	*fakeSqlHandler
+	begins int
+}
+
+func (h *countingBeginHandler) Begin(ctx context.Context) (Tx, error) {
+	h.begins++
+	return h.fakeSqlHandler.Begin(ctx)
+}
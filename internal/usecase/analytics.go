@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+)
+
+// AveragePriceAnalytics はカテゴリー別の平均購入価格
+type AveragePriceAnalytics struct {
+	Categories map[string]CategoryAveragePrice `json:"categories"`
+}
+
+// PriceRangeAnalytics はカテゴリー別の最低・最高購入価格
+type PriceRangeAnalytics struct {
+	Categories map[string]CategoryPriceRange `json:"categories"`
+}
+
+// カテゴリー別の平均購入価格を返す（論理削除されたアイテムと、WithUserScope の場合は他のユーザーのアイテムは含めない）
+// アイテムのないカテゴリーも 0 件・平均 null で含める
+func (u *itemUsecase) GetAveragePriceAnalytics(ctx context.Context) (*AveragePriceAnalytics, error) {
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	averages, err := u.itemRepo.GetAveragePriceByCategory(ctx, ItemFilter{UserID: owner})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get average prices: %w", err)
+	}
+
+	names, err := u.categoryNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[string]CategoryAveragePrice)
+	for _, category := range names {
+		categories[category] = averages[category]
+	}
+
+	return &AveragePriceAnalytics{Categories: categories}, nil
+}
+
+// カテゴリー別の最低・最高購入価格を返す（論理削除されたアイテムと、WithUserScope の場合は他のユーザーのアイテムは含めない）
+// アイテムのないカテゴリーも 0 件・最低/最高 null で含める
+func (u *itemUsecase) GetPriceRangeAnalytics(ctx context.Context) (*PriceRangeAnalytics, error) {
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges, err := u.itemRepo.GetPriceRangeByCategory(ctx, ItemFilter{UserID: owner})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price ranges: %w", err)
+	}
+
+	names, err := u.categoryNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make(map[string]CategoryPriceRange)
+	for _, category := range names {
+		categories[category] = ranges[category]
+	}
+
+	return &PriceRangeAnalytics{Categories: categories}, nil
+}
+
+// 購入月（YYYY-MM）ごとの件数と購入価格の合計を古い月から順に返す（アイテムのない月は含めない）
+// WithUserScope の場合は呼び出したユーザーのアイテムのみを集計する
+func (u *itemUsecase) GetTimelineAnalytics(ctx context.Context) ([]MonthlyStats, error) {
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	timeline, err := u.itemRepo.GetMonthlyTimeline(ctx, ItemFilter{UserID: owner})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get timeline: %w", err)
+	}
+
+	return timeline, nil
+}
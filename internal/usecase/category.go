@@ -0,0 +1,187 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// CategoryUsecase はアイテムを登録できるカテゴリーの一覧・追加・削除・名前の変更
+type CategoryUsecase interface {
+	GetCategories(ctx context.Context) ([]*entity.Category, error)
+	CreateCategory(ctx context.Context, input CreateCategoryInput) (*entity.Category, error)
+	DeleteCategory(ctx context.Context, id int64) error
+	RenameCategory(ctx context.Context, input RenameCategoryInput) (*RenameCategoryResult, error)
+}
+
+type CreateCategoryInput struct {
+	Name string `json:"name"`
+}
+
+// RenameCategoryInput はカテゴリー名の変更（from の名前のカテゴリーを to にする）
+type RenameCategoryInput struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// RenameCategoryResult は名前を変更したカテゴリーと、カテゴリーを変更したアイテム数（論理削除されたものは含まない）
+type RenameCategoryResult struct {
+	Category     *entity.Category `json:"category"`
+	UpdatedItems int              `json:"updated_items"`
+}
+
+// WithCategories はカテゴリーの保存先を設定する（アイテムのカテゴリーはこの一覧と照合する）
+func WithCategories(repo CategoryRepository) Option {
+	return func(u *itemUsecase) {
+		if repo != nil {
+			u.categories = repo
+		}
+	}
+}
+
+// カテゴリーを ID 順にすべて返す
+func (u *itemUsecase) GetCategories(ctx context.Context) ([]*entity.Category, error) {
+	categories, err := u.categories.FindCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// カテゴリーを追加する（名前はアイテム名と同じく NFKC で正規化し、大文字・小文字だけが異なる名前は重複とする）
+func (u *itemUsecase) CreateCategory(ctx context.Context, input CreateCategoryInput) (*entity.Category, error) {
+	category, err := entity.NewCategory(normalizeName(input.Name))
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := u.categories.CreateCategory(ctx, category)
+	if err != nil {
+		if domainErrors.IsDuplicateCategoryError(err) {
+			return nil, domainErrors.ErrDuplicateCategory
+		}
+		return nil, fmt.Errorf("failed to create category: %w", err)
+	}
+	// 集計はアイテムのないカテゴリーも含めるため、一覧が変わったら破棄する
+	u.summaryCache.invalidate()
+
+	return created, nil
+}
+
+// カテゴリーを削除する（論理削除されていないアイテムが登録されている間は削除できない）
+func (u *itemUsecase) DeleteCategory(ctx context.Context, id int64) error {
+	if id <= 0 {
+		return domainErrors.ErrInvalidInput
+	}
+
+	if err := u.categories.DeleteCategory(ctx, id); err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrCategoryNotFound):
+			return domainErrors.ErrCategoryNotFound
+		case domainErrors.IsCategoryInUseError(err):
+			return domainErrors.ErrCategoryInUse
+		}
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	u.summaryCache.invalidate()
+
+	return nil
+}
+
+// カテゴリー名を変更し、そのカテゴリーの論理削除されていないアイテムのカテゴリーも 1 つのトランザクションで変更する
+// 論理削除されたアイテムは削除時点のカテゴリーのまま残す
+// to が別の既存のカテゴリーと同じ名前（大文字・小文字を区別しない）の場合は統合せず ErrDuplicateCategory を返す
+// 大文字・小文字だけの変更はできる
+func (u *itemUsecase) RenameCategory(ctx context.Context, input RenameCategoryInput) (*RenameCategoryResult, error) {
+	from := normalizeName(input.From)
+	to := &entity.Category{Name: normalizeName(input.To)}
+
+	verr := &domainErrors.ValidationError{}
+	if from == "" {
+		verr.Add("from", domainErrors.CodeRequired)
+	}
+	if toErr, ok := domainErrors.AsValidationError(to.Validate()); ok {
+		for _, f := range toErr.Fields {
+			f.Field = "to"
+			verr.Fields = append(verr.Fields, f)
+		}
+	}
+	if err := verr.Err(); err != nil {
+		return nil, err
+	}
+
+	category, ids, err := u.categories.RenameCategory(ctx, from, to.Name)
+	if err != nil {
+		switch {
+		case errors.Is(err, domainErrors.ErrCategoryNotFound):
+			return nil, domainErrors.ErrCategoryNotFound
+		case domainErrors.IsDuplicateCategoryError(err):
+			return nil, domainErrors.ErrDuplicateCategory
+		}
+		return nil, fmt.Errorf("failed to rename category: %w", err)
+	}
+	u.summaryCache.invalidate()
+	u.invalidateItems(ctx, ids...)
+	u.publishUpdated(ctx, ids)
+
+	return &RenameCategoryResult{Category: category, UpdatedItems: len(ids)}, nil
+}
+
+// publishUpdated はカテゴリーを変更した論理削除されていないアイテムを更新として通知する
+// 変更はコミット済みのため、取得に失敗した場合はログに残して通知しない
+func (u *itemUsecase) publishUpdated(ctx context.Context, ids []int64) {
+	if len(u.publishers) == 0 || len(ids) == 0 {
+		return
+	}
+
+	items, err := u.itemRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to retrieve updated items for events", "error", err)
+		return
+	}
+	u.publish(ctx, entity.ItemEventUpdated, items...)
+}
+
+// categoryNames はアイテムを登録できるカテゴリー名を ID 順に返す
+func (u *itemUsecase) categoryNames(ctx context.Context) ([]string, error) {
+	categories, err := u.categories.FindCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve categories: %w", err)
+	}
+
+	names := make([]string, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+	}
+	return names, nil
+}
+
+// defaultCategories は WithCategories で保存先を設定しない場合のカテゴリー（初期のカテゴリーに固定し、変更できない）
+type defaultCategories struct{}
+
+var errCategoriesReadOnly = errors.New("categories cannot be changed without a category repository")
+
+func (defaultCategories) FindCategories(ctx context.Context) ([]*entity.Category, error) {
+	categories := make([]*entity.Category, len(entity.DefaultCategories))
+	for i, name := range entity.DefaultCategories {
+		categories[i] = &entity.Category{ID: int64(i + 1), Name: name}
+	}
+	return categories, nil
+}
+
+func (defaultCategories) CreateCategory(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	return nil, errCategoriesReadOnly
+}
+
+func (defaultCategories) DeleteCategory(ctx context.Context, id int64) error {
+	return errCategoriesReadOnly
+}
+
+func (defaultCategories) RenameCategory(ctx context.Context, from, to string) (*entity.Category, []int64, error) {
+	return nil, nil, errCategoriesReadOnly
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strconv"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// itemCacheKey はアイテム 1 件をキャッシュするキー
+func itemCacheKey(id int64) string {
+	return "items:" + strconv.FormatInt(id, 10)
+}
+
+// cachedItem はキャッシュにあるアイテムを返す
+// キャッシュは取得を速くするためのものなので、失敗した場合はログに残してリポジトリから取得させる
+func (u *itemUsecase) cachedItem(ctx context.Context, id int64) (*entity.Item, bool) {
+	if u.itemCache == nil {
+		return nil, false
+	}
+
+	value, found, err := u.itemCache.Get(ctx, itemCacheKey(id))
+	if err != nil {
+		slog.WarnContext(ctx, "failed to read item cache", "item_id", id, "error", err)
+		return nil, false
+	}
+	if !found {
+		return nil, false
+	}
+
+	var item entity.Item
+	if err := json.Unmarshal(value, &item); err != nil {
+		slog.WarnContext(ctx, "failed to decode cached item", "item_id", id, "error", err)
+		return nil, false
+	}
+	return &item, true
+}
+
+// cacheItem はリポジトリから取得したアイテムを itemCacheTTL の間キャッシュする
+func (u *itemUsecase) cacheItem(ctx context.Context, item *entity.Item) {
+	if u.itemCache == nil {
+		return
+	}
+
+	value, err := json.Marshal(item)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to encode item for cache", "item_id", item.ID, "error", err)
+		return
+	}
+	if err := u.itemCache.Set(ctx, itemCacheKey(item.ID), value, u.itemCacheTTL); err != nil {
+		slog.WarnContext(ctx, "failed to write item cache", "item_id", item.ID, "error", err)
+	}
+}
+
+// invalidateItems は変更したアイテムのキャッシュを削除する
+// 削除に失敗した場合、そのアイテムは TTL が切れるまで変更前の内容を返すことがある
+func (u *itemUsecase) invalidateItems(ctx context.Context, ids ...int64) {
+	if u.itemCache == nil || len(ids) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(ids))
+	for _, id := range ids {
+		keys = append(keys, itemCacheKey(id))
+	}
+	if err := u.itemCache.Delete(ctx, keys...); err != nil {
+		slog.WarnContext(ctx, "failed to invalidate item cache", "item_ids", ids, "error", err)
+	}
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// publish は items それぞれの eventType のイベントをすべての通知先に渡す（変更のコミット後に呼ぶ）
+func (u *itemUsecase) publish(ctx context.Context, eventType string, items ...*entity.Item) {
+	if len(u.publishers) == 0 {
+		return
+	}
+
+	now := u.now()
+	for _, item := range items {
+		event := entity.ItemEvent{Type: eventType, Item: item, Timestamp: now}
+		for _, p := range u.publishers {
+			p.Publish(ctx, event)
+		}
+	}
+}
+
+// deleteSnapshots は通知先がある場合のみ、削除する前のアイテムを ID ごとに返す
+func (u *itemUsecase) deleteSnapshots(ctx context.Context, ids []int64) (map[int64]*entity.Item, error) {
+	if len(u.publishers) == 0 {
+		return nil, nil
+	}
+
+	items, err := u.itemRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	snapshots := make(map[int64]*entity.Item, len(items))
+	for _, item := range items {
+		snapshots[item.ID] = item
+	}
+	return snapshots, nil
+}
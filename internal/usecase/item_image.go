@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// アップロードできる画像の最大バイト数（WithMaxImageSize で設定しない場合）
+const DefaultMaxImageSize = 5 << 20
+
+// アップロードできる画像の形式と、保存するファイルの拡張子
+var imageExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// http.DetectContentType が形式の判定に使う先頭のバイト数
+const sniffLength = 512
+
+// ImageUpload はアップロードされた画像ファイル
+type ImageUpload struct {
+	// ファイルのバイト数
+	Size int64
+	Body io.Reader
+}
+
+// UploadItemImage は画像を保存し、アイテムの image_url を保存先の URL にする
+// 画像の形式はクライアントが指定した Content-Type ではなく、ファイルの先頭のバイト列から判定する
+func (u *itemUsecase) UploadItemImage(ctx context.Context, id int64, image ImageUpload) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if u.imageStorage == nil {
+		return nil, errors.New("image storage is not configured")
+	}
+
+	verr := &domainErrors.ValidationError{}
+	if image.Size > u.maxImageSize {
+		verr.Add("image", domainErrors.CodeTooLarge, u.maxImageSize)
+		return nil, verr
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(image.Body, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		verr.Add("image", domainErrors.CodeUnsupportedValue, strings.Join(slices.Sorted(maps.Keys(imageExtensions)), ", "), contentType)
+		return nil, verr
+	}
+
+	// 存在しないアイテムの画像は保存しない
+	exists, err := u.ItemExists(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, domainErrors.ErrItemNotFound
+	}
+
+	// アップロードのたびに別のキーにし、以前の URL をキャッシュしたクライアントに古い画像を返さない
+	key := fmt.Sprintf("items/%d/%d%s", id, u.now().UnixNano(), ext)
+	url, err := u.imageStorage.Save(ctx, key, contentType, io.MultiReader(bytes.NewReader(head), image.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save image: %w", err)
+	}
+
+	return u.UpdateItem(ctx, id, UpdateItemInput{ImageURL: &url})
+}
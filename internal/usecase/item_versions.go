@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// WithVersionHistory はアイテムを更新するたびに更新前のアイテムをそのバージョンとして記録する
+// 更新とバージョンは transactor の 1 つのトランザクションで書き込み、どちらかが失敗した場合は両方とも残さない
+// カテゴリーの一括変更・名前の変更の変更前のバージョンは、リポジトリが変更と同じトランザクションで記録する
+func WithVersionHistory(transactor Transactor, versions ItemVersionRepository) Option {
+	return func(u *itemUsecase) {
+		if transactor != nil && versions != nil {
+			u.transactor = transactor
+			u.versions = versions
+		}
+	}
+}
+
+// GetItemVersions は記録したバージョンと現在のバージョンを古い順に返す
+func (u *itemUsecase) GetItemVersions(ctx context.Context, id int64) ([]*entity.ItemVersion, error) {
+	item, err := u.currentItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := []*entity.ItemVersion{}
+	if u.versions != nil {
+		if versions, err = u.versions.FindItemVersions(ctx, id); err != nil {
+			return nil, fmt.Errorf("failed to retrieve item versions: %w", err)
+		}
+	}
+	return append(versions, &entity.ItemVersion{Version: item.Version, UpdatedAt: item.UpdatedAt}), nil
+}
+
+// GetItemVersion は version の時点のアイテムを返す（現在のバージョンはアイテムそのもの）
+// 記録していないバージョンは ErrItemVersionNotFound とする
+func (u *itemUsecase) GetItemVersion(ctx context.Context, id int64, version int) (*entity.Item, error) {
+	if version <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.currentItem(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if version == item.Version {
+		return item, nil
+	}
+	if version > item.Version || u.versions == nil {
+		return nil, domainErrors.ErrItemVersionNotFound
+	}
+
+	snapshot, err := u.versions.FindItemVersion(ctx, id, version)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemVersionNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item version: %w", err)
+	}
+	return snapshot, nil
+}
+
+// ItemDiff は 2 つのバージョンの間で値が変わったフィールド（変更がない場合は空の配列）
+type ItemDiff struct {
+	From    int           `json:"from"`
+	To      int           `json:"to"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// FieldChange は 1 つのフィールドの from の時点の値（Before）と to の時点の値（After）
+// 値は JSON と同じ形式で、未設定のフィールドは null
+type FieldChange struct {
+	Field  string      `json:"field"`
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// diffFields は差分を比べるフィールドを JSON の名前で Item の定義順に並べたもの
+// ID・バージョン・日時・登録したユーザーは、バージョンごとに変わるか変更できないため比べない
+var diffFields = []struct {
+	name  string
+	value func(item *entity.Item) interface{}
+}{
+	{"name", func(item *entity.Item) interface{} { return item.Name }},
+	{"category", func(item *entity.Item) interface{} { return item.Category }},
+	{"brand", func(item *entity.Item) interface{} { return item.Brand }},
+	{"purchase_price", func(item *entity.Item) interface{} { return item.PurchasePrice }},
+	{"purchase_date", func(item *entity.Item) interface{} { return item.PurchaseDate }},
+	{"warranty_expiry", func(item *entity.Item) interface{} {
+		if item.WarrantyExpiry == nil {
+			return nil
+		}
+		return *item.WarrantyExpiry
+	}},
+	{"notes", func(item *entity.Item) interface{} { return item.Notes }},
+	{"tags", func(item *entity.Item) interface{} {
+		if len(item.Tags) == 0 {
+			return []string{}
+		}
+		return item.Tags
+	}},
+	{"image_url", func(item *entity.Item) interface{} {
+		if item.ImageURL == "" {
+			return nil
+		}
+		return item.ImageURL
+	}},
+	{"attributes", func(item *entity.Item) interface{} {
+		if len(item.Attributes) == 0 {
+			return nil
+		}
+		return item.Attributes
+	}},
+}
+
+// DiffItemVersions は from と to のバージョンの間で値が変わったフィールドを返す
+// どちらかのバージョンが記録されていない場合は ErrItemVersionNotFound とする
+func (u *itemUsecase) DiffItemVersions(ctx context.Context, id int64, from, to int) (*ItemDiff, error) {
+	before, err := u.GetItemVersion(ctx, id, from)
+	if err != nil {
+		return nil, err
+	}
+	after, err := u.GetItemVersion(ctx, id, to)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &ItemDiff{From: from, To: to, Changes: []FieldChange{}}
+	for _, field := range diffFields {
+		b, a := field.value(before), field.value(after)
+		if !reflect.DeepEqual(b, a) {
+			diff.Changes = append(diff.Changes, FieldChange{Field: field.name, Before: b, After: a})
+		}
+	}
+	return diff, nil
+}
+
+// currentItem は呼び出したユーザーの論理削除されていないアイテムを返す
+func (u *itemUsecase) currentItem(ctx context.Context, id int64) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	return item, nil
+}
+
+// recordVersion は更新前のアイテムをそのバージョンとして記録する
+func (u *itemUsecase) recordVersion(ctx context.Context, previous *entity.Item) error {
+	if u.versions == nil {
+		return nil
+	}
+	if err := u.versions.CreateItemVersion(ctx, previous); err != nil {
+		return fmt.Errorf("failed to record item version: %w", err)
+	}
+	return nil
+}
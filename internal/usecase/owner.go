@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+	domainErrors "Aicon-assignment/internal/domain/errors"
+)
+
+// WithUserScope はアイテムを登録したユーザー（JWT の subject）ごとに分ける
+// アイテムの取得・変更は呼び出したユーザーのアイテムに限り、他のユーザーのアイテムは存在しないもの（ErrItemNotFound）とする
+// 認証されていない呼び出しは ErrUnauthenticated になる
+func WithUserScope() Option {
+	return func(u *itemUsecase) {
+		u.userScope = true
+	}
+}
+
+// owner は呼び出したユーザーを返す（WithUserScope を設定しない場合は nil で絞り込まない）
+func (u *itemUsecase) owner(ctx context.Context) (*string, error) {
+	if !u.userScope {
+		return nil, nil
+	}
+
+	subject := SubjectFromContext(ctx)
+	if subject == "" {
+		return nil, domainErrors.ErrUnauthenticated
+	}
+	return &subject, nil
+}
+
+// owns は item が owner のアイテムかを返す（owner が nil の場合は常に true）
+func owns(owner *string, item *entity.Item) bool {
+	return owner == nil || item.UserID == *owner
+}
+
+// ownedItems は items のうち owner のアイテムを順序を保って返す
+func ownedItems(owner *string, items []*entity.Item) []*entity.Item {
+	if owner == nil {
+		return items
+	}
+
+	owned := make([]*entity.Item, 0, len(items))
+	for _, item := range items {
+		if owns(owner, item) {
+			owned = append(owned, item)
+		}
+	}
+	return owned
+}
+
+// findItem は呼び出したユーザーの論理削除されていないアイテムを返す
+// 他のユーザーのアイテムは存在しないアイテムと同じく ErrItemNotFound とする
+func (u *itemUsecase) findItem(ctx context.Context, id int64) (*entity.Item, error) {
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := u.itemRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !owns(owner, item) {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	return item, nil
+}
+
+// ownedIDs は ids のうち owner の論理削除されていないアイテムの ID を指定順で返す（owner が nil の場合は ids のまま）
+func (u *itemUsecase) ownedIDs(ctx context.Context, owner *string, ids []int64) ([]int64, error) {
+	if owner == nil {
+		return ids, nil
+	}
+
+	items, err := u.itemRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+	owned := make(map[int64]bool, len(items))
+	for _, item := range ownedItems(owner, items) {
+		owned[item.ID] = true
+	}
+
+	filtered := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if owned[id] {
+			filtered = append(filtered, id)
+		}
+	}
+	return filtered, nil
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"Aicon-assignment/internal/domain/entity"
+)
+
+// WithPriceHistory はアイテムの更新で購入価格が変わった場合に変更前後の価格を記録する
+// 更新と価格の履歴は transactor の 1 つのトランザクションで書き込み、どちらかが失敗した場合は両方とも残さない
+func WithPriceHistory(transactor Transactor, history PriceHistoryRepository) Option {
+	return func(u *itemUsecase) {
+		if transactor != nil && history != nil {
+			u.transactor = transactor
+			u.priceHistory = history
+		}
+	}
+}
+
+// GetPriceHistory はアイテムの購入価格の変更を古い順に返す（WithPriceHistory を設定しない場合は空）
+func (u *itemUsecase) GetPriceHistory(ctx context.Context, id int64) ([]*entity.PriceChange, error) {
+	if _, err := u.currentItem(ctx, id); err != nil {
+		return nil, err
+	}
+	if u.priceHistory == nil {
+		return []*entity.PriceChange{}, nil
+	}
+
+	changes, err := u.priceHistory.FindPriceHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve price history: %w", err)
+	}
+	return changes, nil
+}
+
+// recordPriceChange は購入価格が previous から変わった場合に価格の履歴を記録する
+func (u *itemUsecase) recordPriceChange(ctx context.Context, previous, updated *entity.Item) error {
+	if u.priceHistory == nil || updated.PurchasePrice == previous.PurchasePrice {
+		return nil
+	}
+
+	change := &entity.PriceChange{ItemID: updated.ID, OldPrice: previous.PurchasePrice, NewPrice: updated.PurchasePrice}
+	if err := u.priceHistory.CreatePriceChange(ctx, change); err != nil {
+		return fmt.Errorf("failed to record price change: %w", err)
+	}
+	return nil
+}
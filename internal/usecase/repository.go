@@ -2,27 +2,253 @@ package usecase
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"Aicon-assignment/internal/domain/entity"
 )
 
+// ItemFilter narrows item listings; nil fields are not applied
+type ItemFilter struct {
+	// Categories matches items in any of the listed categories; an empty list is not applied
+	Categories []string
+	// ExcludedCategories drops items in any of the listed categories; combined with Categories by AND
+	ExcludedCategories []string
+	Brand              *string
+
+	// Tags matches items carrying every listed tag, or any of them when TagsMatchAny is set;
+	// an empty list is not applied
+	Tags         []string
+	TagsMatchAny bool
+
+	// Attributes matches items whose attributes satisfy every listed condition; an empty list is not applied
+	Attributes []AttributeFilter
+
+	// MinPrice / MaxPrice bound purchase_price inclusively; either may be omitted
+	MinPrice *int
+	MaxPrice *int
+
+	// PurchasedAfter / PurchasedBefore bound purchase_date inclusively (date part only)
+	PurchasedAfter  *time.Time
+	PurchasedBefore *time.Time
+
+	// Deleted selects soft-deleted items instead of active ones
+	Deleted bool
+
+	// Search is a case-insensitive partial match on the item name; empty means no search
+	// Name search (GET /items?q=) goes through this filter rather than a separate usecase method
+	Search string
+
+	// UserID limits items to those registered by the user
+	UserID *string
+	// IDs limits items to the listed IDs; an empty list is not applied
+	IDs []int64
+}
+
+// AttributeFilter matches items whose attribute at Path equals Value, compared as text
+// (strings without quotes, numbers and booleans as they appear in JSON)
+type AttributeFilter struct {
+	// Path lists the member names from the top-level object down, e.g. ["case", "material"]
+	Path  []string
+	Value string
+}
+
+// SortField names a field items can be ordered by; the repository maps it to a column
+type SortField string
+
+const (
+	SortByPurchaseDate  SortField = "purchase_date"
+	SortByPurchasePrice SortField = "purchase_price"
+	SortByCreatedAt     SortField = "created_at"
+	SortByCategory      SortField = "category"
+)
+
+// SortKey is one key of the ordering
+type SortKey struct {
+	Field      SortField
+	Descending bool
+}
+
+// SortOption describes the requested ordering as keys applied in order (ties on every key are ordered by ID);
+// the zero value keeps the default order
+type SortOption struct {
+	Keys []SortKey
+}
+
+// CategoryStats aggregates the items of one category
+type CategoryStats struct {
+	Count      int `json:"count"`
+	TotalPrice int `json:"total_price"`
+}
+
+// CategoryAveragePrice is the average purchase price of the items of one category;
+// AveragePrice is nil when the category has no items
+type CategoryAveragePrice struct {
+	Count        int      `json:"count"`
+	AveragePrice *float64 `json:"average_price"`
+}
+
+// CategoryPriceRange is the lowest and highest purchase price of the items of one category;
+// MinPrice and MaxPrice are nil when the category has no items
+type CategoryPriceRange struct {
+	Count    int  `json:"count"`
+	MinPrice *int `json:"min_price"`
+	MaxPrice *int `json:"max_price"`
+}
+
+// MonthlyStats aggregates the items purchased in one month; Month is formatted as YYYY-MM
+type MonthlyStats struct {
+	Month      string `json:"month"`
+	Count      int    `json:"count"`
+	TotalValue int    `json:"total_value"`
+}
+
 // ItemRepository defines the interface for item data access
 type ItemRepository interface {
-	// FindAll retrieves all items
-	FindAll(ctx context.Context) ([]*entity.Item, error)
+	// FindAll retrieves all items matching the filter
+	FindAll(ctx context.Context, filter ItemFilter) ([]*entity.Item, error)
+
+	// GetItems retrieves a page of items matching the filter in the requested order
+	// (creation time, newest first, when no sort field is given)
+	GetItems(ctx context.Context, filter ItemFilter, sort SortOption, limit, offset int) ([]*entity.Item, error)
+
+	// GetItemsAfter retrieves up to limit items matching the filter whose ID is greater than afterID, ordered by ID
+	// (keyset pagination)
+	GetItemsAfter(ctx context.Context, filter ItemFilter, afterID int64, limit int) ([]*entity.Item, error)
+
+	// FindChangedSince retrieves the items whose updated_at is at or after since, including soft-deleted ones,
+	// ordered by updated_at and then ID (soft-deleting or restoring an item also sets updated_at)
+	// A non-nil userID limits the items to those registered by the user
+	FindChangedSince(ctx context.Context, userID *string, since time.Time) ([]*entity.Item, error)
+
+	// FindWarrantyExpiring retrieves the non-deleted items whose warranty_expiry is between from and to inclusive
+	// (date part only), ordered by warranty_expiry and then ID
+	// A non-nil userID limits the items to those registered by the user
+	FindWarrantyExpiring(ctx context.Context, userID *string, from, to time.Time) ([]*entity.Item, error)
+
+	// Count returns the number of items matching the filter
+	Count(ctx context.Context, filter ItemFilter) (int, error)
 
 	// FindByID retrieves an item by ID
 	FindByID(ctx context.Context, id int64) (*entity.Item, error)
 
+	// Exists reports whether a non-deleted item with the ID exists without loading it
+	Exists(ctx context.Context, id int64) (bool, error)
+
+	// FindByIDs retrieves the non-deleted items with the given IDs in no particular order;
+	// IDs that do not match an item are skipped
+	FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error)
+
 	// Create creates a new item and returns it with the generated ID
 	Create(ctx context.Context, item *entity.Item) (*entity.Item, error)
 
-	// Delete deletes an item by ID
+	// CreateItems creates all items in a single transaction and returns them with their generated IDs
+	CreateItems(ctx context.Context, items []*entity.Item) ([]*entity.Item, error)
+
+	// Delete soft-deletes an item by ID
 	Delete(ctx context.Context, id int64) error
 
-	// GetSummaryByCategory returns item counts grouped by category (bonus feature)
-	GetSummaryByCategory(ctx context.Context) (map[string]int, error)
+	// Restore clears deleted_at of a soft-deleted item; returns ErrItemNotFound if no soft-deleted item matches
+	Restore(ctx context.Context, id int64) error
+
+	// DeleteItems soft-deletes the given items in a single transaction and returns the IDs that were actually deleted
+	DeleteItems(ctx context.Context, ids []int64) ([]int64, error)
+
+	// MoveItems changes the category of the given non-deleted items in a single transaction and returns the IDs that were actually moved;
+	// the items as they were before the move are stored as item versions in the same transaction
+	// A name conflict in the target category fails the whole operation with ErrDuplicateItem
+	MoveItems(ctx context.Context, ids []int64, category string) ([]int64, error)
+
+	// GetSummaryByCategory returns item counts and summed purchase prices of the items matching the filter,
+	// grouped by category (bonus feature)
+	GetSummaryByCategory(ctx context.Context, filter ItemFilter) (map[string]CategoryStats, error)
 
-	// Update updates mutable fields of an item and returns the updated entity
+	// GetAveragePriceByCategory returns the item count and AVG(purchase_price) of the items matching the filter
+	// per category; categories without items are not included
+	GetAveragePriceByCategory(ctx context.Context, filter ItemFilter) (map[string]CategoryAveragePrice, error)
+
+	// GetPriceRangeByCategory returns the item count, MIN(purchase_price) and MAX(purchase_price) of the items
+	// matching the filter per category; categories without items are not included
+	GetPriceRangeByCategory(ctx context.Context, filter ItemFilter) (map[string]CategoryPriceRange, error)
+
+	// GetMonthlyTimeline returns the item count and summed purchase prices of the items matching the filter
+	// per year-month of purchase_date, oldest month first; months without items are not included
+	GetMonthlyTimeline(ctx context.Context, filter ItemFilter) ([]MonthlyStats, error)
+
+	// Update persists every field of an item if its stored version still equals item.Version,
+	// incrementing the version; returns ErrVersionConflict when the versions differ
 	Update(ctx context.Context, item *entity.Item) (*entity.Item, error)
 }
+
+// Transactor runs fn in a single transaction; repository calls made with the context passed to fn
+// take part in it, and an error returned from fn rolls all of them back
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// AuditRepository records the audit trail of item changes
+type AuditRepository interface {
+	// CreateAudit inserts an audit entry; within a Transactor transaction it commits or rolls back with the item change
+	CreateAudit(ctx context.Context, entry *entity.AuditEntry) error
+}
+
+// PriceHistoryRepository records the purchase price changes of items
+type PriceHistoryRepository interface {
+	// CreatePriceChange inserts a price change; within a Transactor transaction it commits or rolls back with the item update
+	CreatePriceChange(ctx context.Context, change *entity.PriceChange) error
+	// FindPriceHistory returns the price changes of the item ordered by change time, oldest first (ties ordered by ID)
+	FindPriceHistory(ctx context.Context, itemID int64) ([]*entity.PriceChange, error)
+}
+
+// ItemVersionRepository stores snapshots of items as they were before each update
+type ItemVersionRepository interface {
+	// CreateItemVersion inserts the snapshot of item at item.Version; within a Transactor transaction it commits or rolls back with the item update
+	CreateItemVersion(ctx context.Context, item *entity.Item) error
+	// FindItemVersions returns the recorded versions of the item, oldest first
+	FindItemVersions(ctx context.Context, itemID int64) ([]*entity.ItemVersion, error)
+	// FindItemVersion returns the snapshot of the item at version;
+	// it returns domainErrors.ErrItemVersionNotFound when the version is not recorded
+	FindItemVersion(ctx context.Context, itemID int64, version int) (*entity.Item, error)
+}
+
+// CategoryRepository stores the categories items can be registered in
+type CategoryRepository interface {
+	// FindCategories returns all categories ordered by ID
+	FindCategories(ctx context.Context) ([]*entity.Category, error)
+	// CreateCategory inserts the category and returns it with the ID set;
+	// it returns domainErrors.ErrDuplicateCategory when a category with the same name exists (case-insensitive)
+	CreateCategory(ctx context.Context, category *entity.Category) (*entity.Category, error)
+	// DeleteCategory deletes the category; it returns domainErrors.ErrCategoryNotFound when it does not exist
+	// and domainErrors.ErrCategoryInUse while any non-deleted item is registered in it
+	DeleteCategory(ctx context.Context, id int64) error
+	// RenameCategory renames the category named from to to and moves its non-deleted items
+	// in one transaction, storing the items as they were before the move as item versions,
+	// and returns the renamed category and the IDs of the moved items;
+	// it returns domainErrors.ErrCategoryNotFound when from does not exist
+	// and domainErrors.ErrDuplicateCategory when another category is already named to (case-insensitive)
+	RenameCategory(ctx context.Context, from, to string) (*entity.Category, []int64, error)
+}
+
+// ItemCache stores serialized items shared between application instances (e.g. Redis)
+type ItemCache interface {
+	// Get returns the value stored under key; found is false when the key is missing or expired
+	Get(ctx context.Context, key string) (value []byte, found bool, err error)
+
+	// Set stores value under key for ttl
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the given keys; missing keys are ignored
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// ImageStorage stores uploaded item images (e.g. the local filesystem or S3)
+type ImageStorage interface {
+	// Save stores body under key and returns the http(s) URL the image is served from; an existing key is overwritten
+	Save(ctx context.Context, key, contentType string, body io.Reader) (url string, err error)
+}
+
+// ItemEventPublisher is notified of item changes after they have been committed
+type ItemEventPublisher interface {
+	// Publish must not block on delivery; the context carries request values only and may already be cancelled
+	Publish(ctx context.Context, event entity.ItemEvent)
+}
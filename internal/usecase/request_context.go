@@ -0,0 +1,29 @@
+package usecase
+
+import "context"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID はリクエスト ID を持つ context を返す（HTTP ミドルウェアから設定する）
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext は context のリクエスト ID を返す。未設定の場合は空文字
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+type subjectKey struct{}
+
+// ContextWithSubject は認証済みユーザー（JWT の subject）を持つ context を返す
+func ContextWithSubject(ctx context.Context, subject string) context.Context {
+	return context.WithValue(ctx, subjectKey{}, subject)
+}
+
+// SubjectFromContext は context の認証済みユーザーを返す。未認証の場合は空文字
+func SubjectFromContext(ctx context.Context) string {
+	subject, _ := ctx.Value(subjectKey{}).(string)
+	return subject
+}
@@ -3,18 +3,52 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
 
 	"Aicon-assignment/internal/domain/entity"
 	domainErrors "Aicon-assignment/internal/domain/errors"
 )
 
 type ItemUsecase interface {
-	GetAllItems(ctx context.Context) ([]*entity.Item, error)
+	CategoryUsecase
+	GetAllItems(ctx context.Context, filter ItemFilter) ([]*entity.Item, error)
+	GetItems(ctx context.Context, filter ItemFilter, sort SortOption, limit, offset int) ([]*entity.Item, int, error)
+	GetDeletedItems(ctx context.Context, limit, offset int) ([]*entity.Item, int, error)
+	GetItemsAfter(ctx context.Context, filter ItemFilter, afterID int64, limit int) ([]*entity.Item, bool, error)
+	GetItemChanges(ctx context.Context, since time.Time) ([]*entity.Item, error)
 	GetItemByID(ctx context.Context, id int64) (*entity.Item, error)
+	GetPriceHistory(ctx context.Context, id int64) ([]*entity.PriceChange, error)
+	GetItemVersions(ctx context.Context, id int64) ([]*entity.ItemVersion, error)
+	GetItemVersion(ctx context.Context, id int64, version int) (*entity.Item, error)
+	DiffItemVersions(ctx context.Context, id int64, from, to int) (*ItemDiff, error)
+	ItemExists(ctx context.Context, id int64) (bool, error)
+	GetItemsByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error)
+	GetRecentItems(ctx context.Context, limit int) ([]*entity.Item, error)
+	GetTopItems(ctx context.Context, category *string, n int) ([]*entity.Item, error)
+	GetExpiringWarranties(ctx context.Context, days int) ([]*entity.Item, error)
+	CountItems(ctx context.Context, filter ItemFilter) (int, error)
 	CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error)
+	DryRunCreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error)
+	ValidateItems(ctx context.Context, inputs []CreateItemInput) (*BatchValidationResult, error)
+	CreateItems(ctx context.Context, inputs []CreateItemInput) ([]*entity.Item, error)
+	CloneItem(ctx context.Context, id int64) (*entity.Item, error)
+	UploadItemImage(ctx context.Context, id int64, image ImageUpload) (*entity.Item, error)
 	UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error)
+	ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput) (*entity.Item, error)
 	DeleteItem(ctx context.Context, id int64) error
-	GetCategorySummary(ctx context.Context) (*CategorySummary, error)
+	DeleteItems(ctx context.Context, ids []int64) (*BulkDeleteResult, error)
+	MoveItems(ctx context.Context, input MoveItemsInput) (*BulkMoveResult, error)
+	RestoreItem(ctx context.Context, id int64) (*entity.Item, error)
+	GetCategorySummary(ctx context.Context, from, to *time.Time) (*CategorySummary, error)
+	GetAveragePriceAnalytics(ctx context.Context) (*AveragePriceAnalytics, error)
+	GetPriceRangeAnalytics(ctx context.Context) (*PriceRangeAnalytics, error)
+	GetTimelineAnalytics(ctx context.Context) ([]MonthlyStats, error)
 }
 
 type CreateItemInput struct {
@@ -23,31 +57,294 @@ type CreateItemInput struct {
 	Brand         string `json:"brand"`
 	PurchasePrice int    `json:"purchase_price"`
 	PurchaseDate  string `json:"purchase_date"`
+	// 保証期限（省略・空文字の場合は保証なし）
+	WarrantyExpiry *string `json:"warranty_expiry,omitempty"`
+	// メモ（省略・空文字の場合はメモなし）
+	Notes string `json:"notes,omitempty"`
+	// タグ（省略した場合はタグなし）
+	Tags []string `json:"tags,omitempty"`
+	// 画像の URL（省略・空文字の場合は画像なし）
+	ImageURL string `json:"image_url,omitempty"`
+	// 任意の JSON オブジェクトの追加情報（省略した場合は追加情報なし）
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
 }
 
 type UpdateItemInput struct {
 	Name          *string `json:"name,omitempty"`
 	Brand         *string `json:"brand,omitempty"`
 	PurchasePrice *int    `json:"purchase_price,omitempty"`
+	// 空文字を指定すると保証期限・メモを削除する
+	WarrantyExpiry *string `json:"warranty_expiry,omitempty"`
+	Notes          *string `json:"notes,omitempty"`
+	// タグの一覧で置き換える（空の配列を指定するとすべてのタグを外す）
+	Tags *[]string `json:"tags,omitempty"`
+	// 空文字を指定すると画像の URL を削除する
+	ImageURL *string `json:"image_url,omitempty"`
+	// 追加情報をこのオブジェクトで置き換える（空のオブジェクトを指定すると削除する）
+	Attributes *map[string]interface{} `json:"attributes,omitempty"`
+	// 楽観ロック用に期待するバージョン（未指定の場合は取得時点のバージョンで更新する）
+	Version *int `json:"version,omitempty"`
+}
+
+// 一括削除で一度に指定できる ID の上限
+const MaxBulkDeleteIDs = 100
+
+// カテゴリーの一括変更で一度に指定できる ID の上限
+const MaxBulkMoveIDs = 100
+
+// ID 指定の一括取得で一度に指定できる ID の上限
+const MaxBatchGetIDs = 100
+
+// 最近登録したアイテムの取得件数（未指定の場合）と上限
+const (
+	DefaultRecentItems = 5
+	MaxRecentItems     = 50
+)
+
+// 購入価格の高いアイテムの取得件数（未指定の場合）と上限
+const (
+	DefaultTopItems = 10
+	MaxTopItems     = 100
+)
+
+// notes の最大文字数（WithMaxNotesLength で設定しない場合）
+const DefaultMaxNotesLength = 1000
+
+// 保証期限が近いアイテムを探す日数（未指定の場合）と上限
+const (
+	DefaultWarrantyDays = 30
+	MaxWarrantyDays     = 365
+)
+
+// BatchValidationResult は一括バリデーションの結果（Items は入力と同じ順序）
+type BatchValidationResult struct {
+	// すべての入力が正しい場合に true
+	Valid bool             `json:"valid"`
+	Items []ItemValidation `json:"items"`
+}
+
+// ItemValidation は一括バリデーションの 1 件分の結果（正しい場合の Errors は空の配列）
+type ItemValidation struct {
+	Index  int                       `json:"index"`
+	Valid  bool                      `json:"valid"`
+	Errors []domainErrors.FieldError `json:"errors"`
+}
+
+type BulkDeleteResult struct {
+	Deleted  []int64 `json:"deleted"`
+	NotFound []int64 `json:"not_found"`
+}
+
+// MoveItemsInput は指定したアイテムのカテゴリーを target_category に変更する一括変更の入力
+type MoveItemsInput struct {
+	IDs            []int64 `json:"ids"`
+	TargetCategory string  `json:"target_category"`
+}
+
+type BulkMoveResult struct {
+	Moved    []int64 `json:"moved"`
+	NotFound []int64 `json:"not_found"`
+}
+
+// PUT 用の入力。すべてのフィールドが必須のため、未指定を検出できるようポインタで受け取る
+type ReplaceItemInput struct {
+	Name          *string `json:"name"`
+	Category      *string `json:"category"`
+	Brand         *string `json:"brand"`
+	PurchasePrice *int    `json:"purchase_price"`
+	PurchaseDate  *string `json:"purchase_date"`
+	// 保証期限・メモは任意（省略した場合は保証なし・メモなしに置き換える）
+	WarrantyExpiry *string `json:"warranty_expiry"`
+	Notes          string  `json:"notes"`
+	// タグ・画像の URL は任意（省略した場合はタグなし・画像なしに置き換える）
+	Tags     []string `json:"tags"`
+	ImageURL string   `json:"image_url"`
+	// 追加情報は任意（省略した場合は追加情報なしに置き換える）
+	Attributes map[string]interface{} `json:"attributes"`
 }
 
 type CategorySummary struct {
-	Categories map[string]int `json:"categories"`
-	Total      int            `json:"total"`
+	Categories map[string]CategoryStats `json:"categories"`
+	// Total は TotalCount と同じ値（既存クライアント向けに残している）
+	Total      int `json:"total"`
+	TotalCount int `json:"total_count"`
+	TotalValue int `json:"total_value"`
 }
 
+// ItemMetrics はアイテムの作成・削除件数を記録する（Prometheus などの実装は infrastructure 側）
+type ItemMetrics interface {
+	ItemsCreated(n int)
+	ItemsDeleted(n int)
+}
+
+type noopItemMetrics struct{}
+
+func (noopItemMetrics) ItemsCreated(int) {}
+func (noopItemMetrics) ItemsDeleted(int) {}
+
 type itemUsecase struct {
 	itemRepo ItemRepository
+	metrics  ItemMetrics
+	// 現在時刻（購入日が未来でないかの判定に使う）
+	now func() time.Time
+	// name の最大文字数（entity.MaxNameLength 以下）
+	maxNameLength int
+	// notes の最大文字数（entity.MaxNotesLength 以下）
+	maxNotesLength int
+	// 監査ログの書き込み先と、アイテムの変更と同じトランザクションにするための Transactor（nil の場合は記録しない）
+	transactor Transactor
+	audits     AuditRepository
+	// 購入価格の変更・更新前のバージョンの書き込み先（nil の場合は記録しない、トランザクションは transactor を使う）
+	priceHistory PriceHistoryRepository
+	versions     ItemVersionRepository
+	// カテゴリー別集計をキャッシュする期間と、そのキャッシュ（0 以下の場合は nil で毎回集計する）
+	summaryCacheTTL time.Duration
+	summaryCache    *summaryCache
+	// GetItemByID で取得したアイテムを itemCacheTTL の間保持する共有キャッシュ（nil の場合は使わない）
+	itemCache    ItemCache
+	itemCacheTTL time.Duration
+	// 作成・更新・削除をコミットした後に通知する先（Webhook・イベントバスなど）
+	publishers []ItemEventPublisher
+	// アップロードされた画像の保存先（nil の場合はアップロードできない）と、画像の最大バイト数
+	imageStorage ImageStorage
+	maxImageSize int64
+	// アイテムを登録できるカテゴリーの保存先（WithCategories で設定しない場合は初期のカテゴリーに固定する）
+	categories CategoryRepository
+	// アイテムを登録したユーザーごとに分ける（WithUserScope）
+	userScope bool
+}
+
+// Option は NewItemUsecase の任意設定
+type Option func(*itemUsecase)
+
+// WithMetrics は作成・削除件数の記録先を設定する
+func WithMetrics(metrics ItemMetrics) Option {
+	return func(u *itemUsecase) {
+		if metrics != nil {
+			u.metrics = metrics
+		}
+	}
+}
+
+// WithClock は現在時刻の取得方法を設定する（テストで時刻を固定する場合など）
+func WithClock(now func() time.Time) Option {
+	return func(u *itemUsecase) {
+		if now != nil {
+			u.now = now
+		}
+	}
+}
+
+// WithMaxNameLength は name の最大文字数（rune 数）を設定する
+// DB のカラム長を超えられないため、entity.MaxNameLength より大きい値は entity.MaxNameLength とする
+func WithMaxNameLength(n int) Option {
+	return func(u *itemUsecase) {
+		if n > 0 {
+			u.maxNameLength = min(n, entity.MaxNameLength)
+		}
+	}
+}
+
+// WithMaxNotesLength は notes の最大文字数（rune 数）を設定する
+// entity.MaxNotesLength より大きい値は entity.MaxNotesLength とする
+func WithMaxNotesLength(n int) Option {
+	return func(u *itemUsecase) {
+		if n > 0 {
+			u.maxNotesLength = min(n, entity.MaxNotesLength)
+		}
+	}
+}
+
+// WithAudit はアイテムの作成時に監査ログを記録する
+// アイテムと監査ログは transactor の 1 つのトランザクションで書き込み、どちらかが失敗した場合は両方とも残さない
+func WithAudit(transactor Transactor, audits AuditRepository) Option {
+	return func(u *itemUsecase) {
+		if transactor != nil && audits != nil {
+			u.transactor = transactor
+			u.audits = audits
+		}
+	}
+}
+
+// WithSummaryCache はカテゴリー別集計を ttl の間キャッシュする
+// アイテムの作成・更新・削除・復元を行うとキャッシュは破棄される（同じプロセス内の変更のみ）
+func WithSummaryCache(ttl time.Duration) Option {
+	return func(u *itemUsecase) {
+		u.summaryCacheTTL = ttl
+	}
 }
 
-func NewItemUsecase(itemRepo ItemRepository) ItemUsecase {
-	return &itemUsecase{
-		itemRepo: itemRepo,
+// WithItemCache は GetItemByID で取得したアイテムを cache に ttl の間保持する
+// 更新・削除・復元したアイテムはキャッシュから削除する
+func WithItemCache(cache ItemCache, ttl time.Duration) Option {
+	return func(u *itemUsecase) {
+		if cache != nil && ttl > 0 {
+			u.itemCache = cache
+			u.itemCacheTTL = ttl
+		}
+	}
+}
+
+// WithEventPublisher はアイテムの作成・更新（復元を含む）・削除を publisher に通知する（複数指定した場合はすべてに通知する）
+func WithEventPublisher(publisher ItemEventPublisher) Option {
+	return func(u *itemUsecase) {
+		if publisher != nil {
+			u.publishers = append(u.publishers, publisher)
+		}
+	}
+}
+
+// WithImageStorage はアップロードされた画像の保存先を設定する
+func WithImageStorage(storage ImageStorage) Option {
+	return func(u *itemUsecase) {
+		if storage != nil {
+			u.imageStorage = storage
+		}
 	}
 }
 
-func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
-	items, err := u.itemRepo.FindAll(ctx)
+// WithMaxImageSize はアップロードできる画像の最大バイト数を設定する
+func WithMaxImageSize(n int64) Option {
+	return func(u *itemUsecase) {
+		if n > 0 {
+			u.maxImageSize = n
+		}
+	}
+}
+
+func NewItemUsecase(itemRepo ItemRepository, opts ...Option) ItemUsecase {
+	u := &itemUsecase{
+		itemRepo:      itemRepo,
+		metrics:       noopItemMetrics{},
+		now:           time.Now,
+		maxNameLength: entity.MaxNameLength,
+		// notes は DB のカラム長より短い既定の上限にする
+		maxNotesLength: DefaultMaxNotesLength,
+		maxImageSize:   DefaultMaxImageSize,
+		categories:     defaultCategories{},
+	}
+	for _, opt := range opts {
+		opt(u)
+	}
+	if u.summaryCacheTTL > 0 {
+		u.summaryCache = newSummaryCache(u.summaryCacheTTL, u.now)
+	}
+	return u
+}
+
+// フィルター条件に一致するアイテムをページングせずにすべて取得する
+func (u *itemUsecase) GetAllItems(ctx context.Context, filter ItemFilter) ([]*entity.Item, error) {
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	filter, ok := normalizeFilter(filter)
+	if !ok {
+		return []*entity.Item{}, nil
+	}
+	filter.UserID = owner
+
+	items, err := u.itemRepo.FindAll(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to retrieve items: %w", err)
 	}
@@ -55,10 +352,281 @@ func (u *itemUsecase) GetAllItems(ctx context.Context) ([]*entity.Item, error) {
 	return items, nil
 }
 
+// フィルター条件に一致するアイテムをページ単位で取得し、全件数も合わせて返す
+// 空のカテゴリー・ブランドはエラーにせず空の一覧を返す
+func (u *itemUsecase) GetItems(ctx context.Context, filter ItemFilter, sort SortOption, limit, offset int) ([]*entity.Item, int, error) {
+	if limit <= 0 || offset < 0 {
+		return nil, 0, domainErrors.ErrInvalidInput
+	}
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filter, ok := normalizeFilter(filter)
+	if !ok {
+		return []*entity.Item{}, 0, nil
+	}
+	filter.UserID = owner
+
+	items, err := u.itemRepo.GetItems(ctx, filter, sort, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	total, err := u.itemRepo.Count(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count items: %w", err)
+	}
+
+	return items, total, nil
+}
+
+// フィルター条件に一致するアイテムの件数を返す（アイテム自体は取得しない）
+func (u *itemUsecase) CountItems(ctx context.Context, filter ItemFilter) (int, error) {
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return 0, err
+	}
+	filter, ok := normalizeFilter(filter)
+	if !ok {
+		return 0, nil
+	}
+	filter.UserID = owner
+
+	count, err := u.itemRepo.Count(ctx, filter)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count items: %w", err)
+	}
+
+	return count, nil
+}
+
+// フィルター値の前後の空白を取り除く
+// 指定された値が空になった場合は一致するアイテムがないため false を返す
+func normalizeFilter(filter ItemFilter) (ItemFilter, bool) {
+	normalized := ItemFilter{Deleted: filter.Deleted}
+
+	// 空のカテゴリーは無視し、すべて空の場合は絞り込まない
+	// カテゴリーは追加・削除できるため一覧と照合せず、一覧にないカテゴリーは一致するアイテムがないだけとする
+	normalized.Categories = trimValues(filter.Categories)
+	normalized.ExcludedCategories = trimValues(filter.ExcludedCategories)
+
+	if filter.Brand != nil {
+		brand := strings.TrimSpace(*filter.Brand)
+		if brand == "" {
+			return normalized, false
+		}
+		normalized.Brand = &brand
+	}
+
+	// タグは保存時と同じく小文字にそろえてから重複を除く（AND では重複があると一致しなくなる）
+	tags := make([]string, len(filter.Tags))
+	for i, tag := range filter.Tags {
+		tags[i] = strings.ToLower(tag)
+	}
+	normalized.Tags = trimValues(tags)
+	normalized.TagsMatchAny = filter.TagsMatchAny
+	normalized.Attributes = filter.Attributes
+
+	normalized.MinPrice = filter.MinPrice
+	normalized.MaxPrice = filter.MaxPrice
+	normalized.PurchasedAfter = filter.PurchasedAfter
+	normalized.PurchasedBefore = filter.PurchasedBefore
+
+	// 検索語は空なら全件扱いのため、空でも絞り込み不成立にはしない
+	normalized.Search = strings.TrimSpace(filter.Search)
+
+	return normalized, true
+}
+
+// 前後の空白を取り除き、空の値と重複を除く（順序は保つ）
+func trimValues(values []string) []string {
+	var trimmed []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		trimmed = append(trimmed, value)
+	}
+	return trimmed
+}
+
+// 論理削除されたアイテム（ゴミ箱）をページ単位で取得し、全件数も合わせて返す
+func (u *itemUsecase) GetDeletedItems(ctx context.Context, limit, offset int) ([]*entity.Item, int, error) {
+	return u.GetItems(ctx, ItemFilter{Deleted: true}, SortOption{}, limit, offset)
+}
+
+// 最近登録したアイテムを登録日時の新しい順に最大 limit 件取得する（MaxRecentItems を超える limit は MaxRecentItems とする）
+func (u *itemUsecase) GetRecentItems(ctx context.Context, limit int) ([]*entity.Item, error) {
+	if limit <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort := SortOption{Keys: []SortKey{{Field: SortByCreatedAt, Descending: true}}}
+	items, err := u.itemRepo.GetItems(ctx, ItemFilter{UserID: owner}, sort, min(limit, MaxRecentItems), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve recent items: %w", err)
+	}
+
+	return items, nil
+}
+
+// 購入価格の高い順に最大 n 件取得する（MaxTopItems を超える n は MaxTopItems とする）
+// category を指定した場合はそのカテゴリーのアイテムのみを対象にする（一覧と同じく前後の空白を除き、空の場合は絞り込まない）。同じ価格のアイテムは ID 順
+func (u *itemUsecase) GetTopItems(ctx context.Context, category *string, n int) ([]*entity.Item, error) {
+	if n <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort := SortOption{Keys: []SortKey{{Field: SortByPurchasePrice, Descending: true}}}
+	var filter ItemFilter
+	if category != nil {
+		filter.Categories = []string{*category}
+	}
+	filter, ok := normalizeFilter(filter)
+	if !ok {
+		return []*entity.Item{}, nil
+	}
+	filter.UserID = owner
+
+	items, err := u.itemRepo.GetItems(ctx, filter, sort, min(n, MaxTopItems), 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve top items: %w", err)
+	}
+
+	return items, nil
+}
+
+// 保証期限が今日から days 日後までのアイテムを保証期限の近い順に取得する（期限切れのアイテムは含めない）
+func (u *itemUsecase) GetExpiringWarranties(ctx context.Context, days int) ([]*entity.Item, error) {
+	if days < 0 || days > MaxWarrantyDays {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	items, err := u.itemRepo.FindWarrantyExpiring(ctx, owner, today, today.AddDate(0, 0, days))
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve expiring warranties: %w", err)
+	}
+
+	return items, nil
+}
+
+// フィルター条件に一致するアイテムのうち afterID より後のものを ID 順に取得し、次のページが存在するかを返す
+func (u *itemUsecase) GetItemsAfter(ctx context.Context, filter ItemFilter, afterID int64, limit int) ([]*entity.Item, bool, error) {
+	if limit <= 0 || afterID < 0 {
+		return nil, false, domainErrors.ErrInvalidInput
+	}
+
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	filter, ok := normalizeFilter(filter)
+	if !ok {
+		return []*entity.Item{}, false, nil
+	}
+	filter.UserID = owner
+
+	// 次ページの有無を判定するため 1 件多く取得する
+	items, err := u.itemRepo.GetItemsAfter(ctx, filter, afterID, limit+1)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
+
+	return items, hasMore, nil
+}
+
+// since 以降に登録・更新・論理削除・復元されたアイテムを変更日時の順に返す（差分同期用）
+// 論理削除されたアイテムも DeletedAt を設定した状態で含める
+func (u *itemUsecase) GetItemChanges(ctx context.Context, since time.Time) ([]*entity.Item, error) {
+	if since.IsZero() {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := u.itemRepo.FindChangedSince(ctx, owner, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve item changes: %w", err)
+	}
+
+	return items, nil
+}
+
+// アイテムが存在するか（論理削除されたアイテムは存在しないものとする）を返す
+func (u *itemUsecase) ItemExists(ctx context.Context, id int64) (bool, error) {
+	if id <= 0 {
+		return false, domainErrors.ErrInvalidInput
+	}
+
+	// ユーザーごとに分ける場合は、登録したユーザーを確認するためアイテムを読み込む
+	if u.userScope {
+		_, err := u.findItem(ctx, id)
+		switch {
+		case err == nil:
+			return true, nil
+		case domainErrors.IsNotFoundError(err):
+			return false, nil
+		case domainErrors.IsUnauthenticatedError(err):
+			return false, err
+		}
+		return false, fmt.Errorf("failed to check item: %w", err)
+	}
+
+	exists, err := u.itemRepo.Exists(ctx, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to check item: %w", err)
+	}
+
+	return exists, nil
+}
+
+// WithItemCache を指定した場合はキャッシュを先に参照し、なければリポジトリから取得してキャッシュする
 func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item, error) {
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
 	}
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// キャッシュはユーザーに関係なく共有するため、返す前に登録したユーザーを確認する
+	if item, ok := u.cachedItem(ctx, id); ok {
+		if !owns(owner, item) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return item, nil
+	}
 
 	item, err := u.itemRepo.FindByID(ctx, id)
 	if err != nil {
@@ -67,12 +635,137 @@ func (u *itemUsecase) GetItemByID(ctx context.Context, id int64) (*entity.Item,
 		}
 		return nil, fmt.Errorf("failed to retrieve item: %w", err)
 	}
+	u.cacheItem(ctx, item)
+	if !owns(owner, item) {
+		return nil, domainErrors.ErrItemNotFound
+	}
 
 	return item, nil
 }
 
+// GetItemsByIDs は指定した ID のアイテムを指定順で返す
+// 見つからない ID は結果から除き、重複した ID は最初の位置にだけ含める
+func (u *itemUsecase) GetItemsByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	if len(ids) > MaxBatchGetIDs {
+		return nil, fmt.Errorf("%w: ids must contain %d or fewer elements", domainErrors.ErrInvalidInput, MaxBatchGetIDs)
+	}
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	uniqueIDs := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: ids must be positive integers", domainErrors.ErrInvalidInput)
+		}
+		if !seen[id] {
+			seen[id] = true
+			uniqueIDs = append(uniqueIDs, id)
+		}
+	}
+	if len(uniqueIDs) == 0 {
+		return []*entity.Item{}, nil
+	}
+
+	found, err := u.itemRepo.FindByIDs(ctx, uniqueIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve items: %w", err)
+	}
+
+	// リポジトリの返す順序は不定のため、指定順に並べ直す
+	byID := make(map[int64]*entity.Item, len(found))
+	for _, item := range ownedItems(owner, found) {
+		byID[item.ID] = item
+	}
+	items := make([]*entity.Item, 0, len(found))
+	for _, id := range uniqueIDs {
+		if item, ok := byID[id]; ok {
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
 func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	categories, err := u.categoryNames(ctx)
+	if err != nil {
+		return nil, err
+	}
 	// バリデーションして、新しいエンティティを作成
+	item, err := u.newValidItem(input, categories)
+	if err != nil {
+		// すべての違反を含む *domainErrors.ValidationError
+		return nil, err
+	}
+
+	return u.create(ctx, item)
+}
+
+// 登録と同じバリデーションを行い、登録される内容のアイテムを返す（保存しないため ID は 0）
+func (u *itemUsecase) DryRunCreateItem(ctx context.Context, input CreateItemInput) (*entity.Item, error) {
+	categories, err := u.categoryNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return u.dryRunItem(input, categories)
+}
+
+func (u *itemUsecase) dryRunItem(input CreateItemInput, categories []string) (*entity.Item, error) {
+	item, err := u.newValidItem(input, categories)
+	if err != nil {
+		return nil, err
+	}
+
+	now := u.now()
+	item.CreatedAt = now
+	item.UpdatedAt = now
+	return item, nil
+}
+
+// 複数の入力を登録と同じバリデーションで検証し、入力ごとの結果を返す（何も保存しない）
+func (u *itemUsecase) ValidateItems(ctx context.Context, inputs []CreateItemInput) (*BatchValidationResult, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", domainErrors.ErrInvalidInput)
+	}
+
+	categories, err := u.categoryNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &BatchValidationResult{Valid: true, Items: make([]ItemValidation, 0, len(inputs))}
+	for i, input := range inputs {
+		validation := ItemValidation{Index: i, Valid: true, Errors: []domainErrors.FieldError{}}
+		if _, err := u.dryRunItem(input, categories); err != nil {
+			verr, ok := domainErrors.AsValidationError(err)
+			if !ok {
+				return nil, err
+			}
+			validation.Valid = false
+			validation.Errors = verr.Fields
+			result.Valid = false
+		}
+		result.Items = append(result.Items, validation)
+	}
+
+	return result, nil
+}
+
+// newValidItem は名前を正規化した入力からエンティティを作成し、usecase のルール（categories との照合など）も合わせて検証する
+func (u *itemUsecase) newValidItem(input CreateItemInput, categories []string) (*entity.Item, error) {
+	input.Name = normalizeName(input.Name)
+	item, err := newItem(input)
+	if err = u.validateItem(err, categories, input.Name, input.Category, input.PurchaseDate, input.Notes, input.ImageURL); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// 入力からエンティティを作成する（保証期限・メモはほかのフィールドが正しい場合に合わせて検証する）
+func newItem(input CreateItemInput) (*entity.Item, error) {
 	item, err := entity.NewItem(
 		input.Name,
 		input.Category,
@@ -81,23 +774,200 @@ func (u *itemUsecase) CreateItem(ctx context.Context, input CreateItemInput) (*e
 		input.PurchaseDate,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		return nil, err
+	}
+
+	item.SetWarrantyExpiry(input.WarrantyExpiry)
+	item.SetNotes(input.Notes)
+	item.SetTags(input.Tags)
+	item.SetImageURL(input.ImageURL)
+	item.SetAttributes(input.Attributes)
+	if err := item.Validate(); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// 複製したアイテムの名前に付ける接尾辞
+const cloneNameSuffix = " (copy)"
+
+// 既存のアイテムを複製して新しいアイテムとして登録する
+// ID・登録日時・バージョンは引き継がず、名前には最大文字数に収まる場合のみ " (copy)" を付ける
+func (u *itemUsecase) CloneItem(ctx context.Context, id int64) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+
+	source, err := u.findItem(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find item: %w", err)
+	}
+
+	name := source.Name
+	if utf8.RuneCountInString(name+cloneNameSuffix) <= u.maxNameLength {
+		name += cloneNameSuffix
 	}
 
-	createdItem, err := u.itemRepo.Create(ctx, item)
+	item, err := entity.NewItem(name, source.Category, source.Brand, source.PurchasePrice, source.PurchaseDate)
+	if err != nil {
+		return nil, err
+	}
+	item.SetWarrantyExpiry(source.WarrantyExpiry)
+	item.SetNotes(source.Notes)
+	item.SetTags(source.Tags)
+	item.SetImageURL(source.ImageURL)
+	item.SetAttributes(source.Attributes)
+
+	return u.create(ctx, item)
+}
+
+// create はアイテムを登録し、監査ログを記録する場合は同じトランザクションで記録する
+// 登録したユーザーには認証済みユーザー（JWT の subject）を設定する
+func (u *itemUsecase) create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
+	if _, err := u.owner(ctx); err != nil {
+		return nil, err
+	}
+	item.UserID = SubjectFromContext(ctx)
+
+	var createdItem *entity.Item
+	err := u.inTransaction(ctx, func(ctx context.Context) error {
+		created, err := u.itemRepo.Create(ctx, item)
+		if err != nil {
+			return err
+		}
+		createdItem = created
+		return u.recordCreated(ctx, created)
+	})
+	u.summaryCache.invalidate()
 	if err != nil {
 		return nil, fmt.Errorf("failed to create item: %w", err)
 	}
 
+	u.metrics.ItemsCreated(1)
+	u.publish(ctx, entity.ItemEventCreated, createdItem)
+
 	return createdItem, nil
 }
 
+// 複数のアイテムを一括で登録する
+// すべての入力を検証してから登録するため、1 件でも不正な入力があれば何も登録しない
+func (u *itemUsecase) CreateItems(ctx context.Context, inputs []CreateItemInput) ([]*entity.Item, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("%w: at least one item is required", domainErrors.ErrInvalidInput)
+	}
+	if _, err := u.owner(ctx); err != nil {
+		return nil, err
+	}
+
+	categories, err := u.categoryNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// すべての要素の違反をまとめて返す（フィールド名は items[i].name の形式）
+	verr := &domainErrors.ValidationError{}
+	items := make([]*entity.Item, 0, len(inputs))
+	for i, input := range inputs {
+		item, err := u.newValidItem(input, categories)
+		if err != nil {
+			itemErr, ok := domainErrors.AsValidationError(err)
+			if !ok {
+				return nil, err
+			}
+			verr.Fields = append(verr.Fields, itemErr.WithPrefix(fmt.Sprintf("items[%d]", i)).Fields...)
+			continue
+		}
+		item.UserID = SubjectFromContext(ctx)
+		items = append(items, item)
+	}
+	if err := verr.Err(); err != nil {
+		return nil, err
+	}
+
+	var createdItems []*entity.Item
+	err = u.inTransaction(ctx, func(ctx context.Context) error {
+		created, err := u.itemRepo.CreateItems(ctx, items)
+		if err != nil {
+			return err
+		}
+		createdItems = created
+		return u.recordCreated(ctx, created...)
+	})
+	u.summaryCache.invalidate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create items: %w", err)
+	}
+
+	u.metrics.ItemsCreated(len(createdItems))
+	u.publish(ctx, entity.ItemEventCreated, createdItems...)
+
+	return createdItems, nil
+}
+
+// inTransaction は監査ログ・バージョン・価格の履歴を記録する場合、fn を 1 つのトランザクションで実行する
+func (u *itemUsecase) inTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if u.transactor == nil {
+		return fn(ctx)
+	}
+	return u.transactor.WithinTransaction(ctx, fn)
+}
+
+// recordCreated は作成したアイテムの監査ログを記録する
+func (u *itemUsecase) recordCreated(ctx context.Context, items ...*entity.Item) error {
+	if u.audits == nil {
+		return nil
+	}
+	for _, item := range items {
+		entry := &entity.AuditEntry{
+			ItemID:    item.ID,
+			Action:    entity.AuditActionCreate,
+			Actor:     truncateRunes(SubjectFromContext(ctx), entity.MaxAuditFieldLength),
+			RequestID: truncateRunes(RequestIDFromContext(ctx), entity.MaxAuditFieldLength),
+		}
+		if err := u.audits.CreateAudit(ctx, entry); err != nil {
+			return fmt.Errorf("failed to record audit: %w", err)
+		}
+	}
+	return nil
+}
+
+// update はアイテムを更新し、更新前の previous のバージョンと購入価格の変更を同じトランザクションで記録する
+func (u *itemUsecase) update(ctx context.Context, item, previous *entity.Item) (*entity.Item, error) {
+	var updated *entity.Item
+	err := u.inTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = u.itemRepo.Update(ctx, item)
+		if err != nil {
+			return err
+		}
+		if err := u.recordVersion(ctx, previous); err != nil {
+			return err
+		}
+		return u.recordPriceChange(ctx, previous, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// truncateRunes は s を先頭から n 文字までに切り詰める（クライアントが指定する X-Request-ID などの長さは制限していない）
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n])
+}
+
 func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItemInput) (*entity.Item, error) {
 	if id <= 0 {
 		return nil, domainErrors.ErrInvalidInput
 	}
+	if err := u.validateUpdateItemInput(input); err != nil {
+		return nil, err
+	}
 
-	item, err := u.itemRepo.FindByID(ctx, id)
+	item, err := u.findItem(ctx, id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return nil, domainErrors.ErrItemNotFound
@@ -105,12 +975,19 @@ func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItem
 		return nil, fmt.Errorf("failed to retrieve item: %w", err)
 	}
 
+	if input.Version != nil {
+		if *input.Version != item.Version {
+			return nil, domainErrors.ErrVersionConflict
+		}
+	}
+
+	previous := *item
 	name := item.Name
 	brand := item.Brand
 	purchasePrice := item.PurchasePrice
 
 	if input.Name != nil {
-		name = *input.Name
+		name = normalizeName(*input.Name)
 	}
 	if input.Brand != nil {
 		brand = *input.Brand
@@ -118,28 +995,96 @@ func (u *itemUsecase) UpdateItem(ctx context.Context, id int64, input UpdateItem
 	if input.PurchasePrice != nil {
 		purchasePrice = *input.PurchasePrice
 	}
+	if input.WarrantyExpiry != nil {
+		item.SetWarrantyExpiry(input.WarrantyExpiry)
+	}
+	if input.Notes != nil {
+		item.SetNotes(*input.Notes)
+	}
+	if input.Tags != nil {
+		item.SetTags(*input.Tags)
+	}
+	if input.ImageURL != nil {
+		item.SetImageURL(*input.ImageURL)
+	}
+	if input.Attributes != nil {
+		item.SetAttributes(*input.Attributes)
+	}
 
 	if err := item.Update(name, item.Category, brand, purchasePrice, item.PurchaseDate); err != nil {
-		return nil, fmt.Errorf("%w: %s", domainErrors.ErrInvalidInput, err.Error())
+		return nil, err
 	}
 
-	updated, err := u.itemRepo.Update(ctx, item)
+	updated, err := u.update(ctx, item, &previous)
+	u.summaryCache.invalidate()
+	u.invalidateItems(ctx, id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return nil, domainErrors.ErrItemNotFound
 		}
+		if domainErrors.IsVersionConflictError(err) {
+			return nil, domainErrors.ErrVersionConflict
+		}
 		return nil, fmt.Errorf("failed to update item: %w", err)
 	}
+	u.publish(ctx, entity.ItemEventUpdated, updated)
 
 	return updated, nil
 }
 
+// アイテムのすべてのフィールドを置き換える（未指定のフィールドは既存値を引き継がない）
+func (u *itemUsecase) ReplaceItem(ctx context.Context, id int64, input ReplaceItemInput) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	if err := validateReplaceItemInput(input); err != nil {
+		return nil, err
+	}
+	categories, err := u.categoryNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+
+	previous := *item
+	item.SetWarrantyExpiry(input.WarrantyExpiry)
+	item.SetNotes(input.Notes)
+	item.SetTags(input.Tags)
+	item.SetImageURL(input.ImageURL)
+	item.SetAttributes(input.Attributes)
+	name := normalizeName(*input.Name)
+	err = item.Update(name, *input.Category, *input.Brand, *input.PurchasePrice, *input.PurchaseDate)
+	if err = u.validateItem(err, categories, name, *input.Category, *input.PurchaseDate, input.Notes, input.ImageURL); err != nil {
+		return nil, err
+	}
+
+	replaced, err := u.update(ctx, item, &previous)
+	u.summaryCache.invalidate()
+	u.invalidateItems(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
+		}
+		return nil, fmt.Errorf("failed to replace item: %w", err)
+	}
+	u.publish(ctx, entity.ItemEventUpdated, replaced)
+
+	return replaced, nil
+}
+
 func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
 	if id <= 0 {
 		return domainErrors.ErrInvalidInput
 	}
 
-	_, err := u.itemRepo.FindByID(ctx, id)
+	item, err := u.findItem(ctx, id)
 	if err != nil {
 		if domainErrors.IsNotFoundError(err) {
 			return domainErrors.ErrItemNotFound
@@ -148,36 +1093,387 @@ func (u *itemUsecase) DeleteItem(ctx context.Context, id int64) error {
 	}
 
 	err = u.itemRepo.Delete(ctx, id)
+	u.summaryCache.invalidate()
+	u.invalidateItems(ctx, id)
 	if err != nil {
 		return fmt.Errorf("failed to delete item: %w", err)
 	}
 
+	u.metrics.ItemsDeleted(1)
+	u.publish(ctx, entity.ItemEventDeleted, item)
+
 	return nil
 }
 
-func (u *itemUsecase) GetCategorySummary(ctx context.Context) (*CategorySummary, error) {
-	categoryCounts, err := u.itemRepo.GetSummaryByCategory(ctx)
+// 論理削除されたアイテムを復元する
+// 削除されていないアイテムの場合は何もせずにそのまま返す
+func (u *itemUsecase) RestoreItem(ctx context.Context, id int64) (*entity.Item, error) {
+	if id <= 0 {
+		return nil, domainErrors.ErrInvalidInput
+	}
+	owner, err := u.owner(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get category summary: %w", err)
+		return nil, err
+	}
+
+	// 他のユーザーの論理削除されたアイテムは復元しない
+	if owner != nil {
+		count, err := u.itemRepo.Count(ctx, ItemFilter{Deleted: true, UserID: owner, IDs: []int64{id}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to check item: %w", err)
+		}
+		if count == 0 {
+			return u.GetItemByID(ctx, id)
+		}
 	}
 
-	// 合計計算
-	total := 0
-	for _, count := range categoryCounts {
-		total += count
+	err = u.itemRepo.Restore(ctx, id)
+	u.summaryCache.invalidate()
+	u.invalidateItems(ctx, id)
+	if err != nil && !domainErrors.IsNotFoundError(err) {
+		return nil, fmt.Errorf("failed to restore item: %w", err)
 	}
+	restored := err == nil
 
-	summary := make(map[string]int)
-	for _, category := range entity.GetValidCategories() {
-		if count, exists := categoryCounts[category]; exists {
-			summary[category] = count
-		} else {
-			summary[category] = 0
+	// 復元した場合も、元々削除されていなかった場合も、現在のアイテムを返す
+	item, err := u.findItem(ctx, id)
+	if err != nil {
+		if domainErrors.IsNotFoundError(err) {
+			return nil, domainErrors.ErrItemNotFound
 		}
+		return nil, fmt.Errorf("failed to retrieve item: %w", err)
+	}
+	if restored {
+		u.publish(ctx, entity.ItemEventUpdated, item)
+	}
+
+	return item, nil
+}
+
+// 複数のアイテムを一括で削除する
+// 存在しない ID は NotFound として返し、1 件も存在しない場合のみ ErrItemNotFound とする
+func (u *itemUsecase) DeleteItems(ctx context.Context, ids []int64) (*BulkDeleteResult, error) {
+	uniqueIDs, err := bulkIDs(ids, MaxBulkDeleteIDs)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// 他のユーザーのアイテムは削除せず、存在しない ID と同じく NotFound で返す
+	targets, err := u.ownedIDs(ctx, owner, uniqueIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 通知するイベントには削除前の内容を含めるため、先に取得しておく
+	snapshots, err := u.deleteSnapshots(ctx, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := u.itemRepo.DeleteItems(ctx, targets)
+	u.summaryCache.invalidate()
+	u.invalidateItems(ctx, uniqueIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete items: %w", err)
+	}
+	if len(deleted) == 0 {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	u.metrics.ItemsDeleted(len(deleted))
+	if snapshots != nil {
+		deletedItems := make([]*entity.Item, 0, len(deleted))
+		for _, id := range deleted {
+			if item, ok := snapshots[id]; ok {
+				deletedItems = append(deletedItems, item)
+			}
+		}
+		u.publish(ctx, entity.ItemEventDeleted, deletedItems...)
+	}
+
+	return &BulkDeleteResult{Deleted: deleted, NotFound: missingIDs(uniqueIDs, deleted)}, nil
+}
+
+// 指定したアイテムのカテゴリーを 1 つのトランザクションで変更する
+// 存在しない ID は NotFound として返し、1 件も存在しない場合のみ ErrItemNotFound とする
+// 変更先のカテゴリーで名前が重複する場合は 1 件も変更しない
+func (u *itemUsecase) MoveItems(ctx context.Context, input MoveItemsInput) (*BulkMoveResult, error) {
+	uniqueIDs, err := bulkIDs(input.IDs, MaxBulkMoveIDs)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := u.categoryNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	target := strings.TrimSpace(input.TargetCategory)
+	verr := &domainErrors.ValidationError{}
+	if target == "" {
+		verr.Add("target_category", domainErrors.CodeRequired)
+	} else if !slices.Contains(categories, target) {
+		verr.Add("target_category", domainErrors.CodeInvalidValue, strings.Join(categories, ", "))
+	}
+	if err := verr.Err(); err != nil {
+		return nil, err
+	}
+
+	// 他のユーザーのアイテムは変更せず、存在しない ID と同じく NotFound で返す
+	targets, err := u.ownedIDs(ctx, owner, uniqueIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	moved, err := u.itemRepo.MoveItems(ctx, targets, target)
+	u.summaryCache.invalidate()
+	u.invalidateItems(ctx, uniqueIDs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move items: %w", err)
+	}
+	if len(moved) == 0 {
+		return nil, domainErrors.ErrItemNotFound
+	}
+	u.publishUpdated(ctx, moved)
+
+	return &BulkMoveResult{Moved: moved, NotFound: missingIDs(uniqueIDs, moved)}, nil
+}
+
+// bulkIDs は一括操作の ID を検証し、重複を除いて指定順で返す
+func bulkIDs(ids []int64, max int) ([]int64, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: ids must not be empty", domainErrors.ErrInvalidInput)
+	}
+	if len(ids) > max {
+		return nil, fmt.Errorf("%w: ids must contain %d or fewer elements", domainErrors.ErrInvalidInput, max)
+	}
+
+	seen := make(map[int64]bool, len(ids))
+	unique := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: ids must be positive integers", domainErrors.ErrInvalidInput)
+		}
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique, nil
+}
+
+// missingIDs は ids のうち done に含まれないものを指定順で返す
+func missingIDs(ids, done []int64) []int64 {
+	doneSet := make(map[int64]bool, len(done))
+	for _, id := range done {
+		doneSet[id] = true
+	}
+	missing := []int64{}
+	for _, id := range ids {
+		if !doneSet[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// from / to は購入日の範囲（両端を含む、nil の場合は制限なし）
+// WithSummaryCache を指定した場合は、有効期限内の同じ範囲の集計をキャッシュから返す
+func (u *itemUsecase) GetCategorySummary(ctx context.Context, from, to *time.Time) (*CategorySummary, error) {
+	if from != nil && to != nil && from.After(*to) {
+		return nil, fmt.Errorf("%w: from must be earlier than or equal to to", domainErrors.ErrInvalidInput)
+	}
+
+	owner, err := u.owner(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// キャッシュはユーザーに関係なく共有するため、ユーザーごとに分ける場合は使わない
+	if u.summaryCache == nil || owner != nil {
+		return u.summarize(ctx, owner, from, to)
+	}
+	key := summaryCacheKey(from, to)
+	cached, generation, ok := u.summaryCache.get(key)
+	if ok {
+		return cached, nil
+	}
+	summary, err := u.summarize(ctx, nil, from, to)
+	if err != nil {
+		return nil, err
+	}
+	u.summaryCache.set(key, generation, summary)
+	return summary, nil
+}
+
+// summarize はリポジトリでカテゴリー別に集計する（owner を指定した場合はそのユーザーのアイテムのみ）
+func (u *itemUsecase) summarize(ctx context.Context, owner *string, from, to *time.Time) (*CategorySummary, error) {
+	filter := ItemFilter{PurchasedAfter: from, PurchasedBefore: to, UserID: owner}
+	categoryStats, err := u.itemRepo.GetSummaryByCategory(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category summary: %w", err)
+	}
+	categories, err := u.categoryNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// アイテムのないカテゴリーも 0 件・0 円で含める
+	summary := make(map[string]CategoryStats)
+	for _, category := range categories {
+		summary[category] = categoryStats[category]
+	}
+
+	// 合計はカテゴリー別の集計から計算し、内訳と必ず一致させる
+	totalCount, totalValue := 0, 0
+	for _, stats := range summary {
+		totalCount += stats.Count
+		totalValue += stats.TotalPrice
 	}
 
 	return &CategorySummary{
 		Categories: summary,
-		Total:      total,
+		Total:      totalCount,
+		TotalCount: totalCount,
+		TotalValue: totalValue,
 	}, nil
 }
+
+// 部分更新で指定されたフィールドのバリデーション（すべての違反をまとめて返す）
+func (u *itemUsecase) validateUpdateItemInput(input UpdateItemInput) error {
+	verr := &domainErrors.ValidationError{}
+
+	if input.Name == nil && input.Brand == nil && input.PurchasePrice == nil && input.WarrantyExpiry == nil && input.Notes == nil && input.Tags == nil && input.ImageURL == nil && input.Attributes == nil {
+		verr.Add("", domainErrors.CodeNoFields)
+		return verr
+	}
+
+	if input.Name != nil {
+		name := normalizeName(*input.Name)
+		if name == "" {
+			verr.Add("name", domainErrors.CodeRequired)
+		} else if utf8.RuneCountInString(name) > u.maxNameLength {
+			verr.Add("name", domainErrors.CodeTooLong, u.maxNameLength)
+		}
+	}
+	if input.Brand != nil {
+		brand := strings.TrimSpace(*input.Brand)
+		if brand == "" {
+			verr.Add("brand", domainErrors.CodeRequired)
+		} else if utf8.RuneCountInString(brand) > entity.MaxBrandLength {
+			verr.Add("brand", domainErrors.CodeTooLong, entity.MaxBrandLength)
+		}
+	}
+	if input.PurchasePrice != nil && *input.PurchasePrice < 0 {
+		verr.Add("purchase_price", domainErrors.CodeNegative)
+	}
+	if input.Notes != nil && utf8.RuneCountInString(strings.TrimSpace(*input.Notes)) > u.maxNotesLength {
+		verr.Add("notes", domainErrors.CodeTooLong, u.maxNotesLength)
+	}
+	if input.ImageURL != nil && !isValidImageURL(strings.TrimSpace(*input.ImageURL)) {
+		verr.Add("image_url", domainErrors.CodeInvalidFormat, "http(s) URL")
+	}
+
+	return verr.Err()
+}
+
+// PUT ではすべてのフィールドが必須（値の妥当性は entity で検証する）
+func validateReplaceItemInput(input ReplaceItemInput) error {
+	verr := &domainErrors.ValidationError{}
+
+	if input.Name == nil {
+		verr.Add("name", domainErrors.CodeRequired)
+	}
+	if input.Category == nil {
+		verr.Add("category", domainErrors.CodeRequired)
+	}
+	if input.Brand == nil {
+		verr.Add("brand", domainErrors.CodeRequired)
+	}
+	if input.PurchasePrice == nil {
+		verr.Add("purchase_price", domainErrors.CodeRequired)
+	}
+	if input.PurchaseDate == nil {
+		verr.Add("purchase_date", domainErrors.CodeRequired)
+	}
+
+	return verr.Err()
+}
+
+// normalizeName は名前を NFKC で正規化し、前後の空白を取り除く
+// 全角の英数字・記号や半角カナなどの表記の違いをそろえ、保存・一意制約の比較を同じ表記で行う
+func normalizeName(name string) string {
+	return strings.TrimSpace(norm.NFKC.String(name))
+}
+
+// validateItem は entity のバリデーション結果 err に、usecase で設定したルールの違反を加えて返す
+//   - name が maxNameLength 文字（rune 数）を超える（entity の上限より短く設定した場合）
+//   - category が categories（登録されたカテゴリー）のいずれでもない
+//   - 購入日が未来（サーバー時刻の今日より後）
+//   - notes が maxNotesLength 文字（rune 数）を超える
+//   - image_url が http / https の URL ではない
+//
+// entity が同じフィールドで違反を検出している場合は重ねて報告しない
+func (u *itemUsecase) validateItem(err error, categories []string, name, category, purchaseDate, notes, imageURL string) error {
+	var entityFields []domainErrors.FieldError
+	if err != nil {
+		entityErr, ok := domainErrors.AsValidationError(err)
+		if !ok {
+			return err
+		}
+		entityFields = entityErr.Fields
+	}
+	reported := make(map[string]bool, len(entityFields))
+	for _, f := range entityFields {
+		reported[f.Field] = true
+	}
+
+	verr := &domainErrors.ValidationError{}
+	// name はフィールド順で先頭
+	if !reported["name"] && utf8.RuneCountInString(strings.TrimSpace(name)) > u.maxNameLength {
+		verr.Add("name", domainErrors.CodeTooLong, u.maxNameLength)
+	}
+	// category は name の直後（entity の違反の並びに合わせる）
+	fields := 0
+	for fields < len(entityFields) && entityFields[fields].Field == "name" {
+		fields++
+	}
+	verr.Fields = append(verr.Fields, entityFields[:fields]...)
+	if !reported["category"] && !slices.Contains(categories, strings.TrimSpace(category)) {
+		verr.Add("category", domainErrors.CodeInvalidValue, strings.Join(categories, ", "))
+	}
+	verr.Fields = append(verr.Fields, entityFields[fields:]...)
+
+	now := u.now()
+	purchased, parseErr := time.ParseInLocation("2006-01-02", strings.TrimSpace(purchaseDate), now.Location())
+	if !reported["purchase_date"] && parseErr == nil {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+		if purchased.After(today) {
+			verr.Add("purchase_date", domainErrors.CodeFutureDate)
+		}
+	}
+	if !reported["notes"] && utf8.RuneCountInString(strings.TrimSpace(notes)) > u.maxNotesLength {
+		verr.Add("notes", domainErrors.CodeTooLong, u.maxNotesLength)
+	}
+	if !reported["image_url"] && !isValidImageURL(strings.TrimSpace(imageURL)) {
+		verr.Add("image_url", domainErrors.CodeInvalidFormat, "http(s) URL")
+	}
+
+	return verr.Err()
+}
+
+// 画像の URL はホストを含む http / https の URL に限る（空文字は画像なしのため有効）
+func isValidImageURL(value string) bool {
+	if value == "" {
+		return true
+	}
+	parsed, err := url.Parse(value)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
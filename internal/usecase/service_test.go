@@ -1,8 +1,16 @@
 package usecase
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -17,11 +25,48 @@ type MockItemRepository struct {
 	mock.Mock
 }
 
-func (m *MockItemRepository) FindAll(ctx context.Context) ([]*entity.Item, error) {
-	args := m.Called(ctx)
+func (m *MockItemRepository) FindAll(ctx context.Context, filter ItemFilter) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) GetItems(ctx context.Context, filter ItemFilter, sort SortOption, limit, offset int) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter, sort, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) GetItemsAfter(ctx context.Context, filter ItemFilter, afterID int64, limit int) ([]*entity.Item, error) {
+	args := m.Called(ctx, filter, afterID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) FindChangedSince(ctx context.Context, userID *string, since time.Time) ([]*entity.Item, error) {
+	args := m.Called(ctx, userID, since)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) FindWarrantyExpiring(ctx context.Context, userID *string, from, to time.Time) ([]*entity.Item, error) {
+	args := m.Called(ctx, userID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
 	return args.Get(0).([]*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) Count(ctx context.Context, filter ItemFilter) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockItemRepository) FindByID(ctx context.Context, id int64) (*entity.Item, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -30,6 +75,11 @@ func (m *MockItemRepository) FindByID(ctx context.Context, id int64) (*entity.It
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) Exists(ctx context.Context, id int64) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockItemRepository) Create(ctx context.Context, item *entity.Item) (*entity.Item, error) {
 	args := m.Called(ctx, item)
 	if args.Get(0) == nil {
@@ -38,17 +88,78 @@ func (m *MockItemRepository) Create(ctx context.Context, item *entity.Item) (*en
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+func (m *MockItemRepository) FindByIDs(ctx context.Context, ids []int64) ([]*entity.Item, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
+func (m *MockItemRepository) CreateItems(ctx context.Context, items []*entity.Item) ([]*entity.Item, error) {
+	args := m.Called(ctx, items)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Item), args.Error(1)
+}
+
 func (m *MockItemRepository) Delete(ctx context.Context, id int64) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context) (map[string]int, error) {
-	args := m.Called(ctx)
+func (m *MockItemRepository) Restore(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockItemRepository) DeleteItems(ctx context.Context, ids []int64) ([]int64, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockItemRepository) MoveItems(ctx context.Context, ids []int64, category string) ([]int64, error) {
+	args := m.Called(ctx, ids, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockItemRepository) GetSummaryByCategory(ctx context.Context, filter ItemFilter) (map[string]CategoryStats, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]CategoryStats), args.Error(1)
+}
+
+func (m *MockItemRepository) GetAveragePriceByCategory(ctx context.Context, filter ItemFilter) (map[string]CategoryAveragePrice, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]CategoryAveragePrice), args.Error(1)
+}
+
+func (m *MockItemRepository) GetPriceRangeByCategory(ctx context.Context, filter ItemFilter) (map[string]CategoryPriceRange, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]CategoryPriceRange), args.Error(1)
+}
+
+func (m *MockItemRepository) GetMonthlyTimeline(ctx context.Context, filter ItemFilter) ([]MonthlyStats, error) {
+	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(map[string]int), args.Error(1)
+	return args.Get(0).([]MonthlyStats), args.Error(1)
 }
 
 func (m *MockItemRepository) Update(ctx context.Context, item *entity.Item) (*entity.Item, error) {
@@ -59,6 +170,39 @@ func (m *MockItemRepository) Update(ctx context.Context, item *entity.Item) (*en
 	return args.Get(0).(*entity.Item), args.Error(1)
 }
 
+type MockCategoryRepository struct {
+	mock.Mock
+}
+
+func (m *MockCategoryRepository) FindCategories(ctx context.Context) ([]*entity.Category, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) CreateCategory(ctx context.Context, category *entity.Category) (*entity.Category, error) {
+	args := m.Called(ctx, category)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entity.Category), args.Error(1)
+}
+
+func (m *MockCategoryRepository) DeleteCategory(ctx context.Context, id int64) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockCategoryRepository) RenameCategory(ctx context.Context, from, to string) (*entity.Category, []int64, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+	return args.Get(0).(*entity.Category), args.Get(1).([]int64), args.Error(2)
+}
+
 func TestNewItemUsecase(t *testing.T) {
 	mockRepo := new(MockItemRepository)
 	usecase := NewItemUsecase(mockRepo)
@@ -79,7 +223,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
 				item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02")
 				items := []*entity.Item{item1, item2}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return(items, nil)
 			},
 			expectedCount: 2,
 			expectedErr:   nil,
@@ -88,7 +232,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 			name: "正常系: アイテムが0件",
 			setupMock: func(mockRepo *MockItemRepository) {
 				items := []*entity.Item{}
-				mockRepo.On("FindAll", mock.Anything).Return(items, nil)
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return(items, nil)
 			},
 			expectedCount: 0,
 			expectedErr:   nil,
@@ -96,7 +240,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 		{
 			name: "異常系: データベースエラー",
 			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("FindAll", mock.Anything).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
+				mockRepo.On("FindAll", mock.Anything, ItemFilter{}).Return(([]*entity.Item)(nil), domainErrors.ErrDatabaseError)
 			},
 			expectedCount: 0,
 			expectedErr:   domainErrors.ErrDatabaseError,
@@ -110,7 +254,7 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 			usecase := NewItemUsecase(mockRepo)
 
 			ctx := context.Background()
-			items, err := usecase.GetAllItems(ctx)
+			items, err := usecase.GetAllItems(ctx, ItemFilter{})
 
 			if tt.expectedErr != nil {
 				assert.Error(t, err)
@@ -126,6 +270,481 @@ func TestItemUsecase_GetAllItems(t *testing.T) {
 	}
 }
 
+func TestItemUsecase_GetItems(t *testing.T) {
+	tests := []struct {
+		name          string
+		filter        ItemFilter
+		limit         int
+		offset        int
+		setupMock     func(*MockItemRepository)
+		expectedCount int
+		expectedTotal int
+		expectedErr   error
+	}{
+		{
+			name:   "正常系: ページ単位で取得し全件数を返す",
+			limit:  2,
+			offset: 0,
+			setupMock: func(mockRepo *MockItemRepository) {
+				item1, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item2, _ := entity.NewItem("バッグ1", "バッグ", "HERMÈS", 500000, "2023-01-02")
+				mockRepo.On("GetItems", mock.Anything, ItemFilter{}, SortOption{}, 2, 0).Return([]*entity.Item{item1, item2}, nil)
+				mockRepo.On("Count", mock.Anything, ItemFilter{}).Return(5, nil)
+			},
+			expectedCount: 2,
+			expectedTotal: 5,
+		},
+		{
+			name:   "異常系: 無効なlimit",
+			limit:  0,
+			offset: 0,
+			setupMock: func(mockRepo *MockItemRepository) {
+				// GetItemsは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:   "異常系: 負のoffset",
+			limit:  10,
+			offset: -1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				// GetItemsは呼ばれない
+			},
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name:   "異常系: Countでデータベースエラー",
+			limit:  10,
+			offset: 0,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetItems", mock.Anything, ItemFilter{}, SortOption{}, 10, 0).Return([]*entity.Item{}, nil)
+				mockRepo.On("Count", mock.Anything, ItemFilter{}).Return(0, domainErrors.ErrDatabaseError)
+			},
+			expectedErr: domainErrors.ErrDatabaseError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			items, total, err := usecase.GetItems(context.Background(), tt.filter, SortOption{}, tt.limit, tt.offset)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Len(t, items, tt.expectedCount)
+			assert.Equal(t, tt.expectedTotal, total)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_GetItemsWithFilter(t *testing.T) {
+	strPtr := func(v string) *string { return &v }
+
+	t.Run("正常系: カテゴリーとブランドの前後の空白を取り除いて絞り込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		watch, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		filter := ItemFilter{Categories: []string{"時計"}, Brand: strPtr("ROLEX")}
+		mockRepo.On("GetItems", mock.Anything, filter, SortOption{}, 20, 0).Return([]*entity.Item{watch}, nil)
+		mockRepo.On("Count", mock.Anything, filter).Return(1, nil)
+
+		input := ItemFilter{Categories: []string{" 時計 "}, Brand: strPtr("  ROLEX ")}
+		items, total, err := NewItemUsecase(mockRepo).GetItems(context.Background(), input, SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		assert.Len(t, items, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 検索語の前後の空白を取り除く", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		filter := ItemFilter{Search: "デイトナ"}
+		mockRepo.On("GetItems", mock.Anything, filter, SortOption{}, 20, 0).Return([]*entity.Item{}, nil)
+		mockRepo.On("Count", mock.Anything, filter).Return(0, nil)
+
+		_, _, err := NewItemUsecase(mockRepo).GetItems(context.Background(), ItemFilter{Search: "  デイトナ "}, SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 空のカテゴリーは絞り込まない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetItems", mock.Anything, ItemFilter{}, SortOption{}, 20, 0).Return([]*entity.Item{}, nil)
+		mockRepo.On("Count", mock.Anything, ItemFilter{}).Return(0, nil)
+
+		_, _, err := NewItemUsecase(mockRepo).GetItems(context.Background(), ItemFilter{Categories: []string{"", " "}}, SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 複数のカテゴリーは重複を除く", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		filter := ItemFilter{Categories: []string{"時計", "家具", "バッグ"}}
+		mockRepo.On("GetItems", mock.Anything, filter, SortOption{}, 20, 0).Return([]*entity.Item{}, nil)
+		mockRepo.On("Count", mock.Anything, filter).Return(0, nil)
+
+		input := ItemFilter{Categories: []string{" 時計", "家具", "バッグ", "時計"}}
+		_, _, err := NewItemUsecase(mockRepo).GetItems(context.Background(), input, SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 除外するカテゴリーは空のものを除く", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		filter := ItemFilter{Categories: []string{"時計", "バッグ"}, ExcludedCategories: []string{"バッグ", "家具"}}
+		mockRepo.On("GetItems", mock.Anything, filter, SortOption{}, 20, 0).Return([]*entity.Item{}, nil)
+		mockRepo.On("Count", mock.Anything, filter).Return(0, nil)
+
+		input := ItemFilter{Categories: []string{"時計", "バッグ"}, ExcludedCategories: []string{"バッグ ", "家具", ""}}
+		_, _, err := NewItemUsecase(mockRepo).GetItems(context.Background(), input, SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: タグは小文字にそろえて重複を除く", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		filter := ItemFilter{Tags: []string{"gift", "vintage"}, TagsMatchAny: true}
+		mockRepo.On("GetItems", mock.Anything, filter, SortOption{}, 20, 0).Return([]*entity.Item{}, nil)
+		mockRepo.On("Count", mock.Anything, filter).Return(0, nil)
+
+		input := ItemFilter{Tags: []string{"Gift", " gift", "VINTAGE", ""}, TagsMatchAny: true}
+		_, _, err := NewItemUsecase(mockRepo).GetItems(context.Background(), input, SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 登録されていないカテゴリーもそのまま絞り込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		filter := ItemFilter{Categories: []string{"家具", "車"}}
+		mockRepo.On("GetItems", mock.Anything, filter, SortOption{}, 20, 0).Return([]*entity.Item{}, nil)
+		mockRepo.On("Count", mock.Anything, filter).Return(0, nil)
+
+		items, total, err := NewItemUsecase(mockRepo).GetItems(context.Background(), filter, SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, total)
+		assert.NotNil(t, items)
+		assert.Empty(t, items)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 空白のみのブランドは空の一覧", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		items, _, err := NewItemUsecase(mockRepo).GetItems(context.Background(), ItemFilter{Brand: strPtr("  ")}, SortOption{}, 20, 0)
+
+		require.NoError(t, err)
+		assert.Empty(t, items)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_CountItems(t *testing.T) {
+	t.Run("正常系: 絞り込みなし", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Count", mock.Anything, ItemFilter{}).Return(5, nil)
+
+		count, err := NewItemUsecase(mockRepo).CountItems(context.Background(), ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, count)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: カテゴリーで絞り込み", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		category := "時計"
+		mockRepo.On("Count", mock.Anything, ItemFilter{Categories: []string{category}}).Return(2, nil)
+
+		padded := " 時計 "
+		count, err := NewItemUsecase(mockRepo).CountItems(context.Background(), ItemFilter{Categories: []string{padded}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 存在しないカテゴリーは 0 件", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		category := "家具"
+		mockRepo.On("Count", mock.Anything, ItemFilter{Categories: []string{category}}).Return(0, nil)
+
+		count, err := NewItemUsecase(mockRepo).CountItems(context.Background(), ItemFilter{Categories: []string{category}})
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, count)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_GetRecentItems(t *testing.T) {
+	recent := SortOption{Keys: []SortKey{{Field: SortByCreatedAt, Descending: true}}}
+
+	t.Run("正常系: 登録日時の新しい順に取得", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetItems", mock.Anything, ItemFilter{}, recent, 3, 0).Return([]*entity.Item{{ID: 3}, {ID: 2}, {ID: 1}}, nil)
+
+		items, err := NewItemUsecase(mockRepo).GetRecentItems(context.Background(), 3)
+
+		require.NoError(t, err)
+		assert.Len(t, items, 3)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 上限を超える件数は上限まで", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetItems", mock.Anything, ItemFilter{}, recent, MaxRecentItems, 0).Return([]*entity.Item{}, nil)
+
+		_, err := NewItemUsecase(mockRepo).GetRecentItems(context.Background(), MaxRecentItems+1)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 無効なlimit", func(t *testing.T) {
+		_, err := NewItemUsecase(new(MockItemRepository)).GetRecentItems(context.Background(), 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestItemUsecase_GetTopItems(t *testing.T) {
+	top := SortOption{Keys: []SortKey{{Field: SortByPurchasePrice, Descending: true}}}
+
+	t.Run("正常系: 購入価格の高い順に取得", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetItems", mock.Anything, ItemFilter{}, top, 3, 0).
+			Return([]*entity.Item{{ID: 2, PurchasePrice: 2000000}, {ID: 1, PurchasePrice: 1500000}, {ID: 3, PurchasePrice: 300000}}, nil)
+
+		items, err := NewItemUsecase(mockRepo).GetTopItems(context.Background(), nil, 3)
+
+		require.NoError(t, err)
+		assert.Len(t, items, 3)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: カテゴリーで絞り込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		category := "時計"
+		mockRepo.On("GetItems", mock.Anything, ItemFilter{Categories: []string{category}}, top, 5, 0).Return([]*entity.Item{}, nil)
+
+		_, err := NewItemUsecase(mockRepo).GetTopItems(context.Background(), &category, 5)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: カテゴリーの前後の空白は除く", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		category := " 時計 "
+		mockRepo.On("GetItems", mock.Anything, ItemFilter{Categories: []string{"時計"}}, top, 5, 0).Return([]*entity.Item{}, nil)
+
+		_, err := NewItemUsecase(mockRepo).GetTopItems(context.Background(), &category, 5)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 空のカテゴリーは絞り込まない", func(t *testing.T) {
+		for _, category := range []string{"", "  "} {
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("GetItems", mock.Anything, ItemFilter{}, top, 5, 0).Return([]*entity.Item{}, nil)
+
+			_, err := NewItemUsecase(mockRepo).GetTopItems(context.Background(), &category, 5)
+
+			require.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		}
+	})
+
+	t.Run("正常系: 上限を超える件数は上限まで", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetItems", mock.Anything, ItemFilter{}, top, MaxTopItems, 0).Return([]*entity.Item{}, nil)
+
+		_, err := NewItemUsecase(mockRepo).GetTopItems(context.Background(), nil, MaxTopItems+1)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 無効な件数", func(t *testing.T) {
+		_, err := NewItemUsecase(new(MockItemRepository)).GetTopItems(context.Background(), nil, 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestItemUsecase_GetDeletedItems(t *testing.T) {
+	t.Run("正常系: 論理削除されたアイテムのみ取得", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		deletedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		filter := ItemFilter{Deleted: true}
+		mockRepo.On("GetItems", mock.Anything, filter, SortOption{}, 20, 0).Return([]*entity.Item{{ID: 1, DeletedAt: &deletedAt}}, nil)
+		mockRepo.On("Count", mock.Anything, filter).Return(1, nil)
+
+		items, total, err := NewItemUsecase(mockRepo).GetDeletedItems(context.Background(), 20, 0)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		if assert.Len(t, items, 1) {
+			assert.NotNil(t, items[0].DeletedAt)
+		}
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 無効なlimit", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, _, err := NewItemUsecase(mockRepo).GetDeletedItems(context.Background(), 0, 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestItemUsecase_GetItemsAfter(t *testing.T) {
+	t.Run("正常系: 次のページがある場合", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		items := []*entity.Item{{ID: 3}, {ID: 4}, {ID: 5}}
+		mockRepo.On("GetItemsAfter", mock.Anything, ItemFilter{}, int64(2), 3).Return(items, nil)
+
+		page, hasMore, err := NewItemUsecase(mockRepo).GetItemsAfter(context.Background(), ItemFilter{}, 2, 2)
+
+		require.NoError(t, err)
+		assert.True(t, hasMore)
+		assert.Len(t, page, 2)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 最後のページの場合", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		items := []*entity.Item{{ID: 5}}
+		mockRepo.On("GetItemsAfter", mock.Anything, ItemFilter{}, int64(4), 3).Return(items, nil)
+
+		page, hasMore, err := NewItemUsecase(mockRepo).GetItemsAfter(context.Background(), ItemFilter{}, 4, 2)
+
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+		assert.Len(t, page, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 一覧と同じく前後の空白を除いたフィルターで絞り込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		items := []*entity.Item{{ID: 3}}
+		mockRepo.On("GetItemsAfter", mock.Anything, ItemFilter{Categories: []string{"時計"}, Search: "デイトナ"}, int64(2), 3).Return(items, nil)
+
+		page, hasMore, err := NewItemUsecase(mockRepo).GetItemsAfter(context.Background(), ItemFilter{Categories: []string{" 時計 ", ""}, Search: " デイトナ"}, 2, 2)
+
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+		assert.Equal(t, items, page)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 空のブランドは一致するアイテムがない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		brand := " "
+
+		page, hasMore, err := NewItemUsecase(mockRepo).GetItemsAfter(context.Background(), ItemFilter{Brand: &brand}, 0, 2)
+
+		require.NoError(t, err)
+		assert.False(t, hasMore)
+		assert.Empty(t, page)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 無効なlimit", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, _, err := NewItemUsecase(mockRepo).GetItemsAfter(context.Background(), ItemFilter{}, 0, 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_GetItemChanges(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 論理削除されたアイテムも含む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		deletedAt := since.Add(time.Hour)
+		items := []*entity.Item{{ID: 1}, {ID: 2, DeletedAt: &deletedAt}}
+		mockRepo.On("FindChangedSince", mock.Anything, (*string)(nil), since).Return(items, nil)
+
+		changes, err := NewItemUsecase(mockRepo).GetItemChanges(context.Background(), since)
+
+		require.NoError(t, err)
+		assert.Equal(t, items, changes)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: since が未指定", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).GetItemChanges(context.Background(), time.Time{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: リポジトリエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindChangedSince", mock.Anything, (*string)(nil), since).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := NewItemUsecase(mockRepo).GetItemChanges(context.Background(), since)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_ItemExists(t *testing.T) {
+	t.Run("正常系: 存在する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Exists", mock.Anything, int64(1)).Return(true, nil)
+
+		exists, err := NewItemUsecase(mockRepo).ItemExists(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.True(t, exists)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 存在しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Exists", mock.Anything, int64(2)).Return(false, nil)
+
+		exists, err := NewItemUsecase(mockRepo).ItemExists(context.Background(), 2)
+
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("異常系: 無効なID", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).ItemExists(context.Background(), 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+	})
+}
+
 func TestItemUsecase_GetItemByID(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -198,7 +817,86 @@ func TestItemUsecase_GetItemByID(t *testing.T) {
 	}
 }
 
-func TestItemUsecase_CreateItem(t *testing.T) {
+func TestItemUsecase_GetItemsByIDs(t *testing.T) {
+	items := map[int64]*entity.Item{
+		1: {ID: 1, Name: "時計1"},
+		2: {ID: 2, Name: "バッグ1"},
+		3: {ID: 3, Name: "靴1"},
+	}
+	ids := func(items []*entity.Item) []int64 {
+		result := []int64{}
+		for _, item := range items {
+			result = append(result, item.ID)
+		}
+		return result
+	}
+
+	t.Run("正常系: すべて見つかる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		// リポジトリは ID 順で返すが、指定順に並べ直される
+		mockRepo.On("FindByIDs", mock.Anything, []int64{3, 1, 2}).Return([]*entity.Item{items[1], items[2], items[3]}, nil)
+
+		result, err := NewItemUsecase(mockRepo).GetItemsByIDs(context.Background(), []int64{3, 1, 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{3, 1, 2}, ids(result))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 見つからない ID は除く", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByIDs", mock.Anything, []int64{2, 99, 1}).Return([]*entity.Item{items[1], items[2]}, nil)
+
+		result, err := NewItemUsecase(mockRepo).GetItemsByIDs(context.Background(), []int64{2, 99, 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2, 1}, ids(result))
+	})
+
+	t.Run("正常系: 重複した ID は最初の位置だけ", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByIDs", mock.Anything, []int64{2, 1}).Return([]*entity.Item{items[1], items[2]}, nil)
+
+		result, err := NewItemUsecase(mockRepo).GetItemsByIDs(context.Background(), []int64{2, 1, 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2, 1}, ids(result))
+	})
+
+	t.Run("正常系: 空の ID 一覧は空配列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		result, err := NewItemUsecase(mockRepo).GetItemsByIDs(context.Background(), []int64{})
+
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Empty(t, result)
+		mockRepo.AssertNotCalled(t, "FindByIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 上限を超える ID", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		tooMany := make([]int64, MaxBatchGetIDs+1)
+		for i := range tooMany {
+			tooMany[i] = int64(i + 1)
+		}
+
+		_, err := NewItemUsecase(mockRepo).GetItemsByIDs(context.Background(), tooMany)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindByIDs", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 0 以下の ID", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).GetItemsByIDs(context.Background(), []int64{1, 0})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestItemUsecase_CreateItem(t *testing.T) {
 	tests := []struct {
 		name        string
 		input       CreateItemInput
@@ -298,160 +996,2670 @@ func TestItemUsecase_CreateItem(t *testing.T) {
 	}
 }
 
-func TestItemUsecase_DeleteItem(t *testing.T) {
-	tests := []struct {
-		name        string
-		id          int64
-		setupMock   func(*MockItemRepository)
-		expectError bool
-		expectedErr error
-	}{
-		{
-			name: "正常系: 存在するアイテムを削除",
-			id:   1,
-			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
-				item.ID = 1
-				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
-				mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
-			},
-			expectError: false,
-		},
-		{
-			name: "異常系: 存在しないアイテム",
-			id:   999,
-			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
-			},
-			expectError: true,
-			expectedErr: domainErrors.ErrItemNotFound,
-		},
-		{
-			name: "異常系: 無効なID（0以下）",
-			id:   0,
-			setupMock: func(mockRepo *MockItemRepository) {
-				// FindByIDは呼ばれない
-			},
-			expectError: true,
-			expectedErr: domainErrors.ErrInvalidInput,
-		},
-		{
-			name: "異常系: FindByIDでデータベースエラー",
-			id:   1,
-			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("FindByID", mock.Anything, int64(1)).Return((*entity.Item)(nil), domainErrors.ErrDatabaseError)
-			},
-			expectError: true,
-		},
-		{
-			name: "異常系: Deleteでデータベースエラー",
-			id:   1,
-			setupMock: func(mockRepo *MockItemRepository) {
-				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
-				item.ID = 1
-				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
-				mockRepo.On("Delete", mock.Anything, int64(1)).Return(domainErrors.ErrDatabaseError)
-			},
-			expectError: true,
-		},
+func TestItemUsecase_DryRunCreateItem(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := WithClock(func() time.Time { return now })
+
+	t.Run("正常系: 登録される内容を返し、保存しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		item, err := NewItemUsecase(mockRepo, clock).DryRunCreateItem(ctx, CreateItemInput{Name: " ロレックス デイトナ ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15", Tags: []string{"Gift"}})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), item.ID)
+		assert.Equal(t, "ロレックス デイトナ", item.Name)
+		assert.Equal(t, []string{"gift"}, item.Tags)
+		assert.Equal(t, now, item.CreatedAt)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 登録と同じバリデーションエラーを返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo, clock).DryRunCreateItem(ctx, CreateItemInput{Name: "時計1", Category: "家具", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2024-01-02"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.EqualError(t, err, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他, purchase_date must not be in the future")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_ValidateItems(t *testing.T) {
+	ctx := context.Background()
+	clock := WithClock(func() time.Time { return time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC) })
+
+	t.Run("正常系: 入力ごとの結果を同じ順序で返し、保存しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		result, err := NewItemUsecase(mockRepo, clock).ValidateItems(ctx, []CreateItemInput{
+			{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"},
+			{Name: "時計1", Category: "家具", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2024-01-02"},
+			{Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: "2023-02-20"},
+			{Category: "靴", Brand: "Christian Louboutin", PurchasePrice: -1, PurchaseDate: "2023-04-05"},
+		})
+
+		require.NoError(t, err)
+		assert.False(t, result.Valid)
+		require.Len(t, result.Items, 4)
+		assert.Equal(t, ItemValidation{Index: 0, Valid: true, Errors: []domainErrors.FieldError{}}, result.Items[0])
+		assert.Equal(t, 1, result.Items[1].Index)
+		assert.False(t, result.Items[1].Valid)
+		assert.Equal(t, []string{"category", "purchase_date"}, []string{result.Items[1].Errors[0].Field, result.Items[1].Errors[1].Field})
+		assert.Equal(t, domainErrors.CodeFutureDate, result.Items[1].Errors[1].Code)
+		assert.True(t, result.Items[2].Valid)
+		assert.False(t, result.Items[3].Valid)
+		assert.Equal(t, []string{"name", "purchase_price"}, []string{result.Items[3].Errors[0].Field, result.Items[3].Errors[1].Field})
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: すべて正しい場合は Valid が true", func(t *testing.T) {
+		result, err := NewItemUsecase(new(MockItemRepository), clock).ValidateItems(ctx, []CreateItemInput{
+			{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, result.Valid)
+		assert.True(t, result.Items[0].Valid)
+	})
+
+	t.Run("異常系: 空の配列", func(t *testing.T) {
+		_, err := NewItemUsecase(new(MockItemRepository), clock).ValidateItems(ctx, nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestItemUsecase_WarrantyExpiry(t *testing.T) {
+	input := func(warrantyExpiry string) CreateItemInput {
+		return CreateItemInput{
+			Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+			WarrantyExpiry: &warrantyExpiry,
+		}
 	}
+	t.Run("正常系: 保証期限を登録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.WarrantyExpiry != nil && *item.WarrantyExpiry == "2025-01-15"
+		})).Return(&entity.Item{ID: 1}, nil)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mockRepo := new(MockItemRepository)
-			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
+		_, err := NewItemUsecase(mockRepo).CreateItem(context.Background(), input(" 2025-01-15 "))
 
-			ctx := context.Background()
-			err := usecase.DeleteItem(ctx, tt.id)
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
 
-			if tt.expectError {
-				assert.Error(t, err)
-				if tt.expectedErr != nil {
-					assert.ErrorIs(t, err, tt.expectedErr)
-				}
-			} else {
-				assert.NoError(t, err)
-			}
+	t.Run("正常系: 購入日と同じ日と空文字は登録できる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(&entity.Item{ID: 1}, nil)
+
+		_, err := NewItemUsecase(mockRepo).CreateItem(context.Background(), input("2023-01-15"))
+		require.NoError(t, err)
+		_, err = NewItemUsecase(mockRepo).CreateItem(context.Background(), input(""))
+		require.NoError(t, err)
+
+		created := mockRepo.Calls[1].Arguments.Get(1).(*entity.Item)
+		assert.Nil(t, created.WarrantyExpiry)
+	})
+
+	t.Run("異常系: 購入日より前・形式が不正", func(t *testing.T) {
+		for warrantyExpiry, code := range map[string]domainErrors.Code{
+			"2023-01-14": domainErrors.CodeBeforePurchase,
+			"2025/01/15": domainErrors.CodeInvalidFormat,
+		} {
+			mockRepo := new(MockItemRepository)
+
+			_, err := NewItemUsecase(mockRepo).CreateItem(context.Background(), input(warrantyExpiry))
 
+			verr, ok := domainErrors.AsValidationError(err)
+			require.True(t, ok, warrantyExpiry)
+			assert.Equal(t, "warranty_expiry", verr.Fields[0].Field)
+			assert.Equal(t, code, verr.Fields[0].Code)
 			mockRepo.AssertExpectations(t)
+		}
+	})
+
+	t.Run("異常系: 購入日を保証期限より後に置き換える", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		expiry := "2024-01-15"
+		existing := &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-15", WarrantyExpiry: &expiry, Version: 1}
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+
+		name, category, brand, price, date := "時計1", "時計", "ROLEX", 1000000, "2024-02-01"
+		_, err := NewItemUsecase(mockRepo).ReplaceItem(context.Background(), 1, ReplaceItemInput{
+			Name: &name, Category: &category, Brand: &brand, PurchasePrice: &price, PurchaseDate: &date, WarrantyExpiry: &expiry,
 		})
-	}
+
+		verr, ok := domainErrors.AsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, domainErrors.CodeBeforePurchase, verr.Fields[0].Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: PATCH の空文字で保証期限を削除", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		expiry := "2025-01-15"
+		existing := &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-15", WarrantyExpiry: &expiry, Version: 1}
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.WarrantyExpiry == nil
+		})).Return(&entity.Item{ID: 1, Version: 2}, nil)
+
+		empty := ""
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{WarrantyExpiry: &empty})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
 }
 
-func TestItemUsecase_GetCategorySummary(t *testing.T) {
-	tests := []struct {
-		name               string
-		setupMock          func(*MockItemRepository)
-		expectedTotal      int
-		expectedWatchCount int
-		expectedBagCount   int
-		expectError        bool
-	}{
-		{
-			name: "正常系: 複数カテゴリーのアイテムがある場合",
-			setupMock: func(mockRepo *MockItemRepository) {
-				summary := map[string]int{
-					"時計":  2,
-					"バッグ": 1,
-				}
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
-			},
-			expectedTotal:      3,
-			expectedWatchCount: 2,
-			expectedBagCount:   1,
-			expectError:        false,
-		},
-		{
-			name: "正常系: アイテムが0件の場合",
-			setupMock: func(mockRepo *MockItemRepository) {
-				summary := map[string]int{}
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return(summary, nil)
-			},
-			expectedTotal:      0,
-			expectedWatchCount: 0,
-			expectedBagCount:   0,
-			expectError:        false,
-		},
-		{
-			name: "異常系: データベースエラー",
-			setupMock: func(mockRepo *MockItemRepository) {
-				mockRepo.On("GetSummaryByCategory", mock.Anything).Return((map[string]int)(nil), domainErrors.ErrDatabaseError)
-			},
-			expectError: true,
-		},
+func TestItemUsecase_NormalizeName(t *testing.T) {
+	ctx := context.Background()
+	input := func(name string) CreateItemInput {
+		return CreateItemInput{Name: name, Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}
+	}
+	createdWithName := func(mockRepo *MockItemRepository, name string) {
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == name
+		})).Return(&entity.Item{ID: 1, Name: name}, nil)
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
+	t.Run("正常系: 前後の空白を取り除く", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		createdWithName(mockRepo, "ロレックス デイトナ")
+
+		item, err := NewItemUsecase(mockRepo).CreateItem(ctx, input(" \tロレックス デイトナ　"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "ロレックス デイトナ", item.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 全角英数字・全角空白・半角カナを NFKC でそろえる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		createdWithName(mockRepo, "ROLEX デイトナ 116500")
+
+		item, err := NewItemUsecase(mockRepo).CreateItem(ctx, input("ＲＯＬＥＸ　ﾃﾞｲﾄﾅ　１１６５００"))
+
+		require.NoError(t, err)
+		assert.Equal(t, "ROLEX デイトナ 116500", item.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 更新・置き換えの名前も正規化する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		existing := &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-15", Version: 1}
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == "Omega"
+		})).Return(&entity.Item{ID: 1, Name: "Omega", Version: 2}, nil)
+
+		name := " Ｏｍｅｇａ "
+		_, err := NewItemUsecase(mockRepo).UpdateItem(ctx, 1, UpdateItemInput{Name: &name})
+		require.NoError(t, err)
+
+		category, brand, price, date := "時計", "OMEGA", 800000, "2023-03-01"
+		_, err = NewItemUsecase(mockRepo).ReplaceItem(ctx, 1, ReplaceItemInput{Name: &name, Category: &category, Brand: &brand, PurchasePrice: &price, PurchaseDate: &date})
+		require.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "Update", 2)
+	})
+
+	t.Run("異常系: 空白だけの名前（全角空白を含む）", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).CreateItem(ctx, input(" 　\u3000 "))
+
+		verr, ok := domainErrors.AsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, domainErrors.FieldError{Field: "name", Code: domainErrors.CodeRequired, Message: "is required"}, verr.Fields[0])
+
+		name := "\u3000"
+		_, err = NewItemUsecase(mockRepo).UpdateItem(ctx, 1, UpdateItemInput{Name: &name})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_GetExpiringWarranties(t *testing.T) {
+	now := time.Date(2024, 1, 10, 15, 30, 0, 0, time.UTC)
+	clock := WithClock(func() time.Time { return now })
+	today := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 今日から days 日後まで", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		items := []*entity.Item{{ID: 2}, {ID: 1}}
+		mockRepo.On("FindWarrantyExpiring", mock.Anything, (*string)(nil), today, today.AddDate(0, 0, 30)).Return(items, nil)
+
+		expiring, err := NewItemUsecase(mockRepo, clock).GetExpiringWarranties(context.Background(), 30)
+
+		require.NoError(t, err)
+		assert.Equal(t, items, expiring)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: days が範囲外", func(t *testing.T) {
+		for _, days := range []int{-1, MaxWarrantyDays + 1} {
 			mockRepo := new(MockItemRepository)
-			tt.setupMock(mockRepo)
-			usecase := NewItemUsecase(mockRepo)
 
-			ctx := context.Background()
-			summary, err := usecase.GetCategorySummary(ctx)
+			_, err := NewItemUsecase(mockRepo, clock).GetExpiringWarranties(context.Background(), days)
 
-			if tt.expectError {
-				assert.Error(t, err)
-				assert.Nil(t, summary)
-				mockRepo.AssertExpectations(t)
-				return
-			}
+			assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+			mockRepo.AssertExpectations(t)
+		}
+	})
+}
 
-			require.NoError(t, err)
-			require.NotNil(t, summary)
+func TestItemUsecase_CloneItem(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	source := &entity.Item{
+		ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+		CreatedAt: createdAt, UpdatedAt: createdAt, Version: 4,
+	}
 
-			assert.Equal(t, tt.expectedTotal, summary.Total)
-			assert.Equal(t, tt.expectedWatchCount, summary.Categories["時計"])
-			assert.Equal(t, tt.expectedBagCount, summary.Categories["バッグ"])
+	t.Run("正常系: ID・日時・バージョンを引き継がずに登録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(source, nil)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.ID == 0 && item.Name == "ロレックス デイトナ (copy)" && item.Category == "時計" && item.Brand == "ROLEX" &&
+				item.PurchasePrice == 1500000 && item.PurchaseDate == "2023-01-15" &&
+				item.Version == 1 && !item.CreatedAt.Equal(source.CreatedAt)
+		})).Return(&entity.Item{ID: 2, Name: "ロレックス デイトナ (copy)", Version: 1}, nil)
 
-			// すべてのカテゴリーがレスポンスに含まれているかチェック
-			expectedCategories := []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
-			for _, category := range expectedCategories {
-				assert.Contains(t, summary.Categories, category)
-			}
+		cloned, err := NewItemUsecase(mockRepo).CloneItem(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), cloned.ID)
+		assert.Equal(t, 1, cloned.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 最大文字数を超える場合は接尾辞を付けない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		long := *source
+		long.Name = strings.Repeat("時", entity.MaxNameLength-3)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&long, nil)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == long.Name
+		})).Return(&entity.Item{ID: 2}, nil)
+
+		_, err := NewItemUsecase(mockRepo).CloneItem(context.Background(), 1)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 複製元が存在しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := NewItemUsecase(mockRepo).CloneItem(context.Background(), 99)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 無効なID", func(t *testing.T) {
+		_, err := NewItemUsecase(new(MockItemRepository)).CloneItem(context.Background(), 0)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+}
+
+func TestItemUsecase_CreateItems(t *testing.T) {
+	validInput := CreateItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}
+	otherInput := CreateItemInput{Name: "エルメス バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: "2023-02-20"}
+
+	t.Run("正常系: すべてのアイテムを登録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("CreateItems", mock.Anything, mock.MatchedBy(func(items []*entity.Item) bool {
+			return len(items) == 2
+		})).Return([]*entity.Item{{ID: 1}, {ID: 2}}, nil)
+
+		items, err := NewItemUsecase(mockRepo).CreateItems(context.Background(), []CreateItemInput{validInput, otherInput})
+
+		require.NoError(t, err)
+		assert.Len(t, items, 2)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 一部が不正な場合は何も登録しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		invalidInput := validInput
+		invalidInput.Category = "無効なカテゴリー"
+
+		items, err := NewItemUsecase(mockRepo).CreateItems(context.Background(), []CreateItemInput{validInput, invalidInput})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "items[1]")
+		assert.Nil(t, items)
+		mockRepo.AssertNotCalled(t, "CreateItems", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 空の配列", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).CreateItems(context.Background(), []CreateItemInput{})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "CreateItems", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: トランザクションが失敗", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("CreateItems", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := NewItemUsecase(mockRepo).CreateItems(context.Background(), []CreateItemInput{validInput})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		mockRepo.AssertExpectations(t)
+	})
+}
 
+func TestItemUsecase_ValidationErrors(t *testing.T) {
+	fieldErrors := func(t *testing.T, err error) []domainErrors.FieldError {
+		t.Helper()
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		verr, ok := domainErrors.AsValidationError(err)
+		require.True(t, ok, "error must be a ValidationError: %v", err)
+		return verr.Fields
+	}
+
+	t.Run("異常系: 作成時はすべての違反をフィールド順に返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).CreateItem(context.Background(), CreateItemInput{
+			Name:          "",
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: -1,
+			PurchaseDate:  "2023/01/15",
+		})
+
+		assert.Equal(t, []domainErrors.FieldError{
+			{Field: "name", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "purchase_price", Code: domainErrors.CodeNegative, Message: "must be 0 or greater"},
+			{Field: "purchase_date", Code: domainErrors.CodeInvalidFormat, Message: "must be in YYYY-MM-DD format", Params: []interface{}{"YYYY-MM-DD"}},
+		}, fieldErrors(t, err))
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 一括登録はすべての要素の違反を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		valid := CreateItemInput{Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15"}
+		noName := valid
+		noName.Name = ""
+		badCategoryAndPrice := valid
+		badCategoryAndPrice.Category = "無効なカテゴリー"
+		badCategoryAndPrice.PurchasePrice = -1
+
+		_, err := NewItemUsecase(mockRepo).CreateItems(context.Background(), []CreateItemInput{noName, valid, badCategoryAndPrice})
+
+		assert.Equal(t, []domainErrors.FieldError{
+			{Field: "items[0].name", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "items[2].category", Code: domainErrors.CodeInvalidValue, Message: "must be one of: 時計, バッグ, ジュエリー, 靴, その他", Params: []interface{}{"時計, バッグ, ジュエリー, 靴, その他"}},
+			{Field: "items[2].purchase_price", Code: domainErrors.CodeNegative, Message: "must be 0 or greater"},
+		}, fieldErrors(t, err))
+		mockRepo.AssertNotCalled(t, "CreateItems", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 更新時は取得前にすべての違反を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		name := " "
+		price := -100
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name, PurchasePrice: &price})
+
+		assert.Equal(t, []domainErrors.FieldError{
+			{Field: "name", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "purchase_price", Code: domainErrors.CodeNegative, Message: "must be 0 or greater"},
+		}, fieldErrors(t, err))
+		assert.EqualError(t, err, "name is required, purchase_price must be 0 or greater")
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 置き換え時は未指定のフィールドをすべて返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		name := "アイテム"
+
+		_, err := NewItemUsecase(mockRepo).ReplaceItem(context.Background(), 1, ReplaceItemInput{Name: &name})
+
+		assert.Equal(t, []domainErrors.FieldError{
+			{Field: "category", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "brand", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "purchase_price", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "purchase_date", Code: domainErrors.CodeRequired, Message: "is required"},
+		}, fieldErrors(t, err))
+	})
+}
+
+func TestItemUsecase_CategoryValidation(t *testing.T) {
+	for _, category := range entity.DefaultCategories {
+		t.Run("正常系: 作成 "+category, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+				return item.Category == category
+			})).Return(&entity.Item{ID: 1, Category: category}, nil)
+
+			_, err := NewItemUsecase(mockRepo).CreateItem(context.Background(), CreateItemInput{
+				Name: "アイテム", Category: category, Brand: "ブランド", PurchasePrice: 100, PurchaseDate: "2023-01-15",
+			})
+
+			assert.NoError(t, err)
 			mockRepo.AssertExpectations(t)
 		})
 	}
+
+	t.Run("異常系: 作成 一覧にないカテゴリー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).CreateItem(context.Background(), CreateItemInput{
+			Name: "アイテム", Category: "wathc", Brand: "ブランド", PurchasePrice: 100, PurchaseDate: "2023-01-15",
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.EqualError(t, err, "category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 置き換え 一覧にないカテゴリー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		existing, _ := entity.NewItem("アイテム", "時計", "ブランド", 100, "2023-01-15")
+		existing.ID = 1
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+
+		name, category, brand, price, date := "アイテム", "wathc", "ブランド", 100, "2023-01-15"
+		_, err := NewItemUsecase(mockRepo).ReplaceItem(context.Background(), 1, ReplaceItemInput{
+			Name: &name, Category: &category, Brand: &brand, PurchasePrice: &price, PurchaseDate: &date,
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("正常系: カテゴリーを含まない部分更新は既存のカテゴリーのまま", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		existing, _ := entity.NewItem("アイテム", "バッグ", "ブランド", 100, "2023-01-15")
+		existing.ID = 1
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Category == "バッグ" && item.Name == "新しい名前"
+		})).Return(existing, nil)
+
+		name := "新しい名前"
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 追加したカテゴリーで作成できる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("FindCategories", mock.Anything).Return([]*entity.Category{{ID: 1, Name: "時計"}, {ID: 6, Name: "家具"}}, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, Category: "家具"}, nil)
+
+		_, err := NewItemUsecase(mockRepo, WithCategories(categoryRepo)).CreateItem(context.Background(), CreateItemInput{
+			Name: "テーブル", Category: "家具", Brand: "ブランド", PurchasePrice: 100, PurchaseDate: "2023-01-15",
+		})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 削除されたカテゴリーでは作成できない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("FindCategories", mock.Anything).Return([]*entity.Category{{ID: 1, Name: "時計"}, {ID: 6, Name: "家具"}}, nil)
+
+		_, err := NewItemUsecase(mockRepo, WithCategories(categoryRepo)).CreateItem(context.Background(), CreateItemInput{
+			Name: "バーキン", Category: "バッグ", Brand: "HERMÈS", PurchasePrice: 2000000, PurchaseDate: "2023-01-15",
+		})
+
+		assert.EqualError(t, err, "category must be one of: 時計, 家具")
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: カテゴリーの取得に失敗", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("FindCategories", mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := NewItemUsecase(mockRepo, WithCategories(categoryRepo)).CreateItem(context.Background(), CreateItemInput{
+			Name: "ロレックス", Category: "時計", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2023-01-15",
+		})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_Categories(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 保存先がない場合は初期のカテゴリー", func(t *testing.T) {
+		categories, err := NewItemUsecase(new(MockItemRepository)).GetCategories(ctx)
+
+		require.NoError(t, err)
+		require.Len(t, categories, len(entity.DefaultCategories))
+		assert.Equal(t, &entity.Category{ID: 1, Name: "時計"}, categories[0])
+	})
+
+	t.Run("異常系: 保存先がない場合は変更できない", func(t *testing.T) {
+		u := NewItemUsecase(new(MockItemRepository))
+
+		_, err := u.CreateCategory(ctx, CreateCategoryInput{Name: "家具"})
+		assert.Error(t, err)
+		assert.Error(t, u.DeleteCategory(ctx, 1))
+	})
+
+	t.Run("正常系: 名前を正規化して追加", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("CreateCategory", mock.Anything, &entity.Category{Name: "Furniture"}).Return(&entity.Category{ID: 6, Name: "Furniture"}, nil)
+
+		created, err := NewItemUsecase(new(MockItemRepository), WithCategories(categoryRepo)).CreateCategory(ctx, CreateCategoryInput{Name: " Ｆｕｒｎｉｔｕｒｅ "})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), created.ID)
+		categoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 追加 名前のバリデーション", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		u := NewItemUsecase(new(MockItemRepository), WithCategories(categoryRepo))
+
+		_, err := u.CreateCategory(ctx, CreateCategoryInput{Name: " "})
+		assert.EqualError(t, err, "name is required")
+		_, err = u.CreateCategory(ctx, CreateCategoryInput{Name: "時計,バッグ"})
+		assert.EqualError(t, err, `name must not contain ","`)
+		categoryRepo.AssertNotCalled(t, "CreateCategory", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 追加 重複", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("CreateCategory", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDuplicateCategory)
+
+		_, err := NewItemUsecase(new(MockItemRepository), WithCategories(categoryRepo)).CreateCategory(ctx, CreateCategoryInput{Name: "時計"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateCategory)
+	})
+
+	t.Run("正常系: 削除", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("DeleteCategory", mock.Anything, int64(6)).Return(nil)
+
+		err := NewItemUsecase(new(MockItemRepository), WithCategories(categoryRepo)).DeleteCategory(ctx, 6)
+
+		assert.NoError(t, err)
+		categoryRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 削除 アイテムが登録されている", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("DeleteCategory", mock.Anything, int64(1)).Return(domainErrors.ErrCategoryInUse)
+
+		err := NewItemUsecase(new(MockItemRepository), WithCategories(categoryRepo)).DeleteCategory(ctx, 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryInUse)
+	})
+
+	t.Run("異常系: 削除 存在しない・不正な ID", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("DeleteCategory", mock.Anything, int64(99)).Return(domainErrors.ErrCategoryNotFound)
+		u := NewItemUsecase(new(MockItemRepository), WithCategories(categoryRepo))
+
+		assert.ErrorIs(t, u.DeleteCategory(ctx, 99), domainErrors.ErrCategoryNotFound)
+		assert.ErrorIs(t, u.DeleteCategory(ctx, 0), domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("正常系: 集計は追加したカテゴリーも 0 件で含める", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("FindCategories", mock.Anything).Return([]*entity.Category{{ID: 1, Name: "時計"}, {ID: 6, Name: "家具"}}, nil)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(map[string]CategoryStats{"時計": {Count: 1, TotalPrice: 100}}, nil)
+
+		summary, err := NewItemUsecase(mockRepo, WithCategories(categoryRepo)).GetCategorySummary(ctx, nil, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]CategoryStats{"時計": {Count: 1, TotalPrice: 100}, "家具": {}}, summary.Categories)
+	})
+
+	t.Run("正常系: 名前の変更 キャッシュを削除して更新を通知", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("RenameCategory", mock.Anything, "時計", "腕時計").Return(&entity.Category{ID: 1, Name: "腕時計"}, []int64{1, 2}, nil)
+		mockRepo.On("FindByIDs", mock.Anything, []int64{1, 2}).Return([]*entity.Item{{ID: 1, Category: "腕時計"}}, nil)
+		cache := newFakeItemCache()
+		publisher := &fakeEventPublisher{}
+		u := NewItemUsecase(mockRepo, WithCategories(categoryRepo), WithItemCache(cache, time.Minute), WithEventPublisher(publisher))
+
+		result, err := u.RenameCategory(ctx, RenameCategoryInput{From: " 時計 ", To: "腕時計"})
+
+		require.NoError(t, err)
+		assert.Equal(t, &RenameCategoryResult{Category: &entity.Category{ID: 1, Name: "腕時計"}, UpdatedItems: 2}, result)
+		assert.Equal(t, []string{"items:1", "items:2"}, cache.deleted)
+		require.Len(t, publisher.events, 1)
+		assert.Equal(t, entity.ItemEventUpdated, publisher.events[0].Type)
+	})
+
+	t.Run("異常系: 名前の変更 バリデーション", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		u := NewItemUsecase(new(MockItemRepository), WithCategories(categoryRepo))
+
+		_, err := u.RenameCategory(ctx, RenameCategoryInput{From: " ", To: "時計,バッグ"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.EqualError(t, err, `from is required, to must not contain ","`)
+		categoryRepo.AssertNotCalled(t, "RenameCategory", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 名前の変更 既存のカテゴリーには統合しない・存在しない", func(t *testing.T) {
+		categoryRepo := new(MockCategoryRepository)
+		categoryRepo.On("RenameCategory", mock.Anything, "時計", "バッグ").Return(nil, nil, domainErrors.ErrDuplicateCategory)
+		categoryRepo.On("RenameCategory", mock.Anything, "家具", "インテリア").Return(nil, nil, domainErrors.ErrCategoryNotFound)
+		u := NewItemUsecase(new(MockItemRepository), WithCategories(categoryRepo))
+
+		_, err := u.RenameCategory(ctx, RenameCategoryInput{From: "時計", To: "バッグ"})
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateCategory)
+		_, err = u.RenameCategory(ctx, RenameCategoryInput{From: "家具", To: "インテリア"})
+		assert.ErrorIs(t, err, domainErrors.ErrCategoryNotFound)
+	})
+}
+
+func TestItemUsecase_PurchaseDateNotInFuture(t *testing.T) {
+	// サーバー時刻を 2024-03-10 15:00 に固定する
+	now := time.Date(2024, 3, 10, 15, 0, 0, 0, time.Local)
+	clock := WithClock(func() time.Time { return now })
+
+	tests := []struct {
+		name         string
+		purchaseDate string
+		wantErr      bool
+	}{
+		{name: "正常系: 過去の日付", purchaseDate: "2023-01-15", wantErr: false},
+		{name: "正常系: 今日の日付", purchaseDate: "2024-03-10", wantErr: false},
+		{name: "異常系: 未来の日付", purchaseDate: "2024-03-11", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run("作成: "+tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			if !tt.wantErr {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(&entity.Item{ID: 1}, nil)
+			}
+
+			_, err := NewItemUsecase(mockRepo, clock).CreateItem(context.Background(), CreateItemInput{
+				Name:          "ロレックス デイトナ",
+				Category:      "時計",
+				Brand:         "ROLEX",
+				PurchasePrice: 1500000,
+				PurchaseDate:  tt.purchaseDate,
+			})
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.EqualError(t, err, "purchase_date must not be in the future")
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+
+		t.Run("置き換え: "+tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			existing, _ := entity.NewItem("アイテム", "時計", "ブランド", 100, "2023-01-15")
+			existing.ID = 1
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+			if !tt.wantErr {
+				mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(existing, nil)
+			}
+
+			name, category, brand, price, date := "アイテム", "時計", "ブランド", 100, tt.purchaseDate
+			_, err := NewItemUsecase(mockRepo, clock).ReplaceItem(context.Background(), 1, ReplaceItemInput{
+				Name: &name, Category: &category, Brand: &brand, PurchasePrice: &price, PurchaseDate: &date,
+			})
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("異常系: ほかの違反と一緒に返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo, clock).CreateItem(context.Background(), CreateItemInput{
+			Name:          "",
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: 100,
+			PurchaseDate:  "2030-01-01",
+		})
+
+		verr, ok := domainErrors.AsValidationError(err)
+		require.True(t, ok)
+		assert.Equal(t, []domainErrors.FieldError{
+			{Field: "name", Code: domainErrors.CodeRequired, Message: "is required"},
+			{Field: "purchase_date", Code: domainErrors.CodeFutureDate, Message: "must not be in the future"},
+		}, verr.Fields)
+	})
+}
+
+func TestItemUsecase_NameMaxLength(t *testing.T) {
+	create := func(t *testing.T, u ItemUsecase, mockRepo *MockItemRepository, name string, wantErr bool) error {
+		t.Helper()
+		if !wantErr {
+			mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(&entity.Item{ID: 1}, nil)
+		}
+		_, err := u.CreateItem(context.Background(), CreateItemInput{
+			Name:          name,
+			Category:      "時計",
+			Brand:         "ROLEX",
+			PurchasePrice: 100,
+			PurchaseDate:  "2023-01-15",
+		})
+		return err
+	}
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		value   string
+		wantErr string
+	}{
+		{name: "正常系: ちょうど100文字", value: strings.Repeat("a", 100)},
+		{name: "正常系: マルチバイト文字でちょうど100文字", value: strings.Repeat("時", 100)},
+		{name: "異常系: 101文字", value: strings.Repeat("a", 101), wantErr: "name must be 100 characters or less"},
+		{name: "異常系: マルチバイト文字で101文字", value: strings.Repeat("時", 101), wantErr: "name must be 100 characters or less"},
+		{name: "異常系: 空", value: "", wantErr: "name is required"},
+		{name: "正常系: 上限を設定してちょうど上限", opts: []Option{WithMaxNameLength(10)}, value: strings.Repeat("時", 10)},
+		{name: "異常系: 上限を設定して1文字超過", opts: []Option{WithMaxNameLength(10)}, value: strings.Repeat("時", 11), wantErr: "name must be 10 characters or less"},
+		{name: "異常系: DB の上限より大きい値は100文字とする", opts: []Option{WithMaxNameLength(200)}, value: strings.Repeat("時", 101), wantErr: "name must be 100 characters or less"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			err := create(t, NewItemUsecase(mockRepo, tt.opts...), mockRepo, tt.value, tt.wantErr != "")
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.EqualError(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("異常系: 部分更新でも上限を超える名前は拒否", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		name := strings.Repeat("時", 11)
+
+		_, err := NewItemUsecase(mockRepo, WithMaxNameLength(10)).UpdateItem(context.Background(), 1, UpdateItemInput{Name: &name})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.EqualError(t, err, "name must be 10 characters or less")
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_Tags(t *testing.T) {
+	existing := func() *entity.Item {
+		return &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2023-01-15", Tags: []string{"gift"}, Version: 1}
+	}
+	withTags := func(tags ...string) interface{} {
+		return mock.MatchedBy(func(item *entity.Item) bool { return slices.Equal(item.Tags, tags) })
+	}
+
+	t.Run("正常系: 登録時にタグを設定", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, withTags("gift", "vintage")).Return(&entity.Item{ID: 1}, nil)
+
+		_, err := NewItemUsecase(mockRepo).CreateItem(context.Background(), CreateItemInput{
+			Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2023-01-15", Tags: []string{"Vintage", "gift", "gift"},
+		})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 部分更新でタグを置き換え・削除", func(t *testing.T) {
+		for _, tags := range [][]string{{"heirloom", "vintage"}, {}} {
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil)
+			mockRepo.On("Update", mock.Anything, withTags(tags...)).Return(&entity.Item{ID: 1}, nil)
+
+			_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{Tags: &tags})
+
+			require.NoError(t, err, tags)
+			mockRepo.AssertExpectations(t)
+		}
+	})
+
+	t.Run("正常系: 部分更新で省略したタグはそのまま", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil)
+		mockRepo.On("Update", mock.Anything, withTags("gift")).Return(&entity.Item{ID: 1}, nil)
+		price := 200
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{PurchasePrice: &price})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 上限を超えるタグは拒否", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil)
+		tags := []string{strings.Repeat("タ", 21)}
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{Tags: &tags})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.EqualError(t, err, "tags[0] must be 20 characters or less")
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_ImageURL(t *testing.T) {
+	input := func(imageURL string) CreateItemInput {
+		return CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2023-01-15", ImageURL: imageURL}
+	}
+	withImageURL := func(imageURL string) interface{} {
+		return mock.MatchedBy(func(item *entity.Item) bool { return item.ImageURL == imageURL })
+	}
+
+	tests := []struct {
+		name     string
+		imageURL string
+		expected string
+		wantErr  bool
+	}{
+		{name: "正常系: https の URL", imageURL: " https://example.com/images/1.jpg ", expected: "https://example.com/images/1.jpg"},
+		{name: "正常系: http の URL", imageURL: "http://example.com/1.png", expected: "http://example.com/1.png"},
+		{name: "正常系: 省略した場合は画像なし", imageURL: "", expected: ""},
+		{name: "異常系: http / https 以外のスキーム", imageURL: "ftp://example.com/1.jpg", wantErr: true},
+		{name: "異常系: javascript スキーム", imageURL: "javascript:alert(1)", wantErr: true},
+		{name: "異常系: ホストのない URL", imageURL: "https:///1.jpg", wantErr: true},
+		{name: "異常系: 相対パス", imageURL: "/images/1.jpg", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			if !tt.wantErr {
+				mockRepo.On("Create", mock.Anything, withImageURL(tt.expected)).Return(&entity.Item{ID: 1}, nil)
+			}
+
+			_, err := NewItemUsecase(mockRepo).CreateItem(context.Background(), input(tt.imageURL))
+
+			if tt.wantErr {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.EqualError(t, err, "image_url must be in http(s) URL format")
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("正常系: 部分更新で空文字を指定すると削除", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2023-01-15", ImageURL: "https://example.com/1.jpg", Version: 1}, nil)
+		mockRepo.On("Update", mock.Anything, withImageURL("")).Return(&entity.Item{ID: 1}, nil)
+		empty := ""
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{ImageURL: &empty})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 部分更新でも不正なスキームは拒否", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		imageURL := "file:///etc/passwd"
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{ImageURL: &imageURL})
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+// fakeImageStorage は保存した画像をメモリに保持する
+type fakeImageStorage struct {
+	key         string
+	contentType string
+	body        []byte
+	err         error
+}
+
+func (f *fakeImageStorage) Save(ctx context.Context, key, contentType string, body io.Reader) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", err
+	}
+	f.key, f.contentType, f.body = key, contentType, data
+	return "https://images.example.com/" + key, nil
+}
+
+func TestItemUsecase_UploadItemImage(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := WithClock(func() time.Time { return now })
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR\x00\x00\x00\x01\x00\x00\x00\x01")
+	upload := func(body []byte) ImageUpload {
+		return ImageUpload{Size: int64(len(body)), Body: bytes.NewReader(body)}
+	}
+
+	t.Run("正常系: 画像を保存して image_url を更新", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Exists", mock.Anything, int64(1)).Return(true, nil)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2023-01-15", Version: 1}, nil)
+		expectedURL := fmt.Sprintf("https://images.example.com/items/1/%d.png", now.UnixNano())
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool { return item.ImageURL == expectedURL })).Return(&entity.Item{ID: 1, ImageURL: expectedURL, Version: 2}, nil)
+		storage := &fakeImageStorage{}
+
+		item, err := NewItemUsecase(mockRepo, clock, WithImageStorage(storage)).UploadItemImage(ctx, 1, upload(png))
+
+		require.NoError(t, err)
+		assert.Equal(t, expectedURL, item.ImageURL)
+		assert.Equal(t, "image/png", storage.contentType)
+		// 形式の判定に読んだ先頭のバイト列も含めて保存する
+		assert.Equal(t, png, storage.body)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 最大サイズを超える画像", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		storage := &fakeImageStorage{}
+
+		_, err := NewItemUsecase(mockRepo, WithImageStorage(storage), WithMaxImageSize(10)).UploadItemImage(ctx, 1, upload(png))
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.EqualError(t, err, "image must be 10 bytes or less")
+		assert.Empty(t, storage.key)
+		mockRepo.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 画像以外のファイル", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		storage := &fakeImageStorage{}
+
+		_, err := NewItemUsecase(mockRepo, WithImageStorage(storage)).UploadItemImage(ctx, 1, upload([]byte("<html><body>not an image</body></html>")))
+
+		var verr *domainErrors.ValidationError
+		require.ErrorAs(t, err, &verr)
+		assert.Equal(t, domainErrors.CodeUnsupportedValue, verr.Fields[0].Code)
+		assert.Empty(t, storage.key)
+		mockRepo.AssertNotCalled(t, "Exists", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Exists", mock.Anything, int64(999)).Return(false, nil)
+		storage := &fakeImageStorage{}
+
+		_, err := NewItemUsecase(mockRepo, WithImageStorage(storage)).UploadItemImage(ctx, 999, upload(png))
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Empty(t, storage.key)
+	})
+
+	t.Run("異常系: 保存に失敗した場合は更新しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Exists", mock.Anything, int64(1)).Return(true, nil)
+		storage := &fakeImageStorage{err: errors.New("disk full")}
+
+		_, err := NewItemUsecase(mockRepo, WithImageStorage(storage)).UploadItemImage(ctx, 1, upload(png))
+
+		assert.ErrorContains(t, err, "disk full")
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_Notes(t *testing.T) {
+	input := func(notes string) CreateItemInput {
+		return CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2023-01-15", Notes: notes}
+	}
+	existing := func() *entity.Item {
+		return &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 100, PurchaseDate: "2023-01-15", Notes: "正規店で購入", Version: 1}
+	}
+	withNotes := func(notes string) interface{} {
+		return mock.MatchedBy(func(item *entity.Item) bool { return item.Notes == notes })
+	}
+
+	t.Run("正常系: 登録時に前後の空白を除いて設定", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, withNotes("正規店で購入\n保証書あり")).Return(&entity.Item{ID: 1}, nil)
+
+		_, err := NewItemUsecase(mockRepo).CreateItem(context.Background(), input(" 正規店で購入\n保証書あり\n"))
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 部分更新で変更・削除", func(t *testing.T) {
+		for notes, expected := range map[string]string{"並行輸入品": "並行輸入品", "": "", "  ": ""} {
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil)
+			mockRepo.On("Update", mock.Anything, withNotes(expected)).Return(&entity.Item{ID: 1}, nil)
+
+			_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{Notes: &notes})
+
+			require.NoError(t, err, notes)
+			mockRepo.AssertExpectations(t)
+		}
+	})
+
+	t.Run("正常系: 部分更新で省略したメモはそのまま", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing(), nil)
+		mockRepo.On("Update", mock.Anything, withNotes("正規店で購入")).Return(&entity.Item{ID: 1}, nil)
+		price := 200
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{PurchasePrice: &price})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name    string
+		opts    []Option
+		value   string
+		wantErr string
+	}{
+		{name: "正常系: 既定はちょうど1000文字まで", value: strings.Repeat("メ", 1000)},
+		{name: "異常系: 既定で1001文字", value: strings.Repeat("メ", 1001), wantErr: "notes must be 1000 characters or less"},
+		{name: "異常系: 上限を設定して1文字超過", opts: []Option{WithMaxNotesLength(10)}, value: strings.Repeat("メ", 11), wantErr: "notes must be 10 characters or less"},
+		{name: "異常系: entity の上限より大きい値は10000文字とする", opts: []Option{WithMaxNotesLength(20000)}, value: strings.Repeat("メ", 10001), wantErr: "notes must be 10000 characters or less"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			if tt.wantErr == "" {
+				mockRepo.On("Create", mock.Anything, mock.AnythingOfType("*entity.Item")).Return(&entity.Item{ID: 1}, nil)
+			}
+
+			_, err := NewItemUsecase(mockRepo, tt.opts...).CreateItem(context.Background(), input(tt.value))
+
+			if tt.wantErr != "" {
+				assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+				assert.EqualError(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("異常系: 部分更新でも上限を超えるメモは拒否", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		notes := strings.Repeat("メ", 11)
+
+		_, err := NewItemUsecase(mockRepo, WithMaxNotesLength(10)).UpdateItem(context.Background(), 1, UpdateItemInput{Notes: &notes})
+
+		assert.EqualError(t, err, "notes must be 10 characters or less")
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_ReplaceItem(t *testing.T) {
+	strPtr := func(v string) *string { return &v }
+	intPtr := func(v int) *int { return &v }
+	fullInput := ReplaceItemInput{
+		Name:          strPtr("エルメス バーキン"),
+		Category:      strPtr("バッグ"),
+		Brand:         strPtr("HERMÈS"),
+		PurchasePrice: intPtr(2000000),
+		PurchaseDate:  strPtr("2023-02-20"),
+	}
+
+	t.Run("正常系: すべてのフィールドを置き換える", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		existing, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		existing.ID = 1
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Name == "エルメス バーキン" && item.Category == "バッグ" && item.Brand == "HERMÈS" &&
+				item.PurchasePrice == 2000000 && item.PurchaseDate == "2023-02-20"
+		})).Return(existing, nil)
+
+		_, err := NewItemUsecase(mockRepo).ReplaceItem(context.Background(), 1, fullInput)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: フィールドが不足", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		input := fullInput
+		input.PurchaseDate = nil
+
+		_, err := NewItemUsecase(mockRepo).ReplaceItem(context.Background(), 1, input)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := NewItemUsecase(mockRepo).ReplaceItem(context.Background(), 999, fullInput)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 無効なカテゴリー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		existing, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(existing, nil)
+		input := fullInput
+		input.Category = strPtr("無効なカテゴリー")
+
+		_, err := NewItemUsecase(mockRepo).ReplaceItem(context.Background(), 1, input)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_UpdateItemVersion(t *testing.T) {
+	strPtr := func(v string) *string { return &v }
+	intPtr := func(v int) *int { return &v }
+
+	newExisting := func() *entity.Item {
+		existing, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		existing.ID = 1
+		existing.Version = 3
+		return existing
+	}
+
+	t.Run("正常系: 一致するバージョンで更新", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(), nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Version == 3 && item.Name == "時計2"
+		})).Return(&entity.Item{ID: 1, Name: "時計2", Version: 4}, nil)
+
+		updated, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{Name: strPtr("時計2"), Version: intPtr(3)})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, updated.Version)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 古いバージョンは競合", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(), nil)
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{Name: strPtr("時計2"), Version: intPtr(2)})
+
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 取得後に他の更新が入った場合は競合", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrVersionConflict)
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{Name: strPtr("時計2")})
+
+		assert.ErrorIs(t, err, domainErrors.ErrVersionConflict)
+	})
+
+	t.Run("正常系: バージョン未指定は取得時点のバージョンで更新", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(), nil)
+		mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.Version == 3
+		})).Return(&entity.Item{ID: 1, Version: 4}, nil)
+
+		_, err := NewItemUsecase(mockRepo).UpdateItem(context.Background(), 1, UpdateItemInput{Brand: strPtr("OMEGA")})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_DeleteItem(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          int64
+		setupMock   func(*MockItemRepository)
+		expectError bool
+		expectedErr error
+	}{
+		{
+			name: "正常系: 存在するアイテムを削除",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item.ID = 1
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+			},
+			expectError: false,
+		},
+		{
+			name: "異常系: 存在しないアイテム",
+			id:   999,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(999)).Return((*entity.Item)(nil), domainErrors.ErrItemNotFound)
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrItemNotFound,
+		},
+		{
+			name: "異常系: 無効なID（0以下）",
+			id:   0,
+			setupMock: func(mockRepo *MockItemRepository) {
+				// FindByIDは呼ばれない
+			},
+			expectError: true,
+			expectedErr: domainErrors.ErrInvalidInput,
+		},
+		{
+			name: "異常系: FindByIDでデータベースエラー",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return((*entity.Item)(nil), domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+		{
+			name: "異常系: Deleteでデータベースエラー",
+			id:   1,
+			setupMock: func(mockRepo *MockItemRepository) {
+				item, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+				item.ID = 1
+				mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+				mockRepo.On("Delete", mock.Anything, int64(1)).Return(domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			ctx := context.Background()
+			err := usecase.DeleteItem(ctx, tt.id)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_RestoreItem(t *testing.T) {
+	t.Run("正常系: 論理削除されたアイテムを復元", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		item := &entity.Item{ID: 1, Name: "時計1"}
+		mockRepo.On("Restore", mock.Anything, int64(1)).Return(nil)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+
+		restored, err := NewItemUsecase(mockRepo).RestoreItem(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), restored.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 削除されていないアイテムは何もしない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		item := &entity.Item{ID: 1, Name: "時計1"}
+		mockRepo.On("Restore", mock.Anything, int64(1)).Return(domainErrors.ErrItemNotFound)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+
+		restored, err := NewItemUsecase(mockRepo).RestoreItem(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), restored.ID)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Restore", mock.Anything, int64(999)).Return(domainErrors.ErrItemNotFound)
+		mockRepo.On("FindByID", mock.Anything, int64(999)).Return(nil, domainErrors.ErrItemNotFound)
+
+		_, err := NewItemUsecase(mockRepo).RestoreItem(context.Background(), 999)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Restore", mock.Anything, int64(1)).Return(domainErrors.ErrDatabaseError)
+
+		_, err := NewItemUsecase(mockRepo).RestoreItem(context.Background(), 1)
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_DeleteItems(t *testing.T) {
+	t.Run("正常系: すべて削除", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("DeleteItems", mock.Anything, []int64{1, 2}).Return([]int64{1, 2}, nil)
+
+		result, err := NewItemUsecase(mockRepo).DeleteItems(context.Background(), []int64{1, 2, 1})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, result.Deleted)
+		assert.Empty(t, result.NotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 一部が存在しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("DeleteItems", mock.Anything, []int64{1, 999, 2}).Return([]int64{1, 2}, nil)
+
+		result, err := NewItemUsecase(mockRepo).DeleteItems(context.Background(), []int64{1, 999, 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{1, 2}, result.Deleted)
+		assert.Equal(t, []int64{999}, result.NotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: すべて存在しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("DeleteItems", mock.Anything, []int64{998, 999}).Return([]int64{}, nil)
+
+		_, err := NewItemUsecase(mockRepo).DeleteItems(context.Background(), []int64{998, 999})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 空のリスト", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).DeleteItems(context.Background(), nil)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "DeleteItems", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 上限を超えるリスト", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		ids := make([]int64, MaxBulkDeleteIDs+1)
+		for i := range ids {
+			ids[i] = int64(i + 1)
+		}
+
+		_, err := NewItemUsecase(mockRepo).DeleteItems(context.Background(), ids)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "DeleteItems", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_MoveItems(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("正常系: 一部が存在しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("MoveItems", mock.Anything, []int64{1, 999, 2}, "バッグ").Return([]int64{1, 2}, nil)
+		cache := newFakeItemCache()
+		u := NewItemUsecase(mockRepo, WithItemCache(cache, time.Minute))
+
+		result, err := u.MoveItems(ctx, MoveItemsInput{IDs: []int64{1, 999, 2, 1}, TargetCategory: " バッグ "})
+
+		require.NoError(t, err)
+		assert.Equal(t, &BulkMoveResult{Moved: []int64{1, 2}, NotFound: []int64{999}}, result)
+		assert.Equal(t, []string{"items:1", "items:999", "items:2"}, cache.deleted)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: すべて存在しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("MoveItems", mock.Anything, []int64{998, 999}, "バッグ").Return([]int64{}, nil)
+
+		_, err := NewItemUsecase(mockRepo).MoveItems(ctx, MoveItemsInput{IDs: []int64{998, 999}, TargetCategory: "バッグ"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+
+	t.Run("異常系: 登録されていないカテゴリー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		u := NewItemUsecase(mockRepo)
+
+		_, err := u.MoveItems(ctx, MoveItemsInput{IDs: []int64{1}, TargetCategory: "家具"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		assert.EqualError(t, err, "target_category must be one of: 時計, バッグ, ジュエリー, 靴, その他")
+		_, err = u.MoveItems(ctx, MoveItemsInput{IDs: []int64{1}})
+		assert.EqualError(t, err, "target_category is required")
+		_, err = u.MoveItems(ctx, MoveItemsInput{TargetCategory: "バッグ"})
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "MoveItems", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 変更先で名前が重複する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("MoveItems", mock.Anything, []int64{1, 2}, "バッグ").Return(nil, domainErrors.ErrDuplicateItem)
+
+		_, err := NewItemUsecase(mockRepo).MoveItems(ctx, MoveItemsInput{IDs: []int64{1, 2}, TargetCategory: "バッグ"})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDuplicateItem)
+	})
+}
+
+func TestItemUsecase_GetCategorySummary(t *testing.T) {
+	tests := []struct {
+		name               string
+		setupMock          func(*MockItemRepository)
+		expectedTotal      int
+		expectedWatchCount int
+		expectedBagCount   int
+		expectedWatchPrice int
+		expectedBagPrice   int
+		expectError        bool
+	}{
+		{
+			name: "正常系: 複数カテゴリーのアイテムがある場合",
+			setupMock: func(mockRepo *MockItemRepository) {
+				summary := map[string]CategoryStats{
+					"時計":  {Count: 2, TotalPrice: 2500000},
+					"バッグ": {Count: 1, TotalPrice: 2000000},
+				}
+				mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(summary, nil)
+			},
+			expectedTotal:      3,
+			expectedWatchCount: 2,
+			expectedBagCount:   1,
+			expectedWatchPrice: 2500000,
+			expectedBagPrice:   2000000,
+			expectError:        false,
+		},
+		{
+			name: "正常系: アイテムが0件の場合",
+			setupMock: func(mockRepo *MockItemRepository) {
+				summary := map[string]CategoryStats{}
+				mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(summary, nil)
+			},
+			expectedTotal:      0,
+			expectedWatchCount: 0,
+			expectedBagCount:   0,
+			expectError:        false,
+		},
+		{
+			name: "異常系: データベースエラー",
+			setupMock: func(mockRepo *MockItemRepository) {
+				mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return((map[string]CategoryStats)(nil), domainErrors.ErrDatabaseError)
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			tt.setupMock(mockRepo)
+			usecase := NewItemUsecase(mockRepo)
+
+			ctx := context.Background()
+			summary, err := usecase.GetCategorySummary(ctx, nil, nil)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, summary)
+				mockRepo.AssertExpectations(t)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, summary)
+
+			assert.Equal(t, tt.expectedTotal, summary.Total)
+			assert.Equal(t, tt.expectedWatchCount, summary.Categories["時計"].Count)
+			assert.Equal(t, tt.expectedBagCount, summary.Categories["バッグ"].Count)
+			assert.Equal(t, tt.expectedWatchPrice, summary.Categories["時計"].TotalPrice)
+			assert.Equal(t, tt.expectedBagPrice, summary.Categories["バッグ"].TotalPrice)
+			assert.Equal(t, CategoryStats{}, summary.Categories["靴"])
+
+			// すべてのカテゴリーがレスポンスに含まれているかチェック
+			expectedCategories := []string{"時計", "バッグ", "ジュエリー", "靴", "その他"}
+			for _, category := range expectedCategories {
+				assert.Contains(t, summary.Categories, category)
+			}
+
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestItemUsecase_SummaryCache(t *testing.T) {
+	stats := map[string]CategoryStats{"時計": {Count: 1, TotalPrice: 1500000}}
+
+	newCachedUsecase := func(mockRepo *MockItemRepository, now *time.Time) ItemUsecase {
+		return NewItemUsecase(mockRepo,
+			WithSummaryCache(30*time.Second),
+			WithClock(func() time.Time { return *now }),
+		)
+	}
+
+	t.Run("正常系: TTL 内の 2 回目はリポジトリを呼ばない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(stats, nil)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		u := newCachedUsecase(mockRepo, &now)
+
+		first, err := u.GetCategorySummary(context.Background(), nil, nil)
+		require.NoError(t, err)
+		now = now.Add(29 * time.Second)
+		second, err := u.GetCategorySummary(context.Background(), nil, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, first, second)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 1)
+	})
+
+	t.Run("正常系: TTL を過ぎると集計し直す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(stats, nil)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		u := newCachedUsecase(mockRepo, &now)
+
+		_, err := u.GetCategorySummary(context.Background(), nil, nil)
+		require.NoError(t, err)
+		now = now.Add(30 * time.Second)
+		_, err = u.GetCategorySummary(context.Background(), nil, nil)
+		require.NoError(t, err)
+
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 2)
+	})
+
+	t.Run("正常系: 購入日の範囲ごとにキャッシュする", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(stats, nil)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{PurchasedAfter: &from}).Return(map[string]CategoryStats{}, nil)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		u := newCachedUsecase(mockRepo, &now)
+
+		all, err := u.GetCategorySummary(context.Background(), nil, nil)
+		require.NoError(t, err)
+		ranged, err := u.GetCategorySummary(context.Background(), &from, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, all.TotalCount)
+		assert.Equal(t, 0, ranged.TotalCount)
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 2)
+	})
+
+	t.Run("正常系: 削除するとキャッシュを破棄する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(stats, nil).Once()
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(map[string]CategoryStats{}, nil).Once()
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		u := newCachedUsecase(mockRepo, &now)
+
+		before, err := u.GetCategorySummary(context.Background(), nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, u.DeleteItem(context.Background(), 1))
+		after, err := u.GetCategorySummary(context.Background(), nil, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, before.TotalCount)
+		assert.Equal(t, 0, after.TotalCount)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 作成・更新でもキャッシュを破棄する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(stats, nil)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 2}, nil)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-15"}, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		u := newCachedUsecase(mockRepo, &now)
+		ctx := context.Background()
+
+		_, err := u.GetCategorySummary(ctx, nil, nil)
+		require.NoError(t, err)
+		_, err = u.CreateItem(ctx, CreateItemInput{Name: "時計2", Category: "時計", Brand: "ROLEX", PurchasePrice: 1, PurchaseDate: "2023-01-15"})
+		require.NoError(t, err)
+		_, err = u.GetCategorySummary(ctx, nil, nil)
+		require.NoError(t, err)
+		price := 100
+		_, err = u.UpdateItem(ctx, 1, UpdateItemInput{PurchasePrice: &price})
+		require.NoError(t, err)
+		_, err = u.GetCategorySummary(ctx, nil, nil)
+		require.NoError(t, err)
+
+		mockRepo.AssertNumberOfCalls(t, "GetSummaryByCategory", 3)
+	})
+
+	t.Run("正常系: 返した集計を変更してもキャッシュに影響しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(stats, nil)
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		u := newCachedUsecase(mockRepo, &now)
+
+		first, err := u.GetCategorySummary(context.Background(), nil, nil)
+		require.NoError(t, err)
+		first.Categories["時計"] = CategoryStats{}
+		second, err := u.GetCategorySummary(context.Background(), nil, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, CategoryStats{Count: 1, TotalPrice: 1500000}, second.Categories["時計"])
+	})
+
+	t.Run("正常系: 並行して読み出せる", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(stats, nil)
+		u := NewItemUsecase(mockRepo, WithSummaryCache(30*time.Second))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				summary, err := u.GetCategorySummary(context.Background(), nil, nil)
+				assert.NoError(t, err)
+				assert.Equal(t, 1, summary.TotalCount)
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("異常系: エラーはキャッシュしない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return((map[string]CategoryStats)(nil), domainErrors.ErrDatabaseError).Once()
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{}).Return(stats, nil).Once()
+		now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		u := newCachedUsecase(mockRepo, &now)
+
+		_, err := u.GetCategorySummary(context.Background(), nil, nil)
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		summary, err := u.GetCategorySummary(context.Background(), nil, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, summary.TotalCount)
+	})
+}
+
+func TestItemUsecase_GetAveragePriceAnalytics(t *testing.T) {
+	t.Run("正常系: アイテムのないカテゴリーは平均が nil", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		average := 1150000.0
+		mockRepo.On("GetAveragePriceByCategory", mock.Anything, ItemFilter{}).
+			Return(map[string]CategoryAveragePrice{"時計": {Count: 2, AveragePrice: &average}}, nil)
+
+		analytics, err := NewItemUsecase(mockRepo).GetAveragePriceAnalytics(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, analytics.Categories, len(entity.DefaultCategories))
+		assert.Equal(t, CategoryAveragePrice{Count: 2, AveragePrice: &average}, analytics.Categories["時計"])
+		assert.Equal(t, CategoryAveragePrice{}, analytics.Categories["靴"])
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: WithUserScope の場合は呼び出したユーザーで絞り込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		subject := "alice"
+		mockRepo.On("GetAveragePriceByCategory", mock.Anything, ItemFilter{UserID: &subject}).
+			Return(map[string]CategoryAveragePrice{}, nil)
+
+		_, err := NewItemUsecase(mockRepo, WithUserScope()).GetAveragePriceAnalytics(ContextWithSubject(context.Background(), subject))
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: WithUserScope で認証されていない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo, WithUserScope()).GetAveragePriceAnalytics(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+		mockRepo.AssertNotCalled(t, "GetAveragePriceByCategory", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetAveragePriceByCategory", mock.Anything, ItemFilter{}).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := NewItemUsecase(mockRepo).GetAveragePriceAnalytics(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_GetPriceRangeAnalytics(t *testing.T) {
+	t.Run("正常系: アイテムのないカテゴリーは最低・最高が nil", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		minPrice, maxPrice := 800000, 1500000
+		mockRepo.On("GetPriceRangeByCategory", mock.Anything, ItemFilter{}).
+			Return(map[string]CategoryPriceRange{"時計": {Count: 2, MinPrice: &minPrice, MaxPrice: &maxPrice}}, nil)
+
+		analytics, err := NewItemUsecase(mockRepo).GetPriceRangeAnalytics(context.Background())
+
+		require.NoError(t, err)
+		assert.Len(t, analytics.Categories, len(entity.DefaultCategories))
+		assert.Equal(t, CategoryPriceRange{Count: 2, MinPrice: &minPrice, MaxPrice: &maxPrice}, analytics.Categories["時計"])
+		assert.Equal(t, CategoryPriceRange{}, analytics.Categories["靴"])
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: WithUserScope の場合は呼び出したユーザーで絞り込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		subject := "alice"
+		mockRepo.On("GetPriceRangeByCategory", mock.Anything, ItemFilter{UserID: &subject}).
+			Return(map[string]CategoryPriceRange{}, nil)
+
+		_, err := NewItemUsecase(mockRepo, WithUserScope()).GetPriceRangeAnalytics(ContextWithSubject(context.Background(), subject))
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetPriceRangeByCategory", mock.Anything, ItemFilter{}).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := NewItemUsecase(mockRepo).GetPriceRangeAnalytics(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_GetTimelineAnalytics(t *testing.T) {
+	t.Run("正常系: 購入月ごとの集計を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		timeline := []MonthlyStats{{Month: "2023-01", Count: 2, TotalValue: 2300000}, {Month: "2023-02", Count: 1, TotalValue: 2000000}}
+		mockRepo.On("GetMonthlyTimeline", mock.Anything, ItemFilter{}).Return(timeline, nil)
+
+		actual, err := NewItemUsecase(mockRepo).GetTimelineAnalytics(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, timeline, actual)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: WithUserScope の場合は呼び出したユーザーで絞り込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		subject := "alice"
+		mockRepo.On("GetMonthlyTimeline", mock.Anything, ItemFilter{UserID: &subject}).Return([]MonthlyStats{}, nil)
+
+		_, err := NewItemUsecase(mockRepo, WithUserScope()).GetTimelineAnalytics(ContextWithSubject(context.Background(), subject))
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: データベースエラー", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetMonthlyTimeline", mock.Anything, ItemFilter{}).Return(nil, domainErrors.ErrDatabaseError)
+
+		_, err := NewItemUsecase(mockRepo).GetTimelineAnalytics(context.Background())
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestItemUsecase_GetCategorySummaryWithDateRange(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	t.Run("正常系: 購入日の範囲をフィルターとして渡す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{PurchasedAfter: &from, PurchasedBefore: &to}).
+			Return(map[string]CategoryStats{"時計": {Count: 1, TotalPrice: 1500000}}, nil)
+
+		summary, err := NewItemUsecase(mockRepo).GetCategorySummary(context.Background(), &from, &to)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, summary.Total)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 片側のみの範囲", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{PurchasedBefore: &to}).
+			Return(map[string]CategoryStats{}, nil)
+
+		_, err := NewItemUsecase(mockRepo).GetCategorySummary(context.Background(), nil, &to)
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 範囲が逆転している", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+
+		_, err := NewItemUsecase(mockRepo).GetCategorySummary(context.Background(), &to, &from)
+
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "GetSummaryByCategory", mock.Anything, mock.Anything)
+	})
+}
+
+func TestItemUsecase_GetCategorySummaryTotals(t *testing.T) {
+	from := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		from  *time.Time
+		stats map[string]CategoryStats
+	}{
+		{
+			name: "正常系: 合計がカテゴリー別の集計の和と一致する",
+			stats: map[string]CategoryStats{
+				"時計":    {Count: 2, TotalPrice: 2500000},
+				"バッグ":   {Count: 1, TotalPrice: 2000000},
+				"ジュエリー": {Count: 3, TotalPrice: 900000},
+			},
+		},
+		{
+			name: "正常系: フィルター適用時も内訳と一致する",
+			from: &from,
+			stats: map[string]CategoryStats{
+				"靴": {Count: 1, TotalPrice: 150000},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockItemRepository)
+			mockRepo.On("GetSummaryByCategory", mock.Anything, ItemFilter{PurchasedAfter: tt.from}).Return(tt.stats, nil)
+
+			summary, err := NewItemUsecase(mockRepo).GetCategorySummary(context.Background(), tt.from, nil)
+			require.NoError(t, err)
+
+			sumCount, sumValue := 0, 0
+			for _, stats := range summary.Categories {
+				sumCount += stats.Count
+				sumValue += stats.TotalPrice
+			}
+			assert.Equal(t, sumCount, summary.TotalCount)
+			assert.Equal(t, sumValue, summary.TotalValue)
+			assert.Equal(t, summary.TotalCount, summary.Total)
+		})
+	}
+}
+
+type fakeItemMetrics struct {
+	created int
+	deleted int
+}
+
+func (m *fakeItemMetrics) ItemsCreated(n int) { m.created += n }
+func (m *fakeItemMetrics) ItemsDeleted(n int) { m.deleted += n }
+
+func TestItemUsecase_Metrics(t *testing.T) {
+	t.Run("正常系: 作成・一括作成で作成件数を記録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		metrics := &fakeItemMetrics{}
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+		mockRepo.On("CreateItems", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 2}, {ID: 3}}, nil)
+		uc := NewItemUsecase(mockRepo, WithMetrics(metrics))
+		input := CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"}
+
+		_, err := uc.CreateItem(context.Background(), input)
+		require.NoError(t, err)
+		_, err = uc.CreateItems(context.Background(), []CreateItemInput{input, input})
+		require.NoError(t, err)
+
+		assert.Equal(t, 3, metrics.created)
+	})
+
+	t.Run("正常系: 削除・一括削除で削除件数を記録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		metrics := &fakeItemMetrics{}
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		mockRepo.On("DeleteItems", mock.Anything, []int64{2, 3}).Return([]int64{2}, nil)
+		uc := NewItemUsecase(mockRepo, WithMetrics(metrics))
+
+		require.NoError(t, uc.DeleteItem(context.Background(), 1))
+		_, err := uc.DeleteItems(context.Background(), []int64{2, 3})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, metrics.deleted)
+	})
+
+	t.Run("異常系: 失敗した操作は記録しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		metrics := &fakeItemMetrics{}
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+		uc := NewItemUsecase(mockRepo, WithMetrics(metrics))
+
+		_, err := uc.CreateItem(context.Background(), CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"})
+		require.Error(t, err)
+
+		assert.Equal(t, 0, metrics.created)
+	})
+}
+
+// fakeTransactor は fn の結果からコミット・ロールバックのどちらになったかを記録する
+type fakeTransactor struct {
+	committed  int
+	rolledBack int
+}
+
+func (f *fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := fn(ctx); err != nil {
+		f.rolledBack++
+		return err
+	}
+	f.committed++
+	return nil
+}
+
+type fakeAuditRepository struct {
+	entries []*entity.AuditEntry
+	err     error
+}
+
+func (f *fakeAuditRepository) CreateAudit(ctx context.Context, entry *entity.AuditEntry) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+func TestItemUsecase_CreateItemAudit(t *testing.T) {
+	input := CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"}
+	ctx := ContextWithSubject(ContextWithRequestID(context.Background(), "req-1"), "user-1")
+
+	t.Run("正常系: 作成したアイテムの監査ログを同じトランザクションで記録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+		mockRepo.On("CreateItems", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 2}, {ID: 3}}, nil)
+		transactor := &fakeTransactor{}
+		audits := &fakeAuditRepository{}
+		uc := NewItemUsecase(mockRepo, WithAudit(transactor, audits))
+
+		_, err := uc.CreateItem(ctx, input)
+		require.NoError(t, err)
+		_, err = uc.CreateItems(ctx, []CreateItemInput{input, input})
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, transactor.committed)
+		require.Len(t, audits.entries, 3)
+		assert.Equal(t, &entity.AuditEntry{ItemID: 1, Action: entity.AuditActionCreate, Actor: "user-1", RequestID: "req-1"}, audits.entries[0])
+		assert.Equal(t, int64(3), audits.entries[2].ItemID)
+	})
+
+	t.Run("異常系: 監査ログの記録に失敗した場合はロールバックしてエラーを返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		metrics := &fakeItemMetrics{}
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+		transactor := &fakeTransactor{}
+		uc := NewItemUsecase(mockRepo, WithMetrics(metrics), WithAudit(transactor, &fakeAuditRepository{err: domainErrors.ErrDatabaseError}))
+
+		item, err := uc.CreateItem(ctx, input)
+
+		assert.Nil(t, item)
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, 1, transactor.rolledBack)
+		assert.Equal(t, 0, metrics.created)
+	})
+
+	t.Run("正常系: 長いリクエスト ID はカラム長に切り詰める", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+		audits := &fakeAuditRepository{}
+		uc := NewItemUsecase(mockRepo, WithAudit(&fakeTransactor{}, audits))
+
+		_, err := uc.CreateItem(ContextWithRequestID(context.Background(), strings.Repeat("あ", 300)), input)
+		require.NoError(t, err)
+
+		assert.Equal(t, strings.Repeat("あ", entity.MaxAuditFieldLength), audits.entries[0].RequestID)
+	})
+}
+
+func TestItemUsecase_UserScope(t *testing.T) {
+	alice := ContextWithSubject(context.Background(), "alice")
+	bob := ContextWithSubject(context.Background(), "bob")
+	aliceItem := func() *entity.Item {
+		return &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", Version: 1, UserID: "alice"}
+	}
+
+	t.Run("正常系: 登録したユーザーを設定する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(item *entity.Item) bool {
+			return item.UserID == "alice"
+		})).Return(aliceItem(), nil)
+
+		_, err := NewItemUsecase(mockRepo, WithUserScope()).CreateItem(alice, CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"})
+
+		require.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: 一覧は呼び出したユーザーのアイテムに絞り込む", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		userID := "bob"
+		mockRepo.On("FindAll", mock.Anything, ItemFilter{UserID: &userID}).Return([]*entity.Item{}, nil)
+
+		items, err := NewItemUsecase(mockRepo, WithUserScope()).GetAllItems(bob, ItemFilter{})
+
+		require.NoError(t, err)
+		assert.Empty(t, items)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテムの取得・更新・削除は存在しないものとする", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(aliceItem(), nil)
+		uc := NewItemUsecase(mockRepo, WithUserScope())
+		name := "時計2"
+
+		_, err := uc.GetItemByID(bob, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		_, err = uc.UpdateItem(bob, 1, UpdateItemInput{Name: &name})
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		err = uc.DeleteItem(bob, 1)
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+
+		item, err := uc.GetItemByID(alice, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "alice", item.UserID)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("異常系: 他のユーザーのアイテムは一括削除しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByIDs", mock.Anything, []int64{1, 2}).Return([]*entity.Item{aliceItem(), {ID: 2, UserID: "bob"}}, nil)
+		mockRepo.On("DeleteItems", mock.Anything, []int64{2}).Return([]int64{2}, nil)
+
+		result, err := NewItemUsecase(mockRepo, WithUserScope()).DeleteItems(bob, []int64{1, 2})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{2}, result.Deleted)
+		assert.Equal(t, []int64{1}, result.NotFound)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("異常系: 認証されていない呼び出し", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		uc := NewItemUsecase(mockRepo, WithUserScope())
+
+		_, err := uc.GetAllItems(context.Background(), ItemFilter{})
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+		_, err = uc.GetItemByID(context.Background(), 1)
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+		_, err = uc.CreateItem(context.Background(), CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"})
+		assert.ErrorIs(t, err, domainErrors.ErrUnauthenticated)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("正常系: WithUserScope を設定しない場合は絞り込まない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(aliceItem(), nil)
+
+		item, err := NewItemUsecase(mockRepo).GetItemByID(bob, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), item.ID)
+	})
+}
+
+func TestRequestIDFromContext(t *testing.T) {
+	assert.Equal(t, "", RequestIDFromContext(context.Background()))
+	assert.Equal(t, "req-1", RequestIDFromContext(ContextWithRequestID(context.Background(), "req-1")))
+}
+
+type fakeItemCache struct {
+	values  map[string][]byte
+	ttls    map[string]time.Duration
+	deleted []string
+	err     error
+}
+
+func newFakeItemCache() *fakeItemCache {
+	return &fakeItemCache{values: make(map[string][]byte), ttls: make(map[string]time.Duration)}
+}
+
+func (f *fakeItemCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	if f.err != nil {
+		return nil, false, f.err
+	}
+	value, ok := f.values[key]
+	return value, ok, nil
+}
+
+func (f *fakeItemCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.values[key] = value
+	f.ttls[key] = ttl
+	return nil
+}
+
+func (f *fakeItemCache) Delete(ctx context.Context, keys ...string) error {
+	f.deleted = append(f.deleted, keys...)
+	for _, key := range keys {
+		delete(f.values, key)
+	}
+	return f.err
+}
+
+func TestItemUsecase_ItemCache(t *testing.T) {
+	ctx := context.Background()
+	stored := &entity.Item{
+		ID: 1, Name: "ロレックス デイトナ", Category: "時計", Brand: "ROLEX", PurchasePrice: 1500000, PurchaseDate: "2023-01-15",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Version: 1,
+	}
+
+	t.Run("正常系: 2 回目はキャッシュから返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(stored, nil)
+		cache := newFakeItemCache()
+		u := NewItemUsecase(mockRepo, WithItemCache(cache, time.Minute))
+
+		first, err := u.GetItemByID(ctx, 1)
+		require.NoError(t, err)
+		second, err := u.GetItemByID(ctx, 1)
+		require.NoError(t, err)
+
+		assert.Equal(t, stored, first)
+		assert.Equal(t, stored, second)
+		assert.Equal(t, time.Minute, cache.ttls["items:1"])
+		mockRepo.AssertNumberOfCalls(t, "FindByID", 1)
+	})
+
+	t.Run("正常系: 更新するとキャッシュを削除する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		current := *stored
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&current, nil)
+		updated := *stored
+		updated.PurchasePrice, updated.Version = 1600000, 2
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(&updated, nil)
+		cache := newFakeItemCache()
+		u := NewItemUsecase(mockRepo, WithItemCache(cache, time.Minute))
+
+		_, err := u.GetItemByID(ctx, 1)
+		require.NoError(t, err)
+		price := 1600000
+		_, err = u.UpdateItem(ctx, 1, UpdateItemInput{PurchasePrice: &price})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"items:1"}, cache.deleted)
+		assert.NotContains(t, cache.values, "items:1")
+	})
+
+	t.Run("正常系: 削除・一括削除するとキャッシュを削除する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(stored, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		mockRepo.On("DeleteItems", mock.Anything, []int64{2, 3}).Return([]int64{2}, nil)
+		cache := newFakeItemCache()
+		u := NewItemUsecase(mockRepo, WithItemCache(cache, time.Minute))
+
+		_, err := u.GetItemByID(ctx, 1)
+		require.NoError(t, err)
+		require.NoError(t, u.DeleteItem(ctx, 1))
+		_, err = u.DeleteItems(ctx, []int64{2, 3})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"items:1", "items:2", "items:3"}, cache.deleted)
+		assert.Empty(t, cache.values)
+	})
+
+	t.Run("異常系: キャッシュのエラー時はリポジトリから取得する", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(stored, nil)
+		cache := newFakeItemCache()
+		cache.err = errors.New("redis: connection refused")
+		u := NewItemUsecase(mockRepo, WithItemCache(cache, time.Minute))
+
+		item, err := u.GetItemByID(ctx, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, stored, item)
+	})
+
+	t.Run("異常系: 存在しないアイテムはキャッシュしない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrItemNotFound)
+		cache := newFakeItemCache()
+		u := NewItemUsecase(mockRepo, WithItemCache(cache, time.Minute))
+
+		_, err := u.GetItemByID(ctx, 99)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+		assert.Empty(t, cache.values)
+	})
+}
+
+type fakeEventPublisher struct {
+	events []entity.ItemEvent
+}
+
+func (f *fakeEventPublisher) Publish(ctx context.Context, event entity.ItemEvent) {
+	f.events = append(f.events, event)
+}
+
+func TestItemUsecase_EventPublisher(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	clock := WithClock(func() time.Time { return now })
+
+	t.Run("正常系: 作成・一括作成をコミット後に通知", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1}, nil)
+		mockRepo.On("CreateItems", mock.Anything, mock.Anything).Return([]*entity.Item{{ID: 2}, {ID: 3}}, nil)
+		publisher := &fakeEventPublisher{}
+		u := NewItemUsecase(mockRepo, clock, WithEventPublisher(publisher))
+		input := CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"}
+
+		_, err := u.CreateItem(ctx, input)
+		require.NoError(t, err)
+		_, err = u.CreateItems(ctx, []CreateItemInput{input, input})
+		require.NoError(t, err)
+
+		assert.Equal(t, []entity.ItemEvent{
+			{Type: entity.ItemEventCreated, Item: &entity.Item{ID: 1}, Timestamp: now},
+			{Type: entity.ItemEventCreated, Item: &entity.Item{ID: 2}, Timestamp: now},
+			{Type: entity.ItemEventCreated, Item: &entity.Item{ID: 3}, Timestamp: now},
+		}, publisher.events)
+	})
+
+	t.Run("正常系: 削除は削除前の内容を通知", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		item := &entity.Item{ID: 1, Name: "ロレックス デイトナ"}
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(item, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(nil)
+		publisher := &fakeEventPublisher{}
+
+		require.NoError(t, NewItemUsecase(mockRepo, clock, WithEventPublisher(publisher)).DeleteItem(ctx, 1))
+
+		assert.Equal(t, []entity.ItemEvent{{Type: entity.ItemEventDeleted, Item: item, Timestamp: now}}, publisher.events)
+	})
+
+	t.Run("正常系: 一括削除は実際に削除したアイテムのみ通知", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByIDs", mock.Anything, []int64{1, 2, 99}).Return([]*entity.Item{{ID: 2}, {ID: 1}}, nil)
+		mockRepo.On("DeleteItems", mock.Anything, []int64{1, 2, 99}).Return([]int64{1, 2}, nil)
+		publisher := &fakeEventPublisher{}
+
+		_, err := NewItemUsecase(mockRepo, clock, WithEventPublisher(publisher)).DeleteItems(ctx, []int64{1, 2, 99})
+
+		require.NoError(t, err)
+		assert.Equal(t, []entity.ItemEvent{
+			{Type: entity.ItemEventDeleted, Item: &entity.Item{ID: 1}, Timestamp: now},
+			{Type: entity.ItemEventDeleted, Item: &entity.Item{ID: 2}, Timestamp: now},
+		}, publisher.events)
+	})
+
+	t.Run("正常系: 更新・置き換え・復元を通知", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchaseDate: "2023-01-01"}, nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, Version: 2}, nil)
+		mockRepo.On("Restore", mock.Anything, int64(1)).Return(nil).Once()
+		mockRepo.On("Restore", mock.Anything, int64(1)).Return(domainErrors.ErrItemNotFound).Once()
+		publisher := &fakeEventPublisher{}
+		u := NewItemUsecase(mockRepo, clock, WithEventPublisher(publisher))
+		name, category, brand, price, date := "時計2", "時計", "ROLEX", 100, "2023-01-01"
+
+		_, err := u.UpdateItem(ctx, 1, UpdateItemInput{PurchasePrice: &price})
+		require.NoError(t, err)
+		_, err = u.ReplaceItem(ctx, 1, ReplaceItemInput{Name: &name, Category: &category, Brand: &brand, PurchasePrice: &price, PurchaseDate: &date})
+		require.NoError(t, err)
+		_, err = u.RestoreItem(ctx, 1)
+		require.NoError(t, err)
+		// 削除されていなかった場合は変更がないので通知しない
+		_, err = u.RestoreItem(ctx, 1)
+		require.NoError(t, err)
+
+		require.Len(t, publisher.events, 3)
+		for _, event := range publisher.events {
+			assert.Equal(t, entity.ItemEventUpdated, event.Type)
+		}
+	})
+
+	t.Run("異常系: 失敗した変更は通知しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil, domainErrors.ErrDatabaseError)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(&entity.Item{ID: 1}, nil)
+		mockRepo.On("Delete", mock.Anything, int64(1)).Return(domainErrors.ErrDatabaseError)
+		publisher := &fakeEventPublisher{}
+		u := NewItemUsecase(mockRepo, clock, WithEventPublisher(publisher))
+
+		_, err := u.CreateItem(ctx, CreateItemInput{Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01"})
+		assert.Error(t, err)
+		assert.Error(t, u.DeleteItem(ctx, 1))
+
+		assert.Empty(t, publisher.events)
+	})
+}
+
+type fakePriceHistoryRepository struct {
+	changes []*entity.PriceChange
+	err     error
+}
+
+func (f *fakePriceHistoryRepository) CreatePriceChange(ctx context.Context, change *entity.PriceChange) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.changes = append(f.changes, change)
+	return nil
+}
+
+func (f *fakePriceHistoryRepository) FindPriceHistory(ctx context.Context, itemID int64) ([]*entity.PriceChange, error) {
+	return f.changes, f.err
+}
+
+func TestItemUsecase_PriceHistory(t *testing.T) {
+	intPtr := func(v int) *int { return &v }
+	strPtr := func(v string) *string { return &v }
+	newExisting := func() *entity.Item {
+		existing, _ := entity.NewItem("時計1", "時計", "ROLEX", 1000000, "2023-01-01")
+		existing.ID = 1
+		existing.Version = 1
+		return existing
+	}
+	updatedTo := func(price int) *entity.Item {
+		updated := newExisting()
+		updated.PurchasePrice = price
+		updated.Version = 2
+		return updated
+	}
+
+	t.Run("正常系: 購入価格を変更した場合は変更前後の価格を同じトランザクションで記録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(updatedTo(1200000), nil)
+		transactor := &fakeTransactor{}
+		history := &fakePriceHistoryRepository{}
+		uc := NewItemUsecase(mockRepo, WithPriceHistory(transactor, history))
+
+		_, err := uc.UpdateItem(context.Background(), 1, UpdateItemInput{PurchasePrice: intPtr(1200000)})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, transactor.committed)
+		assert.Equal(t, []*entity.PriceChange{{ItemID: 1, OldPrice: 1000000, NewPrice: 1200000}}, history.changes)
+	})
+
+	t.Run("正常系: PUT で購入価格を変更した場合も記録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(updatedTo(900000), nil)
+		history := &fakePriceHistoryRepository{}
+		uc := NewItemUsecase(mockRepo, WithPriceHistory(&fakeTransactor{}, history))
+
+		_, err := uc.ReplaceItem(context.Background(), 1, ReplaceItemInput{Name: strPtr("時計1"), Category: strPtr("時計"), Brand: strPtr("ROLEX"), PurchasePrice: intPtr(900000), PurchaseDate: strPtr("2023-01-01")})
+		require.NoError(t, err)
+
+		assert.Equal(t, []*entity.PriceChange{{ItemID: 1, OldPrice: 1000000, NewPrice: 900000}}, history.changes)
+	})
+
+	t.Run("正常系: 購入価格が変わらない更新は記録しない", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(updatedTo(1000000), nil)
+		history := &fakePriceHistoryRepository{}
+		uc := NewItemUsecase(mockRepo, WithPriceHistory(&fakeTransactor{}, history))
+
+		_, err := uc.UpdateItem(context.Background(), 1, UpdateItemInput{Name: strPtr("時計2")})
+		require.NoError(t, err)
+		// 同じ価格を指定した場合も変更ではない
+		_, err = uc.UpdateItem(context.Background(), 1, UpdateItemInput{PurchasePrice: intPtr(1000000)})
+		require.NoError(t, err)
+
+		assert.Empty(t, history.changes)
+	})
+
+	t.Run("異常系: 履歴の記録に失敗した場合はロールバックしてエラーを返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(updatedTo(1200000), nil)
+		transactor := &fakeTransactor{}
+		uc := NewItemUsecase(mockRepo, WithPriceHistory(transactor, &fakePriceHistoryRepository{err: domainErrors.ErrDatabaseError}))
+
+		updated, err := uc.UpdateItem(context.Background(), 1, UpdateItemInput{PurchasePrice: intPtr(1200000)})
+
+		assert.Nil(t, updated)
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, 1, transactor.rolledBack)
+	})
+
+	t.Run("正常系: 履歴を返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(), nil)
+		history := &fakePriceHistoryRepository{changes: []*entity.PriceChange{{ID: 1, ItemID: 1, OldPrice: 1000000, NewPrice: 1200000}}}
+		uc := NewItemUsecase(mockRepo, WithPriceHistory(&fakeTransactor{}, history))
+
+		changes, err := uc.GetPriceHistory(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, history.changes, changes)
+	})
+
+	t.Run("正常系: 履歴を記録しない場合は空", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(), nil)
+
+		changes, err := NewItemUsecase(mockRepo).GetPriceHistory(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("異常系: 存在しないアイテム", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(99)).Return(nil, domainErrors.ErrItemNotFound)
+		uc := NewItemUsecase(mockRepo, WithPriceHistory(&fakeTransactor{}, &fakePriceHistoryRepository{}))
+
+		_, err := uc.GetPriceHistory(context.Background(), 99)
+
+		assert.ErrorIs(t, err, domainErrors.ErrItemNotFound)
+	})
+}
+
+type fakeItemVersionRepository struct {
+	snapshots []*entity.Item
+	err       error
+}
+
+func (f *fakeItemVersionRepository) CreateItemVersion(ctx context.Context, item *entity.Item) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.snapshots = append(f.snapshots, item)
+	return nil
+}
+
+func (f *fakeItemVersionRepository) FindItemVersions(ctx context.Context, itemID int64) ([]*entity.ItemVersion, error) {
+	versions := []*entity.ItemVersion{}
+	for _, snapshot := range f.snapshots {
+		versions = append(versions, &entity.ItemVersion{Version: snapshot.Version, UpdatedAt: snapshot.UpdatedAt})
+	}
+	return versions, f.err
+}
+
+func (f *fakeItemVersionRepository) FindItemVersion(ctx context.Context, itemID int64, version int) (*entity.Item, error) {
+	for _, snapshot := range f.snapshots {
+		if snapshot.Version == version {
+			return snapshot, nil
+		}
+	}
+	return nil, domainErrors.ErrItemVersionNotFound
+}
+
+func TestItemUsecase_ItemVersions(t *testing.T) {
+	strPtr := func(v string) *string { return &v }
+	updatedAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	newExisting := func(version int) *entity.Item {
+		return &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", Version: version, UpdatedAt: updatedAt, Tags: []string{}}
+	}
+
+	t.Run("正常系: 更新前のアイテムをそのバージョンとして記録", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(1), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, Name: "時計2", PurchasePrice: 1000000, Version: 2}, nil)
+		transactor := &fakeTransactor{}
+		versions := &fakeItemVersionRepository{}
+		uc := NewItemUsecase(mockRepo, WithVersionHistory(transactor, versions))
+
+		_, err := uc.UpdateItem(context.Background(), 1, UpdateItemInput{Name: strPtr("時計2")})
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, transactor.committed)
+		assert.Equal(t, []*entity.Item{newExisting(1)}, versions.snapshots)
+	})
+
+	t.Run("異常系: バージョンの記録に失敗した場合はロールバックしてエラーを返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(1), nil)
+		mockRepo.On("Update", mock.Anything, mock.Anything).Return(&entity.Item{ID: 1, Version: 2}, nil)
+		transactor := &fakeTransactor{}
+		uc := NewItemUsecase(mockRepo, WithVersionHistory(transactor, &fakeItemVersionRepository{err: domainErrors.ErrDatabaseError}))
+
+		_, err := uc.UpdateItem(context.Background(), 1, UpdateItemInput{Name: strPtr("時計2")})
+
+		assert.ErrorIs(t, err, domainErrors.ErrDatabaseError)
+		assert.Equal(t, 1, transactor.rolledBack)
+	})
+
+	t.Run("正常系: 記録したバージョンの後に現在のバージョンを返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(3), nil)
+		versions := &fakeItemVersionRepository{snapshots: []*entity.Item{newExisting(1), newExisting(2)}}
+		uc := NewItemUsecase(mockRepo, WithVersionHistory(&fakeTransactor{}, versions))
+
+		list, err := uc.GetItemVersions(context.Background(), 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.ItemVersion{{Version: 1, UpdatedAt: updatedAt}, {Version: 2, UpdatedAt: updatedAt}, {Version: 3, UpdatedAt: updatedAt}}, list)
+	})
+
+	t.Run("正常系: 指定したバージョンのアイテムを返す", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(3), nil)
+		old := newExisting(1)
+		old.Name = "時計0"
+		uc := NewItemUsecase(mockRepo, WithVersionHistory(&fakeTransactor{}, &fakeItemVersionRepository{snapshots: []*entity.Item{old}}))
+
+		item, err := uc.GetItemVersion(context.Background(), 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, "時計0", item.Name)
+
+		current, err := uc.GetItemVersion(context.Background(), 1, 3)
+		require.NoError(t, err)
+		assert.Equal(t, "時計1", current.Name)
+	})
+
+	t.Run("異常系: 記録していないバージョン", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(3), nil)
+		uc := NewItemUsecase(mockRepo, WithVersionHistory(&fakeTransactor{}, &fakeItemVersionRepository{}))
+
+		for _, version := range []int{2, 4} {
+			_, err := uc.GetItemVersion(context.Background(), 1, version)
+			assert.ErrorIs(t, err, domainErrors.ErrItemVersionNotFound, "version %d", version)
+		}
+		_, err := uc.GetItemVersion(context.Background(), 1, 0)
+		assert.ErrorIs(t, err, domainErrors.ErrInvalidInput)
+	})
+
+	t.Run("正常系: バージョンを記録しない場合は現在のバージョンのみ", func(t *testing.T) {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(newExisting(3), nil)
+		uc := NewItemUsecase(mockRepo)
+
+		list, err := uc.GetItemVersions(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, []*entity.ItemVersion{{Version: 3, UpdatedAt: updatedAt}}, list)
+
+		_, err = uc.GetItemVersion(context.Background(), 1, 2)
+		assert.ErrorIs(t, err, domainErrors.ErrItemVersionNotFound)
+	})
+}
+
+func TestItemUsecase_DiffItemVersions(t *testing.T) {
+	newVersion := func(version int) *entity.Item {
+		return &entity.Item{ID: 1, Name: "時計1", Category: "時計", Brand: "ROLEX", PurchasePrice: 1000000, PurchaseDate: "2023-01-01", Version: version, Tags: []string{}}
+	}
+	newUsecase := func(current *entity.Item, snapshots ...*entity.Item) ItemUsecase {
+		mockRepo := new(MockItemRepository)
+		mockRepo.On("FindByID", mock.Anything, int64(1)).Return(current, nil)
+		return NewItemUsecase(mockRepo, WithVersionHistory(&fakeTransactor{}, &fakeItemVersionRepository{snapshots: snapshots}))
+	}
+
+	t.Run("正常系: 名前と価格の変更", func(t *testing.T) {
+		current := newVersion(3)
+		current.Name = "時計2"
+		current.PurchasePrice = 1200000
+		uc := newUsecase(current, newVersion(1), newVersion(2))
+
+		diff, err := uc.DiffItemVersions(context.Background(), 1, 1, 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, &ItemDiff{From: 1, To: 3, Changes: []FieldChange{
+			{Field: "name", Before: "時計1", After: "時計2"},
+			{Field: "purchase_price", Before: 1000000, After: 1200000},
+		}}, diff)
+	})
+
+	t.Run("正常系: 同じバージョンの場合は変更なし", func(t *testing.T) {
+		uc := newUsecase(newVersion(3), newVersion(1), newVersion(2))
+
+		diff, err := uc.DiffItemVersions(context.Background(), 1, 2, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, &ItemDiff{From: 2, To: 2, Changes: []FieldChange{}}, diff)
+	})
+
+	t.Run("正常系: 新しいバージョンから古いバージョンへの差分", func(t *testing.T) {
+		old := newVersion(1)
+		old.Tags = []string{"限定"}
+		uc := newUsecase(newVersion(2), old)
+
+		diff, err := uc.DiffItemVersions(context.Background(), 1, 2, 1)
+
+		require.NoError(t, err)
+		assert.Equal(t, []FieldChange{{Field: "tags", Before: []string{}, After: []string{"限定"}}}, diff.Changes)
+	})
+
+	t.Run("異常系: 記録していないバージョン", func(t *testing.T) {
+		uc := newUsecase(newVersion(3), newVersion(1))
+
+		_, err := uc.DiffItemVersions(context.Background(), 1, 1, 2)
+		assert.ErrorIs(t, err, domainErrors.ErrItemVersionNotFound)
+
+		_, err = uc.DiffItemVersions(context.Background(), 1, 5, 3)
+		assert.ErrorIs(t, err, domainErrors.ErrItemVersionNotFound)
+	})
 }
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"maps"
+	"sync"
+	"time"
+)
+
+// summaryCache はカテゴリー別集計を購入日の範囲ごとに ttl の間保持する
+// アイテムの作成・更新・削除のたびに invalidate で破棄する
+type summaryCache struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]summaryCacheEntry
+	// invalidate のたびに増やす。集計中に書き込みがあった場合、その結果は保存しない
+	generation uint64
+}
+
+type summaryCacheEntry struct {
+	summary   *CategorySummary
+	expiresAt time.Time
+}
+
+func newSummaryCache(ttl time.Duration, now func() time.Time) *summaryCache {
+	return &summaryCache{ttl: ttl, now: now, entries: make(map[string]summaryCacheEntry)}
+}
+
+// summaryCacheKey は購入日の範囲をキーにする（nil は制限なし）
+func summaryCacheKey(from, to *time.Time) string {
+	key := ""
+	if from != nil {
+		key += from.Format(time.RFC3339Nano)
+	}
+	key += "/"
+	if to != nil {
+		key += to.Format(time.RFC3339Nano)
+	}
+	return key
+}
+
+// get は有効期限内の集計のコピーと、set に渡す現在の世代を返す
+func (c *summaryCache) get(key string) (*CategorySummary, uint64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok || !c.now().Before(entry.expiresAt) {
+		return nil, c.generation, false
+	}
+	return cloneSummary(entry.summary), c.generation, true
+}
+
+// set は get 以降に invalidate されていなければ集計を保存する
+func (c *summaryCache) set(key string, generation uint64, summary *CategorySummary) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if generation != c.generation {
+		return
+	}
+	c.entries[key] = summaryCacheEntry{summary: cloneSummary(summary), expiresAt: c.now().Add(c.ttl)}
+}
+
+// invalidate は保持しているすべての集計を破棄する（キャッシュを使わない場合は何もしない）
+func (c *summaryCache) invalidate() {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	clear(c.entries)
+}
+
+// 呼び出し側が変更してもキャッシュに影響しないようにコピーする
+func cloneSummary(summary *CategorySummary) *CategorySummary {
+	cloned := *summary
+	cloned.Categories = maps.Clone(summary.Categories)
+	return &cloned
+}
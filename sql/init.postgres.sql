@@ -0,0 +1,153 @@
+-- PostgreSQL 用のスキーマ（DB_DRIVER=postgres の場合に使用、sql/init.sql と同じ構成）
+
+-- Create categories table for the categories items can be registered in (names are unique, case-insensitive)
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE categories IS 'Categories items can be registered in';
+COMMENT ON COLUMN categories.name IS 'Category name (referenced by items.category)';
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_categories_name ON categories (LOWER(name));
+
+INSERT INTO categories (name) VALUES ('時計'), ('バッグ'), ('ジュエリー'), ('靴'), ('その他') ON CONFLICT DO NOTHING;
+
+-- Create items table for managing valuable items and collections
+CREATE TABLE IF NOT EXISTS items (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(50) NOT NULL,
+    brand VARCHAR(100) NOT NULL,
+    purchase_price INT NOT NULL DEFAULT 0,
+    purchase_date DATE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL DEFAULT NULL,
+    version INT NOT NULL DEFAULT 1,
+    warranty_expiry DATE NULL DEFAULT NULL,
+    notes TEXT NULL,
+    image_url VARCHAR(2048) NULL DEFAULT NULL,
+    attributes JSONB NULL DEFAULT NULL,
+    user_id VARCHAR(255) NOT NULL DEFAULT ''
+);
+
+COMMENT ON TABLE items IS 'Table for managing valuable items and collections';
+COMMENT ON COLUMN items.name IS 'Item name';
+COMMENT ON COLUMN items.category IS 'Item category (one of categories.name)';
+COMMENT ON COLUMN items.brand IS 'Brand name';
+COMMENT ON COLUMN items.purchase_price IS 'Purchase price in yen';
+COMMENT ON COLUMN items.purchase_date IS 'Purchase date in YYYY-MM-DD format';
+COMMENT ON COLUMN items.created_at IS 'Record creation timestamp';
+COMMENT ON COLUMN items.updated_at IS 'Record update timestamp';
+COMMENT ON COLUMN items.deleted_at IS 'Soft delete timestamp (NULL while active)';
+COMMENT ON COLUMN items.version IS 'Optimistic lock version (incremented on every update)';
+COMMENT ON COLUMN items.warranty_expiry IS 'Warranty end date (NULL without warranty)';
+COMMENT ON COLUMN items.notes IS 'Free-form notes such as provenance (NULL without notes)';
+COMMENT ON COLUMN items.image_url IS 'http(s) URL of the item image (NULL without image)';
+COMMENT ON COLUMN items.attributes IS 'Category-specific extra data as a JSON object (NULL without attributes)';
+COMMENT ON COLUMN items.user_id IS 'JWT subject of the user who registered the item (empty without authentication)';
+
+CREATE INDEX IF NOT EXISTS idx_category ON items (category);
+CREATE INDEX IF NOT EXISTS idx_brand ON items (brand);
+CREATE INDEX IF NOT EXISTS idx_purchase_date ON items (purchase_date);
+CREATE INDEX IF NOT EXISTS idx_created_at ON items (created_at);
+CREATE INDEX IF NOT EXISTS idx_updated_at ON items (updated_at);
+CREATE INDEX IF NOT EXISTS idx_deleted_at ON items (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_warranty_expiry ON items (warranty_expiry);
+CREATE INDEX IF NOT EXISTS idx_user_id ON items (user_id);
+-- 未削除のアイテムの名前はユーザー・カテゴリごとに一意（大文字・小文字を区別しない）
+CREATE UNIQUE INDEX IF NOT EXISTS uq_category_name ON items (user_id, category, LOWER(name)) WHERE deleted_at IS NULL;
+
+-- MySQL の ON UPDATE CURRENT_TIMESTAMP と同じく、更新時に updated_at を設定する
+CREATE OR REPLACE FUNCTION set_updated_at() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS items_set_updated_at ON items;
+CREATE TRIGGER items_set_updated_at
+    BEFORE UPDATE ON items
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Create item_audits table for the audit trail of item changes (written in the same transaction as the change)
+CREATE TABLE IF NOT EXISTS item_audits (
+    id BIGSERIAL PRIMARY KEY,
+    item_id BIGINT NOT NULL REFERENCES items (id),
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(255) NOT NULL DEFAULT '',
+    request_id VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE item_audits IS 'Audit trail of item changes';
+COMMENT ON COLUMN item_audits.action IS 'Change type: create';
+COMMENT ON COLUMN item_audits.actor IS 'JWT subject of the user (empty without authentication)';
+COMMENT ON COLUMN item_audits.request_id IS 'X-Request-ID of the request';
+
+CREATE INDEX IF NOT EXISTS idx_item_audits_item_id ON item_audits (item_id);
+
+-- Create item_price_history table for purchase price changes (written in the same transaction as the update)
+CREATE TABLE IF NOT EXISTS item_price_history (
+    id BIGSERIAL PRIMARY KEY,
+    item_id BIGINT NOT NULL REFERENCES items (id),
+    old_price INT NOT NULL,
+    new_price INT NOT NULL,
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE item_price_history IS 'Purchase price changes of items';
+COMMENT ON COLUMN item_price_history.old_price IS 'Purchase price before the update';
+COMMENT ON COLUMN item_price_history.new_price IS 'Purchase price after the update';
+
+CREATE INDEX IF NOT EXISTS idx_item_price_history_item_id_changed_at ON item_price_history (item_id, changed_at);
+
+-- Create item_versions table for snapshots of items before each update (written in the same transaction as the update)
+CREATE TABLE IF NOT EXISTS item_versions (
+    id BIGSERIAL PRIMARY KEY,
+    item_id BIGINT NOT NULL REFERENCES items (id),
+    version INT NOT NULL,
+    snapshot JSONB NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (item_id, version)
+);
+
+COMMENT ON TABLE item_versions IS 'Snapshots of items before each update';
+COMMENT ON COLUMN item_versions.version IS 'Version of the item the snapshot was taken at';
+COMMENT ON COLUMN item_versions.snapshot IS 'The item as it was at the version';
+COMMENT ON COLUMN item_versions.updated_at IS 'When the item became the version';
+
+-- Create tags table and the item_tags join table (tag names are stored in lowercase)
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(20) NOT NULL UNIQUE
+);
+
+COMMENT ON TABLE tags IS 'Tags attached to items';
+COMMENT ON COLUMN tags.name IS 'Tag name (lowercase, unique)';
+
+CREATE TABLE IF NOT EXISTS item_tags (
+    item_id BIGINT NOT NULL REFERENCES items (id),
+    tag_id BIGINT NOT NULL REFERENCES tags (id),
+    PRIMARY KEY (item_id, tag_id)
+);
+
+COMMENT ON TABLE item_tags IS 'Tags of each item';
+
+CREATE INDEX IF NOT EXISTS idx_item_tags_tag_id ON item_tags (tag_id);
+
+-- Insert sample data for testing (only into an empty table)
+INSERT INTO items (name, category, brand, purchase_price, purchase_date)
+SELECT v.name, v.category, v.brand, v.purchase_price, v.purchase_date::DATE
+FROM (VALUES
+    ('ロレックス デイトナ', '時計', 'ROLEX', 1500000, '2023-01-15'),
+    ('エルメス バーキン', 'バッグ', 'HERMÈS', 2000000, '2023-02-20'),
+    ('ティファニー ネックレス', 'ジュエリー', 'Tiffany & Co.', 300000, '2023-03-10'),
+    ('ルブタン パンプス', '靴', 'Christian Louboutin', 150000, '2023-04-05'),
+    ('アップルウォッチ', 'その他', 'Apple', 50000, '2023-05-12')
+) AS v(name, category, brand, purchase_price, purchase_date)
+WHERE NOT EXISTS (SELECT 1 FROM items);
@@ -2,25 +2,105 @@
 SET NAMES utf8mb4 COLLATE utf8mb4_unicode_ci;
 SET CHARACTER SET utf8mb4;
 
+-- Create categories table for the categories items can be registered in (names are unique, case-insensitive)
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL COMMENT 'Category name (referenced by items.category)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE KEY uq_categories_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Categories items can be registered in';
+
+INSERT IGNORE INTO categories (name) VALUES ('時計'), ('バッグ'), ('ジュエリー'), ('靴'), ('その他');
+
 -- Create items table for managing valuable items and collections
 CREATE TABLE IF NOT EXISTS items (
     id BIGINT AUTO_INCREMENT PRIMARY KEY,
     name VARCHAR(100) NOT NULL COMMENT 'Item name',
-    category VARCHAR(50) NOT NULL COMMENT 'Item category: 時計, バッグ, ジュエリー, 靴, その他',
+    category VARCHAR(50) NOT NULL COMMENT 'Item category (one of categories.name)',
     brand VARCHAR(100) NOT NULL COMMENT 'Brand name',
     purchase_price INT NOT NULL DEFAULT 0 COMMENT 'Purchase price in yen',
     purchase_date DATE NOT NULL COMMENT 'Purchase date in YYYY-MM-DD format',
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
     updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP COMMENT 'Record update timestamp',
+    deleted_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Soft delete timestamp (NULL while active)',
+    version INT NOT NULL DEFAULT 1 COMMENT 'Optimistic lock version (incremented on every update)',
+    warranty_expiry DATE NULL DEFAULT NULL COMMENT 'Warranty end date (NULL without warranty)',
+    notes TEXT NULL COMMENT 'Free-form notes such as provenance (NULL without notes)',
+    image_url VARCHAR(2048) NULL DEFAULT NULL COMMENT 'http(s) URL of the item image (NULL without image)',
+    attributes JSON NULL DEFAULT NULL COMMENT 'Category-specific extra data as a JSON object (NULL without attributes)',
+    user_id VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'JWT subject of the user who registered the item (empty without authentication)',
+    active_name VARCHAR(100) GENERATED ALWAYS AS (IF(deleted_at IS NULL, name, NULL)) VIRTUAL COMMENT 'Name while not soft-deleted (for the unique key; NULL never clashes)',
     
+    UNIQUE KEY uq_category_name (user_id, category, active_name),
     INDEX idx_category (category),
     INDEX idx_brand (brand),
     INDEX idx_purchase_date (purchase_date),
-    INDEX idx_created_at (created_at)
+    INDEX idx_created_at (created_at),
+    INDEX idx_updated_at (updated_at),
+    INDEX idx_deleted_at (deleted_at),
+    INDEX idx_warranty_expiry (warranty_expiry),
+    INDEX idx_user_id (user_id)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Table for managing valuable items and collections';
 
--- Insert sample data for testing
-INSERT INTO items (name, category, brand, purchase_price, purchase_date) VALUES
+-- Create item_audits table for the audit trail of item changes (written in the same transaction as the change)
+CREATE TABLE IF NOT EXISTS item_audits (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Changed item',
+    action VARCHAR(20) NOT NULL COMMENT 'Change type: create',
+    actor VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'JWT subject of the user (empty without authentication)',
+    request_id VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'X-Request-ID of the request',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id (item_id),
+    CONSTRAINT fk_item_audits_item FOREIGN KEY (item_id) REFERENCES items (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Audit trail of item changes';
+
+-- Create item_price_history table for purchase price changes (written in the same transaction as the update)
+CREATE TABLE IF NOT EXISTS item_price_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Updated item',
+    old_price INT NOT NULL COMMENT 'Purchase price before the update',
+    new_price INT NOT NULL COMMENT 'Purchase price after the update',
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Update timestamp',
+
+    INDEX idx_item_id_changed_at (item_id, changed_at),
+    CONSTRAINT fk_item_price_history_item FOREIGN KEY (item_id) REFERENCES items (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Purchase price changes of items';
+
+-- Create item_versions table for snapshots of items before each update (written in the same transaction as the update)
+CREATE TABLE IF NOT EXISTS item_versions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Updated item',
+    version INT NOT NULL COMMENT 'Version of the item the snapshot was taken at',
+    snapshot JSON NOT NULL COMMENT 'The item as it was at the version',
+    updated_at TIMESTAMP NOT NULL COMMENT 'When the item became the version',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE KEY uq_item_versions_item_version (item_id, version),
+    CONSTRAINT fk_item_versions_item FOREIGN KEY (item_id) REFERENCES items (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Snapshots of items before each update';
+
+-- Create tags table and the item_tags join table (tag names are stored in lowercase)
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT 'Tag name (lowercase, unique)',
+
+    UNIQUE KEY uq_tags_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Tags attached to items';
+
+CREATE TABLE IF NOT EXISTS item_tags (
+    item_id BIGINT NOT NULL COMMENT 'Tagged item',
+    tag_id BIGINT NOT NULL COMMENT 'Attached tag',
+
+    PRIMARY KEY (item_id, tag_id),
+    INDEX idx_tag_id (tag_id),
+    CONSTRAINT fk_item_tags_item FOREIGN KEY (item_id) REFERENCES items (id),
+    CONSTRAINT fk_item_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Tags of each item';
+
+-- Insert sample data for testing (rows clashing with uq_category_name on re-run are skipped)
+INSERT IGNORE INTO items (name, category, brand, purchase_price, purchase_date) VALUES
 ('ロレックス デイトナ', '時計', 'ROLEX', 1500000, '2023-01-15'),
 ('エルメス バーキン', 'バッグ', 'HERMÈS', 2000000, '2023-02-20'),
 ('ティファニー ネックレス', 'ジュエリー', 'Tiffany & Co.', 300000, '2023-03-10'),
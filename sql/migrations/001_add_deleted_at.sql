@@ -0,0 +1,4 @@
+-- 既存の items テーブルに論理削除用のカラムを追加
+ALTER TABLE items
+    ADD COLUMN deleted_at TIMESTAMP NULL DEFAULT NULL COMMENT 'Soft delete timestamp (NULL while active)' AFTER updated_at,
+    ADD INDEX idx_deleted_at (deleted_at);
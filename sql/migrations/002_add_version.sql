@@ -0,0 +1,3 @@
+-- 楽観ロック用のバージョンカラムを追加（既存行は 1 から開始）
+ALTER TABLE items
+    ADD COLUMN version INT NOT NULL DEFAULT 1 COMMENT 'Optimistic lock version (incremented on every update)' AFTER deleted_at;
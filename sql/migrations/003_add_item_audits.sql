@@ -0,0 +1,13 @@
+-- アイテムの変更の監査ログを記録するテーブルを追加
+-- Create item_audits table for the audit trail of item changes (written in the same transaction as the change)
+CREATE TABLE IF NOT EXISTS item_audits (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Changed item',
+    action VARCHAR(20) NOT NULL COMMENT 'Change type: create',
+    actor VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'JWT subject of the user (empty without authentication)',
+    request_id VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'X-Request-ID of the request',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    INDEX idx_item_id (item_id),
+    CONSTRAINT fk_item_audits_item FOREIGN KEY (item_id) REFERENCES items (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Audit trail of item changes';
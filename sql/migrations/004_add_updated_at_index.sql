@@ -0,0 +1,3 @@
+-- 差分同期（GET /items/changes）で updated_at を範囲検索するためのインデックスを追加
+ALTER TABLE items
+    ADD INDEX idx_updated_at (updated_at);
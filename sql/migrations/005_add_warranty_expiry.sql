@@ -0,0 +1,4 @@
+-- 保証期限のカラムを追加（既存行は保証なしの NULL）
+ALTER TABLE items
+    ADD COLUMN warranty_expiry DATE NULL DEFAULT NULL COMMENT 'Warranty end date (NULL without warranty)' AFTER version,
+    ADD INDEX idx_warranty_expiry (warranty_expiry);
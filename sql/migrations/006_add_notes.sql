@@ -0,0 +1,3 @@
+-- 自由記述のメモのカラムを追加（既存行はメモなしの NULL）
+ALTER TABLE items
+    ADD COLUMN notes TEXT NULL COMMENT 'Free-form notes such as provenance (NULL without notes)' AFTER warranty_expiry;
@@ -0,0 +1,18 @@
+-- アイテムのタグを管理するテーブルを追加（tags とアイテムを関連付ける item_tags）
+-- Create tags table and the item_tags join table (tag names are stored in lowercase)
+CREATE TABLE IF NOT EXISTS tags (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(20) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin NOT NULL COMMENT 'Tag name (lowercase, unique)',
+
+    UNIQUE KEY uq_tags_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Tags attached to items';
+
+CREATE TABLE IF NOT EXISTS item_tags (
+    item_id BIGINT NOT NULL COMMENT 'Tagged item',
+    tag_id BIGINT NOT NULL COMMENT 'Attached tag',
+
+    PRIMARY KEY (item_id, tag_id),
+    INDEX idx_tag_id (tag_id),
+    CONSTRAINT fk_item_tags_item FOREIGN KEY (item_id) REFERENCES items (id),
+    CONSTRAINT fk_item_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Tags of each item';
@@ -0,0 +1,3 @@
+-- 画像の URL のカラムを追加（既存行は画像なしの NULL）
+ALTER TABLE items
+    ADD COLUMN image_url VARCHAR(2048) NULL DEFAULT NULL COMMENT 'http(s) URL of the item image (NULL without image)' AFTER notes;
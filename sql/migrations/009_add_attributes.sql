@@ -0,0 +1,3 @@
+-- 追加情報の JSON のカラムを追加（既存行は追加情報なしの NULL）
+ALTER TABLE items
+    ADD COLUMN attributes JSON NULL DEFAULT NULL COMMENT 'Category-specific extra data as a JSON object (NULL without attributes)' AFTER image_url;
@@ -0,0 +1,6 @@
+-- 未削除のアイテムの名前をカテゴリごとに一意にする（照合順序 utf8mb4_unicode_ci のため大文字・小文字を区別しない）
+-- 論理削除したアイテムは active_name が NULL になり、一意制約の対象外になる
+-- 同じカテゴリに同じ名前の未削除のアイテムがある場合は失敗するため、事前に名前を変更するか削除してください
+ALTER TABLE items
+    ADD COLUMN active_name VARCHAR(100) GENERATED ALWAYS AS (IF(deleted_at IS NULL, name, NULL)) VIRTUAL COMMENT 'Name while not soft-deleted (for the unique key; NULL never clashes)' AFTER attributes,
+    ADD UNIQUE KEY uq_category_name (category, active_name);
@@ -0,0 +1,14 @@
+-- アイテムを登録できるカテゴリーを categories テーブルで管理する（照合順序 utf8mb4_unicode_ci のため名前は大文字・小文字を区別せずに一意）
+-- 初期のカテゴリーに加え、既存のアイテムが使っているカテゴリーも登録する
+CREATE TABLE IF NOT EXISTS categories (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(50) NOT NULL COMMENT 'Category name (referenced by items.category)',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE KEY uq_categories_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Categories items can be registered in';
+
+INSERT IGNORE INTO categories (name) VALUES ('時計'), ('バッグ'), ('ジュエリー'), ('靴'), ('その他');
+INSERT IGNORE INTO categories (name) SELECT DISTINCT category FROM items;
+
+ALTER TABLE items MODIFY category VARCHAR(50) NOT NULL COMMENT 'Item category (one of categories.name)';
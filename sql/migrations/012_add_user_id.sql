@@ -0,0 +1,7 @@
+-- アイテムを登録したユーザー（JWT の subject）を保存する（既存のアイテムは所有者なしの空文字になる）
+-- 名前の一意制約をユーザーごとにするため、uq_category_name に user_id を加えて作り直す
+ALTER TABLE items
+    ADD COLUMN user_id VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'JWT subject of the user who registered the item (empty without authentication)' AFTER attributes,
+    ADD INDEX idx_user_id (user_id),
+    DROP INDEX uq_category_name,
+    ADD UNIQUE KEY uq_category_name (user_id, category, active_name);
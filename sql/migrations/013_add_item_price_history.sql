@@ -0,0 +1,12 @@
+-- アイテムの購入価格の変更を記録するテーブルを追加
+-- Create item_price_history table for purchase price changes (written in the same transaction as the update)
+CREATE TABLE IF NOT EXISTS item_price_history (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Updated item',
+    old_price INT NOT NULL COMMENT 'Purchase price before the update',
+    new_price INT NOT NULL COMMENT 'Purchase price after the update',
+    changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Update timestamp',
+
+    INDEX idx_item_id_changed_at (item_id, changed_at),
+    CONSTRAINT fk_item_price_history_item FOREIGN KEY (item_id) REFERENCES items (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Purchase price changes of items';
@@ -0,0 +1,13 @@
+-- アイテムの更新前のバージョンを記録するテーブルを追加
+-- Create item_versions table for snapshots of items before each update (written in the same transaction as the update)
+CREATE TABLE IF NOT EXISTS item_versions (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_id BIGINT NOT NULL COMMENT 'Updated item',
+    version INT NOT NULL COMMENT 'Version of the item the snapshot was taken at',
+    snapshot JSON NOT NULL COMMENT 'The item as it was at the version',
+    updated_at TIMESTAMP NOT NULL COMMENT 'When the item became the version',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP COMMENT 'Record creation timestamp',
+
+    UNIQUE KEY uq_item_versions_item_version (item_id, version),
+    CONSTRAINT fk_item_versions_item FOREIGN KEY (item_id) REFERENCES items (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='Snapshots of items before each update';